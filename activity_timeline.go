@@ -0,0 +1,232 @@
+package tachograph
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+)
+
+// SpecificCondition is a specific condition that can be entered by a driver.
+type SpecificCondition int
+
+const (
+	// SpecificConditionOutOfScope is a period outside the scope of the
+	// tachograph regulation.
+	SpecificConditionOutOfScope SpecificCondition = iota + 1
+	// SpecificConditionFerryTrainCrossing is a period on a ferry or train.
+	SpecificConditionFerryTrainCrossing
+)
+
+// String returns a human-readable name for the specific condition.
+func (c SpecificCondition) String() string {
+	switch c {
+	case SpecificConditionOutOfScope:
+		return "out of scope"
+	case SpecificConditionFerryTrainCrossing:
+		return "ferry/train crossing"
+	default:
+		return "unknown"
+	}
+}
+
+// SpecificConditionPeriod is a period during which a specific condition was active.
+type SpecificConditionPeriod struct {
+	// Condition is the specific condition.
+	Condition SpecificCondition
+	// Period is the period the condition was active. The end is zero if no
+	// end of the condition was recorded.
+	Period
+}
+
+// ActivityPeriod is a period of a single driver activity.
+type ActivityPeriod struct {
+	Period
+	// Activity is the driver activity.
+	Activity ddv1.DriverActivityValue
+	// Slot is the slot the card was inserted into.
+	Slot ddv1.CardSlotNumber
+	// Crew reports whether the driver was driving in crew.
+	Crew bool
+	// CardInserted reports whether the card was inserted.
+	CardInserted bool
+	// ManualEntry reports whether the activity was entered manually while the
+	// card was not inserted.
+	ManualEntry bool
+	// OutOfScope reports whether the period was out of scope of the regulation.
+	OutOfScope bool
+	// FerryTrainCrossing reports whether the period was on a ferry or train.
+	FerryTrainCrossing bool
+}
+
+// Unknown reports whether the card was not inserted and no activity was
+// entered manually, so that the activity is unknown.
+func (p ActivityPeriod) Unknown() bool {
+	return !p.CardInserted && !p.ManualEntry
+}
+
+// ActivityPeriods returns the activity timeline of a driver card, with the
+// specific conditions of the card overlaid.
+//
+// Each activity lasts until the next activity change or the end of its day.
+// Periods are split where a specific condition begins or ends, so that every
+// period is either entirely inside or entirely outside each condition.
+// POA (availability) is an activity, not a specific condition, and is reported
+// as [ddv1.DriverActivityValue_AVAILABILITY].
+func ActivityPeriods(card *cardv1.DriverCardFile) []ActivityPeriod {
+	var periods []ActivityPeriod
+	for _, record := range driverCardDailyRecords(card) {
+		periods = append(periods, cardActivityPeriods(record.GetActivityRecordDate().AsTime(), record.GetActivityChangeInfo())...)
+	}
+	return OverlaySpecificConditions(periods, SpecificConditionPeriods(card))
+}
+
+// ActivityPeriods returns the activity timeline of both slots of a vehicle
+// unit, with the specific conditions recorded by the vehicle unit overlaid.
+//
+// The periods are ordered by start and slot.
+func (h *VehicleHistory) ActivityPeriods() []ActivityPeriod {
+	var periods []ActivityPeriod
+	var conditions []*ddv1.SpecificConditionRecord
+	for _, day := range h.ActivityDays {
+		for _, slot := range []ddv1.CardSlotNumber{ddv1.CardSlotNumber_DRIVER_SLOT, ddv1.CardSlotNumber_CO_DRIVER_SLOT} {
+			var changes []*ddv1.ActivityChangeInfo
+			for _, c := range day.ActivityChanges {
+				if c.GetSlot() == slot {
+					changes = append(changes, c)
+				}
+			}
+			periods = append(periods, cardActivityPeriods(day.Date, changes)...)
+		}
+		conditions = append(conditions, day.SpecificConditions...)
+	}
+	slices.SortStableFunc(periods, func(a, b ActivityPeriod) int {
+		return cmp.Or(a.Start.Compare(b.Start), cmp.Compare(a.Slot, b.Slot))
+	})
+	slices.SortStableFunc(conditions, func(a, b *ddv1.SpecificConditionRecord) int {
+		return a.GetEntryTime().AsTime().Compare(b.GetEntryTime().AsTime())
+	})
+	return OverlaySpecificConditions(periods, specificConditionPeriods(conditions))
+}
+
+// SpecificConditionPeriods returns the specific condition periods recorded on
+// a driver card, ordered by start.
+//
+// Begin and end records are paired per condition. A begin record without a
+// matching end record yields a period with a zero end. End records without a
+// matching begin record are ignored.
+func SpecificConditionPeriods(card *cardv1.DriverCardFile) []SpecificConditionPeriod {
+	return specificConditionPeriods(driverCardSpecificConditions(card))
+}
+
+// OverlaySpecificConditions marks the activity periods that fall inside the
+// specific condition periods, splitting periods at the condition boundaries.
+func OverlaySpecificConditions(periods []ActivityPeriod, conditions []SpecificConditionPeriod) []ActivityPeriod {
+	active := func(c SpecificConditionPeriod, t time.Time) bool {
+		return !t.Before(c.Start) && (c.End.IsZero() || t.Before(c.End))
+	}
+	result := make([]ActivityPeriod, 0, len(periods))
+	for _, p := range periods {
+		cuts := []time.Time{p.Start, p.End}
+		for _, c := range conditions {
+			for _, t := range []time.Time{c.Start, c.End} {
+				if t.After(p.Start) && t.Before(p.End) {
+					cuts = append(cuts, t)
+				}
+			}
+		}
+		slices.SortFunc(cuts, time.Time.Compare)
+		cuts = slices.Compact(cuts)
+		for i := 1; i < len(cuts); i++ {
+			segment := p
+			segment.Period = Period{Start: cuts[i-1], End: cuts[i]}
+			for _, c := range conditions {
+				if !active(c, segment.Start) {
+					continue
+				}
+				switch c.Condition {
+				case SpecificConditionOutOfScope:
+					segment.OutOfScope = true
+				case SpecificConditionFerryTrainCrossing:
+					segment.FerryTrainCrossing = true
+				}
+			}
+			result = append(result, segment)
+		}
+	}
+	return result
+}
+
+// cardActivityPeriods converts the activity changes of a driver card day into
+// periods. Each activity lasts until the next change or the end of the day.
+func cardActivityPeriods(date time.Time, changes []*ddv1.ActivityChangeInfo) []ActivityPeriod {
+	const minutesPerDay = 24 * 60
+	sorted := slices.Clone(changes)
+	slices.SortStableFunc(sorted, func(a, b *ddv1.ActivityChangeInfo) int {
+		return int(a.GetTimeOfChangeMinutes() - b.GetTimeOfChangeMinutes())
+	})
+	var result []ActivityPeriod
+	for i, c := range sorted {
+		start := min(c.GetTimeOfChangeMinutes(), minutesPerDay)
+		end := int32(minutesPerDay)
+		if i+1 < len(sorted) {
+			end = min(sorted[i+1].GetTimeOfChangeMinutes(), minutesPerDay)
+		}
+		if end <= start {
+			continue
+		}
+		// The crew bit of a change made while the card was not inserted tells
+		// whether the activity was entered manually.
+		result = append(result, ActivityPeriod{
+			Period: Period{
+				Start: date.Add(time.Duration(start) * time.Minute),
+				End:   date.Add(time.Duration(end) * time.Minute),
+			},
+			Activity:     c.GetActivity(),
+			Slot:         c.GetSlot(),
+			Crew:         c.GetInserted() && c.GetCrew(),
+			CardInserted: c.GetInserted(),
+			ManualEntry:  !c.GetInserted() && c.GetCrew(),
+		})
+	}
+	return result
+}
+
+// specificConditionPeriods pairs the begin and end records of specific
+// conditions into periods, ordered by start.
+func specificConditionPeriods(records []*ddv1.SpecificConditionRecord) []SpecificConditionPeriod {
+	var result []SpecificConditionPeriod
+	open := map[SpecificCondition]int{}
+	for _, r := range records {
+		var condition SpecificCondition
+		var begin bool
+		switch r.GetSpecificConditionType() {
+		case ddv1.SpecificConditionType_OUT_OF_SCOPE_BEGIN:
+			condition, begin = SpecificConditionOutOfScope, true
+		case ddv1.SpecificConditionType_OUT_OF_SCOPE_END:
+			condition = SpecificConditionOutOfScope
+		case ddv1.SpecificConditionType_FERRY_TRAIN_CROSSING_BEGIN:
+			condition, begin = SpecificConditionFerryTrainCrossing, true
+		case ddv1.SpecificConditionType_FERRY_TRAIN_CROSSING_END:
+			condition = SpecificConditionFerryTrainCrossing
+		default:
+			continue
+		}
+		t := timeOf(r.GetEntryTime())
+		i, isOpen := open[condition]
+		switch {
+		case begin && !isOpen:
+			open[condition] = len(result)
+			result = append(result, SpecificConditionPeriod{
+				Condition: condition,
+				Period:    Period{Start: t},
+			})
+		case !begin && isOpen:
+			result[i].End = t
+			delete(open, condition)
+		}
+	}
+	return result
+}
@@ -0,0 +1,94 @@
+package tachograph
+
+import (
+	"testing"
+	"time"
+
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+)
+
+func TestOverlaySpecificConditions(t *testing.T) {
+	day := testDay(0)
+	manual := testActivityChange(ddv1.CardSlotNumber_DRIVER_SLOT, ddv1.DriverActivityValue_WORK, 0)
+	manual.SetInserted(false)
+	manual.SetCrew(true)
+	periods := cardActivityPeriods(day, []*ddv1.ActivityChangeInfo{
+		manual,
+		testActivityChange(ddv1.CardSlotNumber_DRIVER_SLOT, ddv1.DriverActivityValue_DRIVING, 8*60),
+		testActivityChange(ddv1.CardSlotNumber_DRIVER_SLOT, ddv1.DriverActivityValue_BREAK_REST, 10*60),
+	})
+	if len(periods) != 3 {
+		t.Fatalf("cardActivityPeriods() = %d periods, want 3", len(periods))
+	}
+	if !periods[0].ManualEntry || periods[0].CardInserted || periods[0].Crew || periods[0].Unknown() {
+		t.Errorf("manual entry period = %+v", periods[0])
+	}
+
+	ferry := SpecificConditionPeriod{
+		Condition: SpecificConditionFerryTrainCrossing,
+		Period:    Period{Start: day.Add(11 * time.Hour), End: day.Add(15 * time.Hour)},
+	}
+	outOfScope := SpecificConditionPeriod{
+		Condition: SpecificConditionOutOfScope,
+		Period:    Period{Start: day.Add(20 * time.Hour)},
+	}
+	got := OverlaySpecificConditions(periods, []SpecificConditionPeriod{ferry, outOfScope})
+	want := []struct {
+		start, end        time.Duration
+		activity          ddv1.DriverActivityValue
+		ferry, outOfScope bool
+	}{
+		{start: 0, end: 8 * time.Hour, activity: ddv1.DriverActivityValue_WORK},
+		{start: 8 * time.Hour, end: 10 * time.Hour, activity: ddv1.DriverActivityValue_DRIVING},
+		{start: 10 * time.Hour, end: 11 * time.Hour, activity: ddv1.DriverActivityValue_BREAK_REST},
+		{start: 11 * time.Hour, end: 15 * time.Hour, activity: ddv1.DriverActivityValue_BREAK_REST, ferry: true},
+		{start: 15 * time.Hour, end: 20 * time.Hour, activity: ddv1.DriverActivityValue_BREAK_REST},
+		{start: 20 * time.Hour, end: 24 * time.Hour, activity: ddv1.DriverActivityValue_BREAK_REST, outOfScope: true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("OverlaySpecificConditions() = %+v, want %d periods", got, len(want))
+	}
+	for i, w := range want {
+		g := got[i]
+		if !g.Start.Equal(day.Add(w.start)) || !g.End.Equal(day.Add(w.end)) || g.Activity != w.activity ||
+			g.FerryTrainCrossing != w.ferry || g.OutOfScope != w.outOfScope {
+			t.Errorf("period %d = %+v, want %+v", i, g, w)
+		}
+	}
+}
+
+func TestVehicleHistory_ActivityPeriods(t *testing.T) {
+	file := testVehicleUnitFileGen1("WDB9634031L123456", Period{Start: testDay(0), End: testDay(1)}, map[time.Time][]*ddv1.ActivityChangeInfo{
+		testDay(0): {
+			testActivityChange(ddv1.CardSlotNumber_DRIVER_SLOT, ddv1.DriverActivityValue_BREAK_REST, 0),
+			testActivityChange(ddv1.CardSlotNumber_CO_DRIVER_SLOT, ddv1.DriverActivityValue_BREAK_REST, 0),
+			testActivityChange(ddv1.CardSlotNumber_DRIVER_SLOT, ddv1.DriverActivityValue_DRIVING, 6*60),
+			testActivityChange(ddv1.CardSlotNumber_CO_DRIVER_SLOT, ddv1.DriverActivityValue_AVAILABILITY, 7*60),
+		},
+	})
+	history, err := MergeVehicleUnitFiles(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(minutes int) time.Time { return testDay(0).Add(time.Duration(minutes) * time.Minute) }
+	want := []struct {
+		slot       ddv1.CardSlotNumber
+		activity   ddv1.DriverActivityValue
+		start, end time.Time
+	}{
+		{ddv1.CardSlotNumber_DRIVER_SLOT, ddv1.DriverActivityValue_BREAK_REST, at(0), at(6 * 60)},
+		{ddv1.CardSlotNumber_CO_DRIVER_SLOT, ddv1.DriverActivityValue_BREAK_REST, at(0), at(7 * 60)},
+		{ddv1.CardSlotNumber_DRIVER_SLOT, ddv1.DriverActivityValue_DRIVING, at(6 * 60), at(24 * 60)},
+		{ddv1.CardSlotNumber_CO_DRIVER_SLOT, ddv1.DriverActivityValue_AVAILABILITY, at(7 * 60), at(24 * 60)},
+	}
+	got := history.ActivityPeriods()
+	if len(got) != len(want) {
+		t.Fatalf("ActivityPeriods() = %d periods, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		if got[i].Slot != w.slot || got[i].Activity != w.activity || !got[i].Start.Equal(w.start) || !got[i].End.Equal(w.end) {
+			t.Errorf("period %d = %v %v %v-%v, want %v %v %v-%v", i,
+				got[i].Slot, got[i].Activity, got[i].Start, got[i].End, w.slot, w.activity, w.start, w.end)
+		}
+	}
+}
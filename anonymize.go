@@ -0,0 +1,486 @@
+package tachograph
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/way-platform/tachograph-go/internal/dd"
+	"github.com/way-platform/tachograph-go/internal/vu"
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// AnonymizeOptions configures the anonymization of tachograph files.
+type AnonymizeOptions struct {
+	// Seed determines the pseudonyms and the position offset.
+	//
+	// Anonymization is deterministic: with the same seed, the same original
+	// value is always replaced with the same pseudonym, also across files.
+	// Anyone who knows the seed can confirm a guess of an original value, so
+	// use a secret seed when sharing files outside of a trusted group.
+	Seed int64
+}
+
+// AnonymizeFile returns an anonymized copy of a tachograph file.
+//
+// See [AnonymizeOptions] if you need more control over the anonymization.
+func AnonymizeFile(file *tachographv1.File) (*tachographv1.File, error) {
+	return AnonymizeOptions{}.AnonymizeFile(file)
+}
+
+// AnonymizeFile returns an anonymized copy of a tachograph file.
+//
+// The following data is replaced in all card EFs and VU transfers:
+//   - Names of card holders, companies, workshops and control bodies
+//   - Addresses of companies, workshops and control bodies
+//   - Card numbers
+//   - Driving licence numbers
+//   - Vehicle identification numbers and registration numbers
+//   - Serial numbers of cards, vehicle units and sensors
+//   - Birth dates, keeping the year
+//   - GNSS positions, shifted by a seed-dependent offset that preserves the
+//     distances between positions
+//
+// Pseudonyms keep the format of the original value: letters are replaced with
+// letters, and digits with digits. The same value gets the same pseudonym in
+// card and VU files.
+//
+// Times, activities, odometer values, countries and all other data are kept,
+// so that the anonymized file can still be analyzed. Signatures are zeroed,
+// since they no longer match the data. Certificates reference the card or
+// vehicle unit they were issued to, so they are removed from driver cards, and
+// zeroed in VU downloads, where the transfer layout requires them.
+//
+// Driver card files are marshaled from their anonymized values, without their
+// raw data. VU files are marshaled from the raw data of their transfers, so
+// the sensitive fields are replaced in the raw data, at the positions given by
+// the transfer layouts. In both cases, changes to the decoded values of the
+// input that are not in its binary form are not kept.
+//
+// The anonymized file is marshaled and unmarshaled again, so it is guaranteed
+// to round-trip through [MarshalFile]. Raw card files are not supported.
+func (o AnonymizeOptions) AnonymizeFile(file *tachographv1.File) (*tachographv1.File, error) {
+	if file == nil {
+		return nil, fmt.Errorf("file cannot be nil")
+	}
+	a := newAnonymizer(o.Seed)
+	var data []byte
+	var err error
+	switch file.GetType() {
+	case tachographv1.File_DRIVER_CARD:
+		data, err = a.anonymizeDriverCardFile(file)
+	case tachographv1.File_VEHICLE_UNIT:
+		data, err = a.anonymizeVehicleUnitFile(file)
+	default:
+		return nil, fmt.Errorf("anonymization not supported for file type: %v", file.GetType())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return UnmarshalFile(data)
+}
+
+// sensitiveStringFields are the names of string value fields that hold
+// personal or identifying data.
+var sensitiveStringFields = map[protoreflect.Name]bool{
+	"holder_surname":                true,
+	"holder_first_names":            true,
+	"card_holder_surname":           true,
+	"card_holder_first_names":       true,
+	"driver_identification_number":  true,
+	"owner_identification":          true,
+	"vehicle_identification_number": true,
+	"vin":                           true,
+	"driving_licence_number":        true,
+	"company_name":                  true,
+	"company_address":               true,
+	"company_or_workshop_name":      true,
+	"workshop_name":                 true,
+	"workshop_address":              true,
+	"control_body_name":             true,
+	"control_body_address":          true,
+}
+
+// anonymizer replaces sensitive values in a file.
+//
+// The pseudonyms are derived from the binary encoding of the original values,
+// so that decoded values and raw data get the same pseudonyms.
+type anonymizer struct {
+	seed      int64
+	latOffset float64
+	lonOffset float64
+}
+
+func newAnonymizer(seed int64) *anonymizer {
+	a := &anonymizer{seed: seed}
+	r := a.rand("position", nil)
+	a.latOffset = r.Float64()*10 - 5
+	a.lonOffset = r.Float64()*20 - 10
+	return a
+}
+
+// anonymizeDriverCardFile anonymizes the decoded values of a driver card file,
+// and marshals it from the anonymized values.
+//
+// The file is marshaled and parsed again first, so that the EFs kept as raw
+// card records are decoded too. The raw data, which holds the original values,
+// is then ignored.
+func (a *anonymizer) anonymizeDriverCardFile(file *tachographv1.File) ([]byte, error) {
+	data, err := MarshalFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal file: %w", err)
+	}
+	decoded, err := UnmarshalFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode file: %w", err)
+	}
+	removeCertificates(decoded.GetDriverCard())
+	if err := a.anonymizeMessage(decoded.ProtoReflect()); err != nil {
+		return nil, err
+	}
+	zeroSignatures(decoded.ProtoReflect())
+	data, err = MarshalOptions{IgnoreRawData: true}.MarshalFile(decoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal anonymized file: %w", err)
+	}
+	return data, nil
+}
+
+// anonymizeVehicleUnitFile replaces the sensitive fields of the transfers of a
+// vehicle unit file in place, see [vu.SensitiveFields].
+func (a *anonymizer) anonymizeVehicleUnitFile(file *tachographv1.File) ([]byte, error) {
+	data, err := MarshalFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal file: %w", err)
+	}
+	rawFile, err := vu.ScanRawVehicleUnitFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to split transfers: %w", err)
+	}
+	result := make([]byte, 0, len(data))
+	for _, record := range rawFile.GetRecords() {
+		value := bytes.Clone(record.GetValue())
+		fields, err := vu.SensitiveFields(record.GetType(), value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to anonymize transfer: %w", err)
+		}
+		for _, field := range fields {
+			a.anonymizeSensitiveField(field.Kind, value[field.Offset:field.Offset+field.Length])
+		}
+		result = binary.BigEndian.AppendUint16(result, uint16(record.GetTag()))
+		result = append(result, value...)
+	}
+	return result, nil
+}
+
+// anonymizeSensitiveField replaces an encoded value of a transfer in place,
+// with the same pseudonym as the decoded value in card files.
+func (a *anonymizer) anonymizeSensitiveField(kind vu.SensitiveFieldKind, data []byte) {
+	var replacement []byte
+	switch kind {
+	case vu.SensitiveName, vu.SensitiveRegistrationNumber:
+		replacement = a.pseudonymizeStringValue(data)
+	case vu.SensitiveCardNumber, vu.SensitiveVehicleIdentificationNumber:
+		replacement = a.pseudonymizeText(data)
+	case vu.SensitiveSerialNumber:
+		replacement = a.pseudonymizeSerialNumber(data)
+	case vu.SensitiveGeoCoordinates:
+		replacement = a.shiftGeoCoordinates(data)
+	case vu.SensitiveCertificate, vu.SensitiveSignature:
+		clear(data)
+	}
+	copy(data, replacement)
+}
+
+// rand returns a random source determined by the seed and the original value.
+func (a *anonymizer) rand(kind string, original []byte) *rand.Rand {
+	h := sha256.New()
+	_ = binary.Write(h, binary.BigEndian, a.seed)
+	h.Write([]byte(kind))
+	h.Write(original)
+	return rand.New(rand.NewChaCha8([32]byte(h.Sum(nil))))
+}
+
+// anonymizeMessage replaces the sensitive values in a message and its
+// sub-messages.
+func (a *anonymizer) anonymizeMessage(m protoreflect.Message) error {
+	for _, fd := range populatedFields(m) {
+		switch {
+		case fd.IsMap():
+			continue
+		case fd.IsList() && fd.Kind() == protoreflect.MessageKind:
+			list := m.Get(fd).List()
+			for i := range list.Len() {
+				if err := a.anonymizeField(m, fd, list.Get(i).Message()); err != nil {
+					return err
+				}
+			}
+		case fd.Kind() == protoreflect.MessageKind:
+			if err := a.anonymizeField(m, fd, m.Get(fd).Message()); err != nil {
+				return err
+			}
+		case fd.Kind() == protoreflect.StringKind && fd.Name() == "vehicle_identification_number":
+			m.Set(fd, protoreflect.ValueOfString(a.anonymizeVIN(m.Get(fd).String())))
+		}
+	}
+	return nil
+}
+
+// anonymizeField replaces the sensitive values in a message field.
+func (a *anonymizer) anonymizeField(parent protoreflect.Message, fd protoreflect.FieldDescriptor, m protoreflect.Message) error {
+	var opts dd.UnmarshalOptions
+	var err error
+	switch v := m.Interface().(type) {
+	case *ddv1.StringValue:
+		if isSensitiveStringField(parent, fd) {
+			err = anonymizeValue(v, dd.AppendStringValue, opts.UnmarshalStringValue, a.pseudonymizeStringValue)
+		}
+	case *ddv1.Ia5StringValue:
+		if isSensitiveStringField(parent, fd) {
+			err = anonymizeValue(v, dd.AppendIa5StringValue, opts.UnmarshalIa5StringValue, a.pseudonymizeText)
+		}
+	case *ddv1.ExtendedSerialNumber:
+		err = anonymizeValue(v, dd.AppendExtendedSerialNumber, opts.UnmarshalExtendedSerialNumber, a.pseudonymizeSerialNumber)
+	case *ddv1.GeoCoordinates:
+		err = anonymizeValue(v, dd.AppendGeoCoordinates, opts.UnmarshalGeoCoordinates, a.shiftGeoCoordinates)
+	case *ddv1.Date:
+		if fd.Name() == "card_holder_birth_date" {
+			err = anonymizeValue(v, dd.AppendDate, opts.UnmarshalDate, a.pseudonymizeBirthDate)
+		}
+	default:
+		if isSecurityMessage(m) {
+			return nil
+		}
+		return a.anonymizeMessage(m)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to anonymize %s: %w", fd.FullName(), err)
+	}
+	return nil
+}
+
+// anonymizeValue replaces a data dictionary value by encoding it, replacing
+// the encoded bytes, and decoding the replacement into the value.
+//
+// The replace function returns nil when there is nothing to anonymize.
+func anonymizeValue[T proto.Message](
+	value T,
+	appendFunc func([]byte, T) ([]byte, error),
+	unmarshalFunc func([]byte) (T, error),
+	replace func([]byte) []byte,
+) error {
+	original, err := appendFunc(nil, value)
+	if err != nil {
+		return err
+	}
+	replacement := replace(original)
+	if replacement == nil || bytes.Equal(original, replacement) {
+		return nil
+	}
+	anonymized, err := unmarshalFunc(replacement)
+	if err != nil {
+		return err
+	}
+	proto.Reset(value)
+	proto.Merge(value, anonymized)
+	return nil
+}
+
+// anonymizeVIN replaces a vehicle identification number stored as a plain
+// string.
+func (a *anonymizer) anonymizeVIN(vin string) string {
+	const lenVIN = 17
+	if vin == "" || len(vin) > lenVIN {
+		return vin
+	}
+	original := []byte(fmt.Sprintf("%-*s", lenVIN, vin))
+	replacement := a.pseudonymizeText(original)
+	if replacement == nil {
+		return vin
+	}
+	return strings.TrimRight(string(replacement), " ")
+}
+
+// pseudonymizeText replaces the letters and digits in encoded text, keeping
+// all other characters.
+func (a *anonymizer) pseudonymizeText(original []byte) []byte {
+	if isBlank(original) {
+		return nil
+	}
+	r := a.rand("text", original)
+	result := make([]byte, len(original))
+	for i, c := range original {
+		switch {
+		case c >= '0' && c <= '9':
+			result[i] = '0' + byte(r.IntN(10))
+		case c >= 'A' && c <= 'Z':
+			result[i] = 'A' + byte(r.IntN(26))
+		case c >= 'a' && c <= 'z', c >= 0x80:
+			result[i] = 'a' + byte(r.IntN(26))
+		default:
+			result[i] = c
+		}
+	}
+	return result
+}
+
+// pseudonymizeStringValue replaces the text of an encoded string value,
+// keeping its code page.
+func (a *anonymizer) pseudonymizeStringValue(original []byte) []byte {
+	if len(original) < 2 {
+		return nil
+	}
+	text := a.pseudonymizeText(original[1:])
+	if text == nil {
+		return nil
+	}
+	return append([]byte{original[0]}, text...)
+}
+
+// pseudonymizeSerialNumber replaces the serial number of an encoded extended
+// serial number, keeping its date, type and manufacturer.
+func (a *anonymizer) pseudonymizeSerialNumber(original []byte) []byte {
+	if isBlank(original[0:4]) {
+		return nil
+	}
+	result := bytes.Clone(original)
+	binary.BigEndian.PutUint32(result[0:4], a.rand("serial", original).Uint32())
+	return result
+}
+
+// pseudonymizeBirthDate replaces the month and day of an encoded birth date,
+// keeping its year.
+func (a *anonymizer) pseudonymizeBirthDate(original []byte) []byte {
+	if isBlank(original) {
+		return nil
+	}
+	r := a.rand("date", original)
+	month, day := 1+r.IntN(12), 1+r.IntN(28)
+	result := bytes.Clone(original)
+	result[2] = byte(month/10<<4 | month%10)
+	result[3] = byte(day/10<<4 | day%10)
+	return result
+}
+
+// shiftGeoCoordinates shifts encoded coordinates by the position offset.
+func (a *anonymizer) shiftGeoCoordinates(original []byte) []byte {
+	const unknown = 0x7FFFFF
+	var opts dd.UnmarshalOptions
+	coordinates, err := opts.UnmarshalGeoCoordinates(original)
+	if err != nil || isBlank(original) || coordinates.GetLatitude() == unknown || coordinates.GetLongitude() == unknown {
+		return nil
+	}
+	lat := max(-90, min(90, geoCoordinateDegrees(coordinates.GetLatitude())+a.latOffset))
+	lon := math.Mod(geoCoordinateDegrees(coordinates.GetLongitude())+a.lonOffset+540, 360) - 180
+	shifted := &ddv1.GeoCoordinates{}
+	shifted.SetLatitude(degreesGeoCoordinate(lat))
+	shifted.SetLongitude(degreesGeoCoordinate(lon))
+	result, err := dd.AppendGeoCoordinates(nil, shifted)
+	if err != nil {
+		return nil
+	}
+	return result
+}
+
+// geoCoordinateDegrees converts a coordinate in the ±DDDMM.M × 10 format to
+// decimal degrees.
+func geoCoordinateDegrees(v int32) float64 {
+	sign := 1.0
+	if v < 0 {
+		sign, v = -1, -v
+	}
+	return sign * (float64(v/1000) + float64(v%1000)/600)
+}
+
+// degreesGeoCoordinate converts decimal degrees to a coordinate in the
+// ±DDDMM.M × 10 format.
+func degreesGeoCoordinate(degrees float64) int32 {
+	sign := int32(1)
+	if degrees < 0 {
+		sign, degrees = -1, -degrees
+	}
+	whole := math.Floor(degrees)
+	tenthMinutes := math.Round((degrees - whole) * 600)
+	if tenthMinutes == 600 {
+		whole, tenthMinutes = whole+1, 0
+	}
+	return sign * (int32(whole)*1000 + int32(tenthMinutes))
+}
+
+// removeCertificates removes the certificate EFs of a driver card file.
+func removeCertificates(driverCard *cardv1.DriverCardFile) {
+	if tachograph := driverCard.GetTachograph(); tachograph != nil {
+		tachograph.ClearCardCertificate()
+		tachograph.ClearCaCertificate()
+	}
+	if tachographG2 := driverCard.GetTachographG2(); tachographG2 != nil {
+		tachographG2.ClearCardMaCertificate()
+		tachographG2.ClearCardSignCertificate()
+		tachographG2.ClearCaCertificate()
+		tachographG2.ClearLinkCertificate()
+	}
+}
+
+// zeroSignatures zeroes the signatures of a message and its sub-messages.
+func zeroSignatures(m protoreflect.Message) {
+	for _, fd := range populatedFields(m) {
+		switch {
+		case fd.IsMap():
+			continue
+		case fd.IsList() && fd.Kind() == protoreflect.MessageKind:
+			list := m.Get(fd).List()
+			for i := range list.Len() {
+				zeroSignatures(list.Get(i).Message())
+			}
+		case fd.Kind() == protoreflect.MessageKind:
+			zeroSignatures(m.Get(fd).Message())
+		case fd.Kind() == protoreflect.BytesKind && strings.HasPrefix(string(fd.Name()), "signature"):
+			m.Set(fd, protoreflect.ValueOfBytes(make([]byte, len(m.Get(fd).Bytes()))))
+		}
+	}
+}
+
+// populatedFields returns the populated fields of a message, so that the
+// message can be modified while iterating.
+func populatedFields(m protoreflect.Message) []protoreflect.FieldDescriptor {
+	var fields []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, fd)
+		return true
+	})
+	return fields
+}
+
+// isSensitiveStringField reports whether a string value field holds personal
+// or identifying data.
+func isSensitiveStringField(parent protoreflect.Message, fd protoreflect.FieldDescriptor) bool {
+	if _, ok := parent.Interface().(*ddv1.VehicleRegistrationIdentification); ok {
+		return fd.Name() == "number"
+	}
+	return sensitiveStringFields[fd.Name()]
+}
+
+// isSecurityMessage reports whether a message is a certificate, which is not
+// anonymized.
+func isSecurityMessage(m protoreflect.Message) bool {
+	return strings.HasPrefix(string(m.Descriptor().FullName()), "wayplatform.connect.tachograph.security.")
+}
+
+// isBlank reports whether encoded data consists of a single repeated byte,
+// such as spaces or zeros, and so holds no information to anonymize.
+func isBlank(data []byte) bool {
+	for _, b := range data {
+		if b != data[0] {
+			return false
+		}
+	}
+	return true
+}
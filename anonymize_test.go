@@ -0,0 +1,222 @@
+package tachograph
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// testDriverCardData assembles a driver card file from the EF test data of
+// the card package.
+func testDriverCardData(t testing.TB) []byte {
+	t.Helper()
+	var data []byte
+	for _, ef := range []struct {
+		fileID uint16
+		name   string
+	}{
+		{fileID: 0x0002, name: "icc"},
+		{fileID: 0x0005, name: "ic"},
+		{fileID: 0x0501, name: "application_identification"},
+		{fileID: 0x0520, name: "identification"},
+		{fileID: 0x0521, name: "driving_licence"},
+		{fileID: 0x0505, name: "vehicles"},
+		{fileID: 0x0506, name: "places"},
+	} {
+		b64, err := os.ReadFile(filepath.Join("internal", "card", "testdata", ef.name+".b64"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		value, err := base64.StdEncoding.DecodeString(string(b64))
+		if err != nil {
+			t.Fatal(err)
+		}
+		data = binary.BigEndian.AppendUint16(data, ef.fileID)
+		data = append(data, 0x00)
+		data = binary.BigEndian.AppendUint16(data, uint16(len(value)))
+		data = append(data, value...)
+	}
+	return data
+}
+
+func TestAnonymizeFile(t *testing.T) {
+	file, err := UnmarshalFile(testDriverCardData(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := proto.CloneOf(file)
+
+	anonymized, err := AnonymizeOptions{Seed: 1}.AnonymizeFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(file, original) {
+		t.Error("AnonymizeFile() modified its input")
+	}
+
+	data, err := MarshalFile(anonymized)
+	if err != nil {
+		t.Fatalf("MarshalFile() error = %v", err)
+	}
+	for _, sensitive := range []string{"TEST_SURNAME", "TEST_FIRSTNAME", "DRIVER00000001", "TEST-DL-123", "TEST-VRN"} {
+		if bytes.Contains(data, []byte(sensitive)) {
+			t.Errorf("anonymized file contains %q", sensitive)
+		}
+	}
+	roundTripped, err := UnmarshalFile(data)
+	if err != nil {
+		t.Fatalf("UnmarshalFile() error = %v", err)
+	}
+	if !proto.Equal(roundTripped, anonymized) {
+		t.Error("anonymized file does not round-trip")
+	}
+
+	tachograph := anonymized.GetDriverCard().GetTachograph()
+	holder := tachograph.GetIdentification().GetDriverCardHolder()
+	if got := holder.GetCardHolderSurname().GetValue(); len(got) != len("TEST_SURNAME") || got[4] != '_' {
+		t.Errorf("surname = %q, want format of TEST_SURNAME", got)
+	}
+	if got := holder.GetCardHolderBirthDate().GetYear(); got != 2000 {
+		t.Errorf("birth year = %d, want 2000", got)
+	}
+	if got, want := DriverCardNumber(anonymized.GetDriverCard()), DriverCardNumber(file.GetDriverCard()); got == want || len(got) != len(want) {
+		t.Errorf("card number = %q, want pseudonym of %q", got, want)
+	}
+	uses := VehicleUses(anonymized.GetDriverCard())
+	originalUses := VehicleUses(file.GetDriverCard())
+	if len(uses) != len(originalUses) {
+		t.Fatalf("got %d vehicle uses, want %d", len(uses), len(originalUses))
+	}
+	for i := range uses {
+		if uses[i].Registration.Number == originalUses[i].Registration.Number ||
+			!uses[i].FirstUse.Equal(originalUses[i].FirstUse) ||
+			uses[i].OdometerEndKm != originalUses[i].OdometerEndKm {
+			t.Errorf("vehicle use %d = %+v, want anonymized %+v", i, uses[i], originalUses[i])
+		}
+	}
+
+	again, err := AnonymizeOptions{Seed: 1}.AnonymizeFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(again, anonymized) {
+		t.Error("AnonymizeFile() is not deterministic")
+	}
+	otherSeed, err := AnonymizeOptions{Seed: 2}.AnonymizeFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proto.Equal(otherSeed, anonymized) {
+		t.Error("AnonymizeFile() ignores the seed")
+	}
+}
+
+func TestAnonymizeFile_certificates(t *testing.T) {
+	certificate := bytes.Repeat([]byte{0xC1}, 194)
+	data := testDriverCardData(t)
+	data = append(data, 0xC1, 0x00, 0x00, 0x00, 194)
+	data = append(data, certificate...)
+	file, err := UnmarshalFile(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !file.GetDriverCard().GetTachograph().HasCardCertificate() {
+		t.Fatal("test card has no certificate")
+	}
+	anonymized, err := AnonymizeFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if anonymized.GetDriverCard().GetTachograph().HasCardCertificate() {
+		t.Error("anonymized card has a certificate")
+	}
+	data, err = MarshalFile(anonymized)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, certificate[:16]) {
+		t.Error("anonymized file contains the certificate")
+	}
+}
+
+func TestAnonymizeFile_vehicleUnit(t *testing.T) {
+	for _, name := range []string{"synthetic_gen1", "synthetic_gen2_v1", "synthetic_gen2_v2"} {
+		t.Run(name, func(t *testing.T) {
+			file, err := UnmarshalFile(testSyntheticVehicleUnitFile(t, name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			anonymized, err := AnonymizeOptions{Seed: 1}.AnonymizeFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := MarshalFile(anonymized)
+			if err != nil {
+				t.Fatalf("MarshalFile() error = %v", err)
+			}
+			for _, sensitive := range []string{
+				"MUSTERMANN", "D1234567890123", "W123456789012", "WDB9634031L123456",
+				"M-AB 1234", "M-XY 987", "Werkstatt Beispiel", "Hauptstrasse 1", "Spedition Muster",
+			} {
+				if bytes.Contains(data, []byte(sensitive)) {
+					t.Errorf("anonymized file contains %q", sensitive)
+				}
+			}
+			for _, certificate := range [][]byte{bytes.Repeat([]byte{0xC1}, 16), bytes.Repeat([]byte{0xC2}, 16)} {
+				if bytes.Contains(data, certificate) {
+					t.Errorf("anonymized file contains certificate data % X", certificate)
+				}
+			}
+
+			records := newVehicleUnitRecords(anonymized.GetVehicleUnit())
+			original := newVehicleUnitRecords(file.GetVehicleUnit())
+			if len(records.vin) != len(original.vin) || records.vin == original.vin {
+				t.Errorf("VIN = %q, want pseudonym of %q", records.vin, original.vin)
+			}
+			if records.download.Registration.Nation != original.download.Registration.Nation ||
+				records.download.Registration.Number == original.download.Registration.Number {
+				t.Errorf("registration = %+v, want anonymized %+v", records.download.Registration, original.download.Registration)
+			}
+			if !records.download.DownloadTime.Equal(original.download.DownloadTime) {
+				t.Errorf("download time = %v, want %v", records.download.DownloadTime, original.download.DownloadTime)
+			}
+			if len(records.cardInsertions) != 1 {
+				t.Fatalf("card insertions = %d, want 1", len(records.cardInsertions))
+			}
+			insertion, originalInsertion := records.cardInsertions[0], original.cardInsertions[0]
+			if insertion.CardHolderName == originalInsertion.CardHolderName ||
+				!insertion.InsertionTime.Equal(originalInsertion.InsertionTime) ||
+				insertion.OdometerAtWithdrawalKm != originalInsertion.OdometerAtWithdrawalKm {
+				t.Errorf("card insertion = %+v, want anonymized %+v", insertion, originalInsertion)
+			}
+			// Card numbers get the same pseudonyms as in card files.
+			driverIdentification := string(newAnonymizer(1).pseudonymizeText([]byte("D1234567890123")))
+			if !strings.Contains(insertion.CardNumber, driverIdentification) {
+				t.Errorf("card number = %q, want pseudonym %q", insertion.CardNumber, driverIdentification)
+			}
+			if len(records.events) != len(original.events) || len(records.speedBlocks) != len(original.speedBlocks) {
+				t.Errorf("events and speed blocks = %d %d, want %d %d",
+					len(records.events), len(records.speedBlocks), len(original.events), len(original.speedBlocks))
+			}
+			for i := range records.places {
+				if records.places[i].Position != nil && proto.Equal(records.places[i].Position, original.places[i].Position) {
+					t.Errorf("place %d position = %v, want shifted", i, records.places[i].Position)
+				}
+			}
+		})
+	}
+}
+
+func TestGeoCoordinateDegrees(t *testing.T) {
+	for _, v := range []int32{0, 48075, -48075, 179599, -120005, 90000} {
+		if got := degreesGeoCoordinate(geoCoordinateDegrees(v)); got != v {
+			t.Errorf("degreesGeoCoordinate(geoCoordinateDegrees(%d)) = %d", v, got)
+		}
+	}
+}
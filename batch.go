@@ -0,0 +1,384 @@
+package tachograph
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+)
+
+// BatchOptions configures the processing of many tachograph files.
+type BatchOptions struct {
+	// Workers is the maximum number of files processed concurrently.
+	// If zero, this defaults to [runtime.GOMAXPROCS].
+	Workers int
+	// Verify enables the verification of the certificates of each file.
+	Verify bool
+	// VerifyOptions configures the verification, if enabled.
+	VerifyOptions VerifyOptions
+}
+
+// VerificationStatus is the outcome of the verification of a file in a batch.
+type VerificationStatus int
+
+const (
+	// VerificationSkipped means that the file was not verified.
+	VerificationSkipped VerificationStatus = iota
+	// VerificationValid means that the certificates of the file are valid.
+	VerificationValid
+	// VerificationInvalid means that the verification of the file failed.
+	VerificationInvalid
+	// VerificationUnsupported means that verification is not supported for the file type.
+	VerificationUnsupported
+)
+
+// String returns the name of the verification status.
+func (s VerificationStatus) String() string {
+	switch s {
+	case VerificationSkipped:
+		return "SKIPPED"
+	case VerificationValid:
+		return "VALID"
+	case VerificationInvalid:
+		return "INVALID"
+	case VerificationUnsupported:
+		return "NOT SUPPORTED"
+	default:
+		return fmt.Sprintf("VerificationStatus(%d)", int(s))
+	}
+}
+
+// BatchResult is the summary of a file processed in a batch.
+type BatchResult struct {
+	// Path is the path of the file. For a file in a zip archive, this is the
+	// path of the archive joined with the name of the file in the archive.
+	Path string
+	// Type is the type of the file, if it could be parsed.
+	Type tachographv1.File_Type
+	// Generation is the generation of the file data, if known.
+	Generation ddv1.Generation
+	// CardNumber is the card number of a driver card.
+	CardNumber string
+	// VIN is the vehicle identification number of a vehicle unit.
+	VIN string
+	// Period is the period covered by the activity data of a driver card, or by
+	// the downloadable period of a vehicle unit.
+	Period Period
+	// FileName is the name of the file following the naming convention of
+	// downloaded files, if the file holds the data it is derived from.
+	// See [NewFileName].
+	FileName string
+	// Verification is the outcome of the verification of the file.
+	Verification VerificationStatus
+	// Err is the error that occurred while reading, parsing or verifying the
+	// file, if any.
+	Err error
+}
+
+// ProcessFiles parses and summarizes tachograph files, directories and zip archives.
+//
+// See [BatchOptions] if you need more control over the processing.
+func ProcessFiles(ctx context.Context, paths ...string) ([]BatchResult, error) {
+	return BatchOptions{}.ProcessFiles(ctx, paths...)
+}
+
+// ProcessFiles parses and summarizes tachograph files, directories and zip archives.
+//
+// Directories are walked recursively for files with a tachograph file
+// extension (.ddd, .c1b, .v1b, .esm, .tgd) and zip archives, and zip archives
+// are read for files with a tachograph file extension. Paths given
+// explicitly are processed regardless of their extension.
+//
+// Files are processed by a bounded pool of workers, and processing continues
+// past failures: the error of each file, or the panic it caused, is reported
+// in its result. The results are returned in the order the files were found.
+// Zip archives are only held open while their files are processed.
+//
+// Returns an error only if the context is canceled before all files are
+// processed, together with the results processed so far.
+func (o BatchOptions) ProcessFiles(ctx context.Context, paths ...string) ([]BatchResult, error) {
+	workers := o.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	var sources []batchSource
+	var archives []*batchArchive
+	defer func() {
+		for _, archive := range archives {
+			archive.close()
+		}
+	}()
+	addArchive := func(path string) {
+		archive, err := newBatchArchive(path)
+		if err != nil {
+			sources = append(sources, batchSource{path: path, err: fmt.Errorf("error opening zip archive %s: %w", path, err)})
+			return
+		}
+		archives = append(archives, archive)
+		for _, f := range archive.files {
+			sources = append(sources, batchSource{
+				path: filepath.Join(path, filepath.FromSlash(f.name)),
+				open: func() (io.ReadCloser, error) { return archive.open(f.index) },
+				done: archive.done,
+			})
+		}
+	}
+	addFile := func(path string) {
+		sources = append(sources, batchSource{path: path, open: func() (io.ReadCloser, error) { return os.Open(path) }})
+	}
+	for _, root := range paths {
+		info, err := os.Stat(root)
+		switch {
+		case err != nil:
+			sources = append(sources, batchSource{path: root, err: err})
+		case info.IsDir():
+			err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+				switch {
+				case err != nil:
+					sources = append(sources, batchSource{path: path, err: err})
+				case d.IsDir():
+				case isZipFileName(path):
+					addArchive(path)
+				case isTachographFileName(path):
+					addFile(path)
+				}
+				return nil
+			})
+			if err != nil {
+				sources = append(sources, batchSource{path: root, err: err})
+			}
+		case isZipFileName(root):
+			addArchive(root)
+		default:
+			addFile(root)
+		}
+	}
+	results := make([]BatchResult, len(sources))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(workers, len(sources)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = o.process(ctx, sources[i])
+			}
+		}()
+	}
+	err := ctx.Err()
+	n := 0
+feed:
+	for ; err == nil && n < len(sources); n++ {
+		select {
+		case indexes <- n:
+		case <-ctx.Done():
+			err = ctx.Err()
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	return results[:n], err
+}
+
+// batchSource is a file to be processed in a batch.
+type batchSource struct {
+	path string
+	// open opens the file for reading.
+	open func() (io.ReadCloser, error)
+	// err is the error that occurred while finding the file, if any.
+	err error
+	// done is called after the file is processed, if set.
+	done func()
+}
+
+// batchArchive is a zip archive of files processed in a batch.
+//
+// The archive is only open while its files are processed: it is opened when
+// the first of its files is read, and closed after the last is processed, so
+// that a batch of many archives doesn't hold all of them open.
+type batchArchive struct {
+	path  string
+	files []batchArchiveFile
+
+	mu        sync.Mutex
+	reader    *zip.ReadCloser
+	remaining int
+}
+
+// batchArchiveFile is a tachograph file in a zip archive.
+type batchArchiveFile struct {
+	// index is the index of the file in the archive's directory.
+	index int
+	name  string
+}
+
+// newBatchArchive lists the tachograph files of a zip archive.
+func newBatchArchive(path string) (*batchArchive, error) {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	archive := &batchArchive{path: path}
+	for i, f := range reader.File {
+		if f.FileInfo().IsDir() || !isTachographFileName(f.Name) {
+			continue
+		}
+		archive.files = append(archive.files, batchArchiveFile{index: i, name: f.Name})
+	}
+	archive.remaining = len(archive.files)
+	return archive, nil
+}
+
+// open opens the file at index in the archive's directory, opening the
+// archive if needed.
+func (a *batchArchive) open(index int) (io.ReadCloser, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.reader == nil {
+		reader, err := zip.OpenReader(a.path)
+		if err != nil {
+			return nil, err
+		}
+		a.reader = reader
+	}
+	if index >= len(a.reader.File) {
+		return nil, fmt.Errorf("file %d not found in zip archive %s", index, a.path)
+	}
+	return a.reader.File[index].Open()
+}
+
+// done closes the archive after the last of its files is processed.
+func (a *batchArchive) done() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.remaining--; a.remaining == 0 {
+		a.closeLocked()
+	}
+}
+
+// close closes the archive if it is open, such as when the batch is canceled
+// before all its files are processed.
+func (a *batchArchive) close() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.closeLocked()
+}
+
+func (a *batchArchive) closeLocked() {
+	if a.reader != nil {
+		_ = a.reader.Close()
+		a.reader = nil
+	}
+}
+
+// process reads, parses, summarizes and optionally verifies a single file.
+//
+// A panic while processing the file is recovered and reported as the error of
+// its result, so that a single malformed file doesn't abort the batch.
+func (o BatchOptions) process(ctx context.Context, source batchSource) (result BatchResult) {
+	if source.done != nil {
+		defer source.done()
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			result = BatchResult{Path: source.path, Err: fmt.Errorf("panic processing file %s: %v", source.path, r)}
+		}
+	}()
+	result = BatchResult{Path: source.path, Err: source.err}
+	if result.Err != nil {
+		return result
+	}
+	data, err := readBatchSource(source)
+	if err != nil {
+		result.Err = fmt.Errorf("error reading file %s: %w", source.path, err)
+		return result
+	}
+	file, err := UnmarshalFile(data)
+	if err != nil {
+		result.Err = fmt.Errorf("error parsing file %s: %w", source.path, err)
+		return result
+	}
+	result.Type = file.GetType()
+	switch file.GetType() {
+	case tachographv1.File_DRIVER_CARD:
+		card := file.GetDriverCard()
+		result.CardNumber = DriverCardNumber(card)
+		result.Generation = ddv1.Generation_GENERATION_1
+		if card.GetTachographG2() != nil {
+			result.Generation = ddv1.Generation_GENERATION_2
+		}
+		for _, p := range ActivityPeriods(card) {
+			if result.Period.Start.IsZero() || p.Start.Before(result.Period.Start) {
+				result.Period.Start = p.Start
+			}
+			if p.End.After(result.Period.End) {
+				result.Period.End = p.End
+			}
+		}
+	case tachographv1.File_VEHICLE_UNIT:
+		h, err := MergeVehicleUnitFiles(file.GetVehicleUnit())
+		if err != nil {
+			result.Err = fmt.Errorf("error reading vehicle unit file %s: %w", source.path, err)
+			return result
+		}
+		result.VIN = h.VIN
+		if len(h.Downloads) > 0 {
+			result.Generation = h.Downloads[0].Generation
+		}
+		if n := len(h.Coverage); n > 0 {
+			result.Period = Period{Start: h.Coverage[0].Start, End: h.Coverage[n-1].End}
+		}
+	}
+	if name, err := NewFileName(file); err == nil {
+		result.FileName = name.String()
+	}
+	if !o.Verify {
+		return result
+	}
+	switch file.GetType() {
+	case tachographv1.File_DRIVER_CARD:
+		if err := o.VerifyOptions.VerifyFile(ctx, file); err != nil {
+			result.Verification = VerificationInvalid
+			result.Err = fmt.Errorf("error verifying file %s: %w", source.path, err)
+		} else {
+			result.Verification = VerificationValid
+		}
+	default:
+		result.Verification = VerificationUnsupported
+	}
+	return result
+}
+
+func readBatchSource(source batchSource) ([]byte, error) {
+	r, err := source.open()
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(r)
+	return data, errors.Join(err, r.Close())
+}
+
+// tachographFileExtensions are the extensions of downloaded tachograph files
+// in common use.
+var tachographFileExtensions = []string{".ddd", ".c1b", ".v1b", ".esm", ".tgd"}
+
+func isTachographFileName(name string) bool {
+	return slices.Contains(tachographFileExtensions, strings.ToLower(filepath.Ext(name)))
+}
+
+func isZipFileName(name string) bool {
+	return strings.EqualFold(filepath.Ext(name), ".zip")
+}
@@ -0,0 +1,166 @@
+package tachograph
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+)
+
+func TestProcessFiles(t *testing.T) {
+	dir := t.TempDir()
+	data := testDriverCardData(t)
+	for name, content := range map[string][]byte{
+		"2024/01/driver.DDD": data,
+		"2024/01/broken.ddd": {0x00, 0x02, 0x00},
+		"2024/01/notes.txt":  []byte("not a tachograph file"),
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	archive, err := os.Create(filepath.Join(dir, "2024", "02.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(archive)
+	w, err := zw.Create("02/driver.ddd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := BatchOptions{Workers: 2}.ProcessFiles(t.Context(), dir, filepath.Join(dir, "missing.ddd"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, r := range results {
+		rel, err := filepath.Rel(dir, r.Path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, filepath.ToSlash(rel))
+	}
+	want := []string{"2024/01/broken.ddd", "2024/01/driver.DDD", "2024/02.zip/02/driver.ddd", "missing.ddd"}
+	if len(paths) != len(want) {
+		t.Fatalf("got paths %v, want %v", paths, want)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("paths[%d] = %s, want %s", i, paths[i], want[i])
+		}
+	}
+	if results[0].Err == nil {
+		t.Error("expected an error for the broken file")
+	}
+	for _, r := range results[1:3] {
+		if r.Err != nil {
+			t.Errorf("%s: %v", r.Path, r.Err)
+		}
+		if r.Type != tachographv1.File_DRIVER_CARD || r.CardNumber == "" {
+			t.Errorf("%s: got type %v and card number %q, want a driver card", r.Path, r.Type, r.CardNumber)
+		}
+		if r.Verification != VerificationSkipped {
+			t.Errorf("%s: verification = %v, want %v", r.Path, r.Verification, VerificationSkipped)
+		}
+	}
+	if !errors.Is(results[3].Err, os.ErrNotExist) {
+		t.Errorf("missing file error = %v, want %v", results[3].Err, os.ErrNotExist)
+	}
+}
+
+func TestProcessFiles_canceled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "driver.ddd")
+	if err := os.WriteFile(path, testDriverCardData(t), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	if _, err := ProcessFiles(ctx, path); !errors.Is(err, context.Canceled) {
+		t.Errorf("ProcessFiles() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestProcessFiles_panic(t *testing.T) {
+	source := batchSource{
+		path: "panic.ddd",
+		open: func() (io.ReadCloser, error) { panic("malformed") },
+	}
+	result := BatchOptions{}.process(t.Context(), source)
+	if result.Err == nil || result.Path != source.path {
+		t.Errorf("process() = %+v, want an error for the panic", result)
+	}
+}
+
+func TestBatchArchive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "files.zip")
+	archive, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(archive)
+	for _, name := range []string{"a.ddd", "notes.txt", "b.DDD"} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := newBatchArchive(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.files) != 2 || a.files[0].name != "a.ddd" || a.files[1].name != "b.DDD" {
+		t.Fatalf("files = %v, want a.ddd and b.DDD", a.files)
+	}
+	if a.reader != nil {
+		t.Error("archive is open before its files are read")
+	}
+	for i, f := range a.files {
+		r, err := a.open(f.index)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := r.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != f.name {
+			t.Errorf("%s: read %q", f.name, data)
+		}
+		a.done()
+		if open, last := a.reader != nil, i == len(a.files)-1; open == last {
+			t.Errorf("after %s: archive open = %v", f.name, open)
+		}
+	}
+}
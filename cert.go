@@ -42,3 +42,32 @@ func DefaultCertificateResolver() CertificateResolver {
 		cert.NewClient(http.DefaultClient),
 	)
 }
+
+// EmbeddedCertificateResolver returns a certificate resolver that only uses
+// the embedded certificates, and never accesses the network.
+func EmbeddedCertificateResolver() CertificateResolver {
+	return cert.NewEmbeddedResolver()
+}
+
+// DirectoryCertificateResolver returns a certificate resolver that reads
+// certificates from a local directory.
+//
+// The directory uses the following layout, where CHR is the decimal
+// Certificate Holder Reference of the certificate:
+//
+//	root/EC_PK.bin   the European Root CA certificate
+//	g1/<CHR>.bin     Generation 1 (RSA) certificates
+//	g2/<CHR>.bin     Generation 2 (ECC) certificates
+func DirectoryCertificateResolver(dir string) CertificateResolver {
+	return cert.NewDirectoryResolver(dir)
+}
+
+// ChainCertificateResolvers returns a certificate resolver that tries each of
+// the given resolvers in order, until one succeeds.
+func ChainCertificateResolvers(resolvers ...CertificateResolver) CertificateResolver {
+	chain := make([]cert.Resolver, 0, len(resolvers))
+	for _, r := range resolvers {
+		chain = append(chain, r)
+	}
+	return cert.NewChainResolver(chain...)
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/way-platform/tachograph-go"
+)
+
+func newAnonymizeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "anonymize <file1> [file2] [...]",
+		Short: "Anonymize .DDD files",
+		Long: `Anonymize .DDD files, replacing personal and identifying data with pseudonyms.
+
+Each file is written next to the original, with an "_anonymized" suffix, unless an output directory is given.
+All files anonymized together use the same seed, so that the same card or vehicle gets the same pseudonym in every file.
+Without a seed, a random seed is used.`,
+		GroupID: "ddd",
+		Args:    cobra.MinimumNArgs(1),
+	}
+	seed := cmd.Flags().Int64("seed", 0, "seed for deterministic pseudonyms")
+	outputDir := cmd.Flags().StringP("output-dir", "o", "", "directory to write the anonymized files to")
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		opts := tachograph.AnonymizeOptions{Seed: *seed}
+		if !cmd.Flags().Changed("seed") {
+			var b [8]byte
+			if _, err := rand.Read(b[:]); err != nil {
+				return fmt.Errorf("error generating seed: %w", err)
+			}
+			opts.Seed = int64(binary.BigEndian.Uint64(b[:]))
+		}
+		for _, filename := range args {
+			data, err := os.ReadFile(filename)
+			if err != nil {
+				return fmt.Errorf("error reading file %s: %w", filename, err)
+			}
+			file, err := tachograph.UnmarshalFile(data)
+			if err != nil {
+				return fmt.Errorf("error parsing file %s: %w", filename, err)
+			}
+			anonymized, err := opts.AnonymizeFile(file)
+			if err != nil {
+				return fmt.Errorf("error anonymizing file %s: %w", filename, err)
+			}
+			output, err := tachograph.MarshalFile(anonymized)
+			if err != nil {
+				return fmt.Errorf("error marshaling file %s: %w", filename, err)
+			}
+			outputFilename := anonymizedFilename(filename, *outputDir)
+			if err := os.WriteFile(outputFilename, output, 0o644); err != nil {
+				return fmt.Errorf("error writing file %s: %w", outputFilename, err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), outputFilename)
+		}
+		return nil
+	}
+	return cmd
+}
+
+// anonymizedFilename returns the filename of the anonymized copy of a file.
+func anonymizedFilename(filename, outputDir string) string {
+	ext := filepath.Ext(filename)
+	base := strings.TrimSuffix(filepath.Base(filename), ext) + "_anonymized" + ext
+	if outputDir == "" {
+		outputDir = filepath.Dir(filename)
+	}
+	return filepath.Join(outputDir, base)
+}
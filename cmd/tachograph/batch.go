@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/way-platform/tachograph-go"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+)
+
+func newBatchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "batch <path1> [path2] [...]",
+		Short:   "Summarize directories and zip archives of .DDD files",
+		GroupID: "ddd",
+		Args:    cobra.MinimumNArgs(1),
+	}
+	workers := cmd.Flags().Int("workers", 0, "number of files processed in parallel (default: number of CPUs)")
+	verify := cmd.Flags().Bool("verify", false, "verify the certificates of each file")
+	offline := cmd.Flags().Bool("offline", false, "resolve certificates without network access")
+	certDir := cmd.Flags().String("cert-dir", "", "directory with local certificates (root/EC_PK.bin, g1/<CHR>.bin, g2/<CHR>.bin)")
+	format := cmd.Flags().String("format", "table", "output format (table, jsonl)")
+	names := cmd.Flags().Bool("names", false, "include the file name of each file following the naming convention of downloads")
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if *format != "table" && *format != "jsonl" {
+			return fmt.Errorf("unsupported output format: %s", *format)
+		}
+		opts := tachograph.BatchOptions{
+			Workers: *workers,
+			Verify:  *verify,
+		}
+		if *verify {
+			opts.VerifyOptions.CertificateResolver = certificateResolver(*offline, *certDir)
+		}
+		results, err := opts.ProcessFiles(cmd.Context(), args...)
+		var failed int
+		for _, r := range results {
+			if r.Err != nil {
+				failed++
+			}
+		}
+		if *format == "jsonl" {
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			for _, r := range results {
+				record := newBatchRecord(r)
+				if !*names {
+					record.FileName = ""
+				}
+				if err := enc.Encode(record); err != nil {
+					return err
+				}
+			}
+		} else {
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			header := "FILE\tTYPE\tGENERATION\tCARD/VIN\tPERIOD\tVERIFICATION\tERROR"
+			if *names {
+				header = "FILE\tNAME\tTYPE\tGENERATION\tCARD/VIN\tPERIOD\tVERIFICATION\tERROR"
+			}
+			fmt.Fprintln(w, header)
+			for _, r := range results {
+				record := newBatchRecord(r)
+				period := "-"
+				if record.PeriodStart != "" {
+					period = record.PeriodStart + " - " + record.PeriodEnd
+				}
+				path := record.Path
+				if *names {
+					path += "\t" + orDash(record.FileName)
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+					path,
+					orDash(record.Type),
+					orDash(record.Generation),
+					orDash(record.CardNumber+record.VIN),
+					period,
+					record.Verification,
+					orDash(record.Error))
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if failed > 0 {
+			return fmt.Errorf("processing failed for %d of %d files", failed, len(results))
+		}
+		return nil
+	}
+	return cmd
+}
+
+// batchRecord is a line of the batch command's JSON lines output.
+type batchRecord struct {
+	Path         string `json:"path"`
+	Type         string `json:"type,omitempty"`
+	Generation   string `json:"generation,omitempty"`
+	CardNumber   string `json:"card_number,omitempty"`
+	VIN          string `json:"vin,omitempty"`
+	PeriodStart  string `json:"period_start,omitempty"`
+	PeriodEnd    string `json:"period_end,omitempty"`
+	FileName     string `json:"file_name,omitempty"`
+	Verification string `json:"verification"`
+	Error        string `json:"error,omitempty"`
+}
+
+func newBatchRecord(r tachograph.BatchResult) batchRecord {
+	record := batchRecord{
+		Path:         r.Path,
+		CardNumber:   r.CardNumber,
+		VIN:          r.VIN,
+		FileName:     r.FileName,
+		Verification: r.Verification.String(),
+	}
+	switch r.Type {
+	case tachographv1.File_DRIVER_CARD:
+		record.Type = "driver card"
+	case tachographv1.File_VEHICLE_UNIT:
+		record.Type = "vehicle unit"
+	case tachographv1.File_RAW_CARD:
+		record.Type = "raw card"
+	}
+	if r.Generation != 0 {
+		record.Generation = strings.TrimPrefix(r.Generation.String(), "GENERATION_")
+	}
+	if !r.Period.Start.IsZero() {
+		record.PeriodStart = r.Period.Start.UTC().Format(time.RFC3339)
+		record.PeriodEnd = r.Period.End.UTC().Format(time.RFC3339)
+	}
+	if r.Err != nil {
+		record.Error = r.Err.Error()
+	}
+	return record
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
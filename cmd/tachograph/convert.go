@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/way-platform/tachograph-go"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+)
+
+// File formats supported by the convert command.
+const (
+	formatDDD       = "ddd"
+	formatJSON      = "json"
+	formatTextproto = "textproto"
+	formatBinpb     = "binpb"
+)
+
+func newConvertCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "convert <input> <output>",
+		Short: "Convert between .DDD files and protobuf formats",
+		Long: `Convert between .DDD files and protobuf formats.
+
+Supported formats are ddd (driver card and vehicle unit downloads), json (protojson),
+textproto and binpb (binary protobuf). Formats are inferred from the file extensions
+(.ddd, .c1b, .v1b, .tgd, .esm, .json, .txtpb, .textproto, .binpb, .pb), unless given
+with --from and --to. Use - to read from stdin or write to stdout.
+
+When hand-editing a value of a driver card in json or textproto, remove its raw
+data as well, or convert with --ignore-raw-data, since raw data takes precedence
+over values when marshaling a .DDD file. The transfers of vehicle unit files are
+always written from their raw data, so edits to their values are not written.`,
+		GroupID: "ddd",
+		Args:    cobra.ExactArgs(2),
+	}
+	from := cmd.Flags().String("from", "", "input format (ddd, json, textproto, binpb)")
+	to := cmd.Flags().String("to", "", "output format (ddd, json, textproto, binpb)")
+	ignoreRawData := cmd.Flags().Bool("ignore-raw-data", false, "marshal .DDD files from parsed values only, ignoring raw data")
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		input, output := args[0], args[1]
+		fromFormat, err := resolveFormat(*from, input)
+		if err != nil {
+			return fmt.Errorf("error resolving input format: %w", err)
+		}
+		toFormat, err := resolveFormat(*to, output)
+		if err != nil {
+			return fmt.Errorf("error resolving output format: %w", err)
+		}
+		var data []byte
+		if input == "-" {
+			data, err = io.ReadAll(cmd.InOrStdin())
+		} else {
+			data, err = os.ReadFile(input)
+		}
+		if err != nil {
+			return fmt.Errorf("error reading file %s: %w", input, err)
+		}
+		file, err := decodeFile(fromFormat, data)
+		if err != nil {
+			return fmt.Errorf("error decoding file %s: %w", input, err)
+		}
+		result, err := encodeFile(toFormat, file, tachograph.MarshalOptions{IgnoreRawData: *ignoreRawData})
+		if err != nil {
+			return fmt.Errorf("error encoding file %s: %w", output, err)
+		}
+		if output == "-" {
+			_, err = cmd.OutOrStdout().Write(result)
+		} else {
+			err = os.WriteFile(output, result, 0o644)
+		}
+		if err != nil {
+			return fmt.Errorf("error writing file %s: %w", output, err)
+		}
+		return nil
+	}
+	return cmd
+}
+
+// resolveFormat returns the given format, or infers it from the extension of
+// the filename.
+func resolveFormat(format, filename string) (string, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(filename)) {
+		case ".ddd", ".c1b", ".v1b", ".tgd", ".esm":
+			format = formatDDD
+		case ".json":
+			format = formatJSON
+		case ".txtpb", ".textproto":
+			format = formatTextproto
+		case ".binpb", ".pb":
+			format = formatBinpb
+		default:
+			return "", fmt.Errorf("unknown format of %s, specify the format explicitly", filename)
+		}
+	}
+	switch format {
+	case formatDDD, formatJSON, formatTextproto, formatBinpb:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+// decodeFile decodes a tachograph file in the given format.
+func decodeFile(format string, data []byte) (*tachographv1.File, error) {
+	switch format {
+	case formatDDD:
+		return tachograph.UnmarshalFile(data)
+	case formatJSON, formatTextproto, formatBinpb:
+		var file tachographv1.File
+		var err error
+		switch format {
+		case formatJSON:
+			err = protojson.Unmarshal(data, &file)
+		case formatTextproto:
+			err = prototext.Unmarshal(data, &file)
+		default:
+			err = proto.Unmarshal(data, &file)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &file, nil
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+// encodeFile encodes a tachograph file in the given format.
+func encodeFile(format string, file *tachographv1.File, opts tachograph.MarshalOptions) ([]byte, error) {
+	switch format {
+	case formatDDD:
+		return opts.MarshalFile(file)
+	case formatJSON:
+		return []byte(protojson.Format(file) + "\n"), nil
+	case formatTextproto:
+		return []byte(prototext.Format(file)), nil
+	case formatBinpb:
+		return proto.Marshal(file)
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/way-platform/tachograph-go"
+)
+
+func TestResolveFormat(t *testing.T) {
+	for _, tt := range []struct {
+		filename string
+		want     string
+	}{
+		{filename: "card.DDD", want: formatDDD},
+		{filename: "C_20240302_0930_M_MUSTERMANN_D1234567890123.C1B", want: formatDDD},
+		{filename: "M_20240302_0930_M-AB-1234.V1B", want: formatDDD},
+		{filename: "download.tgd", want: formatDDD},
+		{filename: "download.esm", want: formatDDD},
+		{filename: "card.json", want: formatJSON},
+		{filename: "card.txtpb", want: formatTextproto},
+		{filename: "card.pb", want: formatBinpb},
+	} {
+		got, err := resolveFormat("", tt.filename)
+		if err != nil {
+			t.Errorf("resolveFormat(%q) error = %v", tt.filename, err)
+		} else if got != tt.want {
+			t.Errorf("resolveFormat(%q) = %q, want %q", tt.filename, got, tt.want)
+		}
+	}
+	if _, err := resolveFormat("", "card.txt"); err == nil {
+		t.Error("resolveFormat(card.txt): expected error")
+	}
+}
+
+func TestConvert_vehicleUnit(t *testing.T) {
+	data, err := os.ReadFile("../../testdata/vu/synthetic_gen2_v2.DDD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, format := range []string{formatJSON, formatTextproto, formatBinpb} {
+		t.Run(format, func(t *testing.T) {
+			file, err := decodeFile(formatDDD, data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			encoded, err := encodeFile(format, file, tachograph.MarshalOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := decodeFile(format, encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := encodeFile(formatDDD, decoded, tachograph.MarshalOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Error("converted ddd file differs from the original")
+			}
+		})
+	}
+}
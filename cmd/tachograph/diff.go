@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/way-platform/tachograph-go"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+)
+
+func newDiffCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <file1> <file2>",
+		Short: "Compare two tachograph files semantically",
+		Long: `Compare two tachograph files semantically.
+
+Records are matched by their natural key, such as the date of a daily record or the
+begin time of an event, rather than by their position in the file.
+Files are read as .DDD files, unless their extension is .json, .txtpb, .textproto, .binpb or .pb.
+
+Each difference is printed on a line, prefixed with + (added), - (removed) or ~ (modified).`,
+		GroupID: "ddd",
+		Args:    cobra.ExactArgs(2),
+	}
+	raw := cmd.Flags().Bool("raw", false, "include raw data in the comparison")
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		a, err := readDiffFile(args[0])
+		if err != nil {
+			return err
+		}
+		b, err := readDiffFile(args[1])
+		if err != nil {
+			return err
+		}
+		for _, d := range (tachograph.DiffOptions{IncludeRawData: *raw}).DiffFiles(a, b) {
+			fmt.Fprintln(cmd.OutOrStdout(), d)
+		}
+		return nil
+	}
+	return cmd
+}
+
+// readDiffFile reads a tachograph file in the format given by its extension,
+// defaulting to .DDD.
+func readDiffFile(filename string) (*tachographv1.File, error) {
+	format, err := resolveFormat("", filename)
+	if err != nil {
+		format = formatDDD
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file %s: %w", filename, err)
+	}
+	file, err := decodeFile(format, data)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding file %s: %w", filename, err)
+	}
+	return file, nil
+}
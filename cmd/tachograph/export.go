@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/way-platform/tachograph-go"
+	"github.com/way-platform/tachograph-go/export"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+)
+
+func newExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export <file1> [file2] [...]",
+		Short: "Export .DDD files as tables",
+		Long: `Export .DDD files as tables of activities, events, faults, vehicles, places, control activities and calibrations,
+and optionally the detailed speed of vehicle units.
+
+The tables have the same columns for driver cards and vehicle units of all generations,
+and hold the rows of all files.
+
+With --format csv, a CSV file per table is written to the output directory.
+With --format xlsx, a spreadsheet with a worksheet per table is written to the output file.
+With --format parquet, the tables are written to the output directory as a Parquet dataset,
+partitioned by month, in files named after --name.
+With --format geojson or kml, the places, border crossings, load/unload operations
+and accumulated driving positions are written to the output file as map features.`,
+		GroupID: "ddd",
+		Args:    cobra.MinimumNArgs(1),
+	}
+	format := cmd.Flags().String("format", "csv", "output format (csv, xlsx, parquet, geojson, kml)")
+	output := cmd.Flags().StringP("output", "o", "", `output directory for csv and parquet (default "."), or output file for xlsx, geojson and kml (default "tachograph.<format>")`)
+	name := cmd.Flags().String("name", "part-0", "name of the parquet files, distinct for each batch of files exported to the same dataset")
+	speed := cmd.Flags().Bool("speed", false, "export the detailed speed of vehicle units, with a row per second")
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		files := make([]*tachographv1.File, 0, len(args))
+		for _, filename := range args {
+			data, err := os.ReadFile(filename)
+			if err != nil {
+				return fmt.Errorf("error reading file %s: %w", filename, err)
+			}
+			file, err := tachograph.UnmarshalFile(data)
+			if err != nil {
+				return fmt.Errorf("error parsing file %s: %w", filename, err)
+			}
+			files = append(files, file)
+		}
+		switch *format {
+		case "geojson", "kml":
+			features, err := export.Features(files...)
+			if err != nil {
+				return fmt.Errorf("error exporting files: %w", err)
+			}
+			filename := *output
+			if filename == "" {
+				filename = "tachograph." + *format
+			}
+			if err := writeExportFile(filename, func(f *os.File) error {
+				if *format == "kml" {
+					return export.WriteKML(f, features)
+				}
+				return export.WriteGeoJSON(f, features)
+			}); err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), filename)
+			return nil
+		}
+		tables, err := export.TablesOptions{Speed: *speed}.Tables(files...)
+		if err != nil {
+			return fmt.Errorf("error exporting files: %w", err)
+		}
+		switch *format {
+		case "csv":
+			dir := *output
+			if dir == "" {
+				dir = "."
+			}
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return fmt.Errorf("error creating directory %s: %w", dir, err)
+			}
+			for _, table := range tables {
+				filename := filepath.Join(dir, table.Name+".csv")
+				if err := writeExportFile(filename, func(f *os.File) error {
+					return export.WriteCSV(f, table)
+				}); err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), filename)
+			}
+		case "xlsx":
+			filename := *output
+			if filename == "" {
+				filename = "tachograph.xlsx"
+			}
+			if err := writeExportFile(filename, func(f *os.File) error {
+				return export.WriteXLSX(f, tables)
+			}); err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), filename)
+		case "parquet":
+			dir := *output
+			if dir == "" {
+				dir = "."
+			}
+			if err := export.WriteParquetDataset(dir, *name, tables); err != nil {
+				return fmt.Errorf("error writing Parquet dataset: %w", err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), dir)
+		default:
+			return fmt.Errorf("unsupported format: %s", *format)
+		}
+		return nil
+	}
+	return cmd
+}
+
+// writeExportFile creates a file and writes it with the given function.
+func writeExportFile(filename string, write func(*os.File) error) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("error creating file %s: %w", filename, err)
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("error writing file %s: %w", filename, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("error writing file %s: %w", filename, err)
+	}
+	return nil
+}
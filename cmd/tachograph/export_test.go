@@ -0,0 +1,207 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/parquet-go/parquet-go"
+	"github.com/way-platform/tachograph-go"
+	"github.com/way-platform/tachograph-go/export"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// TestExport_parquet reads the Parquet dataset written by the export command
+// back with an independent Parquet reader.
+func TestExport_parquet(t *testing.T) {
+	filenames := []string{
+		"../../testdata/vu/synthetic_gen1.DDD",
+		"../../testdata/vu/synthetic_gen2_v2.DDD",
+	}
+	dir := t.TempDir()
+	cmd := newExportCommand()
+	cmd.SetArgs(append([]string{"--format", "parquet", "--speed", "-o", dir}, filenames...))
+	cmd.SetOut(io.Discard)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	var files []*tachographv1.File
+	for _, filename := range filenames {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		file, err := tachograph.UnmarshalFile(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, file)
+	}
+	tables, err := export.TablesOptions{Speed: true}.Tables(files...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, table := range tables {
+		t.Run(table.Name, func(t *testing.T) {
+			var got [][]any
+			err := filepath.WalkDir(filepath.Join(dir, table.Name), func(path string, d fs.DirEntry, err error) error {
+				if err != nil || d.IsDir() {
+					return err
+				}
+				got = append(got, readParquetTable(t, path, table.Columns)...)
+				return nil
+			})
+			if err != nil && !(len(table.Rows) == 0 && errors.Is(err, fs.ErrNotExist)) {
+				t.Fatal(err)
+			}
+			want := slices.Clone(table.Rows)
+			sortRows(want)
+			sortRows(got)
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("rows mismatch (-exported +read):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestWriteParquet_types reads a table with a column of each type, and
+// missing values, back with an independent Parquet reader.
+func TestWriteParquet_types(t *testing.T) {
+	table := &export.Table{
+		Name: "types",
+		Columns: []export.Column{
+			{Name: "string", Type: export.ColumnString},
+			{Name: "int", Type: export.ColumnInt},
+			{Name: "bool", Type: export.ColumnBool},
+			{Name: "time", Type: export.ColumnTime},
+			{Name: "float", Type: export.ColumnFloat},
+		},
+		Rows: [][]any{
+			{"a", int64(-1), true, time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC), 1.5},
+			{nil, nil, nil, nil, nil},
+			{"Ünïcode", int64(1) << 40, false, time.Date(1999, 12, 31, 23, 59, 59, 0, time.UTC), -0.25},
+			{"", int64(0), true, nil, 0.0},
+			{nil, int64(7), false, time.Unix(0, 0).UTC(), nil},
+		},
+	}
+	filename := filepath.Join(t.TempDir(), "types.parquet")
+	f, err := os.Create(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := (export.ParquetOptions{RowGroupSize: 2}).WriteParquet(f, table); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(table.Rows, readParquetTable(t, filename, table.Columns)); diff != "" {
+		t.Errorf("rows mismatch (-written +read):\n%s", diff)
+	}
+}
+
+// readParquetTable reads the rows of a Parquet file, checking that its schema
+// matches the columns.
+func readParquetTable(t *testing.T, filename string, columns []export.Column) [][]any {
+	t.Helper()
+	f, err := os.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := parquet.OpenFile(f, info.Size())
+	if err != nil {
+		t.Fatalf("OpenFile(%s) error = %v", filename, err)
+	}
+	fields := file.Schema().Fields()
+	if len(fields) != len(columns) {
+		t.Fatalf("%s: schema has %d fields, want %d", filename, len(fields), len(columns))
+	}
+	for i, column := range columns {
+		if fields[i].Name() != column.Name || !fields[i].Optional() {
+			t.Errorf("field %d = %s (optional %v), want optional %s", i, fields[i].Name(), fields[i].Optional(), column.Name)
+		}
+		if got, want := fields[i].Type().String(), parquetTestType(column); got != want {
+			t.Errorf("field %s has type %s, want %s", column.Name, got, want)
+		}
+	}
+	var rows [][]any
+	for _, rowGroup := range file.RowGroups() {
+		r := rowGroup.Rows()
+		buf := make([]parquet.Row, rowGroup.NumRows())
+		n, err := r.ReadRows(buf)
+		if err != nil && !errors.Is(err, io.EOF) {
+			t.Fatalf("ReadRows() error = %v", err)
+		}
+		if err := r.Close(); err != nil {
+			t.Fatal(err)
+		}
+		for _, row := range buf[:n] {
+			values := make([]any, len(columns))
+			for _, value := range row {
+				values[value.Column()] = parquetTestValue(columns[value.Column()].Type, value)
+			}
+			rows = append(rows, values)
+		}
+	}
+	return rows
+}
+
+// parquetTestType returns the Parquet type of a column, as named by the reader.
+func parquetTestType(column export.Column) string {
+	switch column.Type {
+	case export.ColumnInt:
+		return "INT64"
+	case export.ColumnBool:
+		return "BOOLEAN"
+	case export.ColumnTime:
+		return "TIMESTAMP(isAdjustedToUTC=true,unit=MICROS)"
+	case export.ColumnFloat:
+		return "DOUBLE"
+	}
+	if column.Field != nil && column.Field.Kind() == protoreflect.EnumKind {
+		return "ENUM"
+	}
+	return "STRING"
+}
+
+// parquetTestValue converts a value read from a Parquet file to the value type
+// of a table column.
+func parquetTestValue(columnType export.ColumnType, value parquet.Value) any {
+	if value.IsNull() {
+		return nil
+	}
+	switch columnType {
+	case export.ColumnInt:
+		return value.Int64()
+	case export.ColumnBool:
+		return value.Boolean()
+	case export.ColumnTime:
+		return time.UnixMicro(value.Int64()).UTC()
+	case export.ColumnFloat:
+		return value.Double()
+	default:
+		return string(value.ByteArray())
+	}
+}
+
+// sortRows sorts rows by their formatted values, as partitions are read in
+// another order than the rows were exported.
+func sortRows(rows [][]any) {
+	slices.SortStableFunc(rows, func(a, b []any) int {
+		return strings.Compare(fmt.Sprint(a...), fmt.Sprint(b...))
+	})
+}
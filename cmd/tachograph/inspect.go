@@ -0,0 +1,240 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/way-platform/tachograph-go"
+)
+
+func newInspectCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inspect <file1> [file2] [...]",
+		Short: "Inspect the low-level structure of .DDD files",
+		Long: `Inspect the low-level structure of .DDD files.
+
+Prints the TLV records of card files and the TREP transfers of vehicle unit files,
+with their byte offsets, tags, generation, version, sizes and a hex dump of each
+field of their values, as read by the parsers.
+When a file is malformed, the offset where reading the structure failed is highlighted,
+followed by a hex dump of the unread data, and so is the field where parsing a
+record or transfer failed. The command then exits with a non-zero status.`,
+		GroupID: "ddd",
+		Args:    cobra.MinimumNArgs(1),
+	}
+	noHex := cmd.Flags().Bool("no-hex", false, "omit the hex dumps")
+	maxBytes := cmd.Flags().Int("max-bytes", 256, "maximum bytes of each hex dump, 0 for no limit")
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		var malformed int
+		for _, filename := range args {
+			data, err := os.ReadFile(filename)
+			if err != nil {
+				return fmt.Errorf("error reading file %s: %w", filename, err)
+			}
+			dumpBytes := *maxBytes
+			if *noHex {
+				dumpBytes = -1
+			}
+			inspection := tachograph.InspectFile(data)
+			printInspection(cmd.OutOrStdout(), filename, data, inspection, dumpBytes)
+			if !wellFormed(inspection) {
+				malformed++
+			}
+		}
+		if malformed > 0 {
+			return fmt.Errorf("%d of %d files are malformed", malformed, len(args))
+		}
+		return nil
+	}
+	return cmd
+}
+
+// printInspection prints the structure of a file, with hex dumps of at most
+// maxBytes bytes, no limit if 0, or no hex dumps if negative.
+func printInspection(w io.Writer, filename string, data []byte, inspection *tachograph.Inspection, maxBytes int) {
+	fmt.Fprintf(w, "%s: %v, %v, %v, %d bytes, %d segments\n",
+		filename, inspection.Type, inspection.Generation, inspection.Version, inspection.Size, len(inspection.Segments))
+	for _, segment := range inspection.Segments {
+		kind := "data"
+		if segment.Signature {
+			kind = "signature"
+		}
+		// Card tags are 3 bytes (FID and appendix), vehicle unit tags 2 bytes.
+		tagDigits := 6
+		if len(segment.Header) < 3 {
+			tagDigits = 4
+		}
+		fmt.Fprintf(w, "\n0x%08x  tag 0x%0*x  %s (%s, %v", segment.Offset, tagDigits, segment.Tag, segment.Name, kind, segment.Generation)
+		if segment.Version != 0 {
+			fmt.Fprintf(w, ", %v", segment.Version)
+		}
+		fmt.Fprintf(w, ")  header %d bytes, value %d bytes\n", len(segment.Header), len(segment.Value))
+		if maxBytes < 0 {
+			if segment.Err != nil {
+				printSegmentError(w, segment)
+			}
+			continue
+		}
+		fmt.Fprintf(w, "  header:\n")
+		hexDump(w, segment.Offset, segment.Header, 0)
+		printValue(w, segment, maxBytes)
+	}
+	if inspection.Err != nil {
+		fmt.Fprintf(w, "\n!!! 0x%08x  error: %v\n", inspection.ErrOffset, inspection.Err)
+		if maxBytes >= 0 {
+			fmt.Fprintf(w, "  unread:\n")
+			hexDump(w, inspection.ErrOffset, data[inspection.ErrOffset:], maxBytes)
+		}
+	}
+	if inspection.ParseErr != nil {
+		fmt.Fprintf(w, "\n!!! parse error: %v\n", inspection.ParseErr)
+	}
+	fmt.Fprintln(w)
+}
+
+// wellFormed reports whether a file was read and parsed without errors.
+func wellFormed(inspection *tachograph.Inspection) bool {
+	if inspection.Err != nil || inspection.ParseErr != nil {
+		return false
+	}
+	for _, segment := range inspection.Segments {
+		if segment.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// valueChunk is a field of the value of a segment, or data between fields
+// that no parser read if path is empty.
+type valueChunk struct {
+	path       string
+	begin, end int
+}
+
+// valueChunks splits the value of a segment into its fields and the data
+// between them, also splitting at offset split if it is inside the value.
+func valueChunks(segment tachograph.Segment, split int) []valueChunk {
+	var chunks []valueChunk
+	addGap := func(begin, end int) {
+		if split > begin && split < end {
+			chunks = append(chunks, valueChunk{begin: begin, end: split})
+			begin = split
+		}
+		if begin < end {
+			chunks = append(chunks, valueChunk{begin: begin, end: end})
+		}
+	}
+	offset := 0
+	for _, field := range segment.Fields {
+		begin := max(field.Offset, offset)
+		end := min(field.Offset+field.Length, len(segment.Value))
+		if begin >= end {
+			continue
+		}
+		addGap(offset, begin)
+		chunks = append(chunks, valueChunk{path: field.Path, begin: begin, end: end})
+		offset = end
+	}
+	addGap(offset, len(segment.Value))
+	return chunks
+}
+
+// printValue prints the value of a segment field by field, with hex dumps of
+// at most maxBytes bytes in total, no limit if 0, and highlights the field
+// where parsing the segment failed.
+func printValue(w io.Writer, segment tachograph.Segment, maxBytes int) {
+	valueOffset := segment.Offset + len(segment.Header)
+	// The failure is located in the value by the offset of its field, or at
+	// the start of the value if the field is not known.
+	errAt := -1
+	if segment.Err != nil {
+		errAt = 0
+		var parseErr *tachograph.ParseError
+		if errors.As(segment.Err, &parseErr) && parseErr.Field != "" {
+			errAt = min(max(parseErr.Offset-valueOffset, 0), len(segment.Value))
+		}
+	}
+	if len(segment.Fields) == 0 {
+		if errAt >= 0 {
+			printSegmentError(w, segment)
+		}
+		fmt.Fprintf(w, "  value:\n")
+		hexDump(w, valueOffset, segment.Value, maxBytes)
+		return
+	}
+	remaining := maxBytes
+	for _, chunk := range valueChunks(segment, errAt) {
+		if errAt >= 0 && errAt <= chunk.begin {
+			printSegmentError(w, segment)
+			errAt = -1
+		}
+		if chunk.path != "" {
+			fmt.Fprintf(w, "  %s:\n", chunk.path)
+		} else {
+			fmt.Fprintf(w, "  (not read):\n")
+		}
+		data := segment.Value[chunk.begin:chunk.end]
+		if maxBytes > 0 {
+			data = data[:min(len(data), remaining)]
+			remaining -= len(data)
+		}
+		hexDump(w, valueOffset+chunk.begin, data, 0)
+		if maxBytes > 0 && remaining <= 0 && chunk.begin+len(data) < len(segment.Value) {
+			fmt.Fprintf(w, "    ... %d more bytes\n", len(segment.Value)-chunk.begin-len(data))
+			break
+		}
+	}
+	if errAt >= 0 {
+		printSegmentError(w, segment)
+	}
+}
+
+// printSegmentError highlights the error of parsing a segment.
+func printSegmentError(w io.Writer, segment tachograph.Segment) {
+	offset := segment.Offset
+	var parseErr *tachograph.ParseError
+	if errors.As(segment.Err, &parseErr) {
+		offset = parseErr.Offset
+	}
+	fmt.Fprintf(w, "!!! 0x%08x  error: %v\n", offset, segment.Err)
+}
+
+// hexDump prints data as lines of 16 bytes, prefixed with their offset in the
+// file, and truncated to maxBytes bytes unless 0.
+func hexDump(w io.Writer, offset int, data []byte, maxBytes int) {
+	truncated := 0
+	if maxBytes > 0 && len(data) > maxBytes {
+		truncated = len(data) - maxBytes
+		data = data[:maxBytes]
+	}
+	for i := 0; i < len(data); i += 16 {
+		line := data[i:min(i+16, len(data))]
+		var hexPart, textPart strings.Builder
+		for j := range 16 {
+			if j == 8 {
+				hexPart.WriteByte(' ')
+			}
+			if j < len(line) {
+				fmt.Fprintf(&hexPart, "%02x ", line[j])
+			} else {
+				hexPart.WriteString("   ")
+			}
+		}
+		for _, b := range line {
+			if b >= 0x20 && b < 0x7f {
+				textPart.WriteByte(b)
+			} else {
+				textPart.WriteByte('.')
+			}
+		}
+		fmt.Fprintf(w, "    %08x  %s |%s|\n", offset+i, hexPart.String(), textPart.String())
+	}
+	if truncated > 0 {
+		fmt.Fprintf(w, "    ... %d more bytes\n", truncated)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInspect(t *testing.T) {
+	data, err := os.ReadFile("../../testdata/vu/synthetic_gen1.DDD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.DDD")
+	if err := os.WriteFile(valid, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// An activities transfer with a place record of an invalid entry type.
+	malformed := []byte{0x76, 0x02}
+	malformed = append(malformed, make([]byte, 11)...)
+	malformed = append(malformed, 0x01)
+	malformed = append(malformed, make([]byte, 22)...)
+	malformed = append(malformed, 0xFF, 0x00, 0x00, 0x00, 0x00, 0x00)
+	malformed = append(malformed, 0x00, 0x00)
+	malformed = append(malformed, make([]byte, 128)...)
+	invalid := filepath.Join(dir, "invalid.DDD")
+	if err := os.WriteFile(invalid, malformed, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		cmd := newInspectCommand()
+		cmd.SetOut(&out)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		out, err := run(valid)
+		if err != nil {
+			t.Fatalf("inspect error = %v", err)
+		}
+		for _, field := range []string{"  date_of_day:\n", "  places[0].entry_type:\n", "  faults[0].begin_time:\n"} {
+			if !strings.Contains(out, field) {
+				t.Errorf("output lacks field %q", field)
+			}
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		out, err := run(valid, invalid)
+		if err == nil {
+			t.Fatal("inspect of a malformed file succeeded")
+		}
+		// The failure is highlighted before the field that failed to parse.
+		marker := strings.Index(out, "!!! 0x00000024  error: parse ACTIVITIES_GEN1 field places[0].entry_type at offset 36: ")
+		entryTime := strings.Index(out, "  places[0].entry_time:\n")
+		if marker < 0 || marker < entryTime {
+			t.Errorf("output lacks the failure after the entry time:\n%s", out)
+		}
+	})
+}
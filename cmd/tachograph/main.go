@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"image/color"
 	"os"
@@ -48,6 +49,14 @@ func newRootCommand() *cobra.Command {
 	}
 	cmd.AddGroup(&cobra.Group{ID: "ddd", Title: ".DDD Files"})
 	cmd.AddCommand(newParseCommand())
+	cmd.AddCommand(newVerifyCommand())
+	cmd.AddCommand(newAnonymizeCommand())
+	cmd.AddCommand(newConvertCommand())
+	cmd.AddCommand(newDiffCommand())
+	cmd.AddCommand(newInspectCommand())
+	cmd.AddCommand(newExportCommand())
+	cmd.AddCommand(newReportCommand())
+	cmd.AddCommand(newBatchCommand())
 	cmd.AddGroup(&cobra.Group{ID: "utils", Title: "Utils"})
 	cmd.SetHelpCommandGroupID("utils")
 	cmd.SetCompletionCommandGroupID("utils")
@@ -61,14 +70,26 @@ func newParseCommand() *cobra.Command {
 		GroupID: "ddd",
 		Args:    cobra.MinimumNArgs(1),
 	}
+	lenient := cmd.Flags().Bool("lenient", false, "skip malformed EFs and transfers, and report them on stderr")
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		opts := tachograph.UnmarshalOptions{Lenient: *lenient}
 		for _, filename := range args {
 			data, err := os.ReadFile(filename)
 			if err != nil {
 				return fmt.Errorf("error reading file %s: %w", filename, err)
 			}
-			file, err := tachograph.UnmarshalFile(data)
-			if err != nil {
+			if _, containers, err := tachograph.Unwrap(data); err == nil {
+				for _, c := range containers {
+					fmt.Fprintf(cmd.ErrOrStderr(), "%s: unwrapped %v\n", filename, c)
+				}
+			}
+			file, err := opts.UnmarshalFile(data)
+			var diagnostics tachograph.Diagnostics
+			if errors.As(err, &diagnostics) && file != nil {
+				for _, d := range diagnostics {
+					fmt.Fprintf(cmd.ErrOrStderr(), "%s: skipped %d bytes: %v\n", filename, len(d.Data), d)
+				}
+			} else if err != nil {
 				return fmt.Errorf("error parsing file %s: %w", filename, err)
 			}
 			fmt.Println(protojson.Format(file))
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/way-platform/tachograph-go"
+	"github.com/way-platform/tachograph-go/report"
+)
+
+func newReportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report <file> [output]",
+		Short: "Print a .DDD file as an activity report",
+		Long: `Print a driver card or vehicle unit .DDD file as a human-readable activity report.
+
+The report holds the identification, a 24h activity chart per day, and the events,
+faults, vehicles and places of the file.
+
+The format is inferred from the output file extension (.html, .pdf), unless given with --format.
+Use - or omit the output to write HTML to stdout.`,
+		GroupID: "ddd",
+		Args:    cobra.RangeArgs(1, 2),
+	}
+	format := cmd.Flags().String("format", "", "output format (html, pdf)")
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		input, output := args[0], "-"
+		if len(args) > 1 {
+			output = args[1]
+		}
+		reportFormat := *format
+		if reportFormat == "" {
+			reportFormat = "html"
+			if output != "-" {
+				reportFormat = strings.TrimPrefix(strings.ToLower(filepath.Ext(output)), ".")
+			}
+		}
+		data, err := os.ReadFile(input)
+		if err != nil {
+			return fmt.Errorf("error reading file %s: %w", input, err)
+		}
+		file, err := tachograph.UnmarshalFile(data)
+		if err != nil {
+			return fmt.Errorf("error parsing file %s: %w", input, err)
+		}
+		r, err := report.New(file)
+		if err != nil {
+			return fmt.Errorf("error building report of %s: %w", input, err)
+		}
+		var b bytes.Buffer
+		switch reportFormat {
+		case "html", "htm":
+			err = r.WriteHTML(&b)
+		case "pdf":
+			err = r.WritePDF(&b)
+		default:
+			return fmt.Errorf("unsupported format: %s", reportFormat)
+		}
+		if err != nil {
+			return fmt.Errorf("error writing report: %w", err)
+		}
+		if output == "-" {
+			_, err = cmd.OutOrStdout().Write(b.Bytes())
+		} else {
+			err = os.WriteFile(output, b.Bytes(), 0o644)
+		}
+		if err != nil {
+			return fmt.Errorf("error writing file %s: %w", output, err)
+		}
+		return nil
+	}
+	return cmd
+}
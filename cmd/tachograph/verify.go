@@ -0,0 +1,220 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/way-platform/tachograph-go"
+	securityv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/security/v1"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func newVerifyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "verify <file1> [file2] [...]",
+		Short:   "Verify the authenticity of .DDD files",
+		GroupID: "ddd",
+		Args:    cobra.MinimumNArgs(1),
+	}
+	offline := cmd.Flags().Bool("offline", false, "resolve certificates without network access")
+	certDir := cmd.Flags().String("cert-dir", "", "directory with local certificates (root/EC_PK.bin, g1/<CHR>.bin, g2/<CHR>.bin)")
+	allowUnverified := cmd.Flags().Bool("allow-unverified", false, "succeed when certificates or signatures can't be verified, or the file type isn't supported")
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		opts := tachograph.VerifyOptions{
+			CertificateResolver: certificateResolver(*offline, *certDir),
+		}
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "FILE\tTYPE\tITEM\tCHR\tCAR\tRESULT")
+		var errs []error
+		var failed int
+		for _, filename := range args {
+			results, err := verifyFile(cmd.Context(), opts, filename)
+			ok := err == nil
+			for _, r := range results {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", filename, r.fileType, r.item, r.chr, r.car, r.result)
+				ok = ok && r.ok(*allowUnverified)
+			}
+			if err != nil {
+				errs = append(errs, err)
+			}
+			if !ok {
+				failed++
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		for _, err := range errs {
+			fmt.Fprintln(cmd.ErrOrStderr(), err)
+		}
+		if failed > 0 {
+			return fmt.Errorf("verification failed for %d of %d files", failed, len(args))
+		}
+		return nil
+	}
+	return cmd
+}
+
+// certificateResolver returns the certificate resolver configured by the
+// --offline and --cert-dir flags.
+func certificateResolver(offline bool, certDir string) tachograph.CertificateResolver {
+	var resolvers []tachograph.CertificateResolver
+	if certDir != "" {
+		resolvers = append(resolvers, tachograph.DirectoryCertificateResolver(certDir))
+	}
+	if offline {
+		resolvers = append(resolvers, tachograph.EmbeddedCertificateResolver())
+	} else {
+		resolvers = append(resolvers, tachograph.DefaultCertificateResolver())
+	}
+	return tachograph.ChainCertificateResolvers(resolvers...)
+}
+
+// Results of the verify command.
+const (
+	resultValid        = "VALID"
+	resultInvalid      = "INVALID"
+	resultUnverified   = "UNVERIFIED"
+	resultNotSupported = "NOT SUPPORTED"
+	resultError        = "ERROR"
+)
+
+// verifyResult is a row of the verify command's result table.
+type verifyResult struct {
+	fileType string
+	item     string
+	chr      string
+	car      string
+	result   string
+}
+
+// ok reports whether the result passes verification. Unverified and
+// unsupported results only pass if allowUnverified is set.
+func (r verifyResult) ok(allowUnverified bool) bool {
+	switch r.result {
+	case resultValid:
+		return true
+	case resultUnverified, resultNotSupported:
+		return allowUnverified
+	default:
+		return false
+	}
+}
+
+// verifyFile verifies a single file and returns one result per certificate
+// and EF signature.
+func verifyFile(ctx context.Context, opts tachograph.VerifyOptions, filename string) ([]verifyResult, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return []verifyResult{{fileType: "-", item: "-", chr: "-", car: "-", result: resultError}},
+			fmt.Errorf("error reading file %s: %w", filename, err)
+	}
+	file, err := tachograph.UnmarshalFile(data)
+	if err != nil {
+		return []verifyResult{{fileType: "-", item: "-", chr: "-", car: "-", result: resultError}},
+			fmt.Errorf("error parsing file %s: %w", filename, err)
+	}
+	verifyErr := opts.VerifyFile(ctx, file)
+	if verifyErr != nil {
+		verifyErr = fmt.Errorf("error verifying file %s: %w", filename, verifyErr)
+	}
+	var results []verifyResult
+	switch file.GetType() {
+	case tachographv1.File_DRIVER_CARD:
+		const fileType = "driver card"
+		if tachograph := file.GetDriverCard().GetTachograph(); tachograph != nil {
+			results = appendRsaCertificateResult(results, fileType, "Gen1 CA certificate", tachograph.GetCaCertificate().GetRsaCertificate())
+			results = appendRsaCertificateResult(results, fileType, "Gen1 card certificate", tachograph.GetCardCertificate().GetRsaCertificate())
+			results = appendSignatureResults(results, fileType, "Gen1", tachograph)
+		}
+		if tachographG2 := file.GetDriverCard().GetTachographG2(); tachographG2 != nil {
+			results = appendEccCertificateResult(results, fileType, "Gen2 CA certificate", tachographG2.GetCaCertificate().GetEccCertificate())
+			results = appendEccCertificateResult(results, fileType, "Gen2 card MA certificate", tachographG2.GetCardMaCertificate().GetEccCertificate())
+			results = appendEccCertificateResult(results, fileType, "Gen2 card sign certificate", tachographG2.GetCardSignCertificate().GetEccCertificate())
+			results = appendSignatureResults(results, fileType, "Gen2", tachographG2)
+		}
+		if len(results) == 0 {
+			results = append(results, verifyResult{fileType: fileType, item: "-", chr: "-", car: "-", result: resultError})
+		}
+	case tachographv1.File_VEHICLE_UNIT:
+		results = append(results, verifyResult{fileType: "vehicle unit", item: "-", chr: "-", car: "-", result: resultNotSupported})
+	default:
+		results = append(results, verifyResult{fileType: "raw card", item: "-", chr: "-", car: "-", result: resultNotSupported})
+	}
+	return results, verifyErr
+}
+
+// appendRsaCertificateResult appends the result of a Gen1 certificate, if present.
+func appendRsaCertificateResult(results []verifyResult, fileType, item string, cert *securityv1.RsaCertificate) []verifyResult {
+	if cert == nil {
+		return results
+	}
+	return append(results, verifyResult{
+		fileType: fileType,
+		item:     item,
+		chr:      cmp.Or(cert.GetCertificateHolderReference(), "-"),
+		car:      cmp.Or(cert.GetCertificateAuthorityReference(), "-"),
+		result:   verificationResult(cert.HasSignatureValid(), cert.GetSignatureValid()),
+	})
+}
+
+// appendEccCertificateResult appends the result of a Gen2 certificate, if present.
+func appendEccCertificateResult(results []verifyResult, fileType, item string, cert *securityv1.EccCertificate) []verifyResult {
+	if cert == nil {
+		return results
+	}
+	return append(results, verifyResult{
+		fileType: fileType,
+		item:     item,
+		chr:      cmp.Or(cert.GetCertificateHolderReference(), "-"),
+		car:      cmp.Or(cert.GetCertificateAuthorityReference(), "-"),
+		result:   verificationResult(cert.HasSignatureValid(), cert.GetSignatureValid()),
+	})
+}
+
+// appendSignatureResults appends the results of the signed EFs of a DF.
+func appendSignatureResults(results []verifyResult, fileType, generation string, df proto.Message) []verifyResult {
+	m := df.ProtoReflect()
+	fields := m.Descriptor().Fields()
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		if fd.Kind() != protoreflect.MessageKind || !m.Has(fd) {
+			continue
+		}
+		ef, ok := m.Get(fd).Message().Interface().(interface {
+			GetSignature() []byte
+			HasSignatureVerified() bool
+			GetSignatureVerified() bool
+		})
+		if !ok || len(ef.GetSignature()) == 0 {
+			continue
+		}
+		results = append(results, verifyResult{
+			fileType: fileType,
+			item:     fmt.Sprintf("%s %s signature", generation, fd.Name()),
+			chr:      "-",
+			car:      "-",
+			result:   verificationResult(ef.HasSignatureVerified(), ef.GetSignatureVerified()),
+		})
+	}
+	return results
+}
+
+// verificationResult formats the signature_valid field of a certificate or
+// the signature_verified field of an EF.
+func verificationResult(has, valid bool) string {
+	switch {
+	case !has:
+		return resultUnverified
+	case valid:
+		return resultValid
+	default:
+		return resultInvalid
+	}
+}
@@ -0,0 +1,363 @@
+package tachograph
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+
+	"github.com/way-platform/tachograph-go/internal/card"
+	"github.com/way-platform/tachograph-go/internal/vu"
+)
+
+// ContainerFormat is a format that wraps tachograph file data.
+type ContainerFormat int
+
+const (
+	// ContainerZip is a zip archive holding a tachograph file.
+	ContainerZip ContainerFormat = iota + 1
+	// ContainerBase64 is base64 text, such as the .b64 files used in test
+	// data, optionally wrapped over several lines.
+	ContainerBase64
+	// ContainerMIME is a MIME message, such as an email, with a tachograph
+	// file as an attachment.
+	ContainerMIME
+	// ContainerHeader is a vendor header that precedes the tachograph file data.
+	ContainerHeader
+)
+
+// String returns the name of the container format.
+func (f ContainerFormat) String() string {
+	switch f {
+	case ContainerZip:
+		return "zip archive"
+	case ContainerBase64:
+		return "base64 text"
+	case ContainerMIME:
+		return "MIME message"
+	case ContainerHeader:
+		return "vendor header"
+	default:
+		return fmt.Sprintf("ContainerFormat(%d)", int(f))
+	}
+}
+
+// Container describes a container unwrapped from tachograph file data.
+type Container struct {
+	// Format is the format of the container.
+	Format ContainerFormat
+	// Name is the name of the unwrapped file in a zip archive, or the file
+	// name of an attachment in a MIME message, if any.
+	Name string
+	// Offset is the length of a vendor header, which is the offset of the
+	// tachograph file data in the container.
+	Offset int
+}
+
+// String describes the container.
+func (c Container) String() string {
+	switch {
+	case c.Name != "":
+		return fmt.Sprintf("%v (%s)", c.Format, c.Name)
+	case c.Format == ContainerHeader:
+		return fmt.Sprintf("%v (%d bytes)", c.Format, c.Offset)
+	default:
+		return c.Format.String()
+	}
+}
+
+// maxContainerDepth is the maximum number of nested containers unwrapped,
+// such as a zip archive in a base64 email attachment.
+const maxContainerDepth = 4
+
+// maxUnwrappedSize is the maximum size of a file unwrapped from a zip
+// archive or an email attachment, well above the size of any tachograph file.
+const maxUnwrappedSize = 64 << 20
+
+// maxHeaderSize is the maximum size of a vendor header.
+const maxHeaderSize = 4096
+
+// Unwrap detects and removes the containers around tachograph file data.
+//
+// The tachograph file data is returned together with the unwrapped containers,
+// outermost first. Data that is not wrapped is returned as is, with no
+// containers. The detected containers are:
+//
+//   - zip archives, holding a file with a tachograph file extension, or a
+//     single file
+//   - base64 text, such as the .b64 files used in test data
+//   - MIME messages, such as emails, with an attachment, in any transfer
+//     encoding
+//   - vendor headers of up to 4096 bytes, which precede the EF_ICC of a
+//     card or the first transfer of a vehicle unit
+//
+// Containers may be nested, such as a zip archive attached to an email.
+//
+// If no tachograph file data is found, the error wraps [ErrUnknownTag] and
+// describes the containers found so far. A file of more than 64 MiB in a zip
+// archive or an attachment is an error, rather than being truncated.
+func Unwrap(data []byte) ([]byte, []Container, error) {
+	var containers []Container
+	for range maxContainerDepth + 1 {
+		if isTachographData(data) {
+			return data, containers, nil
+		}
+		unwrapped, container, err := unwrapContainer(data)
+		if err != nil {
+			return nil, containers, fmt.Errorf("%s: %w", containersString(containers, err.Error()), ErrUnknownTag)
+		}
+		data = unwrapped
+		containers = append(containers, container)
+	}
+	return nil, containers, fmt.Errorf("%s: %w", containersString(containers, "too many nested containers"), ErrUnknownTag)
+}
+
+// containersString prefixes a message with the descriptions of containers.
+func containersString(containers []Container, message string) string {
+	var b strings.Builder
+	for _, c := range containers {
+		b.WriteString(c.String())
+		b.WriteString(": ")
+	}
+	b.WriteString(message)
+	return b.String()
+}
+
+// isTachographData reports whether data starts as a tachograph file, as
+// dispatched by [UnmarshalOptions.UnmarshalFile].
+func isTachographData(data []byte) bool {
+	return len(data) >= 2 && (data[0] == 0x76 || binary.BigEndian.Uint16(data) == 0x0002)
+}
+
+// unwrapContainer removes a single container around data.
+func unwrapContainer(data []byte) ([]byte, Container, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")):
+		return unwrapZip(data)
+	case isMIMEMessage(data):
+		return unwrapMIME(data)
+	}
+	if decoded, ok := decodeBase64(data); ok {
+		return decoded, Container{Format: ContainerBase64}, nil
+	}
+	if offset, ok := findTachographData(data); ok {
+		return data[offset:], Container{Format: ContainerHeader, Offset: offset}, nil
+	}
+	return nil, Container{}, errors.New("unknown or unsupported file type")
+}
+
+// unwrapZip returns the file of a zip archive with a tachograph file
+// extension, or its only file.
+func unwrapZip(data []byte) ([]byte, Container, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, Container{}, fmt.Errorf("%v: %w", ContainerZip, err)
+	}
+	var files []*zip.File
+	for _, f := range archive.File {
+		if !f.FileInfo().IsDir() {
+			files = append(files, f)
+		}
+	}
+	var file *zip.File
+	for _, f := range files {
+		if isTachographFileName(f.Name) {
+			file = f
+			break
+		}
+	}
+	if file == nil && len(files) == 1 {
+		file = files[0]
+	}
+	if file == nil {
+		return nil, Container{}, fmt.Errorf("%v: no tachograph file among %d files", ContainerZip, len(files))
+	}
+	r, err := file.Open()
+	if err != nil {
+		return nil, Container{}, fmt.Errorf("%v: %w", ContainerZip, err)
+	}
+	unwrapped, err := readUnwrapped(r)
+	if err := errors.Join(err, r.Close()); err != nil {
+		return nil, Container{}, fmt.Errorf("%v: %s: %w", ContainerZip, file.Name, err)
+	}
+	return unwrapped, Container{Format: ContainerZip, Name: file.Name}, nil
+}
+
+// readUnwrapped reads a file unwrapped from a container. It fails if the file
+// is larger than maxUnwrappedSize, rather than truncating it.
+func readUnwrapped(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxUnwrappedSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxUnwrappedSize {
+		return nil, fmt.Errorf("file too large: more than %d bytes", maxUnwrappedSize)
+	}
+	return data, nil
+}
+
+// isMIMEMessage reports whether data starts with the header of a MIME message.
+func isMIMEMessage(data []byte) bool {
+	header, err := textproto.NewReader(bufio.NewReader(bytes.NewReader(data))).ReadMIMEHeader()
+	return err == nil && header.Get("Content-Type") != ""
+}
+
+// unwrapMIME returns the first attachment of a MIME message, preferring
+// attachments with a tachograph file extension.
+func unwrapMIME(data []byte) ([]byte, Container, error) {
+	message, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return nil, Container{}, fmt.Errorf("%v: %w", ContainerMIME, err)
+	}
+	var attachments []mimeAttachment
+	if err := collectMIMEAttachments(textproto.MIMEHeader(message.Header), message.Body, &attachments); err != nil {
+		return nil, Container{}, fmt.Errorf("%v: %w", ContainerMIME, err)
+	}
+	if len(attachments) == 0 {
+		return nil, Container{}, fmt.Errorf("%v: no attachment", ContainerMIME)
+	}
+	attachment := attachments[0]
+	for _, a := range attachments {
+		if isTachographFileName(a.name) {
+			attachment = a
+			break
+		}
+	}
+	return attachment.data, Container{Format: ContainerMIME, Name: attachment.name}, nil
+}
+
+// mimeAttachment is a decoded attachment of a MIME message.
+type mimeAttachment struct {
+	name string
+	data []byte
+}
+
+// collectMIMEAttachments collects the non-text parts of a MIME entity, walking
+// nested multipart entities.
+func collectMIMEAttachments(header textproto.MIMEHeader, body io.Reader, attachments *[]mimeAttachment) error {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType = "text/plain"
+	}
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := mr.NextRawPart()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := collectMIMEAttachments(part.Header, part, attachments); err != nil {
+				return err
+			}
+		}
+	}
+	name := params["name"]
+	if _, dispositionParams, err := mime.ParseMediaType(header.Get("Content-Disposition")); err == nil && dispositionParams["filename"] != "" {
+		name = dispositionParams["filename"]
+	}
+	if strings.HasPrefix(mediaType, "text/") && name == "" {
+		return nil
+	}
+	switch strings.ToLower(header.Get("Content-Transfer-Encoding")) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+	data, err := readUnwrapped(body)
+	if err != nil {
+		return fmt.Errorf("attachment %q: %w", name, err)
+	}
+	*attachments = append(*attachments, mimeAttachment{name: name, data: data})
+	return nil
+}
+
+// decodeBase64 decodes base64 text, ignoring whitespace and an optional
+// padding.
+func decodeBase64(data []byte) ([]byte, bool) {
+	text := removeWhitespace(bytes.Clone(data))
+	if len(text) < 4 {
+		return nil, false
+	}
+	encoding := base64.StdEncoding
+	if len(text)%4 != 0 {
+		encoding = base64.RawStdEncoding
+	}
+	decoded, err := encoding.DecodeString(string(text))
+	if err != nil {
+		return nil, false
+	}
+	return decoded, true
+}
+
+// removeWhitespace removes ASCII whitespace from data in place.
+func removeWhitespace(data []byte) []byte {
+	result := data[:0]
+	for _, b := range data {
+		switch b {
+		case ' ', '\t', '\r', '\n':
+		default:
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+// findTachographData returns the offset of tachograph file data after a
+// vendor header: the EF_ICC of a card, or a transfer of a vehicle unit, from
+// which the rest of the data splits into EFs or transfers.
+func findTachographData(data []byte) (int, bool) {
+	// The EF_ICC tag, with the data appendix and a length of 25 bytes.
+	efICC := []byte{0x00, 0x02, 0x00, 0x00, 0x19}
+	cardFailed, vehicleUnitFailed := map[int]bool{}, map[int]bool{}
+	for offset := 1; offset < min(len(data)-1, maxHeaderSize+1); offset++ {
+		switch {
+		case bytes.HasPrefix(data[offset:], efICC):
+			if splitsToEnd(data, offset, card.ScanRecord, cardFailed) {
+				return offset, true
+			}
+		case data[offset] == 0x76:
+			if splitsToEnd(data, offset, vu.ScanTransfer, vehicleUnitFailed) {
+				return offset, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// splitsToEnd reports whether data splits into records from offset to its end.
+//
+// The offsets of the records of a failed split are added to failed, since
+// splits from these offsets fail as well, which bounds the time spent on data
+// with many candidate offsets.
+func splitsToEnd(data []byte, offset int, split bufio.SplitFunc, failed map[int]bool) bool {
+	var visited []int
+	for offset < len(data) && !failed[offset] {
+		visited = append(visited, offset)
+		advance, _, err := split(data[offset:], true)
+		if err != nil || advance == 0 {
+			break
+		}
+		offset += advance
+	}
+	if offset == len(data) {
+		return true
+	}
+	for _, v := range visited {
+		failed[v] = true
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+package tachograph
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+)
+
+// testZip returns a zip archive of the given files.
+func testZip(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+	var b bytes.Buffer
+	zw := zip.NewWriter(&b)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+// testBase64Lines returns the base64 encoding of data wrapped at 76 characters.
+func testBase64Lines(data []byte) string {
+	text := base64.StdEncoding.EncodeToString(data)
+	var b strings.Builder
+	for len(text) > 76 {
+		b.WriteString(text[:76] + "\r\n")
+		text = text[76:]
+	}
+	b.WriteString(text + "\r\n")
+	return b.String()
+}
+
+func TestUnwrap(t *testing.T) {
+	data := testDriverCardData(t)
+	email := "From: depot@example.com\r\n" +
+		"Subject: Driver card download\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: multipart/mixed; boundary=\"boundary\"\r\n" +
+		"\r\n" +
+		"--boundary\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"\r\n" +
+		"See the attached download.\r\n" +
+		"--boundary\r\n" +
+		"Content-Type: application/zip\r\n" +
+		"Content-Disposition: attachment; filename=\"downloads.zip\"\r\n" +
+		"Content-Transfer-Encoding: base64\r\n" +
+		"\r\n" +
+		testBase64Lines(testZip(t, map[string][]byte{"driver.DDD": data})) +
+		"--boundary--\r\n"
+
+	for _, tt := range []struct {
+		name           string
+		data           []byte
+		wantContainers []Container
+	}{
+		{name: "plain", data: data},
+		{
+			name:           "base64",
+			data:           []byte(base64.StdEncoding.EncodeToString(data)),
+			wantContainers: []Container{{Format: ContainerBase64}},
+		},
+		{
+			name:           "base64 lines",
+			data:           []byte(testBase64Lines(data)),
+			wantContainers: []Container{{Format: ContainerBase64}},
+		},
+		{
+			name: "zip",
+			data: testZip(t, map[string][]byte{
+				"README.txt":        []byte("driver card downloads"),
+				"2024/C_driver.DDD": data,
+			}),
+			wantContainers: []Container{{Format: ContainerZip, Name: "2024/C_driver.DDD"}},
+		},
+		{
+			name: "email",
+			data: []byte(email),
+			wantContainers: []Container{
+				{Format: ContainerMIME, Name: "downloads.zip"},
+				{Format: ContainerZip, Name: "driver.DDD"},
+			},
+		},
+		{
+			name:           "vendor header",
+			data:           append([]byte("VENDOR\x00\x01\x02\x76\x00"), data...),
+			wantContainers: []Container{{Format: ContainerHeader, Offset: 11}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			unwrapped, containers, err := Unwrap(tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(unwrapped, data) {
+				t.Error("unwrapped data differs from the tachograph file data")
+			}
+			if diff := cmp.Diff(tt.wantContainers, containers); diff != "" {
+				t.Errorf("Unwrap() containers mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnwrap_errors(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		data    []byte
+		wantErr string
+	}{
+		{
+			name:    "unknown",
+			data:    []byte("%PDF-1.7"),
+			wantErr: "unknown or unsupported file type",
+		},
+		{
+			name:    "zip without tachograph file",
+			data:    testZip(t, map[string][]byte{"a.txt": nil, "b.txt": nil}),
+			wantErr: "zip archive: no tachograph file among 2 files",
+		},
+		{
+			name:    "base64 of unknown data",
+			data:    []byte(base64.StdEncoding.EncodeToString([]byte("%PDF-1.7"))),
+			wantErr: "base64 text: unknown or unsupported file type",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := Unwrap(tt.data)
+			if !errors.Is(err, ErrUnknownTag) {
+				t.Fatalf("Unwrap() error = %v, want %v", err, ErrUnknownTag)
+			}
+			if !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Errorf("Unwrap() error = %q, want prefix %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnmarshalFile_container(t *testing.T) {
+	data := testDriverCardData(t)
+	want, err := UnmarshalFile(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := UnmarshalFile([]byte(testBase64Lines(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(want, got) {
+		t.Error("UnmarshalFile() of base64 data differs from the plain data")
+	}
+	var parseErr *ParseError
+	if _, err := UnmarshalFile([]byte("%PDF-1.7")); !errors.As(err, &parseErr) || !errors.Is(err, ErrUnknownTag) {
+		t.Errorf("UnmarshalFile() of unknown data: error = %v, want a *ParseError wrapping %v", err, ErrUnknownTag)
+	}
+}
+
+func TestUnwrap_tooLarge(t *testing.T) {
+	var b bytes.Buffer
+	zw := zip.NewWriter(&b)
+	w, err := zw.Create("large.ddd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	chunk := make([]byte, 1<<20)
+	for range maxUnwrappedSize / len(chunk) {
+		if _, err := w.Write(chunk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := w.Write([]byte{0}); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = Unwrap(b.Bytes())
+	if err == nil || !strings.Contains(err.Error(), "large.ddd: file too large") {
+		t.Errorf("Unwrap() error = %v, want file too large", err)
+	}
+
+	data, err := readUnwrapped(bytes.NewReader(make([]byte, maxUnwrappedSize)))
+	if err != nil || len(data) != maxUnwrappedSize {
+		t.Errorf("readUnwrapped() of %d bytes = %d bytes, %v", maxUnwrappedSize, len(data), err)
+	}
+}
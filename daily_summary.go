@@ -0,0 +1,172 @@
+package tachograph
+
+import (
+	"time"
+
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+)
+
+// DailySummary summarizes a single calendar day of a driver card.
+type DailySummary struct {
+	// Date is the start of the day (00:00 UTC).
+	Date time.Time
+	// Driving is the total driving time.
+	Driving time.Duration
+	// Work is the total other work time.
+	Work time.Duration
+	// Availability is the total availability time.
+	Availability time.Duration
+	// Rest is the total break and rest time.
+	Rest time.Duration
+	// Unknown is the total time the card was not inserted and no activity was
+	// entered manually.
+	Unknown time.Duration
+	// OutOfScope is the total time out of scope of the regulation. This time
+	// is excluded from the activity totals.
+	OutOfScope time.Duration
+	// FerryTrainCrossing is the total time on a ferry or train. This time is
+	// included in the activity totals.
+	FerryTrainCrossing time.Duration
+	// DistanceKm is the distance travelled during the day.
+	DistanceKm int32
+	// Vehicles are the vehicles used during the day, ordered by first use.
+	Vehicles []VehicleUse
+	// StartPlace is the first place where a daily work period began during
+	// the day, nil if none was entered.
+	StartPlace *Place
+	// EndPlace is the last place where a daily work period ended during the
+	// day, nil if none was entered.
+	EndPlace *Place
+	// SpecificConditions are the specific conditions active during the day,
+	// clipped to the day.
+	SpecificConditions []SpecificConditionPeriod
+}
+
+// VehicleUse is the use of a vehicle recorded on a driver card.
+type VehicleUse struct {
+	// Registration is the vehicle registration.
+	Registration VehicleRegistration
+	// VIN is the vehicle identification number, recorded on Gen2 cards only.
+	VIN string
+	// FirstUse is the time the vehicle use began.
+	FirstUse time.Time
+	// LastUse is the time the vehicle use ended, zero if the vehicle was
+	// still in use at download.
+	LastUse time.Time
+	// OdometerBeginKm is the odometer value at the beginning of the use.
+	OdometerBeginKm int32
+	// OdometerEndKm is the odometer value at the end of the use.
+	OdometerEndKm int32
+}
+
+// CardEvent is an event or fault recorded on a driver card.
+type CardEvent struct {
+	// Type is the type of the event or fault.
+	Type ddv1.EventFaultType
+	// Fault is true for faults and false for events.
+	Fault bool
+	// BeginTime is the time the event or fault began.
+	BeginTime time.Time
+	// EndTime is the time the event or fault ended.
+	EndTime time.Time
+	// Registration is the registration of the vehicle in which the event or
+	// fault occurred.
+	Registration VehicleRegistration
+}
+
+// DailySummaries summarizes each day with recorded activity on a driver card.
+//
+// The summaries are ordered by date. Gen2 cards are summarized from the Gen2
+// application, falling back to the Gen1 application for records that are only
+// held there.
+func DailySummaries(card *cardv1.DriverCardFile) []DailySummary {
+	uses := VehicleUses(card)
+	places := Places(card)
+	conditions := SpecificConditionPeriods(card)
+	var result []DailySummary
+	for _, record := range driverCardDailyRecords(card) {
+		date := record.GetActivityRecordDate().AsTime()
+		day := Period{Start: date, End: date.AddDate(0, 0, 1)}
+		summary := DailySummary{
+			Date:       date,
+			DistanceKm: record.GetActivityDayDistance(),
+		}
+		for _, p := range OverlaySpecificConditions(cardActivityPeriods(date, record.GetActivityChangeInfo()), conditions) {
+			d := p.Duration()
+			if p.FerryTrainCrossing {
+				summary.FerryTrainCrossing += d
+			}
+			switch {
+			case p.OutOfScope:
+				summary.OutOfScope += d
+			case p.Unknown():
+				summary.Unknown += d
+			case p.Activity == ddv1.DriverActivityValue_DRIVING:
+				summary.Driving += d
+			case p.Activity == ddv1.DriverActivityValue_WORK:
+				summary.Work += d
+			case p.Activity == ddv1.DriverActivityValue_AVAILABILITY:
+				summary.Availability += d
+			case p.Activity == ddv1.DriverActivityValue_BREAK_REST:
+				summary.Rest += d
+			default:
+				summary.Unknown += d
+			}
+		}
+		for _, u := range uses {
+			if u.FirstUse.Before(day.End) && (u.LastUse.IsZero() || !u.LastUse.Before(day.Start)) {
+				summary.Vehicles = append(summary.Vehicles, u)
+			}
+		}
+		for i, p := range places {
+			if p.Time.Before(day.Start) || !p.Time.Before(day.End) {
+				continue
+			}
+			if summary.StartPlace == nil && isBeginEntryType(p.EntryType) {
+				summary.StartPlace = &places[i]
+			}
+			if isEndEntryType(p.EntryType) {
+				summary.EndPlace = &places[i]
+			}
+		}
+		for _, c := range conditions {
+			if !c.Start.Before(day.End) || (!c.End.IsZero() && !c.End.After(day.Start)) {
+				continue
+			}
+			if c.Start.Before(day.Start) {
+				c.Start = day.Start
+			}
+			if c.End.IsZero() || c.End.After(day.End) {
+				c.End = day.End
+			}
+			summary.SpecificConditions = append(summary.SpecificConditions, c)
+		}
+		result = append(result, summary)
+	}
+	return result
+}
+
+// isBeginEntryType reports whether the entry type begins a daily work period.
+func isBeginEntryType(t ddv1.EntryTypeDailyWorkPeriod) bool {
+	switch t {
+	case ddv1.EntryTypeDailyWorkPeriod_BEGIN,
+		ddv1.EntryTypeDailyWorkPeriod_BEGIN_GNSS,
+		ddv1.EntryTypeDailyWorkPeriod_BEGIN_ITS:
+		return true
+	default:
+		return false
+	}
+}
+
+// isEndEntryType reports whether the entry type ends a daily work period.
+func isEndEntryType(t ddv1.EntryTypeDailyWorkPeriod) bool {
+	switch t {
+	case ddv1.EntryTypeDailyWorkPeriod_END,
+		ddv1.EntryTypeDailyWorkPeriod_END_GNSS,
+		ddv1.EntryTypeDailyWorkPeriod_END_ITS:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,115 @@
+package tachograph
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+)
+
+func testDailyRecord(day time.Time, distanceKm int32, changes ...*ddv1.ActivityChangeInfo) *cardv1.DriverActivityData_DailyRecord {
+	record := &cardv1.DriverActivityData_DailyRecord{}
+	record.SetValid(true)
+	record.SetActivityRecordDate(timestamppb.New(day))
+	record.SetActivityDayDistance(distanceKm)
+	record.SetActivityChangeInfo(changes)
+	return record
+}
+
+func testPlaceRecord(t time.Time, entryType ddv1.EntryTypeDailyWorkPeriod, country ddv1.NationNumeric) *ddv1.PlaceRecord {
+	record := &ddv1.PlaceRecord{}
+	record.SetEntryTime(timestamppb.New(t))
+	record.SetEntryTypeDailyWorkPeriod(entryType)
+	record.SetDailyWorkPeriodCountry(country)
+	return record
+}
+
+func testSpecificConditionRecord(t time.Time, conditionType ddv1.SpecificConditionType) *ddv1.SpecificConditionRecord {
+	record := &ddv1.SpecificConditionRecord{}
+	record.SetEntryTime(timestamppb.New(t))
+	record.SetSpecificConditionType(conditionType)
+	return record
+}
+
+func TestDailySummaries(t *testing.T) {
+	use := Period{Start: testDay(0).Add(6 * time.Hour), End: testDay(0).Add(16 * time.Hour)}
+	card := testDriverCardFile("DRIVER00000001", testCardVehicleRecord("AB123CD", use, 1000, 1350))
+	activityData := &cardv1.DriverActivityData{}
+	activityData.SetDailyRecords([]*cardv1.DriverActivityData_DailyRecord{
+		testDailyRecord(testDay(1), 0,
+			testActivityChange(ddv1.CardSlotNumber_DRIVER_SLOT, ddv1.DriverActivityValue_BREAK_REST, 0),
+		),
+		testDailyRecord(testDay(0), 350,
+			testActivityChange(ddv1.CardSlotNumber_DRIVER_SLOT, ddv1.DriverActivityValue_BREAK_REST, 0),
+			testActivityChange(ddv1.CardSlotNumber_DRIVER_SLOT, ddv1.DriverActivityValue_WORK, 6*60),
+			testActivityChange(ddv1.CardSlotNumber_DRIVER_SLOT, ddv1.DriverActivityValue_DRIVING, 6*60+30),
+			testActivityChange(ddv1.CardSlotNumber_DRIVER_SLOT, ddv1.DriverActivityValue_AVAILABILITY, 11*60),
+			testActivityChange(ddv1.CardSlotNumber_DRIVER_SLOT, ddv1.DriverActivityValue_DRIVING, 12*60),
+			testActivityChange(ddv1.CardSlotNumber_DRIVER_SLOT, ddv1.DriverActivityValue_BREAK_REST, 16*60),
+		),
+	})
+	places := &cardv1.Places{}
+	places.SetRecords([]*ddv1.PlaceRecord{
+		testPlaceRecord(use.Start, ddv1.EntryTypeDailyWorkPeriod_BEGIN, ddv1.NationNumeric_GERMANY),
+		testPlaceRecord(use.End, ddv1.EntryTypeDailyWorkPeriod_END, ddv1.NationNumeric_AUSTRIA),
+	})
+	conditions := &cardv1.SpecificConditions{}
+	conditions.SetRecords([]*ddv1.SpecificConditionRecord{
+		testSpecificConditionRecord(testDay(0).Add(20*time.Hour), ddv1.SpecificConditionType_OUT_OF_SCOPE_BEGIN),
+		testSpecificConditionRecord(testDay(1).Add(2*time.Hour), ddv1.SpecificConditionType_OUT_OF_SCOPE_END),
+	})
+	card.GetTachograph().SetDriverActivityData(activityData)
+	card.GetTachograph().SetPlaces(places)
+	card.GetTachograph().SetSpecificConditions(conditions)
+
+	summaries := DailySummaries(card)
+	if len(summaries) != 2 {
+		t.Fatalf("DailySummaries() = %d days, want 2", len(summaries))
+	}
+	first := summaries[0]
+	if !first.Date.Equal(testDay(0)) {
+		t.Errorf("date = %v, want %v", first.Date, testDay(0))
+	}
+	for _, tt := range []struct {
+		name string
+		got  time.Duration
+		want time.Duration
+	}{
+		{name: "driving", got: first.Driving, want: 8*time.Hour + 30*time.Minute},
+		{name: "work", got: first.Work, want: 30 * time.Minute},
+		{name: "availability", got: first.Availability, want: time.Hour},
+		{name: "rest", got: first.Rest, want: 10 * time.Hour},
+		{name: "unknown", got: first.Unknown, want: 0},
+		{name: "out of scope", got: first.OutOfScope, want: 4 * time.Hour},
+	} {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+	if first.DistanceKm != 350 {
+		t.Errorf("distance = %d, want 350", first.DistanceKm)
+	}
+	if len(first.Vehicles) != 1 || first.Vehicles[0].Registration.Number != "AB123CD" {
+		t.Errorf("vehicles = %+v, want AB123CD", first.Vehicles)
+	}
+	if first.StartPlace == nil || first.StartPlace.Country != ddv1.NationNumeric_GERMANY {
+		t.Errorf("start place = %+v, want Germany", first.StartPlace)
+	}
+	if first.EndPlace == nil || first.EndPlace.Country != ddv1.NationNumeric_AUSTRIA {
+		t.Errorf("end place = %+v, want Austria", first.EndPlace)
+	}
+	wantCondition := Period{Start: testDay(0).Add(20 * time.Hour), End: testDay(1)}
+	if len(first.SpecificConditions) != 1 || first.SpecificConditions[0].Period != wantCondition {
+		t.Errorf("specific conditions = %+v, want out of scope %v", first.SpecificConditions, wantCondition)
+	}
+	second := summaries[1]
+	if second.Rest != 22*time.Hour || second.OutOfScope != 2*time.Hour || len(second.Vehicles) != 0 || second.StartPlace != nil {
+		t.Errorf("second day = %+v", second)
+	}
+	if len(second.SpecificConditions) != 1 || second.SpecificConditions[0].End != testDay(1).Add(2*time.Hour) {
+		t.Errorf("second day specific conditions = %+v", second.SpecificConditions)
+	}
+}
@@ -0,0 +1,103 @@
+package tachograph
+
+import (
+	"fmt"
+
+	"github.com/way-platform/tachograph-go/internal/card"
+	"github.com/way-platform/tachograph-go/internal/vu"
+)
+
+// Diagnostic describes a part of a file that was skipped in lenient parsing.
+type Diagnostic struct {
+	// Name is the name of the skipped elementary file (e.g. EF_PLACES) or
+	// transfer (e.g. ACTIVITIES_GEN1), or empty if the data could not be split
+	// into elementary files or transfers.
+	Name string
+	// Tag is the tag of the skipped elementary file or transfer, if known.
+	Tag uint32
+	// Offset is the byte offset of the skipped data in the file.
+	Offset int
+	// Data is the raw value of the skipped elementary file or transfer, or the
+	// remaining data of the file if it could not be split.
+	Data []byte
+	// Err is the reason the data was skipped.
+	Err error
+}
+
+// Error implements the error interface.
+func (d Diagnostic) Error() string {
+	name := d.Name
+	if name == "" {
+		name = "data"
+	}
+	return fmt.Sprintf("%s at offset %d: %v", name, d.Offset, d.Err)
+}
+
+// Unwrap returns the reason the data was skipped.
+func (d Diagnostic) Unwrap() error {
+	return d.Err
+}
+
+// Diagnostics is the list of parts of a file that were skipped in lenient
+// parsing, ordered by offset.
+type Diagnostics []Diagnostic
+
+// Error implements the error interface.
+func (d Diagnostics) Error() string {
+	switch len(d) {
+	case 0:
+		return "no diagnostics"
+	case 1:
+		return d[0].Error()
+	default:
+		return fmt.Sprintf("%s (and %d more diagnostics)", d[0].Error(), len(d)-1)
+	}
+}
+
+// Unwrap returns the diagnostics as a list of errors.
+func (d Diagnostics) Unwrap() []error {
+	errs := make([]error, len(d))
+	for i := range d {
+		errs[i] = d[i]
+	}
+	return errs
+}
+
+// err returns the diagnostics as an error, or nil if there are none.
+func (d Diagnostics) err() error {
+	if len(d) == 0 {
+		return nil
+	}
+	return d
+}
+
+// recordDiagnostics converts the skipped records of a card file to diagnostics.
+func recordDiagnostics(errs []*card.RecordError) Diagnostics {
+	var result Diagnostics
+	for _, err := range errs {
+		result = append(result, Diagnostic{
+			Name:   err.Record.GetFile().String(),
+			Tag:    uint32(err.Record.GetTag()),
+			Offset: err.Offset,
+			Data:   err.Record.GetValue(),
+			Err:    err.Err,
+		})
+	}
+	return result
+}
+
+// transferDiagnostics converts the skipped transfers of a vehicle unit file to
+// diagnostics.
+func transferDiagnostics(data []byte, errs []*vu.TransferError) Diagnostics {
+	var result Diagnostics
+	for _, err := range errs {
+		d := Diagnostic{Offset: err.Offset, Data: data[err.Offset:], Err: err.Err}
+		if err.Record != nil {
+			d.Name = err.Record.GetType().String()
+			d.Tag = err.Record.GetTag()
+			d.Data = err.Record.GetValue()
+		}
+		result = append(result, d)
+	}
+	return result
+}
@@ -0,0 +1,338 @@
+package tachograph
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DifferenceType is the type of a [Difference].
+type DifferenceType int
+
+const (
+	// DifferenceAdded is a value that is only present in the second file.
+	DifferenceAdded DifferenceType = iota + 1
+	// DifferenceRemoved is a value that is only present in the first file.
+	DifferenceRemoved
+	// DifferenceModified is a value that is present in both files, but differs.
+	DifferenceModified
+)
+
+// String returns a human-readable name for the difference type.
+func (t DifferenceType) String() string {
+	switch t {
+	case DifferenceAdded:
+		return "added"
+	case DifferenceRemoved:
+		return "removed"
+	case DifferenceModified:
+		return "modified"
+	default:
+		return "unknown"
+	}
+}
+
+// Difference is a semantic difference between two tachograph files.
+type Difference struct {
+	// Type is the type of the difference.
+	Type DifferenceType
+	// Path is the path of the value, as dot-separated field names.
+	//
+	// Elements of records are identified by their natural key, such as the
+	// date of a daily record or the begin time and type of an event, rather
+	// than by their index. For example:
+	//
+	//	driver_card.tachograph.driver_activity_data.daily_records[activity_record_date=2024-01-01T00:00:00Z].activity_daily_presence_counter
+	Path string
+	// Old is the formatted value in the first file, empty if added.
+	Old string
+	// New is the formatted value in the second file, empty if removed.
+	New string
+}
+
+// String returns a human-readable representation of the difference.
+func (d Difference) String() string {
+	switch d.Type {
+	case DifferenceAdded:
+		return fmt.Sprintf("+ %s: %s", d.Path, d.New)
+	case DifferenceRemoved:
+		return fmt.Sprintf("- %s: %s", d.Path, d.Old)
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", d.Path, d.Old, d.New)
+	}
+}
+
+// DiffOptions configures the comparison of tachograph files.
+type DiffOptions struct {
+	// IncludeRawData includes the raw data fields in the comparison.
+	//
+	// Raw data is excluded by default, since any change to a value also
+	// changes the raw data it was decoded from.
+	IncludeRawData bool
+}
+
+// DiffFiles compares two tachograph files semantically.
+//
+// See [DiffOptions] if you need more control over the comparison.
+func DiffFiles(a, b *tachographv1.File) []Difference {
+	return DiffOptions{}.DiffFiles(a, b)
+}
+
+// DiffFiles compares two tachograph files semantically, and returns the
+// differences in the order of the fields and records of the files.
+//
+// Records are matched by their natural key rather than by their index: their
+// timestamps, dates and time of change, together with their type, slot and
+// card numbers. Records without a natural key are matched by index.
+func (o DiffOptions) DiffFiles(a, b *tachographv1.File) []Difference {
+	var result []Difference
+	o.diffMessages(&result, "", a.ProtoReflect(), b.ProtoReflect())
+	return result
+}
+
+// diffMessages appends the differences between two messages of the same type.
+func (o DiffOptions) diffMessages(result *[]Difference, path string, a, b protoreflect.Message) {
+	fields := a.Descriptor().Fields()
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		if fd.Name() == "raw_data" && !o.IncludeRawData {
+			continue
+		}
+		fieldPath := string(fd.Name())
+		if path != "" {
+			fieldPath = path + "." + fieldPath
+		}
+		hasA, hasB := a.IsValid() && a.Has(fd), b.IsValid() && b.Has(fd)
+		switch {
+		case !hasA && !hasB:
+			continue
+		case fd.IsList():
+			o.diffLists(result, fieldPath, fd, a, b)
+		case !hasB:
+			*result = append(*result, Difference{Type: DifferenceRemoved, Path: fieldPath, Old: o.formatValue(fd, a.Get(fd))})
+		case !hasA:
+			*result = append(*result, Difference{Type: DifferenceAdded, Path: fieldPath, New: o.formatValue(fd, b.Get(fd))})
+		case fd.Message() != nil && !isTimestamp(fd.Message()) && !fd.IsMap():
+			o.diffMessages(result, fieldPath, a.Get(fd).Message(), b.Get(fd).Message())
+		default:
+			if oldValue, newValue := o.formatValue(fd, a.Get(fd)), o.formatValue(fd, b.Get(fd)); oldValue != newValue {
+				*result = append(*result, Difference{Type: DifferenceModified, Path: fieldPath, Old: oldValue, New: newValue})
+			}
+		}
+	}
+}
+
+// diffLists appends the differences between two repeated fields, matching
+// their elements by natural key.
+func (o DiffOptions) diffLists(result *[]Difference, path string, fd protoreflect.FieldDescriptor, a, b protoreflect.Message) {
+	var listA, listB protoreflect.List
+	if a.IsValid() {
+		listA = a.Get(fd).List()
+	}
+	if b.IsValid() {
+		listB = b.Get(fd).List()
+	}
+	keysA, keysB := elementKeys(fd, listA), elementKeys(fd, listB)
+	indexB := make(map[string]int, len(keysB))
+	for i, key := range keysB {
+		indexB[key] = i
+	}
+	matched := make(map[string]bool, len(keysA))
+	for i, key := range keysA {
+		elementPath := path + "[" + key + "]"
+		j, ok := indexB[key]
+		if !ok {
+			*result = append(*result, Difference{Type: DifferenceRemoved, Path: elementPath, Old: o.formatElement(fd, listA.Get(i))})
+			continue
+		}
+		matched[key] = true
+		if fd.Message() != nil && !isTimestamp(fd.Message()) {
+			o.diffMessages(result, elementPath, listA.Get(i).Message(), listB.Get(j).Message())
+		} else if oldValue, newValue := o.formatElement(fd, listA.Get(i)), o.formatElement(fd, listB.Get(j)); oldValue != newValue {
+			*result = append(*result, Difference{Type: DifferenceModified, Path: elementPath, Old: oldValue, New: newValue})
+		}
+	}
+	for j, key := range keysB {
+		if !matched[key] {
+			*result = append(*result, Difference{Type: DifferenceAdded, Path: path + "[" + key + "]", New: o.formatElement(fd, listB.Get(j))})
+		}
+	}
+}
+
+// elementKeys returns the natural keys of the elements of a list.
+//
+// The key of a message element consists of its timestamp, date and time
+// fields, its type and slot fields, and its card numbers. Elements without any
+// of these fields are keyed by their index. Duplicate keys are numbered by
+// occurrence.
+func elementKeys(fd protoreflect.FieldDescriptor, list protoreflect.List) []string {
+	if list == nil {
+		return nil
+	}
+	keys := make([]string, list.Len())
+	seen := make(map[string]int, list.Len())
+	for i := range list.Len() {
+		var key string
+		if fd.Message() != nil {
+			key = naturalKey(list.Get(i).Message())
+		}
+		if key == "" {
+			key = strconv.Itoa(i)
+		}
+		if n := seen[key]; n > 0 {
+			seen[key]++
+			key = fmt.Sprintf("%s#%d", key, n+1)
+		} else {
+			seen[key] = 1
+		}
+		keys[i] = key
+	}
+	return keys
+}
+
+// naturalKey returns the natural key of a record, or an empty string if the
+// record has no key fields.
+//
+// The key consists of the first timestamp of the record, since later
+// timestamps such as end times may still change, its time of change, its type
+// and slot, and its card numbers other than those at the end of an event, so
+// that the records of cards in the driver and co-driver slots don't collide.
+func naturalKey(m protoreflect.Message) string {
+	var parts []string
+	var hasTimestamp bool
+	fields := m.Descriptor().Fields()
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		if fd.IsList() || fd.IsMap() || !m.Has(fd) {
+			continue
+		}
+		name := string(fd.Name())
+		switch fd.Kind() {
+		case protoreflect.MessageKind:
+			if isCardNumber(fd.Message()) {
+				if cardNumber := cardNumberKey(m.Get(fd).Message()); cardNumber != "" && !strings.HasSuffix(name, "_end") {
+					parts = append(parts, name+"="+cardNumber)
+				}
+				continue
+			}
+			if !isTimestamp(fd.Message()) || hasTimestamp {
+				continue
+			}
+			hasTimestamp = true
+		case protoreflect.EnumKind:
+			if !strings.Contains(name, "type") && !strings.Contains(name, "slot") {
+				continue
+			}
+		case protoreflect.Int32Kind, protoreflect.Int64Kind:
+			if !strings.HasPrefix(name, "time_of_") {
+				continue
+			}
+		default:
+			continue
+		}
+		parts = append(parts, name+"="+formatScalar(fd, m.Get(fd)))
+	}
+	return strings.Join(parts, ",")
+}
+
+// isCardNumber reports whether a message is a full card number, with or
+// without its generation.
+func isCardNumber(md protoreflect.MessageDescriptor) bool {
+	switch md.FullName() {
+	case (*ddv1.FullCardNumber)(nil).ProtoReflect().Descriptor().FullName(),
+		(*ddv1.FullCardNumberAndGeneration)(nil).ProtoReflect().Descriptor().FullName():
+		return true
+	}
+	return false
+}
+
+// cardNumberKey returns the card number of a full card number message, with or
+// without its generation.
+func cardNumberKey(m protoreflect.Message) string {
+	switch cardNumber := m.Interface().(type) {
+	case *ddv1.FullCardNumber:
+		return cardNumberString(cardNumber)
+	case *ddv1.FullCardNumberAndGeneration:
+		return cardNumberString(cardNumber.GetFullCardNumber())
+	}
+	return ""
+}
+
+// formatValue formats a singular field value.
+func (o DiffOptions) formatValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	if fd.IsMap() {
+		return fmt.Sprintf("map with %d entries", v.Map().Len())
+	}
+	return o.formatElement(fd, v)
+}
+
+// formatElement formats a singular field value or a list element.
+func (o DiffOptions) formatElement(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	if fd.Message() == nil || isTimestamp(fd.Message()) {
+		return formatScalar(fd, v)
+	}
+	m := proto.Clone(v.Message().Interface())
+	if !o.IncludeRawData {
+		clearRawData(m.ProtoReflect())
+	}
+	return strings.Join(strings.Fields(prototext.Format(m)), " ")
+}
+
+// formatScalar formats a scalar, enum or timestamp value.
+func formatScalar(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		if ts, ok := v.Message().Interface().(*timestamppb.Timestamp); ok {
+			return ts.AsTime().UTC().Format(time.RFC3339)
+		}
+		return v.String()
+	case protoreflect.EnumKind:
+		if value := fd.Enum().Values().ByNumber(v.Enum()); value != nil {
+			return string(value.Name())
+		}
+		return strconv.Itoa(int(v.Enum()))
+	case protoreflect.BytesKind:
+		const maxBytes = 16
+		data := v.Bytes()
+		if len(data) > maxBytes {
+			return fmt.Sprintf("%s... (%d bytes)", hex.EncodeToString(data[:maxBytes]), len(data))
+		}
+		return hex.EncodeToString(data)
+	case protoreflect.StringKind:
+		return strconv.Quote(v.String())
+	default:
+		return v.String()
+	}
+}
+
+// clearRawData clears the raw data fields of a message and its sub-messages.
+func clearRawData(m protoreflect.Message) {
+	for _, fd := range populatedFields(m) {
+		switch {
+		case fd.Name() == "raw_data":
+			m.Clear(fd)
+		case fd.IsList() && fd.Message() != nil:
+			list := m.Get(fd).List()
+			for i := range list.Len() {
+				clearRawData(list.Get(i).Message())
+			}
+		case fd.Message() != nil && !fd.IsMap():
+			clearRawData(m.Get(fd).Message())
+		}
+	}
+}
+
+// isTimestamp reports whether a message type is a timestamp, which is
+// compared as a single value.
+func isTimestamp(md protoreflect.MessageDescriptor) bool {
+	return md.FullName() == "google.protobuf.Timestamp"
+}
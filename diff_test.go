@@ -0,0 +1,126 @@
+package tachograph
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+)
+
+func TestDiffFiles(t *testing.T) {
+	testFile := func(records []*ddv1.CardVehicleRecord, days ...*cardv1.DriverActivityData_DailyRecord) *tachographv1.File {
+		card := testDriverCardFile("DRIVER00000001", records...)
+		activityData := &cardv1.DriverActivityData{}
+		activityData.SetDailyRecords(days)
+		card.GetTachograph().SetDriverActivityData(activityData)
+		file := &tachographv1.File{}
+		file.SetType(tachographv1.File_DRIVER_CARD)
+		file.SetDriverCard(card)
+		return file
+	}
+	firstUse := Period{Start: testDay(0).Add(6 * time.Hour), End: testDay(0).Add(16 * time.Hour)}
+	secondUse := Period{Start: testDay(2).Add(6 * time.Hour), End: testDay(2).Add(16 * time.Hour)}
+	a := testFile(
+		[]*ddv1.CardVehicleRecord{testCardVehicleRecord("AB123CD", firstUse, 1000, 1350)},
+		testDailyRecord(testDay(0), 350),
+		testDailyRecord(testDay(1), 0),
+	)
+	// Records are rotated, as in the cyclic buffers of the card.
+	b := testFile(
+		[]*ddv1.CardVehicleRecord{
+			testCardVehicleRecord("EF456GH", secondUse, 5000, 5200),
+			testCardVehicleRecord("AB123CD", firstUse, 1000, 1350),
+		},
+		testDailyRecord(testDay(1), 0),
+		testDailyRecord(testDay(2), 200),
+		testDailyRecord(testDay(0), 400),
+	)
+
+	if diff := DiffFiles(a, a); len(diff) != 0 {
+		t.Errorf("DiffFiles(a, a) = %v, want no differences", diff)
+	}
+	const (
+		vehicles = "driver_card.tachograph.vehicles_used.records"
+		days     = "driver_card.tachograph.driver_activity_data.daily_records"
+	)
+	dayKey := func(day time.Time) string {
+		return "[activity_record_date=" + day.Format(time.RFC3339) + "]"
+	}
+	want := map[string]DifferenceType{
+		vehicles + "[vehicle_first_use=" + secondUse.Start.Format(time.RFC3339) + "]": DifferenceAdded,
+		days + dayKey(testDay(0)) + ".activity_day_distance":                          DifferenceModified,
+		days + dayKey(testDay(2)):                                                     DifferenceAdded,
+	}
+	diff := DiffFiles(a, b)
+	if len(diff) != len(want) {
+		t.Errorf("DiffFiles(a, b) = %d differences, want %d", len(diff), len(want))
+	}
+	for _, d := range diff {
+		if wantType, ok := want[d.Path]; !ok || d.Type != wantType {
+			t.Errorf("unexpected difference: %v", d)
+		}
+		if d.Type == DifferenceModified && (d.Old != "350" || d.New != "400") {
+			t.Errorf("difference %v, want 350 -> 400", d)
+		}
+	}
+
+	reverse := DiffFiles(b, a)
+	for _, d := range reverse {
+		if d.Type == DifferenceAdded {
+			t.Errorf("DiffFiles(b, a) has added %v, want removed", d)
+		}
+		if d.Type == DifferenceRemoved && !strings.HasPrefix(d.String(), "- ") {
+			t.Errorf("Difference.String() = %q, want prefix \"- \"", d.String())
+		}
+	}
+}
+
+func TestDiffFiles_vehicleUnit(t *testing.T) {
+	testFile := func(coDriver string, odometerKm int32) *tachographv1.File {
+		vehicleUnit := testVehicleUnitFileGen1("WDB9634031L123456", Period{Start: testDay(0), End: testDay(1)}, map[time.Time][]*ddv1.ActivityChangeInfo{
+			testDay(0): nil,
+		})
+		activities := vehicleUnit.GetGen1().GetActivities()[0]
+		driverIW := activities.GetCardIwData()[0]
+		driverIW.SetOdometerAtInsertionKm(odometerKm)
+		// The co-driver card is inserted at the same time as the driver card.
+		coDriverIW := &ddv1.VuCardIWRecord{}
+		coDriverIW.SetFullCardNumber(testDriverCardNumber(coDriver))
+		coDriverIW.SetCardSlotNumber(ddv1.CardSlotNumber_CO_DRIVER_SLOT)
+		coDriverIW.SetCardInsertionTime(driverIW.GetCardInsertionTime())
+		activities.SetCardIwData([]*ddv1.VuCardIWRecord{coDriverIW, driverIW})
+		file := &tachographv1.File{}
+		file.SetType(tachographv1.File_VEHICLE_UNIT)
+		file.SetVehicleUnit(vehicleUnit)
+		return file
+	}
+	a := testFile("DRIVER00000002", 1000)
+	b := testFile("DRIVER00000003", 1001)
+	// Records are listed in another order.
+	iwData := b.GetVehicleUnit().GetGen1().GetActivities()[0].GetCardIwData()
+	slices.Reverse(iwData)
+
+	iw := "vehicle_unit.gen1.activities[date_of_day=" + testDay(0).Format(time.RFC3339) + "].card_iw_data"
+	insertion := testDay(0).Add(6 * time.Hour).Format(time.RFC3339)
+	iwKey := func(cardNumber string, slot ddv1.CardSlotNumber) string {
+		return "[full_card_number=" + cardNumber + ",card_insertion_time=" + insertion + ",card_slot_number=" + slot.String() + "]"
+	}
+	want := map[string]DifferenceType{
+		iw + iwKey("DRIVER00000002", ddv1.CardSlotNumber_CO_DRIVER_SLOT):                            DifferenceRemoved,
+		iw + iwKey("DRIVER00000003", ddv1.CardSlotNumber_CO_DRIVER_SLOT):                            DifferenceAdded,
+		iw + iwKey("DRIVER00000001", ddv1.CardSlotNumber_DRIVER_SLOT) + ".odometer_at_insertion_km": DifferenceModified,
+	}
+	diff := DiffFiles(a, b)
+	if len(diff) != len(want) {
+		t.Errorf("DiffFiles(a, b) = %v, want %d differences", diff, len(want))
+	}
+	for _, d := range diff {
+		if wantType, ok := want[d.Path]; !ok || d.Type != wantType {
+			t.Errorf("unexpected difference: %v", d)
+		}
+	}
+}
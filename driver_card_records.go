@@ -0,0 +1,279 @@
+package tachograph
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+)
+
+// The functions in this file read records from the Gen1 and Gen2 applications
+// of a driver card. A Gen2 card holds the same records in both applications,
+// so records are deduplicated, preferring the Gen2 record.
+
+// DriverCardNumber returns the card number of a driver card.
+func DriverCardNumber(card *cardv1.DriverCardFile) string {
+	if number := driverIdentificationString(card.GetTachographG2().GetIdentification().GetCard().GetDriverIdentification()); number != "" {
+		return number
+	}
+	return driverIdentificationString(card.GetTachograph().GetIdentification().GetCard().GetDriverIdentification())
+}
+
+// VehicleUses returns the vehicle uses recorded on a driver card, ordered by
+// first use.
+func VehicleUses(card *cardv1.DriverCardFile) []VehicleUse {
+	uses := map[string]VehicleUse{}
+	add := func(u VehicleUse) {
+		if u.FirstUse.IsZero() {
+			return
+		}
+		uses[fmt.Sprintf("%s/%d", u.Registration.Number, u.FirstUse.Unix())] = u
+	}
+	for _, r := range card.GetTachograph().GetVehiclesUsed().GetRecords() {
+		add(VehicleUse{
+			Registration:    newVehicleRegistration(r.GetVehicleRegistration()),
+			FirstUse:        timeOf(r.GetVehicleFirstUse()),
+			LastUse:         timeOf(r.GetVehicleLastUse()),
+			OdometerBeginKm: r.GetVehicleOdometerBeginKm(),
+			OdometerEndKm:   r.GetVehicleOdometerEndKm(),
+		})
+	}
+	for _, r := range card.GetTachographG2().GetVehiclesUsed().GetRecords() {
+		add(VehicleUse{
+			Registration:    newVehicleRegistration(r.GetVehicleRegistration()),
+			VIN:             strings.TrimSpace(r.GetVehicleIdentificationNumber()),
+			FirstUse:        timeOf(r.GetVehicleFirstUse()),
+			LastUse:         timeOf(r.GetVehicleLastUse()),
+			OdometerBeginKm: r.GetVehicleOdometerBeginKm(),
+			OdometerEndKm:   r.GetVehicleOdometerEndKm(),
+		})
+	}
+	return sortedValues(uses, func(a, b VehicleUse) int {
+		return a.FirstUse.Compare(b.FirstUse)
+	})
+}
+
+// driverCardDailyRecords returns the valid daily activity records of a
+// driver card, ordered by date.
+func driverCardDailyRecords(card *cardv1.DriverCardFile) []*cardv1.DriverActivityData_DailyRecord {
+	records := card.GetTachographG2().GetDriverActivityData().GetDailyRecords()
+	if len(records) == 0 {
+		records = card.GetTachograph().GetDriverActivityData().GetDailyRecords()
+	}
+	var result []*cardv1.DriverActivityData_DailyRecord
+	for _, r := range records {
+		if r.GetValid() && r.GetActivityRecordDate() != nil {
+			result = append(result, r)
+		}
+	}
+	slices.SortFunc(result, func(a, b *cardv1.DriverActivityData_DailyRecord) int {
+		return a.GetActivityRecordDate().AsTime().Compare(b.GetActivityRecordDate().AsTime())
+	})
+	return result
+}
+
+// Places returns the places where daily work periods began or ended, recorded
+// on a driver card, ordered by entry time.
+func Places(card *cardv1.DriverCardFile) []Place {
+	places := map[string]Place{}
+	add := func(p Place) {
+		if p.Time.IsZero() {
+			return
+		}
+		places[fmt.Sprintf("%d/%d", p.Time.Unix(), p.EntryType)] = p
+	}
+	for _, r := range card.GetTachograph().GetPlaces().GetRecords() {
+		add(Place{
+			Time:       timeOf(r.GetEntryTime()),
+			EntryType:  r.GetEntryTypeDailyWorkPeriod(),
+			Country:    r.GetDailyWorkPeriodCountry(),
+			OdometerKm: r.GetVehicleOdometerKm(),
+		})
+	}
+	authentication := map[int64]ddv1.PositionAuthenticationStatus{}
+	for _, r := range card.GetTachographG2().GetPlacesAuthentication().GetRecords() {
+		authentication[r.GetEntryTime().GetSeconds()] = r.GetAuthenticationStatus()
+	}
+	for _, r := range card.GetTachographG2().GetPlaces().GetRecords() {
+		add(Place{
+			Time:                 timeOf(r.GetEntryTime()),
+			EntryType:            r.GetEntryTypeDailyWorkPeriod(),
+			Country:              r.GetDailyWorkPeriodCountry(),
+			OdometerKm:           r.GetVehicleOdometerKm(),
+			Position:             r.GetEntryGnssPlaceRecord().GetGeoCoordinates(),
+			AuthenticationStatus: authentication[r.GetEntryTime().GetSeconds()],
+		})
+	}
+	return sortedValues(places, func(a, b Place) int {
+		return cmp.Or(a.Time.Compare(b.Time), cmp.Compare(a.EntryType, b.EntryType))
+	})
+}
+
+// driverCardSpecificConditions returns the specific condition records of a
+// driver card, ordered by entry time.
+func driverCardSpecificConditions(card *cardv1.DriverCardFile) []*ddv1.SpecificConditionRecord {
+	records := map[string]*ddv1.SpecificConditionRecord{}
+	add := func(r *ddv1.SpecificConditionRecord) {
+		if r.GetEntryTime() == nil {
+			return
+		}
+		records[fmt.Sprintf("%d/%d", r.GetEntryTime().GetSeconds(), r.GetSpecificConditionType())] = r
+	}
+	for _, r := range card.GetTachograph().GetSpecificConditions().GetRecords() {
+		add(r)
+	}
+	for _, r := range card.GetTachographG2().GetSpecificConditions().GetRecords() {
+		add(r)
+	}
+	return sortedValues(records, func(a, b *ddv1.SpecificConditionRecord) int {
+		return cmp.Or(
+			a.GetEntryTime().AsTime().Compare(b.GetEntryTime().AsTime()),
+			cmp.Compare(a.GetSpecificConditionType(), b.GetSpecificConditionType()),
+		)
+	})
+}
+
+// CardEvents returns the events and faults recorded on a driver card,
+// ordered by begin time.
+func CardEvents(card *cardv1.DriverCardFile) []CardEvent {
+	events := map[string]CardEvent{}
+	add := func(e CardEvent) {
+		if e.BeginTime.IsZero() {
+			return
+		}
+		events[fmt.Sprintf("%t/%d/%d", e.Fault, e.Type, e.BeginTime.Unix())] = e
+	}
+	for _, tachograph := range []interface {
+		GetEventsData() *cardv1.EventsData
+		GetFaultsData() *cardv1.FaultsData
+	}{card.GetTachograph(), card.GetTachographG2()} {
+		for _, r := range tachograph.GetEventsData().GetEvents() {
+			if !r.GetValid() {
+				continue
+			}
+			add(CardEvent{
+				Type:         r.GetEventType(),
+				BeginTime:    timeOf(r.GetEventBeginTime()),
+				EndTime:      timeOf(r.GetEventEndTime()),
+				Registration: newVehicleRegistration(r.GetEventVehicleRegistration()),
+			})
+		}
+		for _, r := range tachograph.GetFaultsData().GetFaults() {
+			if !r.GetValid() {
+				continue
+			}
+			add(CardEvent{
+				Type:         r.GetFaultType(),
+				Fault:        true,
+				BeginTime:    timeOf(r.GetFaultBeginTime()),
+				EndTime:      timeOf(r.GetFaultEndTime()),
+				Registration: newVehicleRegistration(r.GetFaultVehicleRegistration()),
+			})
+		}
+	}
+	return sortedValues(events, func(a, b CardEvent) int {
+		return cmp.Or(a.BeginTime.Compare(b.BeginTime), cmp.Compare(a.Type, b.Type))
+	})
+}
+
+// ControlActivities returns the controls recorded on a driver card, ordered by
+// time. Each application of a card only holds the last control of the card.
+func ControlActivities(card *cardv1.DriverCardFile) []ControlActivity {
+	controls := map[int64]ControlActivity{}
+	for _, r := range []*cardv1.ControlActivityData{
+		card.GetTachograph().GetControlActivityData(),
+		card.GetTachographG2().GetControlActivityData(),
+	} {
+		if !r.GetValid() || r.GetControlTime() == nil {
+			continue
+		}
+		controls[r.GetControlTime().GetSeconds()] = ControlActivity{
+			Time:              timeOf(r.GetControlTime()),
+			Type:              r.GetControlType(),
+			ControlCardNumber: cardNumberString(r.GetControlCardNumber().GetFullCardNumber()),
+			Registration:      newVehicleRegistration(r.GetControlVehicleRegistration()),
+			DownloadPeriod: Period{
+				Start: timeOf(r.GetControlDownloadPeriodBegin()),
+				End:   timeOf(r.GetControlDownloadPeriodEnd()),
+			},
+		}
+	}
+	return sortedValues(controls, func(a, b ControlActivity) int {
+		return a.Time.Compare(b.Time)
+	})
+}
+
+// BorderCrossings returns the border crossings recorded on a driver card,
+// ordered by time.
+func BorderCrossings(card *cardv1.DriverCardFile) []BorderCrossing {
+	var result []BorderCrossing
+	for _, r := range card.GetTachographG2().GetBorderCrossings().GetRecords() {
+		gnssPlace := r.GetGnssPlaceAuthRecord()
+		if gnssPlace.GetTimestamp() == nil {
+			continue
+		}
+		result = append(result, BorderCrossing{
+			Time:                 timeOf(gnssPlace.GetTimestamp()),
+			CountryLeft:          r.GetCountryLeft(),
+			CountryEntered:       r.GetCountryEntered(),
+			OdometerKm:           r.GetVehicleOdometerKm(),
+			Position:             gnssPlace.GetGeoCoordinates(),
+			AuthenticationStatus: gnssPlace.GetAuthenticationStatus(),
+		})
+	}
+	slices.SortStableFunc(result, func(a, b BorderCrossing) int {
+		return a.Time.Compare(b.Time)
+	})
+	return result
+}
+
+// LoadUnloadOperations returns the load and unload operations recorded on a
+// driver card, ordered by time.
+func LoadUnloadOperations(card *cardv1.DriverCardFile) []LoadUnloadOperation {
+	var result []LoadUnloadOperation
+	for _, r := range card.GetTachographG2().GetLoadUnloadOperations().GetRecords() {
+		if r.GetTimestamp() == nil {
+			continue
+		}
+		gnssPlace := r.GetGnssPlaceAuthRecord()
+		result = append(result, LoadUnloadOperation{
+			Time:                 timeOf(r.GetTimestamp()),
+			OperationType:        r.GetOperationType(),
+			OdometerKm:           r.GetVehicleOdometerKm(),
+			Position:             gnssPlace.GetGeoCoordinates(),
+			AuthenticationStatus: gnssPlace.GetAuthenticationStatus(),
+		})
+	}
+	slices.SortStableFunc(result, func(a, b LoadUnloadOperation) int {
+		return a.Time.Compare(b.Time)
+	})
+	return result
+}
+
+// AccumulatedDrivingPositions returns the accumulated driving positions
+// recorded on a driver card, ordered by time.
+func AccumulatedDrivingPositions(card *cardv1.DriverCardFile) []AccumulatedDrivingPosition {
+	authentication := map[int64]ddv1.PositionAuthenticationStatus{}
+	for _, r := range card.GetTachographG2().GetGnssPlacesAuthentication().GetRecords() {
+		authentication[r.GetTimestamp().GetSeconds()] = r.GetAuthenticationStatus()
+	}
+	var result []AccumulatedDrivingPosition
+	for _, r := range card.GetTachographG2().GetGnssPlaces().GetRecords() {
+		if r.GetTimestamp() == nil {
+			continue
+		}
+		result = append(result, AccumulatedDrivingPosition{
+			Time:                 timeOf(r.GetTimestamp()),
+			OdometerKm:           r.GetVehicleOdometerKm(),
+			Position:             r.GetGnssPlaceRecord().GetGeoCoordinates(),
+			AuthenticationStatus: authentication[r.GetTimestamp().GetSeconds()],
+		})
+	}
+	slices.SortStableFunc(result, func(a, b AccumulatedDrivingPosition) int {
+		return a.Time.Compare(b.Time)
+	})
+	return result
+}
@@ -0,0 +1,146 @@
+package tachograph
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/way-platform/tachograph-go/internal/card"
+	"github.com/way-platform/tachograph-go/internal/dd"
+	"github.com/way-platform/tachograph-go/internal/vu"
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
+)
+
+// Sentinel errors that classify parse failures.
+//
+// A [*ParseError] wraps one of these errors when the cause of the failure is
+// known, so they can be tested for with [errors.Is].
+var (
+	// ErrTruncated indicates that the data ended before a complete structure
+	// could be read. It wraps [io.ErrUnexpectedEOF].
+	ErrTruncated = dd.ErrTruncated
+
+	// ErrUnknownTag indicates an unknown file type, elementary file tag or
+	// transfer tag.
+	ErrUnknownTag = dd.ErrUnknownTag
+
+	// ErrInvalidValue indicates a value that does not conform to the data
+	// dictionary, such as a wrong length, a malformed BCD or an unknown enum.
+	ErrInvalidValue = dd.ErrInvalidValue
+
+	// ErrUnsupportedVersion indicates a card structure version or download
+	// interface version that is newer than the versions known to the parser.
+	ErrUnsupportedVersion = dd.ErrUnsupportedVersion
+)
+
+// ParseError is an error parsing a tachograph file.
+//
+// It locates the failure in the file, and wraps the underlying error.
+// Use [errors.As] to retrieve it from an error returned by [UnmarshalFile].
+type ParseError struct {
+	// File is the type of the file that failed to parse, or
+	// [tachographv1.File_RAW_CARD] if a card file could not be split into
+	// elementary files.
+	File tachographv1.File_Type
+	// ElementaryFile is the elementary file (EF) of a card file that failed to
+	// parse, if known.
+	ElementaryFile cardv1.ElementaryFileType
+	// Transfer is the transfer (TREP) of a vehicle unit file that failed to
+	// parse, if known.
+	Transfer vuv1.TransferType
+	// Record is the index of the EF or transfer in the file.
+	Record int
+	// Field is the path of the field that failed to parse within the EF or
+	// transfer (e.g. "daily_records.activity_record_length"), if known.
+	//
+	// The field is not known for failures of the EF or transfer as a whole,
+	// such as a wrong length.
+	Field string
+	// Offset is the absolute byte offset in the file of the field that failed
+	// to parse, or of the EF or transfer if the field is not known.
+	Offset int
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	var b strings.Builder
+	b.WriteString("parse ")
+	switch {
+	case e.ElementaryFile != cardv1.ElementaryFileType_ELEMENTARY_FILE_UNSPECIFIED:
+		fmt.Fprintf(&b, "%v", e.ElementaryFile)
+	case e.Transfer != vuv1.TransferType_TRANSFER_TYPE_UNSPECIFIED:
+		fmt.Fprintf(&b, "%v", e.Transfer)
+	case e.File != tachographv1.File_TYPE_UNSPECIFIED:
+		fmt.Fprintf(&b, "%v", e.File)
+	default:
+		b.WriteString("file")
+	}
+	if e.Field != "" {
+		fmt.Fprintf(&b, " field %s", e.Field)
+	}
+	fmt.Fprintf(&b, " at offset %d: %s", e.Offset, causeMessage(e.Err))
+	return b.String()
+}
+
+// causeMessage returns the message of err without the locations of the
+// record, transfer and field errors in its chain, which a [ParseError]
+// reports once for the whole chain.
+func causeMessage(err error) string {
+	switch err := err.(type) {
+	case nil:
+		return "<nil>"
+	case *card.RecordError:
+		return causeMessage(err.Err)
+	case *vu.TransferError:
+		return causeMessage(err.Err)
+	case *dd.FieldError:
+		return causeMessage(err.Err)
+	}
+	message := err.Error()
+	inner := errors.Unwrap(err)
+	if inner == nil {
+		return message
+	}
+	context, ok := strings.CutSuffix(message, inner.Error())
+	if !ok {
+		return message
+	}
+	return context + causeMessage(inner)
+}
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// newParseError converts an error from the internal parsers to a
+// [*ParseError], locating it in the file by its record and field errors.
+func newParseError(fileType tachographv1.File_Type, err error) *ParseError {
+	result := &ParseError{File: fileType, Err: err}
+	headerSize := 0
+	var recordErr *card.RecordError
+	var transferErr *vu.TransferError
+	switch {
+	case errors.As(err, &recordErr):
+		result.ElementaryFile = recordErr.Record.GetFile()
+		result.Record = recordErr.Index
+		result.Offset = recordErr.Offset
+		headerSize = 5 // tag (3 bytes) and length (2 bytes)
+	case errors.As(err, &transferErr):
+		result.Transfer = transferErr.Record.GetType()
+		result.Record = transferErr.Index
+		result.Offset = transferErr.Offset
+		if transferErr.Record != nil {
+			headerSize = 2 // tag (2 bytes)
+		}
+	}
+	if field, offset, ok := dd.FieldPath(err); ok {
+		result.Field = field
+		result.Offset += headerSize + offset
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+package tachograph
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
+)
+
+func TestUnmarshalFile_parseError(t *testing.T) {
+	data := testDriverCardData(t)
+	const records = 7 // number of EFs in the test driver card data
+
+	t.Run("truncated EF", func(t *testing.T) {
+		input := append(data[:len(data):len(data)], 0x05, 0x06) // truncated TLV header
+		_, err := UnmarshalFile(input)
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("UnmarshalFile() error = %v, want *ParseError", err)
+		}
+		want := ParseError{File: tachographv1.File_RAW_CARD, Record: records, Offset: len(data)}
+		if parseErr.File != want.File || parseErr.Record != want.Record || parseErr.Offset != want.Offset {
+			t.Errorf("ParseError = %+v, want %+v", parseErr, want)
+		}
+		if !errors.Is(err, ErrTruncated) {
+			t.Errorf("errors.Is(%v, ErrTruncated) = false", err)
+		}
+	})
+
+	t.Run("invalid field", func(t *testing.T) {
+		activityOffset := len(data)
+		input := append(data[:len(data):len(data)], 0x05, 0x04, 0x00, 0x00, 0x0C) // EF_DRIVER_ACTIVITY_DATA
+		input = append(input, 0x00, 0x00, 0x00, 0x00)                             // oldest and newest day record pointers
+		input = append(input, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0x00)     // daily record with an invalid length
+		_, err := UnmarshalFile(input)
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("UnmarshalFile() error = %v, want *ParseError", err)
+		}
+		want := ParseError{
+			File:           tachographv1.File_DRIVER_CARD,
+			ElementaryFile: cardv1.ElementaryFileType_EF_DRIVER_ACTIVITY_DATA,
+			Record:         records,
+			Field:          "daily_records.activity_record_length",
+			Offset:         activityOffset + 5 + 4 + 2,
+		}
+		if parseErr.File != want.File || parseErr.ElementaryFile != want.ElementaryFile ||
+			parseErr.Record != want.Record || parseErr.Field != want.Field || parseErr.Offset != want.Offset {
+			t.Errorf("ParseError = %+v, want %+v", parseErr, want)
+		}
+		if !errors.Is(err, ErrInvalidValue) {
+			t.Errorf("errors.Is(%v, ErrInvalidValue) = false", err)
+		}
+		if !strings.Contains(err.Error(), "failed to parse cyclic activity daily records") {
+			t.Errorf("error %q lacks the context of the daily records", err)
+		}
+	})
+
+	t.Run("invalid EF field", func(t *testing.T) {
+		eventsOffset := len(data)
+		input := append(data[:len(data):len(data)], 0x05, 0x02, 0x00, 0x00, 0x30) // EF_EVENTS_DATA
+		input = append(input, make([]byte, 24)...)                                // empty event record
+		input = append(input, 0xFF, 0x00, 0x00, 0x00, 0x01)                       // event record with an invalid event type
+		input = append(input, make([]byte, 19)...)
+		_, err := UnmarshalFile(input)
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("UnmarshalFile() error = %v, want *ParseError", err)
+		}
+		want := ParseError{
+			File:           tachographv1.File_DRIVER_CARD,
+			ElementaryFile: cardv1.ElementaryFileType_EF_EVENTS_DATA,
+			Record:         records,
+			Field:          "events[1].event_type",
+			Offset:         eventsOffset + 5 + 24,
+		}
+		if parseErr.File != want.File || parseErr.ElementaryFile != want.ElementaryFile ||
+			parseErr.Record != want.Record || parseErr.Field != want.Field || parseErr.Offset != want.Offset {
+			t.Errorf("ParseError = %+v, want %+v", parseErr, want)
+		}
+		if !errors.Is(err, ErrInvalidValue) {
+			t.Errorf("errors.Is(%v, ErrInvalidValue) = false", err)
+		}
+	})
+
+	t.Run("invalid transfer field", func(t *testing.T) {
+		input := []byte{0x76, 0x02}                               // ACTIVITIES_GEN1
+		input = append(input, make([]byte, 11)...)                // date, odometer, no card insertions or activity changes
+		input = append(input, 0x01)                               // one place record
+		input = append(input, make([]byte, 22)...)                // card number and entry time
+		input = append(input, 0xFF, 0x00, 0x00, 0x00, 0x00, 0x00) // invalid entry type
+		input = append(input, 0x00, 0x00)                         // no specific conditions
+		input = append(input, make([]byte, 128)...)               // signature
+		_, err := UnmarshalFile(input)
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("UnmarshalFile() error = %v, want *ParseError", err)
+		}
+		want := ParseError{
+			File:     tachographv1.File_VEHICLE_UNIT,
+			Transfer: vuv1.TransferType_ACTIVITIES_GEN1,
+			Field:    "places[0].entry_type",
+			Offset:   2 + 12 + 22,
+		}
+		if parseErr.File != want.File || parseErr.Transfer != want.Transfer ||
+			parseErr.Record != want.Record || parseErr.Field != want.Field || parseErr.Offset != want.Offset {
+			t.Errorf("ParseError = %+v, want %+v", parseErr, want)
+		}
+		if !errors.Is(err, ErrInvalidValue) {
+			t.Errorf("errors.Is(%v, ErrInvalidValue) = false", err)
+		}
+		if got := strings.Count(err.Error(), "at offset"); got != 1 {
+			t.Errorf("error %q has %d offsets, want 1", err, got)
+		}
+	})
+
+	t.Run("unknown file type", func(t *testing.T) {
+		_, err := UnmarshalFile([]byte{0x12, 0x34})
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("UnmarshalFile() error = %v, want *ParseError", err)
+		}
+		if !errors.Is(err, ErrUnknownTag) {
+			t.Errorf("errors.Is(%v, ErrUnknownTag) = false", err)
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// WriteCSV writes a table as CSV, with a header row of column names.
+//
+// Times are written in RFC 3339 format, and missing values as empty fields.
+func WriteCSV(w io.Writer, table *Table) error {
+	cw := csv.NewWriter(w)
+	record := make([]string, len(table.Columns))
+	for i, column := range table.Columns {
+		record[i] = column.Name
+	}
+	if err := cw.Write(record); err != nil {
+		return fmt.Errorf("error writing header of table %s: %w", table.Name, err)
+	}
+	for _, row := range table.Rows {
+		for i, value := range row {
+			record[i] = formatValue(value)
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("error writing row of table %s: %w", table.Name, err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// formatValue formats a table value as text.
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,9 @@
+// Package export flattens tachograph files into tables, and writes the tables
+// as CSV files, XLSX spreadsheets or Apache Parquet datasets. It also extracts
+// the GNSS positions of tachograph files as map features, written as GeoJSON
+// or KML.
+//
+// The tables have the same columns for driver cards and vehicle units of all
+// generations, so that downstream consumers do not depend on the nesting of
+// the protobuf data model.
+package export
@@ -0,0 +1,185 @@
+package export
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/way-platform/tachograph-go"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+)
+
+// GeometryType is the type of the geometry of a [Feature].
+type GeometryType int
+
+const (
+	// GeometryPoint is a single position.
+	GeometryPoint GeometryType = iota + 1
+	// GeometryLineString is a line through two or more positions.
+	GeometryLineString
+)
+
+// Feature is a geographic feature of tachograph data.
+type Feature struct {
+	// Geometry is the type of the geometry of the feature.
+	Geometry GeometryType
+	// Coordinates are the WGS84 longitude and latitude pairs of the geometry,
+	// in decimal degrees: one pair for a point, and two or more for a line
+	// string.
+	Coordinates [][2]float64
+	// Properties are the properties of the feature, by name. Values are
+	// strings, int64, bool or [time.Time] values in UTC.
+	Properties map[string]any
+}
+
+// Features returns the geographic features of tachograph files.
+//
+// The kind property of each feature is one of:
+//
+//   - place: a point where a daily work period began or ended
+//   - border_crossing: a point where a border was crossed
+//   - load_unload: a point where a load or unload operation took place
+//   - accumulated_driving: a line through the accumulated driving positions
+//
+// Each accumulated driving line holds consecutive positions with the same
+// authentication status, followed by the first position of the next line, so
+// that the track is continuous. All features have an authentication_status
+// property when the status was recorded. Records without a known position are
+// skipped. Driver card and vehicle unit files are supported.
+func Features(files ...*tachographv1.File) ([]Feature, error) {
+	var result []Feature
+	for i, file := range files {
+		var source []any
+		var places []tachograph.Place
+		var crossings []tachograph.BorderCrossing
+		var operations []tachograph.LoadUnloadOperation
+		var positions []tachograph.AccumulatedDrivingPosition
+		switch file.GetType() {
+		case tachographv1.File_DRIVER_CARD:
+			card := file.GetDriverCard()
+			source = []any{"card_number", tachograph.DriverCardNumber(card)}
+			places = tachograph.Places(card)
+			crossings = tachograph.BorderCrossings(card)
+			operations = tachograph.LoadUnloadOperations(card)
+			positions = tachograph.AccumulatedDrivingPositions(card)
+		case tachographv1.File_VEHICLE_UNIT:
+			h, err := tachograph.MergeVehicleUnitFiles(file.GetVehicleUnit())
+			if err != nil {
+				return nil, fmt.Errorf("file %d: %w", i, err)
+			}
+			var registration tachograph.VehicleRegistration
+			for _, d := range h.Downloads {
+				registration = d.Registration
+			}
+			source = []any{
+				"vin", h.VIN,
+				"registration_nation", enumName(registration.Nation),
+				"registration_number", registration.Number,
+			}
+			places, crossings = h.Places, h.BorderCrossings
+			operations, positions = h.LoadUnloadOperations, h.AccumulatedDrivingPositions
+		default:
+			return nil, fmt.Errorf("file %d: unsupported file type: %v", i, file.GetType())
+		}
+		for _, p := range places {
+			result = appendPoint(result, p.Position, source,
+				"kind", "place",
+				"time", p.Time,
+				"entry_type", enumName(p.EntryType),
+				"country", enumName(p.Country),
+				"odometer_km", p.OdometerKm,
+				"authentication_status", enumName(p.AuthenticationStatus))
+		}
+		for _, c := range crossings {
+			result = appendPoint(result, c.Position, source,
+				"kind", "border_crossing",
+				"time", c.Time,
+				"country_left", enumName(c.CountryLeft),
+				"country_entered", enumName(c.CountryEntered),
+				"odometer_km", c.OdometerKm,
+				"authentication_status", enumName(c.AuthenticationStatus))
+		}
+		for _, o := range operations {
+			result = appendPoint(result, o.Position, source,
+				"kind", "load_unload",
+				"time", o.Time,
+				"operation_type", enumName(o.OperationType),
+				"odometer_km", o.OdometerKm,
+				"authentication_status", enumName(o.AuthenticationStatus))
+		}
+		result = appendAccumulatedDriving(result, positions, source)
+	}
+	return result, nil
+}
+
+// appendPoint appends a point feature, unless the position is unknown.
+func appendPoint(features []Feature, position *ddv1.GeoCoordinates, source []any, properties ...any) []Feature {
+	lat, lon, ok := tachograph.DecimalDegrees(position)
+	if !ok {
+		return features
+	}
+	return append(features, Feature{
+		Geometry:    GeometryPoint,
+		Coordinates: [][2]float64{{lon, lat}},
+		Properties:  newProperties(append(properties, source...)...),
+	})
+}
+
+// appendAccumulatedDriving appends the line string features through
+// accumulated driving positions, split where the authentication status
+// changes.
+func appendAccumulatedDriving(features []Feature, positions []tachograph.AccumulatedDrivingPosition, source []any) []Feature {
+	type point struct {
+		coordinates [2]float64
+		position    tachograph.AccumulatedDrivingPosition
+	}
+	var points []point
+	for _, p := range positions {
+		if lat, lon, ok := tachograph.DecimalDegrees(p.Position); ok {
+			points = append(points, point{coordinates: [2]float64{lon, lat}, position: p})
+		}
+	}
+	for i := 0; i < len(points)-1; {
+		j := i + 1
+		for j < len(points)-1 && points[j].position.AuthenticationStatus == points[i].position.AuthenticationStatus {
+			j++
+		}
+		line := Feature{Geometry: GeometryLineString}
+		for _, p := range points[i : j+1] {
+			line.Coordinates = append(line.Coordinates, p.coordinates)
+		}
+		line.Properties = newProperties(append([]any{
+			"kind", "accumulated_driving",
+			"begin_time", points[i].position.Time,
+			"end_time", points[j].position.Time,
+			"authentication_status", enumName(points[i].position.AuthenticationStatus),
+		}, source...)...)
+		features = append(features, line)
+		i = j
+	}
+	return features
+}
+
+// newProperties returns the properties of alternating names and values,
+// skipping zero times and empty strings, and mapping int32 to int64.
+func newProperties(namesAndValues ...any) map[string]any {
+	properties := make(map[string]any, len(namesAndValues)/2)
+	for i := 0; i+1 < len(namesAndValues); i += 2 {
+		name := namesAndValues[i].(string)
+		switch v := namesAndValues[i+1].(type) {
+		case time.Time:
+			if !v.IsZero() {
+				properties[name] = v.UTC()
+			}
+		case string:
+			if v != "" {
+				properties[name] = v
+			}
+		case int32:
+			properties[name] = int64(v)
+		default:
+			properties[name] = v
+		}
+	}
+	return properties
+}
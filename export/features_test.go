@@ -0,0 +1,166 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"testing"
+	"time"
+
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// testGnssDriverCardFile builds a driver card with a load operation, and
+// accumulated driving positions of which one is unknown and the last two are
+// authenticated.
+func testGnssDriverCardFile() *tachographv1.File {
+	coordinates := func(latitude, longitude int32) *ddv1.GeoCoordinates {
+		c := &ddv1.GeoCoordinates{}
+		c.SetLatitude(latitude)
+		c.SetLongitude(longitude)
+		return c
+	}
+	at := func(hours int) *timestamppb.Timestamp {
+		return timestamppb.New(testDay.Add(time.Duration(hours) * time.Hour))
+	}
+
+	authRecord := &cardv1.GnssPlaceAuthRecord{}
+	authRecord.SetTimestamp(at(7))
+	authRecord.SetGeoCoordinates(coordinates(52300, 13240))
+	authRecord.SetAuthenticationStatus(ddv1.PositionAuthenticationStatus_AUTHENTICATED)
+	operation := &cardv1.LoadUnloadOperations_Record{}
+	operation.SetTimestamp(at(7))
+	operation.SetOperationType(ddv1.OperationType_LOAD_OPERATION)
+	operation.SetGnssPlaceAuthRecord(authRecord)
+	operation.SetVehicleOdometerKm(1100)
+	operations := &cardv1.LoadUnloadOperations{}
+	operations.SetRecords([]*cardv1.LoadUnloadOperations_Record{operation})
+
+	var records []*cardv1.GnssPlaces_Record
+	for i, c := range []*ddv1.GeoCoordinates{coordinates(52300, 13240), coordinates(51200, 12230), coordinates(0x7FFFFF, 0x7FFFFF), coordinates(50070, 8410), coordinates(48080, 11340)} {
+		place := &ddv1.GNSSPlaceRecord{}
+		place.SetGeoCoordinates(c)
+		r := &cardv1.GnssPlaces_Record{}
+		r.SetTimestamp(at(3 * (i + 1)))
+		r.SetGnssPlaceRecord(place)
+		records = append(records, r)
+	}
+	gnssPlaces := &cardv1.GnssPlaces{}
+	gnssPlaces.SetRecords(records)
+	var authentications []*cardv1.GnssPlacesAuthentication_Record
+	for _, hours := range []int{12, 15} {
+		r := &cardv1.GnssPlacesAuthentication_Record{}
+		r.SetTimestamp(at(hours))
+		r.SetAuthenticationStatus(ddv1.PositionAuthenticationStatus_AUTHENTICATED)
+		authentications = append(authentications, r)
+	}
+	gnssPlacesAuthentication := &cardv1.GnssPlacesAuthentication{}
+	gnssPlacesAuthentication.SetRecords(authentications)
+
+	tachographG2 := &cardv1.DriverCardFile_TachographG2{}
+	tachographG2.SetLoadUnloadOperations(operations)
+	tachographG2.SetGnssPlaces(gnssPlaces)
+	tachographG2.SetGnssPlacesAuthentication(gnssPlacesAuthentication)
+	file := testDriverCardFile()
+	file.GetDriverCard().SetTachographG2(tachographG2)
+	return file
+}
+
+func TestFeatures(t *testing.T) {
+	features, err := Features(testGnssDriverCardFile())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(features) != 3 {
+		t.Fatalf("got %d features, want 3: %+v", len(features), features)
+	}
+	load := features[0]
+	if load.Geometry != GeometryPoint || load.Properties["kind"] != "load_unload" ||
+		load.Properties["operation_type"] != "LOAD_OPERATION" || load.Properties["authentication_status"] != "AUTHENTICATED" {
+		t.Errorf("feature 0 = %+v, want an authenticated load operation", load)
+	}
+	if got, want := load.Coordinates[0], [2]float64{13.4, 52.5}; got != want {
+		t.Errorf("load coordinates = %v, want %v", got, want)
+	}
+	// The unknown position is skipped, and the line is split where the
+	// authentication status changes.
+	for i, want := range []struct {
+		points int
+		status any
+	}{{points: 3}, {points: 2, status: "AUTHENTICATED"}} {
+		line := features[1+i]
+		if line.Geometry != GeometryLineString || line.Properties["kind"] != "accumulated_driving" ||
+			len(line.Coordinates) != want.points || line.Properties["authentication_status"] != want.status {
+			t.Errorf("feature %d = %+v, want a line of %d points with status %v", 1+i, line, want.points, want.status)
+		}
+	}
+}
+
+func TestWriteGeoJSON(t *testing.T) {
+	features, err := Features(testGnssDriverCardFile())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var data bytes.Buffer
+	if err := WriteGeoJSON(&data, features); err != nil {
+		t.Fatal(err)
+	}
+	var collection struct {
+		Type     string
+		Features []struct {
+			Geometry struct {
+				Type        string
+				Coordinates json.RawMessage
+			}
+			Properties map[string]any
+		}
+	}
+	if err := json.Unmarshal(data.Bytes(), &collection); err != nil {
+		t.Fatal(err)
+	}
+	if collection.Type != "FeatureCollection" || len(collection.Features) != len(features) {
+		t.Fatalf("got %s of %d features, want FeatureCollection of %d", collection.Type, len(collection.Features), len(features))
+	}
+	load := collection.Features[0]
+	var coordinates []float64
+	if err := json.Unmarshal(load.Geometry.Coordinates, &coordinates); err != nil {
+		t.Fatal(err)
+	}
+	if load.Geometry.Type != "Point" || len(coordinates) != 2 || coordinates[0] != 13.4 || coordinates[1] != 52.5 {
+		t.Errorf("load geometry = %s %v, want Point [13.4 52.5]", load.Geometry.Type, coordinates)
+	}
+	if got, want := load.Properties["time"], "2024-01-01T07:00:00Z"; got != want {
+		t.Errorf("load time = %v, want %v", got, want)
+	}
+}
+
+func TestWriteKML(t *testing.T) {
+	features, err := Features(testGnssDriverCardFile())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var data bytes.Buffer
+	if err := WriteKML(&data, features); err != nil {
+		t.Fatal(err)
+	}
+	d := xml.NewDecoder(&data)
+	var placemarks int
+	for {
+		token, err := d.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		if start, ok := token.(xml.StartElement); ok && start.Name.Local == "Placemark" {
+			placemarks++
+		}
+	}
+	if placemarks != len(features) {
+		t.Errorf("got %d placemarks, want %d", placemarks, len(features))
+	}
+}
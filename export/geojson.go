@@ -0,0 +1,49 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// WriteGeoJSON writes features as a GeoJSON feature collection.
+//
+// Times are written as RFC 3339 strings in UTC.
+func WriteGeoJSON(w io.Writer, features []Feature) error {
+	type geometry struct {
+		Type        string `json:"type"`
+		Coordinates any    `json:"coordinates"`
+	}
+	type feature struct {
+		Type       string         `json:"type"`
+		Geometry   geometry       `json:"geometry"`
+		Properties map[string]any `json:"properties"`
+	}
+	collection := struct {
+		Type     string    `json:"type"`
+		Features []feature `json:"features"`
+	}{Type: "FeatureCollection", Features: make([]feature, 0, len(features))}
+	for i, f := range features {
+		var g geometry
+		switch {
+		case f.Geometry == GeometryPoint && len(f.Coordinates) == 1:
+			g = geometry{Type: "Point", Coordinates: f.Coordinates[0]}
+		case f.Geometry == GeometryLineString && len(f.Coordinates) >= 2:
+			g = geometry{Type: "LineString", Coordinates: f.Coordinates}
+		default:
+			return fmt.Errorf("feature %d: invalid geometry %d with %d coordinates", i, f.Geometry, len(f.Coordinates))
+		}
+		properties := make(map[string]any, len(f.Properties))
+		for name, value := range f.Properties {
+			if t, ok := value.(time.Time); ok {
+				value = t.Format(time.RFC3339)
+			}
+			properties[name] = value
+		}
+		collection.Features = append(collection.Features, feature{Type: "Feature", Geometry: g, Properties: properties})
+	}
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(collection)
+}
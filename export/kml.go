@@ -0,0 +1,75 @@
+package export
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// WriteKML writes features as a KML document, with a placemark per feature.
+//
+// Each placemark is named after the kind property of the feature, holds the
+// properties as extended data, and is stamped with the time or the begin and
+// end times of the feature.
+func WriteKML(w io.Writer, features []Feature) error {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<kml xmlns="http://www.opengis.net/kml/2.2"><Document>`)
+	for i, f := range features {
+		var geometry string
+		switch {
+		case f.Geometry == GeometryPoint && len(f.Coordinates) == 1:
+			geometry = "Point"
+		case f.Geometry == GeometryLineString && len(f.Coordinates) >= 2:
+			geometry = "LineString"
+		default:
+			return fmt.Errorf("feature %d: invalid geometry %d with %d coordinates", i, f.Geometry, len(f.Coordinates))
+		}
+		b.WriteString(`<Placemark>`)
+		if kind, ok := f.Properties["kind"].(string); ok {
+			fmt.Fprintf(&b, `<name>%s</name>`, xmlEscape(kind))
+		}
+		begin, _ := f.Properties["begin_time"].(time.Time)
+		end, _ := f.Properties["end_time"].(time.Time)
+		if t, ok := f.Properties["time"].(time.Time); ok {
+			fmt.Fprintf(&b, `<TimeStamp><when>%s</when></TimeStamp>`, t.Format(time.RFC3339))
+		} else if !begin.IsZero() || !end.IsZero() {
+			b.WriteString(`<TimeSpan>`)
+			if !begin.IsZero() {
+				fmt.Fprintf(&b, `<begin>%s</begin>`, begin.Format(time.RFC3339))
+			}
+			if !end.IsZero() {
+				fmt.Fprintf(&b, `<end>%s</end>`, end.Format(time.RFC3339))
+			}
+			b.WriteString(`</TimeSpan>`)
+		}
+		names := make([]string, 0, len(f.Properties))
+		for name := range f.Properties {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		b.WriteString(`<ExtendedData>`)
+		for _, name := range names {
+			fmt.Fprintf(&b, `<Data name="%s"><value>%s</value></Data>`, xmlEscape(name), xmlEscape(formatValue(f.Properties[name])))
+		}
+		b.WriteString(`</ExtendedData>`)
+		fmt.Fprintf(&b, `<%s><coordinates>`, geometry)
+		for j, c := range f.Coordinates {
+			if j > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteString(strconv.FormatFloat(c[0], 'f', -1, 64))
+			b.WriteByte(',')
+			b.WriteString(strconv.FormatFloat(c[1], 'f', -1, 64))
+		}
+		fmt.Fprintf(&b, `</coordinates></%s>`, geometry)
+		b.WriteString(`</Placemark>`)
+	}
+	b.WriteString(`</Document></kml>`)
+	_, err := w.Write(b.Bytes())
+	return err
+}
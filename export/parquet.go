@@ -0,0 +1,471 @@
+package export
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ParquetOptions configures writing tables as Apache Parquet files.
+type ParquetOptions struct {
+	// RowGroupSize is the maximum number of rows of a row group.
+	//
+	// Defaults to 100000.
+	RowGroupSize int
+}
+
+// WriteParquet writes a table as an Apache Parquet file.
+//
+// See [ParquetOptions] if you need more control over the output.
+func WriteParquet(w io.Writer, table *Table) error {
+	return ParquetOptions{}.WriteParquet(w, table)
+}
+
+// WriteParquet writes a table as an Apache Parquet file.
+//
+// All columns are optional. String columns are written as UTF-8 byte arrays,
+// or as enum byte arrays if the column holds the values of a protobuf enum
+// field. Int columns are written as 64-bit integers, float columns as doubles,
+// and time columns as 64-bit UTC timestamps in microseconds. Values are written
+// uncompressed, with plain encoding.
+//
+// The row groups are written to w as they are encoded, followed by the footer.
+func (o ParquetOptions) WriteParquet(w io.Writer, table *Table) error {
+	rowGroupSize := o.RowGroupSize
+	if rowGroupSize <= 0 {
+		rowGroupSize = 100000
+	}
+	cw := &countingWriter{w: w}
+	if _, err := io.WriteString(cw, parquetMagic); err != nil {
+		return err
+	}
+	var rowGroups []parquetRowGroup
+	// An empty table is written as a single empty row group.
+	for start := 0; start == 0 || start < len(table.Rows); start += rowGroupSize {
+		rows := table.Rows[start:min(start+rowGroupSize, len(table.Rows))]
+		rowGroup := parquetRowGroup{numRows: len(rows)}
+		for i, column := range table.Columns {
+			page, err := parquetDataPage(column, i, rows)
+			if err != nil {
+				return fmt.Errorf("error encoding column %s of table %s: %w", column.Name, table.Name, err)
+			}
+			chunk := parquetColumnChunk{offset: cw.n, size: len(page), numValues: len(rows)}
+			if _, err := cw.Write(page); err != nil {
+				return err
+			}
+			rowGroup.columns = append(rowGroup.columns, chunk)
+		}
+		rowGroups = append(rowGroups, rowGroup)
+	}
+	footer := parquetFileMetaData(table, rowGroups)
+	footer = binary.LittleEndian.AppendUint32(footer, uint32(len(footer)))
+	footer = append(footer, parquetMagic...)
+	_, err := cw.Write(footer)
+	return err
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += n
+	return n, err
+}
+
+// WriteParquetDataset writes tables as a Hive-partitioned Parquet dataset.
+//
+// See [ParquetOptions.WriteParquetDataset].
+func WriteParquetDataset(dir, name string, tables []*Table) error {
+	return ParquetOptions{}.WriteParquetDataset(dir, name, tables)
+}
+
+// WriteParquetDataset writes tables as a Hive-partitioned Parquet dataset in
+// dir, partitioned by month.
+//
+// The rows of each table are partitioned by the month of their first time
+// column, and written to dir/<table>/month=<YYYY-MM>/<name>.parquet. Rows
+// without a time are written to the month=__HIVE_DEFAULT_PARTITION__
+// partition. Empty tables are not written.
+//
+// Use a distinct name for each batch of files written to the same dataset.
+func (o ParquetOptions) WriteParquetDataset(dir, name string, tables []*Table) error {
+	for _, table := range tables {
+		timeColumn := -1
+		for i, column := range table.Columns {
+			if column.Type == ColumnTime {
+				timeColumn = i
+				break
+			}
+		}
+		partitions := map[string]*Table{}
+		var order []string
+		for _, row := range table.Rows {
+			partition := "__HIVE_DEFAULT_PARTITION__"
+			if timeColumn >= 0 {
+				if t, ok := row[timeColumn].(time.Time); ok {
+					partition = t.Format("2006-01")
+				}
+			}
+			p, ok := partitions[partition]
+			if !ok {
+				p = &Table{Name: table.Name, Columns: table.Columns}
+				partitions[partition] = p
+				order = append(order, partition)
+			}
+			p.Rows = append(p.Rows, row)
+		}
+		for _, partition := range order {
+			partitionDir := filepath.Join(dir, table.Name, "month="+partition)
+			if err := os.MkdirAll(partitionDir, 0o755); err != nil {
+				return fmt.Errorf("error creating directory %s: %w", partitionDir, err)
+			}
+			filename := filepath.Join(partitionDir, name+".parquet")
+			if err := o.writeParquetFile(filename, partitions[partition]); err != nil {
+				return fmt.Errorf("error writing file %s: %w", filename, err)
+			}
+		}
+	}
+	return nil
+}
+
+// writeParquetFile writes a table as an Apache Parquet file, streamed to the
+// file as the row groups are encoded.
+func (o ParquetOptions) writeParquetFile(filename string, table *Table) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(f)
+	if err := o.WriteParquet(bw, table); err != nil {
+		f.Close()
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+const parquetMagic = "PAR1"
+
+// Parquet format enum values, see
+// https://github.com/apache/parquet-format/blob/master/src/main/thrift/parquet.thrift.
+const (
+	parquetTypeBoolean   = 0
+	parquetTypeInt64     = 2
+	parquetTypeDouble    = 5
+	parquetTypeByteArray = 6
+
+	parquetRepetitionOptional = 1
+
+	parquetConvertedTypeUTF8            = 0
+	parquetConvertedTypeEnum            = 4
+	parquetConvertedTypeTimestampMicros = 10
+
+	parquetEncodingPlain = 0
+	parquetEncodingRLE   = 3
+
+	parquetCodecUncompressed = 0
+
+	parquetPageTypeDataPage = 0
+)
+
+type parquetRowGroup struct {
+	numRows int
+	columns []parquetColumnChunk
+}
+
+type parquetColumnChunk struct {
+	offset    int
+	size      int
+	numValues int
+}
+
+// parquetPhysicalType returns the physical type of a column.
+func parquetPhysicalType(column Column) int32 {
+	switch column.Type {
+	case ColumnBool:
+		return parquetTypeBoolean
+	case ColumnInt, ColumnTime:
+		return parquetTypeInt64
+	case ColumnFloat:
+		return parquetTypeDouble
+	default:
+		return parquetTypeByteArray
+	}
+}
+
+// parquetDataPage encodes a column of rows as a data page, with its header.
+func parquetDataPage(column Column, index int, rows [][]any) ([]byte, error) {
+	levels := make([]bool, len(rows))
+	var values []byte
+	var bits, numBits int
+	for i, row := range rows {
+		value := row[index]
+		if value == nil {
+			continue
+		}
+		levels[i] = true
+		switch column.Type {
+		case ColumnBool:
+			v, ok := value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("row %d: got %T, want bool", i, value)
+			}
+			if v {
+				bits |= 1 << (numBits % 8)
+			}
+			if numBits++; numBits%8 == 0 {
+				values = append(values, byte(bits))
+				bits = 0
+			}
+		case ColumnInt:
+			v, ok := value.(int64)
+			if !ok {
+				return nil, fmt.Errorf("row %d: got %T, want int64", i, value)
+			}
+			values = binary.LittleEndian.AppendUint64(values, uint64(v))
+		case ColumnTime:
+			v, ok := value.(time.Time)
+			if !ok {
+				return nil, fmt.Errorf("row %d: got %T, want time.Time", i, value)
+			}
+			values = binary.LittleEndian.AppendUint64(values, uint64(v.UnixMicro()))
+		case ColumnFloat:
+			v, ok := value.(float64)
+			if !ok {
+				return nil, fmt.Errorf("row %d: got %T, want float64", i, value)
+			}
+			values = binary.LittleEndian.AppendUint64(values, math.Float64bits(v))
+		default:
+			s := formatValue(value)
+			values = binary.LittleEndian.AppendUint32(values, uint32(len(s)))
+			values = append(values, s...)
+		}
+	}
+	if numBits%8 != 0 {
+		values = append(values, byte(bits))
+	}
+	encodedLevels := parquetDefinitionLevels(levels)
+	page := binary.LittleEndian.AppendUint32(nil, uint32(len(encodedLevels)))
+	page = append(page, encodedLevels...)
+	page = append(page, values...)
+
+	var header thriftWriter
+	header.i32Field(1, parquetPageTypeDataPage)
+	header.i32Field(2, int32(len(page)))
+	header.i32Field(3, int32(len(page)))
+	header.beginStructField(5)
+	header.i32Field(1, int32(len(rows)))
+	header.i32Field(2, parquetEncodingPlain)
+	header.i32Field(3, parquetEncodingRLE)
+	header.i32Field(4, parquetEncodingRLE)
+	header.endStruct()
+	header.endStruct()
+	return append(header.buf, page...), nil
+}
+
+// parquetDefinitionLevels encodes the definition levels of an optional column
+// as runs of the RLE/bit-packing hybrid encoding, with a bit width of 1.
+func parquetDefinitionLevels(levels []bool) []byte {
+	var result []byte
+	for i := 0; i < len(levels); {
+		j := i
+		for j < len(levels) && levels[j] == levels[i] {
+			j++
+		}
+		result = binary.AppendUvarint(result, uint64(j-i)<<1)
+		if levels[i] {
+			result = append(result, 1)
+		} else {
+			result = append(result, 0)
+		}
+		i = j
+	}
+	return result
+}
+
+// parquetFileMetaData encodes the file metadata of a table.
+func parquetFileMetaData(table *Table, rowGroups []parquetRowGroup) []byte {
+	var w thriftWriter
+	w.i32Field(1, 1)
+	w.listField(2, thriftStruct, len(table.Columns)+1)
+	w.beginStruct()
+	w.binaryField(4, []byte("schema"))
+	w.i32Field(5, int32(len(table.Columns)))
+	w.endStruct()
+	for _, column := range table.Columns {
+		w.beginStruct()
+		w.i32Field(1, parquetPhysicalType(column))
+		w.i32Field(3, parquetRepetitionOptional)
+		w.binaryField(4, []byte(column.Name))
+		switch {
+		case column.Type == ColumnString && column.Field != nil && column.Field.Kind() == protoreflect.EnumKind:
+			w.i32Field(6, parquetConvertedTypeEnum)
+			w.beginStructField(10)
+			w.beginStructField(4) // ENUM
+			w.endStruct()
+			w.endStruct()
+		case column.Type == ColumnString:
+			w.i32Field(6, parquetConvertedTypeUTF8)
+			w.beginStructField(10)
+			w.beginStructField(1) // STRING
+			w.endStruct()
+			w.endStruct()
+		case column.Type == ColumnTime:
+			w.i32Field(6, parquetConvertedTypeTimestampMicros)
+			w.beginStructField(10)
+			w.beginStructField(8) // TIMESTAMP
+			w.boolField(1, true)
+			w.beginStructField(2)
+			w.beginStructField(2) // MICROS
+			w.endStruct()
+			w.endStruct()
+			w.endStruct()
+			w.endStruct()
+		}
+		w.endStruct()
+	}
+	var numRows int
+	for _, rowGroup := range rowGroups {
+		numRows += rowGroup.numRows
+	}
+	w.i64Field(3, int64(numRows))
+	w.listField(4, thriftStruct, len(rowGroups))
+	for _, rowGroup := range rowGroups {
+		w.beginStruct()
+		w.listField(1, thriftStruct, len(rowGroup.columns))
+		var totalSize int
+		for i, chunk := range rowGroup.columns {
+			totalSize += chunk.size
+			w.beginStruct()
+			w.i64Field(2, int64(chunk.offset))
+			w.beginStructField(3)
+			w.i32Field(1, parquetPhysicalType(table.Columns[i]))
+			w.listField(2, thriftI32, 2)
+			w.i32(parquetEncodingPlain)
+			w.i32(parquetEncodingRLE)
+			w.listField(3, thriftBinary, 1)
+			w.binary([]byte(table.Columns[i].Name))
+			w.i32Field(4, parquetCodecUncompressed)
+			w.i64Field(5, int64(chunk.numValues))
+			w.i64Field(6, int64(chunk.size))
+			w.i64Field(7, int64(chunk.size))
+			w.i64Field(9, int64(chunk.offset))
+			w.endStruct()
+			w.endStruct()
+		}
+		w.i64Field(2, int64(totalSize))
+		w.i64Field(3, int64(rowGroup.numRows))
+		w.endStruct()
+	}
+	w.binaryField(6, []byte("tachograph-go"))
+	w.endStruct()
+	return w.buf
+}
+
+// Thrift compact protocol types.
+const (
+	thriftBooleanTrue  = 1
+	thriftBooleanFalse = 2
+	thriftI32          = 5
+	thriftI64          = 6
+	thriftBinary       = 8
+	thriftList         = 9
+	thriftStruct       = 12
+)
+
+// thriftWriter writes a struct with the Thrift compact protocol, as used by
+// the Parquet metadata.
+type thriftWriter struct {
+	buf []byte
+	// fieldIDs is the stack of the last field IDs of the open structs.
+	fieldIDs []int16
+	lastID   int16
+}
+
+func (w *thriftWriter) fieldHeader(id int16, typ byte) {
+	if delta := id - w.lastID; delta > 0 && delta <= 15 {
+		w.buf = append(w.buf, byte(delta)<<4|typ)
+	} else {
+		w.buf = append(w.buf, typ)
+		w.buf = binary.AppendVarint(w.buf, int64(id))
+	}
+	w.lastID = id
+}
+
+func (w *thriftWriter) i32(v int32) {
+	w.buf = binary.AppendVarint(w.buf, int64(v))
+}
+
+func (w *thriftWriter) binary(v []byte) {
+	w.buf = binary.AppendUvarint(w.buf, uint64(len(v)))
+	w.buf = append(w.buf, v...)
+}
+
+func (w *thriftWriter) boolField(id int16, v bool) {
+	if v {
+		w.fieldHeader(id, thriftBooleanTrue)
+	} else {
+		w.fieldHeader(id, thriftBooleanFalse)
+	}
+}
+
+func (w *thriftWriter) i32Field(id int16, v int32) {
+	w.fieldHeader(id, thriftI32)
+	w.i32(v)
+}
+
+func (w *thriftWriter) i64Field(id int16, v int64) {
+	w.fieldHeader(id, thriftI64)
+	w.buf = binary.AppendVarint(w.buf, v)
+}
+
+func (w *thriftWriter) binaryField(id int16, v []byte) {
+	w.fieldHeader(id, thriftBinary)
+	w.binary(v)
+}
+
+// listField writes the header of a list field. The elements follow.
+func (w *thriftWriter) listField(id int16, elementType byte, size int) {
+	w.fieldHeader(id, thriftList)
+	if size < 15 {
+		w.buf = append(w.buf, byte(size)<<4|elementType)
+	} else {
+		w.buf = append(w.buf, 0xF0|elementType)
+		w.buf = binary.AppendUvarint(w.buf, uint64(size))
+	}
+}
+
+// beginStructField begins a struct field, which is ended by endStruct.
+func (w *thriftWriter) beginStructField(id int16) {
+	w.fieldHeader(id, thriftStruct)
+	w.beginStruct()
+}
+
+// beginStruct begins a struct list element, which is ended by endStruct.
+func (w *thriftWriter) beginStruct() {
+	w.fieldIDs = append(w.fieldIDs, w.lastID)
+	w.lastID = 0
+}
+
+// endStruct ends the innermost open struct, or the top-level struct.
+func (w *thriftWriter) endStruct() {
+	w.buf = append(w.buf, 0)
+	if n := len(w.fieldIDs); n > 0 {
+		w.lastID = w.fieldIDs[n-1]
+		w.fieldIDs = w.fieldIDs[:n-1]
+	}
+}
@@ -0,0 +1,90 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteParquet(t *testing.T) {
+	tables, err := Tables(testDriverCardFile())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, table := range tables {
+		var data bytes.Buffer
+		if err := (ParquetOptions{RowGroupSize: 2}).WriteParquet(&data, table); err != nil {
+			t.Fatal(err)
+		}
+		b := data.Bytes()
+		if !bytes.HasPrefix(b, []byte(parquetMagic)) || !bytes.HasSuffix(b, []byte(parquetMagic)) {
+			t.Fatalf("%s: missing Parquet magic", table.Name)
+		}
+		footerSize := int(binary.LittleEndian.Uint32(b[len(b)-8:]))
+		if footerSize <= 0 || footerSize > len(b)-12 {
+			t.Errorf("%s: footer size %d out of range", table.Name, footerSize)
+		}
+		if footer := b[len(b)-8-footerSize : len(b)-8]; !bytes.Contains(footer, []byte(table.Columns[0].Name)) {
+			t.Errorf("%s: footer does not hold the schema", table.Name)
+		}
+	}
+}
+
+// TestWriteParquet_streaming verifies that the column chunks are written as
+// they are encoded, rather than buffered until the footer.
+func TestWriteParquet_streaming(t *testing.T) {
+	tables, err := Tables(testDriverCardFile())
+	if err != nil {
+		t.Fatal(err)
+	}
+	table := tables[0]
+	var w recordingWriter
+	if err := (ParquetOptions{RowGroupSize: 2}).WriteParquet(&w, table); err != nil {
+		t.Fatal(err)
+	}
+	numRowGroups := max(1, (len(table.Rows)+1)/2)
+	// The magic, a column chunk per column and row group, and the footer.
+	if got, want := len(w.writes), 2+numRowGroups*len(table.Columns); got != want {
+		t.Errorf("got %d writes, want %d", got, want)
+	}
+}
+
+// recordingWriter records the size of each write.
+type recordingWriter struct {
+	writes []int
+}
+
+func (w *recordingWriter) Write(p []byte) (int, error) {
+	w.writes = append(w.writes, len(p))
+	return len(p), nil
+}
+
+func TestWriteParquetDataset(t *testing.T) {
+	tables, err := Tables(testDriverCardFile())
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := WriteParquetDataset(dir, "batch-1", tables); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"activities", "vehicles"} {
+		filename := filepath.Join(dir, name, "month=2024-01", "batch-1.parquet")
+		if _, err := os.Stat(filename); err != nil {
+			t.Errorf("missing partition: %v", err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "calibrations")); !os.IsNotExist(err) {
+		t.Errorf("empty calibrations table was written")
+	}
+}
+
+func TestParquetDefinitionLevels(t *testing.T) {
+	got := parquetDefinitionLevels([]bool{true, true, true, false, true})
+	want := []byte{3 << 1, 1, 1 << 1, 0, 1 << 1, 1}
+	if !bytes.Equal(got, want) {
+		t.Errorf("parquetDefinitionLevels() = %v, want %v", got, want)
+	}
+}
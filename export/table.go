@@ -0,0 +1,115 @@
+package export
+
+import (
+	"fmt"
+	"time"
+
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ColumnType is the type of the values of a [Column].
+type ColumnType int
+
+const (
+	// ColumnString is a column of string values.
+	ColumnString ColumnType = iota + 1
+	// ColumnInt is a column of int64 values.
+	ColumnInt
+	// ColumnBool is a column of bool values.
+	ColumnBool
+	// ColumnTime is a column of [time.Time] values, in UTC.
+	ColumnTime
+	// ColumnFloat is a column of float64 values.
+	ColumnFloat
+)
+
+// Column is a column of a [Table].
+type Column struct {
+	// Name is the name of the column, in snake case.
+	Name string
+	// Type is the type of the values of the column.
+	Type ColumnType
+	// Field is the protobuf field that the values of the column are read
+	// from, or nil if the values are computed. The type of the column is
+	// derived from the field.
+	Field protoreflect.FieldDescriptor
+}
+
+// messageColumnTypes are the column types of the protobuf messages that are
+// written as single values.
+var messageColumnTypes = map[protoreflect.FullName]ColumnType{
+	(*timestamppb.Timestamp)(nil).ProtoReflect().Descriptor().FullName():            ColumnTime,
+	(*ddv1.Date)(nil).ProtoReflect().Descriptor().FullName():                        ColumnTime,
+	(*ddv1.StringValue)(nil).ProtoReflect().Descriptor().FullName():                 ColumnString,
+	(*ddv1.Ia5StringValue)(nil).ProtoReflect().Descriptor().FullName():              ColumnString,
+	(*ddv1.FullCardNumber)(nil).ProtoReflect().Descriptor().FullName():              ColumnString,
+	(*ddv1.FullCardNumberAndGeneration)(nil).ProtoReflect().Descriptor().FullName(): ColumnString,
+}
+
+// fieldColumn returns a column of the values of a field of a protobuf message,
+// with the column type derived from the field: enums and strings are string
+// columns, integers are int columns, and timestamps and dates are time columns.
+//
+// It panics if the message has no such field, or the field has no column type.
+func fieldColumn(name string, m proto.Message, field protoreflect.Name) Column {
+	fd := m.ProtoReflect().Descriptor().Fields().ByName(field)
+	if fd == nil {
+		panic(fmt.Sprintf("export: %s has no field %s", m.ProtoReflect().Descriptor().FullName(), field))
+	}
+	column := Column{Name: name, Field: fd}
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		column.Type = ColumnBool
+	case protoreflect.EnumKind, protoreflect.StringKind:
+		column.Type = ColumnString
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		column.Type = ColumnInt
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		column.Type = ColumnFloat
+	case protoreflect.MessageKind:
+		column.Type = messageColumnTypes[fd.Message().FullName()]
+	}
+	if column.Type == 0 {
+		panic(fmt.Sprintf("export: field %s has no column type", fd.FullName()))
+	}
+	return column
+}
+
+// Table is a flat table of tachograph data.
+type Table struct {
+	// Name is the name of the table, in snake case.
+	Name string
+	// Columns are the columns of the table.
+	Columns []Column
+	// Rows are the rows of the table. Each row holds a value for each column,
+	// of the type of the column, or nil if the value is missing.
+	Rows [][]any
+}
+
+// appendRow appends a row to the table, mapping zero times and empty strings
+// to nil.
+func (t *Table) appendRow(values ...any) {
+	row := make([]any, len(values))
+	for i, v := range values {
+		switch v := v.(type) {
+		case time.Time:
+			if !v.IsZero() {
+				row[i] = v.UTC()
+			}
+		case string:
+			if v != "" {
+				row[i] = v
+			}
+		case int32:
+			row[i] = int64(v)
+		default:
+			row[i] = v
+		}
+	}
+	t.Rows = append(t.Rows, row)
+}
@@ -0,0 +1,330 @@
+package export
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/way-platform/tachograph-go"
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// TablesOptions configures the flattening of tachograph files into tables.
+type TablesOptions struct {
+	// Speed adds a speed table of the detailed speed of vehicle units, with
+	// a row per second.
+	Speed bool
+}
+
+// Tables flattens tachograph files into tables.
+//
+// See [TablesOptions.Tables].
+func Tables(files ...*tachographv1.File) ([]*Table, error) {
+	return TablesOptions{}.Tables(files...)
+}
+
+// Tables flattens tachograph files into tables.
+//
+// The tables are, in order:
+//
+//   - activities: the driver activity periods
+//   - events: the events
+//   - faults: the faults
+//   - vehicles: the vehicles used by a driver card, and the card insertions of a vehicle unit
+//   - places: the places where daily work periods began or ended
+//   - control_activities: the controls of a driver card or vehicle unit
+//   - calibrations: the calibrations of a vehicle unit
+//   - speed: the detailed speed of a vehicle unit, if enabled by [TablesOptions.Speed]
+//
+// All tables are returned, even if empty, and hold the rows of all files.
+// Driver card and vehicle unit files are supported.
+func (o TablesOptions) Tables(files ...*tachographv1.File) ([]*Table, error) {
+	t := newTables()
+	t.speedEnabled = o.Speed
+	for i, file := range files {
+		switch file.GetType() {
+		case tachographv1.File_DRIVER_CARD:
+			t.addDriverCard(file.GetDriverCard())
+		case tachographv1.File_VEHICLE_UNIT:
+			if err := t.addVehicleUnit(file.GetVehicleUnit()); err != nil {
+				return nil, fmt.Errorf("file %d: %w", i, err)
+			}
+		default:
+			return nil, fmt.Errorf("file %d: unsupported file type: %v", i, file.GetType())
+		}
+	}
+	result := []*Table{t.activities, t.events, t.faults, t.vehicles, t.places, t.controlActivities, t.calibrations}
+	if o.Speed {
+		result = append(result, t.speed)
+	}
+	return result, nil
+}
+
+// tables are the tables being built.
+type tables struct {
+	activities        *Table
+	events            *Table
+	faults            *Table
+	vehicles          *Table
+	places            *Table
+	controlActivities *Table
+	calibrations      *Table
+	speed             *Table
+	// speedEnabled adds the detailed speed to the speed table.
+	speedEnabled bool
+}
+
+// newTables returns empty tables with their columns.
+//
+// The columns that hold values of protobuf fields are derived from the fields,
+// read from the messages of the vehicle unit or driver card records that the
+// rows are built from.
+func newTables() *tables {
+	vehicle := []Column{
+		{Name: "card_number", Type: ColumnString},
+		fieldColumn("vin", &ddv1.CardVehicleRecordG2{}, "vehicle_identification_number"),
+		fieldColumn("registration_nation", &ddv1.VehicleRegistrationIdentification{}, "nation"),
+		fieldColumn("registration_number", &ddv1.VehicleRegistrationIdentification{}, "number"),
+	}
+	eventRecord := &vuv1.EventsAndFaultsGen1_EventRecord{}
+	overSpeedingEventRecord := &vuv1.EventsAndFaultsGen1_OverSpeedingEventRecord{}
+	event := []Column{
+		fieldColumn("type", eventRecord, "event_type"),
+		fieldColumn("record_purpose", eventRecord, "record_purpose"),
+		fieldColumn("begin_time", eventRecord, "begin_time"),
+		fieldColumn("end_time", eventRecord, "end_time"),
+		fieldColumn("driver_card_number", eventRecord, "card_number_driver_slot_begin"),
+		fieldColumn("co_driver_card_number", eventRecord, "card_number_codriver_slot_begin"),
+		fieldColumn("similar_events", eventRecord, "similar_events_number"),
+		fieldColumn("max_speed_kmh", overSpeedingEventRecord, "max_speed_kmh"),
+		fieldColumn("average_speed_kmh", overSpeedingEventRecord, "average_speed_kmh"),
+	}
+	columns := func(columns ...Column) []Column {
+		return append(append([]Column(nil), vehicle...), columns...)
+	}
+	activityChange := &ddv1.ActivityChangeInfo{}
+	cardVehicleRecord := &ddv1.CardVehicleRecord{}
+	placeRecord := &ddv1.PlaceRecordG2{}
+	controlActivity := &cardv1.ControlActivityData{}
+	controlType := &ddv1.ControlType{}
+	calibrationRecord := &vuv1.TechnicalDataGen1_CalibrationRecord{}
+	return &tables{
+		activities: &Table{Name: "activities", Columns: columns(
+			fieldColumn("slot", activityChange, "slot"),
+			fieldColumn("activity", activityChange, "activity"),
+			Column{Name: "start", Type: ColumnTime},
+			Column{Name: "end", Type: ColumnTime},
+			Column{Name: "duration_minutes", Type: ColumnInt},
+			fieldColumn("crew", activityChange, "crew"),
+			fieldColumn("card_inserted", activityChange, "inserted"),
+			Column{Name: "manual_entry", Type: ColumnBool},
+			Column{Name: "out_of_scope", Type: ColumnBool},
+			Column{Name: "ferry_train_crossing", Type: ColumnBool},
+		)},
+		events: &Table{Name: "events", Columns: columns(event...)},
+		faults: &Table{Name: "faults", Columns: columns(event...)},
+		vehicles: &Table{Name: "vehicles", Columns: columns(
+			fieldColumn("first_use", cardVehicleRecord, "vehicle_first_use"),
+			fieldColumn("last_use", cardVehicleRecord, "vehicle_last_use"),
+			fieldColumn("odometer_begin_km", cardVehicleRecord, "vehicle_odometer_begin_km"),
+			fieldColumn("odometer_end_km", cardVehicleRecord, "vehicle_odometer_end_km"),
+			Column{Name: "distance_km", Type: ColumnInt},
+		)},
+		places: &Table{Name: "places", Columns: columns(
+			fieldColumn("time", placeRecord, "entry_time"),
+			fieldColumn("entry_type", placeRecord, "entry_type_daily_work_period"),
+			fieldColumn("country", placeRecord, "daily_work_period_country"),
+			fieldColumn("odometer_km", placeRecord, "vehicle_odometer_km"),
+			Column{Name: "latitude", Type: ColumnFloat},
+			Column{Name: "longitude", Type: ColumnFloat},
+			fieldColumn("authentication_status", &ddv1.GNSSPlaceAuthRecord{}, "authentication_status"),
+		)},
+		controlActivities: &Table{Name: "control_activities", Columns: columns(
+			fieldColumn("time", controlActivity, "control_time"),
+			fieldColumn("control_card_number", controlActivity, "control_card_number"),
+			fieldColumn("card_downloading", controlType, "card_downloading"),
+			fieldColumn("vu_downloading", controlType, "vu_downloading"),
+			fieldColumn("printing", controlType, "printing"),
+			fieldColumn("display", controlType, "display"),
+			fieldColumn("calibration_checking", controlType, "calibration_checking"),
+			fieldColumn("download_period_begin", controlActivity, "control_download_period_begin"),
+			fieldColumn("download_period_end", controlActivity, "control_download_period_end"),
+		)},
+		calibrations: &Table{Name: "calibrations", Columns: columns(
+			fieldColumn("purpose", calibrationRecord, "purpose"),
+			fieldColumn("workshop_name", calibrationRecord, "workshop_name"),
+			fieldColumn("workshop_card_number", calibrationRecord, "workshop_card_number"),
+			fieldColumn("w_vehicle_characteristic_constant", calibrationRecord, "w_vehicle_characteristic_constant"),
+			fieldColumn("k_constant_of_recording_equipment", calibrationRecord, "k_constant_of_recording_equipment"),
+			fieldColumn("l_tyre_circumference_eighths_mm", calibrationRecord, "l_tyre_circumference_eighths_mm"),
+			fieldColumn("tyre_size", calibrationRecord, "tyre_size"),
+			fieldColumn("authorised_speed_kmh", calibrationRecord, "authorised_speed_kmh"),
+			fieldColumn("old_odometer_km", calibrationRecord, "old_odometer_value_km"),
+			fieldColumn("new_odometer_km", calibrationRecord, "new_odometer_value_km"),
+			fieldColumn("old_time", calibrationRecord, "old_time_value"),
+			fieldColumn("new_time", calibrationRecord, "new_time_value"),
+			fieldColumn("next_calibration_date", calibrationRecord, "next_calibration_date"),
+		)},
+		speed: &Table{Name: "speed", Columns: columns(
+			Column{Name: "time", Type: ColumnTime},
+			fieldColumn("speed_kmh", &vuv1.DetailedSpeedGen1_DetailedSpeedBlock{}, "speeds_kmh"),
+		)},
+	}
+}
+
+// addDriverCard adds the records of a driver card.
+func (t *tables) addDriverCard(card *cardv1.DriverCardFile) {
+	cardNumber := tachograph.DriverCardNumber(card)
+	uses := tachograph.VehicleUses(card)
+	// vehicleAt returns the vehicle used at a time, if any.
+	vehicleAt := func(at time.Time) tachograph.VehicleUse {
+		for _, u := range uses {
+			if !at.Before(u.FirstUse) && (u.LastUse.IsZero() || at.Before(u.LastUse)) {
+				return u
+			}
+		}
+		return tachograph.VehicleUse{}
+	}
+	for _, p := range tachograph.ActivityPeriods(card) {
+		u := vehicleAt(p.Start)
+		if !p.CardInserted {
+			u = tachograph.VehicleUse{}
+		}
+		t.addActivity(cardNumber, u.VIN, u.Registration, p)
+	}
+	for _, e := range tachograph.CardEvents(card) {
+		t.eventTable(e.Fault).appendRow(cardNumber, "", enumName(e.Registration.Nation), e.Registration.Number,
+			enumName(e.Type), nil, e.BeginTime, e.EndTime, "", "", nil, nil, nil)
+	}
+	for _, u := range uses {
+		var distance any
+		if u.OdometerEndKm >= u.OdometerBeginKm && !u.LastUse.IsZero() {
+			distance = u.OdometerEndKm - u.OdometerBeginKm
+		}
+		t.vehicles.appendRow(cardNumber, u.VIN, enumName(u.Registration.Nation), u.Registration.Number,
+			u.FirstUse, u.LastUse, u.OdometerBeginKm, u.OdometerEndKm, distance)
+	}
+	for _, p := range tachograph.Places(card) {
+		u := vehicleAt(p.Time)
+		t.addPlace(cardNumber, u.VIN, u.Registration, p)
+	}
+	for _, c := range tachograph.ControlActivities(card) {
+		t.addControlActivity(cardNumber, "", c.Registration, c)
+	}
+}
+
+// addVehicleUnit adds the records of a vehicle unit.
+func (t *tables) addVehicleUnit(file *vuv1.VehicleUnitFile) error {
+	h, err := tachograph.MergeVehicleUnitFiles(file)
+	if err != nil {
+		return err
+	}
+	var registration tachograph.VehicleRegistration
+	for _, d := range h.Downloads {
+		registration = d.Registration
+	}
+	// cardAt returns the number of the card inserted in a slot at a time, if any.
+	cardAt := func(p tachograph.ActivityPeriod) string {
+		for _, c := range h.CardInsertions {
+			if c.Slot == p.Slot && !p.Start.Before(c.InsertionTime) && (c.WithdrawalTime.IsZero() || p.Start.Before(c.WithdrawalTime)) {
+				return c.CardNumber
+			}
+		}
+		return ""
+	}
+	for _, p := range h.ActivityPeriods() {
+		var cardNumber string
+		if p.CardInserted {
+			cardNumber = cardAt(p)
+		}
+		t.addActivity(cardNumber, h.VIN, registration, p)
+	}
+	for _, e := range h.Events {
+		var maxSpeed, averageSpeed any
+		if e.MaxSpeedKmh != 0 || e.AverageSpeedKmh != 0 {
+			maxSpeed, averageSpeed = e.MaxSpeedKmh, e.AverageSpeedKmh
+		}
+		t.eventTable(e.Fault).appendRow("", h.VIN, enumName(registration.Nation), registration.Number,
+			enumName(e.Type), enumName(e.RecordPurpose), e.BeginTime, e.EndTime,
+			e.DriverCardNumber, e.CoDriverCardNumber, e.SimilarEvents, maxSpeed, averageSpeed)
+	}
+	for _, c := range h.CardInsertions {
+		var odometerEnd, distance any
+		if !c.WithdrawalTime.IsZero() {
+			odometerEnd = c.OdometerAtWithdrawalKm
+			if c.OdometerAtWithdrawalKm >= c.OdometerAtInsertionKm {
+				distance = c.OdometerAtWithdrawalKm - c.OdometerAtInsertionKm
+			}
+		}
+		t.vehicles.appendRow(c.CardNumber, h.VIN, enumName(registration.Nation), registration.Number,
+			c.InsertionTime, c.WithdrawalTime, c.OdometerAtInsertionKm, odometerEnd, distance)
+	}
+	for _, p := range h.Places {
+		t.addPlace("", h.VIN, registration, p)
+	}
+	for _, c := range h.ControlActivities {
+		t.addControlActivity("", h.VIN, registration, c)
+	}
+	for _, c := range h.Calibrations {
+		t.calibrations.appendRow("", c.VIN, enumName(c.Registration.Nation), c.Registration.Number,
+			enumName(c.Purpose), c.WorkshopName, c.WorkshopCardNumber,
+			c.WVehicleCharacteristicConstant, c.KConstantOfRecordingEquipment, c.LTyreCircumferenceEighthsMm,
+			c.TyreSize, c.AuthorisedSpeedKmh, c.OldOdometerKm, c.NewOdometerKm,
+			c.OldTime, c.NewTime, c.NextCalibrationDate)
+	}
+	if t.speedEnabled {
+		for _, b := range h.SpeedBlocks {
+			for i, speed := range b.SpeedsKmh {
+				t.speed.appendRow("", h.VIN, enumName(registration.Nation), registration.Number,
+					b.BeginTime.Add(time.Duration(i)*time.Second), speed)
+			}
+		}
+	}
+	return nil
+}
+
+// eventTable returns the table of faults or events.
+func (t *tables) eventTable(fault bool) *Table {
+	if fault {
+		return t.faults
+	}
+	return t.events
+}
+
+func (t *tables) addActivity(cardNumber, vin string, registration tachograph.VehicleRegistration, p tachograph.ActivityPeriod) {
+	t.activities.appendRow(cardNumber, vin, enumName(registration.Nation), registration.Number,
+		enumName(p.Slot), enumName(p.Activity), p.Start, p.End, int64(p.Duration()/time.Minute),
+		p.Crew, p.CardInserted, p.ManualEntry, p.OutOfScope, p.FerryTrainCrossing)
+}
+
+func (t *tables) addPlace(cardNumber, vin string, registration tachograph.VehicleRegistration, p tachograph.Place) {
+	var latitude, longitude any
+	if lat, lon, ok := tachograph.DecimalDegrees(p.Position); ok {
+		latitude, longitude = lat, lon
+	}
+	t.places.appendRow(cardNumber, vin, enumName(registration.Nation), registration.Number,
+		p.Time, enumName(p.EntryType), enumName(p.Country), p.OdometerKm,
+		latitude, longitude, enumName(p.AuthenticationStatus))
+}
+
+func (t *tables) addControlActivity(cardNumber, vin string, registration tachograph.VehicleRegistration, c tachograph.ControlActivity) {
+	t.controlActivities.appendRow(cardNumber, vin, enumName(registration.Nation), registration.Number,
+		c.Time, c.ControlCardNumber,
+		c.Type.GetCardDownloading(), c.Type.GetVuDownloading(), c.Type.GetPrinting(), c.Type.GetDisplay(), c.Type.GetCalibrationChecking(),
+		c.DownloadPeriod.Start, c.DownloadPeriod.End)
+}
+
+// enumName returns the name of an enum value, or an empty string if the value
+// is unspecified.
+func enumName(e protoreflect.Enum) string {
+	if e.Number() == 0 {
+		return ""
+	}
+	if value := e.Descriptor().Values().ByNumber(e.Number()); value != nil {
+		return string(value.Name())
+	}
+	return fmt.Sprint(e.Number())
+}
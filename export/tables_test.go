@@ -0,0 +1,162 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var testDay = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// testDriverCardFile builds a driver card with a vehicle used from 06:00 to
+// 16:00, and a day of rest, driving from 06:00 to 10:00, and rest.
+func testDriverCardFile() *tachographv1.File {
+	driverID := &ddv1.DriverIdentification{}
+	number := &ddv1.Ia5StringValue{}
+	number.SetValue("DRIVER00000001")
+	driverID.SetDriverIdentificationNumber(number)
+	identificationCard := &cardv1.Identification_Card{}
+	identificationCard.SetDriverIdentification(driverID)
+	identification := &cardv1.Identification{}
+	identification.SetCard(identificationCard)
+
+	registrationNumber := &ddv1.StringValue{}
+	registrationNumber.SetValue("AB123CD")
+	registration := &ddv1.VehicleRegistrationIdentification{}
+	registration.SetNation(ddv1.NationNumeric_GERMANY)
+	registration.SetNumber(registrationNumber)
+	vehicle := &ddv1.CardVehicleRecord{}
+	vehicle.SetVehicleRegistration(registration)
+	vehicle.SetVehicleFirstUse(timestamppb.New(testDay.Add(6 * time.Hour)))
+	vehicle.SetVehicleLastUse(timestamppb.New(testDay.Add(16 * time.Hour)))
+	vehicle.SetVehicleOdometerBeginKm(1000)
+	vehicle.SetVehicleOdometerEndKm(1350)
+	vehiclesUsed := &cardv1.VehiclesUsed{}
+	vehiclesUsed.SetRecords([]*ddv1.CardVehicleRecord{vehicle})
+
+	var changes []*ddv1.ActivityChangeInfo
+	for _, c := range []struct {
+		activity ddv1.DriverActivityValue
+		minutes  int32
+		inserted bool
+	}{
+		{activity: ddv1.DriverActivityValue_BREAK_REST, minutes: 0},
+		{activity: ddv1.DriverActivityValue_DRIVING, minutes: 6 * 60, inserted: true},
+		{activity: ddv1.DriverActivityValue_BREAK_REST, minutes: 10 * 60},
+	} {
+		change := &ddv1.ActivityChangeInfo{}
+		change.SetSlot(ddv1.CardSlotNumber_DRIVER_SLOT)
+		change.SetInserted(c.inserted)
+		change.SetActivity(c.activity)
+		change.SetTimeOfChangeMinutes(c.minutes)
+		changes = append(changes, change)
+	}
+	day := &cardv1.DriverActivityData_DailyRecord{}
+	day.SetValid(true)
+	day.SetActivityRecordDate(timestamppb.New(testDay))
+	day.SetActivityDayDistance(350)
+	day.SetActivityChangeInfo(changes)
+	activityData := &cardv1.DriverActivityData{}
+	activityData.SetDailyRecords([]*cardv1.DriverActivityData_DailyRecord{day})
+
+	tachograph := &cardv1.DriverCardFile_Tachograph{}
+	tachograph.SetIdentification(identification)
+	tachograph.SetVehiclesUsed(vehiclesUsed)
+	tachograph.SetDriverActivityData(activityData)
+	card := &cardv1.DriverCardFile{}
+	card.SetTachograph(tachograph)
+	file := &tachographv1.File{}
+	file.SetType(tachographv1.File_DRIVER_CARD)
+	file.SetDriverCard(card)
+	return file
+}
+
+func TestTables(t *testing.T) {
+	tables, err := Tables(testDriverCardFile())
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := map[string]*Table{}
+	for _, table := range tables {
+		byName[table.Name] = table
+		for i, row := range table.Rows {
+			if len(row) != len(table.Columns) {
+				t.Errorf("%s row %d has %d values, want %d", table.Name, i, len(row), len(table.Columns))
+			}
+		}
+	}
+	for _, name := range []string{"activities", "events", "faults", "vehicles", "places", "control_activities", "calibrations"} {
+		if byName[name] == nil {
+			t.Errorf("missing table %s", name)
+		}
+	}
+
+	var csvData bytes.Buffer
+	if err := WriteCSV(&csvData, byName["activities"]); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&csvData).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"card_number", "vin", "registration_nation", "registration_number", "slot", "activity", "start", "end", "duration_minutes", "crew", "card_inserted", "manual_entry", "out_of_scope", "ferry_train_crossing"},
+		{"DRIVER00000001", "", "", "", "DRIVER_SLOT", "BREAK_REST", "2024-01-01T00:00:00Z", "2024-01-01T06:00:00Z", "360", "false", "false", "false", "false", "false"},
+		{"DRIVER00000001", "", "GERMANY", "AB123CD", "DRIVER_SLOT", "DRIVING", "2024-01-01T06:00:00Z", "2024-01-01T10:00:00Z", "240", "false", "true", "false", "false", "false"},
+		{"DRIVER00000001", "", "", "", "DRIVER_SLOT", "BREAK_REST", "2024-01-01T10:00:00Z", "2024-01-02T00:00:00Z", "840", "false", "false", "false", "false", "false"},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("got %d CSV records, want %d:\n%v", len(records), len(want), records)
+	}
+	for i := range want {
+		for j := range want[i] {
+			if records[i][j] != want[i][j] {
+				t.Errorf("record %d field %s = %q, want %q", i, want[0][j], records[i][j], want[i][j])
+			}
+		}
+	}
+
+	vehicles := byName["vehicles"]
+	if len(vehicles.Rows) != 1 || vehicles.Rows[0][len(vehicles.Columns)-1] != int64(350) {
+		t.Errorf("vehicles = %v, want a use of 350 km", vehicles.Rows)
+	}
+}
+
+func TestTables_Unsupported(t *testing.T) {
+	file := &tachographv1.File{}
+	file.SetType(tachographv1.File_RAW_CARD)
+	if _, err := Tables(file); err == nil {
+		t.Error("Tables() of a raw card file succeeded, want error")
+	}
+}
+
+func TestFieldColumn(t *testing.T) {
+	for _, tt := range []struct {
+		field protoreflect.Name
+		want  ColumnType
+	}{
+		{field: "vehicle_first_use", want: ColumnTime},
+		{field: "vehicle_odometer_begin_km", want: ColumnInt},
+		{field: "vehicle_registration", want: 0},
+		{field: "missing", want: 0},
+	} {
+		t.Run(string(tt.field), func(t *testing.T) {
+			defer func() {
+				if r := recover(); (r != nil) != (tt.want == 0) {
+					t.Errorf("fieldColumn() panic = %v, want panic %v", r, tt.want == 0)
+				}
+			}()
+			column := fieldColumn("column", &ddv1.CardVehicleRecord{}, tt.field)
+			if column.Type != tt.want || column.Field.Name() != tt.field {
+				t.Errorf("fieldColumn() = %v (field %v), want type %v", column.Type, column.Field.Name(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// WriteXLSX writes tables as an XLSX spreadsheet, with a worksheet per table.
+//
+// Each worksheet has a frozen header row of column names. Times are written as
+// spreadsheet dates in UTC, and missing values as empty cells.
+func WriteXLSX(w io.Writer, tables []*Table) error {
+	type part struct {
+		name    string
+		content []byte
+	}
+	parts := []part{
+		{name: "[Content_Types].xml", content: xlsxContentTypes(len(tables))},
+		{name: "_rels/.rels", content: []byte(xlsxRootRels)},
+		{name: "xl/workbook.xml", content: xlsxWorkbook(tables)},
+		{name: "xl/_rels/workbook.xml.rels", content: xlsxWorkbookRels(len(tables))},
+		{name: "xl/styles.xml", content: []byte(xlsxStyles)},
+	}
+	for i, table := range tables {
+		parts = append(parts, part{name: fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), content: xlsxWorksheet(table)})
+	}
+	zw := zip.NewWriter(w)
+	for _, p := range parts {
+		fw, err := zw.Create(p.name)
+		if err != nil {
+			return fmt.Errorf("error creating %s: %w", p.name, err)
+		}
+		if _, err := fw.Write(p.content); err != nil {
+			return fmt.Errorf("error writing %s: %w", p.name, err)
+		}
+	}
+	return zw.Close()
+}
+
+const xlsxRootRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+// xlsxStyles defines the cell styles: 0 is the default, 1 is a date and time,
+// and 2 is a bold header.
+const xlsxStyles = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd hh:mm:ss"/></numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="3">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`</cellXfs>` +
+	`</styleSheet>`
+
+func xlsxContentTypes(sheets int) []byte {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	b.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := range sheets {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+	}
+	b.WriteString(`</Types>`)
+	return b.Bytes()
+}
+
+func xlsxWorkbook(tables []*Table) []byte {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i, table := range tables {
+		// Sheet names are limited to 31 characters.
+		name := table.Name
+		if len(name) > 31 {
+			name = name[:31]
+		}
+		fmt.Fprintf(&b, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(name), i+1, i+1)
+	}
+	b.WriteString(`</sheets></workbook>`)
+	return b.Bytes()
+}
+
+func xlsxWorkbookRels(sheets int) []byte {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := range sheets {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+	}
+	fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, sheets+1)
+	b.WriteString(`</Relationships>`)
+	return b.Bytes()
+}
+
+func xlsxWorksheet(table *Table) []byte {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	b.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	b.WriteString(`<sheetData><row r="1">`)
+	for i, column := range table.Columns {
+		fmt.Fprintf(&b, `<c r="%s1" s="2" t="inlineStr"><is><t>%s</t></is></c>`, xlsxColumn(i), xmlEscape(column.Name))
+	}
+	b.WriteString(`</row>`)
+	for i, row := range table.Rows {
+		fmt.Fprintf(&b, `<row r="%d">`, i+2)
+		for j, value := range row {
+			ref := xlsxColumn(j) + strconv.Itoa(i+2)
+			switch v := value.(type) {
+			case nil:
+				continue
+			case int64:
+				fmt.Fprintf(&b, `<c r="%s"><v>%d</v></c>`, ref, v)
+			case float64:
+				fmt.Fprintf(&b, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(v, 'f', -1, 64))
+			case bool:
+				var n int
+				if v {
+					n = 1
+				}
+				fmt.Fprintf(&b, `<c r="%s" t="b"><v>%d</v></c>`, ref, n)
+			case time.Time:
+				fmt.Fprintf(&b, `<c r="%s" s="1"><v>%s</v></c>`, ref, strconv.FormatFloat(xlsxDate(v), 'f', -1, 64))
+			default:
+				fmt.Fprintf(&b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, xmlEscape(formatValue(v)))
+			}
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.Bytes()
+}
+
+// xlsxColumn returns the letters of a zero-based column index.
+func xlsxColumn(i int) string {
+	var letters []byte
+	for i++; i > 0; i = (i - 1) / 26 {
+		letters = append([]byte{byte('A' + (i-1)%26)}, letters...)
+	}
+	return string(letters)
+}
+
+// xlsxDate converts a time to a spreadsheet date: the number of days since
+// 1899-12-30.
+func xlsxDate(t time.Time) float64 {
+	epoch := time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+	return float64(t.Sub(epoch).Round(time.Second)/time.Second) / (24 * 60 * 60)
+}
+
+// xmlEscape escapes text for XML content and attributes.
+func xmlEscape(s string) string {
+	var b bytes.Buffer
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
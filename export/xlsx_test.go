@@ -0,0 +1,62 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestWriteXLSX(t *testing.T) {
+	tables, err := Tables(testDriverCardFile())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var data bytes.Buffer
+	if err := WriteXLSX(&data, tables); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data.Bytes()), int64(data.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(zr.File), 5+len(tables); got != want {
+		t.Errorf("got %d parts, want %d", got, want)
+	}
+	for _, f := range zr.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Each part must be well-formed XML.
+		d := xml.NewDecoder(bytes.NewReader(content))
+		for {
+			if _, err := d.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Errorf("%s: %v", f.Name, err)
+				break
+			}
+		}
+	}
+}
+
+func TestXLSXColumn(t *testing.T) {
+	for i, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"} {
+		if got := xlsxColumn(i); got != want {
+			t.Errorf("xlsxColumn(%d) = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestXLSXDate(t *testing.T) {
+	if got, want := xlsxDate(time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)), 45292.5; got != want {
+		t.Errorf("xlsxDate() = %v, want %v", got, want)
+	}
+}
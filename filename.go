@@ -0,0 +1,199 @@
+package tachograph
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+)
+
+// FileName is the metadata in the name of a downloaded tachograph file.
+//
+// Several member states mandate a naming convention for downloaded files,
+// which encodes the download time and the downloaded card or vehicle:
+//
+//	C_YYYYMMDD_HHMM_<first name initial>_<surname>_<card number>.DDD
+//	M_YYYYMMDD_HHMM_<vehicle registration number>.DDD
+//
+// National variants use other extensions, such as .C1B for cards and .V1B for
+// vehicle units, or .TGD and .ESM for both.
+type FileName struct {
+	// Type is the type of the downloaded file: a driver card or a vehicle unit.
+	Type tachographv1.File_Type
+	// DownloadTime is the download time, to the minute.
+	DownloadTime time.Time
+	// Initial is the initial of the first names of a card holder.
+	Initial string
+	// Surname is the surname of a card holder.
+	Surname string
+	// CardNumber is the card number of a driver card.
+	CardNumber string
+	// VehicleRegistrationNumber is the registration number of a vehicle unit.
+	VehicleRegistrationNumber string
+	// Extension is the extension of the file name, including the dot.
+	Extension string
+}
+
+// String returns the file name, with the download time in its own time zone.
+//
+// Characters that are not allowed in file names, and underscores, which
+// separate the fields, are replaced with hyphens.
+func (n FileName) String() string {
+	extension := n.Extension
+	if extension == "" {
+		extension = ".DDD"
+	}
+	timestamp := n.DownloadTime.Format("20060102_1504")
+	switch n.Type {
+	case tachographv1.File_DRIVER_CARD:
+		return "C_" + timestamp + "_" + fileNameField(n.Initial) + "_" + fileNameField(n.Surname) + "_" + fileNameField(n.CardNumber) + extension
+	case tachographv1.File_VEHICLE_UNIT:
+		return "M_" + timestamp + "_" + fileNameField(n.VehicleRegistrationNumber) + extension
+	default:
+		return ""
+	}
+}
+
+// FileNameOptions configures the generation and parsing of file names.
+type FileNameOptions struct {
+	// Extension is the extension of generated file names, such as ".DDD",
+	// ".C1B" or ".V1B". If empty, this defaults to ".DDD".
+	Extension string
+	// Location is the time zone of the download time in file names.
+	// If nil, this defaults to UTC.
+	Location *time.Location
+	// DownloadTime overrides the download time read from the file.
+	//
+	// Driver cards record the time of their last download, and vehicle units
+	// their current time at download. If the file holds no download time, a
+	// download time is required.
+	DownloadTime time.Time
+}
+
+// NewFileName returns the file name of a parsed driver card or vehicle unit
+// file, following the naming convention of downloaded files.
+//
+// See [FileNameOptions] if you need more control over the file name.
+func NewFileName(file *tachographv1.File) (FileName, error) {
+	return FileNameOptions{}.NewFileName(file)
+}
+
+// NewFileName returns the file name of a parsed driver card or vehicle unit
+// file, following the naming convention of downloaded files.
+//
+// For vehicle unit files with several downloads, the latest download is used.
+func (o FileNameOptions) NewFileName(file *tachographv1.File) (FileName, error) {
+	n := FileName{Type: file.GetType(), Extension: o.Extension}
+	switch file.GetType() {
+	case tachographv1.File_DRIVER_CARD:
+		card := file.GetDriverCard()
+		identification := card.GetTachographG2().GetIdentification()
+		download := card.GetTachographG2().GetCardDownload()
+		if identification == nil {
+			identification = card.GetTachograph().GetIdentification()
+		}
+		if download == nil {
+			download = card.GetTachograph().GetCardDownload()
+		}
+		holder := identification.GetDriverCardHolder()
+		if firstNames := strings.TrimSpace(holder.GetCardHolderFirstNames().GetValue()); firstNames != "" {
+			n.Initial = strings.ToUpper(string([]rune(firstNames)[0]))
+		}
+		n.Surname = strings.TrimSpace(holder.GetCardHolderSurname().GetValue())
+		n.CardNumber = DriverCardNumber(card)
+		if n.CardNumber == "" {
+			return FileName{}, fmt.Errorf("driver card has no card number")
+		}
+		n.DownloadTime = timeOf(download.GetTimestamp())
+	case tachographv1.File_VEHICLE_UNIT:
+		records := newVehicleUnitRecords(file.GetVehicleUnit())
+		n.VehicleRegistrationNumber = records.download.Registration.Number
+		if n.VehicleRegistrationNumber == "" {
+			return FileName{}, fmt.Errorf("vehicle unit file has no vehicle registration number")
+		}
+		n.DownloadTime = records.download.DownloadTime
+	default:
+		return FileName{}, fmt.Errorf("file name not supported for file type: %v", file.GetType())
+	}
+	if !o.DownloadTime.IsZero() {
+		n.DownloadTime = o.DownloadTime
+	}
+	if n.DownloadTime.IsZero() {
+		return FileName{}, fmt.Errorf("%v file has no download time", file.GetType())
+	}
+	n.DownloadTime = n.DownloadTime.In(o.location()).Truncate(time.Minute)
+	return n, nil
+}
+
+// ParseFileName parses the name of a downloaded tachograph file.
+//
+// See [FileNameOptions] if you need more control over the parsing.
+func ParseFileName(name string) (FileName, error) {
+	return FileNameOptions{}.ParseFileName(name)
+}
+
+// ParseFileName parses the name of a downloaded tachograph file, following
+// the naming convention of downloaded files. The name may include a
+// directory, which is ignored.
+//
+// The download time is parsed in the time zone of the options.
+func (o FileNameOptions) ParseFileName(name string) (FileName, error) {
+	base := filepath.Base(name)
+	extension := filepath.Ext(base)
+	if !isTachographFileName(base) {
+		return FileName{}, fmt.Errorf("invalid file name %q: unknown extension %q", base, extension)
+	}
+	fields := strings.Split(strings.TrimSuffix(base, extension), "_")
+	if len(fields) < 4 {
+		return FileName{}, fmt.Errorf("invalid file name %q: want <C|M>_YYYYMMDD_HHMM_...", base)
+	}
+	downloadTime, err := time.ParseInLocation("20060102_1504", fields[1]+"_"+fields[2], o.location())
+	if err != nil {
+		return FileName{}, fmt.Errorf("invalid file name %q: invalid download time: %w", base, err)
+	}
+	n := FileName{DownloadTime: downloadTime, Extension: extension}
+	rest := fields[3:]
+	switch strings.ToUpper(fields[0]) {
+	case "C":
+		if len(rest) < 3 {
+			return FileName{}, fmt.Errorf("invalid file name %q: want C_YYYYMMDD_HHMM_<initial>_<surname>_<card number>", base)
+		}
+		n.Type = tachographv1.File_DRIVER_CARD
+		n.Initial = rest[0]
+		n.Surname = strings.Join(rest[1:len(rest)-1], "_")
+		n.CardNumber = rest[len(rest)-1]
+	case "M":
+		n.Type = tachographv1.File_VEHICLE_UNIT
+		n.VehicleRegistrationNumber = strings.Join(rest, "_")
+	default:
+		return FileName{}, fmt.Errorf("invalid file name %q: unknown file type prefix %q", base, fields[0])
+	}
+	// The national .C1B and .V1B extensions are specific to cards and vehicle units.
+	if (strings.EqualFold(extension, ".C1B") && n.Type != tachographv1.File_DRIVER_CARD) ||
+		(strings.EqualFold(extension, ".V1B") && n.Type != tachographv1.File_VEHICLE_UNIT) {
+		return FileName{}, fmt.Errorf("invalid file name %q: extension %q does not match file type %v", base, extension, n.Type)
+	}
+	return n, nil
+}
+
+// location returns the time zone of file names.
+func (o FileNameOptions) location() *time.Location {
+	if o.Location == nil {
+		return time.UTC
+	}
+	return o.Location
+}
+
+// fileNameField replaces the characters of a file name field that are not
+// allowed in file names, or that separate fields, with hyphens.
+func fileNameField(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || unicode.IsControl(r) || strings.ContainsRune(`_/\:*?"<>|`, r) {
+			return '-'
+		}
+		return r
+	}, strings.TrimSpace(s))
+}
@@ -0,0 +1,123 @@
+package tachograph
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+)
+
+func TestNewFileName(t *testing.T) {
+	t.Run("driver card", func(t *testing.T) {
+		file, err := UnmarshalFile(testDriverCardData(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The test driver card data has no last card download.
+		if _, err := NewFileName(file); err == nil {
+			t.Error("NewFileName() without download time: expected error")
+		}
+		opts := FileNameOptions{DownloadTime: time.Date(2024, 3, 15, 14, 30, 59, 0, time.UTC)}
+		name, err := opts.NewFileName(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := name.String(), "C_20240315_1430_T_TEST-SURNAME_DRIVER0000000100.DDD"; got != want {
+			t.Errorf("NewFileName() = %q, want %q", got, want)
+		}
+		opts.Extension = ".C1B"
+		opts.Location = time.FixedZone("CET", 60*60)
+		name, err = opts.NewFileName(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := name.String(), "C_20240315_1530_T_TEST-SURNAME_DRIVER0000000100.C1B"; got != want {
+			t.Errorf("NewFileName() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("vehicle unit", func(t *testing.T) {
+		vehicleUnit := testVehicleUnitFileGen1("WDB9634031L123456", Period{Start: testDay(0), End: testDay(3).Add(9*time.Hour + 5*time.Minute)}, nil)
+		number := &ddv1.StringValue{}
+		number.SetValue("AB 123_CD ")
+		registration := &ddv1.VehicleRegistrationIdentification{}
+		registration.SetNation(ddv1.NationNumeric_FINLAND)
+		registration.SetNumber(number)
+		vehicleUnit.GetGen1().GetOverview().SetVehicleRegistrationWithNation(registration)
+		var file tachographv1.File
+		file.SetType(tachographv1.File_VEHICLE_UNIT)
+		file.SetVehicleUnit(vehicleUnit)
+		name, err := NewFileName(&file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := name.String(), "M_20240304_0905_AB-123-CD.DDD"; got != want {
+			t.Errorf("NewFileName() = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestParseFileName(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		want    FileName
+		wantErr bool
+	}{
+		{
+			name: "archive/C_20240315_1430_T_TEST-SURNAME_DRIVER0000000100.DDD",
+			want: FileName{
+				Type:         tachographv1.File_DRIVER_CARD,
+				DownloadTime: time.Date(2024, 3, 15, 14, 30, 0, 0, time.UTC),
+				Initial:      "T",
+				Surname:      "TEST-SURNAME",
+				CardNumber:   "DRIVER0000000100",
+				Extension:    ".DDD",
+			},
+		},
+		{
+			name: "C_20240315_1430_J_VAN_DER_BERG_NL12345678901234.c1b",
+			want: FileName{
+				Type:         tachographv1.File_DRIVER_CARD,
+				DownloadTime: time.Date(2024, 3, 15, 14, 30, 0, 0, time.UTC),
+				Initial:      "J",
+				Surname:      "VAN_DER_BERG",
+				CardNumber:   "NL12345678901234",
+				Extension:    ".c1b",
+			},
+		},
+		{
+			name: "M_20240304_0905_AB-123-CD.V1B",
+			want: FileName{
+				Type:                      tachographv1.File_VEHICLE_UNIT,
+				DownloadTime:              time.Date(2024, 3, 4, 9, 5, 0, 0, time.UTC),
+				VehicleRegistrationNumber: "AB-123-CD",
+				Extension:                 ".V1B",
+			},
+		},
+		{name: "M_20240304_0905_AB-123-CD.txt", wantErr: true},
+		{name: "M_20240304_0905.DDD", wantErr: true},
+		{name: "C_20240304_0905_SMITH_NL12345678901234.DDD", wantErr: true},
+		{name: "X_20240304_0905_AB-123-CD.DDD", wantErr: true},
+		{name: "M_20241304_0905_AB-123-CD.DDD", wantErr: true},
+		{name: "M_20240304_0905_AB-123-CD.C1B", wantErr: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFileName(tt.name)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseFileName() = %v, expected error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseFileName() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package tachograph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+// FuzzUnmarshalFile checks that parsing tachograph files, strictly or
+// leniently, never panics, that parse failures are reported as
+// [*ParseError], and that parsed files survive a marshal and parse
+// round-trip.
+func FuzzUnmarshalFile(f *testing.F) {
+	f.Add(testDriverCardData(f))
+	f.Add([]byte{0x76, 0x24, 0x00})
+	for _, name := range []string{"synthetic_gen1", "synthetic_gen2_v1", "synthetic_gen2_v2"} {
+		f.Add(testSyntheticVehicleUnitFile(f, name))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if _, err := (UnmarshalOptions{Lenient: true}).UnmarshalFile(data); err != nil {
+			var parseErr *ParseError
+			var diagnostics Diagnostics
+			if !errors.As(err, &parseErr) && !errors.As(err, &diagnostics) {
+				t.Fatalf("lenient parse error is neither a *ParseError nor Diagnostics: %v", err)
+			}
+		}
+		file, err := UnmarshalFile(data)
+		if err != nil {
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("parse error is not a *ParseError: %v", err)
+			}
+			return
+		}
+		marshaled, err := MarshalFile(file)
+		if err != nil {
+			t.Fatalf("marshal parsed file: %v", err)
+		}
+		reparsed, err := UnmarshalFile(marshaled)
+		if err != nil {
+			t.Fatalf("parse marshaled file: %v", err)
+		}
+		if !proto.Equal(file, reparsed) {
+			t.Errorf("round-trip mismatch (-parsed +reparsed):\n%s", cmp.Diff(file, reparsed, protocmp.Transform()))
+		}
+	})
+}
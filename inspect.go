@@ -0,0 +1,227 @@
+package tachograph
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/way-platform/tachograph-go/internal/card"
+	"github.com/way-platform/tachograph-go/internal/dd"
+	"github.com/way-platform/tachograph-go/internal/vu"
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Inspection is the low-level structure of a .DDD file.
+type Inspection struct {
+	// Type is the type of the file, unspecified if unknown.
+	Type tachographv1.File_Type
+	// Generation is the highest generation of the segments of the file.
+	Generation ddv1.Generation
+	// Version is the highest version of the segments of the file.
+	//
+	// Only vehicle unit transfers have a version, so the version of card files
+	// is unspecified.
+	Version ddv1.Version
+	// Size is the size of the file in bytes.
+	Size int
+	// Segments are the segments of the file that could be read.
+	Segments []Segment
+	// Err is the error that stopped reading the segments of the file, if any.
+	Err error
+	// ErrOffset is the byte offset of the data that could not be read as a
+	// segment, if Err is set.
+	ErrOffset int
+	// ParseErr is the error of parsing the file with [UnmarshalFile], if any.
+	ParseErr error
+}
+
+// Segment is a low-level record of a .DDD file: a TLV record of a card file,
+// or a TV record of a vehicle unit file, holding one TREP transfer.
+type Segment struct {
+	// Offset is the byte offset of the segment in the file.
+	Offset int
+	// Tag is the tag of the segment.
+	//
+	// For card files, the tag is the FID of the elementary file followed by the
+	// appendix byte. For vehicle unit files, the tag is the 0x76 prefix
+	// followed by the TREP.
+	Tag uint32
+	// Name is the name of the elementary file or the transfer type.
+	Name string
+	// Generation is the generation of the segment.
+	Generation ddv1.Generation
+	// Version is the version of the transfer, unspecified for card files.
+	Version ddv1.Version
+	// Signature reports whether the segment is the signature of the preceding
+	// segment, for card files.
+	Signature bool
+	// Header is the header of the segment: the tag and length for card files,
+	// and the tag for vehicle unit files.
+	Header []byte
+	// Value is the value of the segment.
+	Value []byte
+	// Fields are the fields of the value read by the parser of the segment, in
+	// the order of their offsets. Segments that are not parsed, such as
+	// signatures, have no fields, and neither has the data after a failure.
+	Fields []Field
+	// Err is the error of parsing the value of the segment, if any. It is a
+	// [*ParseError] that locates the failure in the file.
+	Err error
+}
+
+// Field is a field of the value of a segment.
+type Field struct {
+	// Path is the path of the field, like the field of a [ParseError], e.g.
+	// "events[1].event_type".
+	Path string
+	// Offset is the byte offset of the field in the value of the segment.
+	Offset int
+	// Length is the length of the field in bytes.
+	Length int
+}
+
+// Size returns the size of the segment in bytes, including its header.
+func (s Segment) Size() int {
+	return len(s.Header) + len(s.Value)
+}
+
+// InspectFile reads the low-level structure of a .DDD file.
+//
+// Unlike [UnmarshalFile], InspectFile does not stop at the first error: it
+// returns the segments that could be read before the error, together with the
+// offset of the error, to help debugging malformed files. The segments of
+// driver card and vehicle unit files are parsed one by one, to locate their
+// fields and the errors of the segments that fail to parse.
+func InspectFile(data []byte) *Inspection {
+	result := &Inspection{Size: len(data)}
+	switch {
+	case len(data) < 2:
+		result.Err = fmt.Errorf("insufficient data for tachograph file: %w", ErrTruncated)
+		return result
+	case data[0] == 0x76:
+		result.Type = tachographv1.File_VEHICLE_UNIT
+		result.inspectVehicleUnitFile(data)
+	case binary.BigEndian.Uint16(data[0:2]) == 0x0002:
+		result.Type = tachographv1.File_RAW_CARD
+		result.inspectCardFile(data)
+	default:
+		result.Err = errors.New("unknown or unsupported file type")
+		return result
+	}
+	for _, segment := range result.Segments {
+		result.Generation = max(result.Generation, segment.Generation)
+		result.Version = max(result.Version, segment.Version)
+	}
+	if _, err := UnmarshalFile(data); err != nil {
+		result.ParseErr = err
+	}
+	return result
+}
+
+// inspectVehicleUnitFile reads the TV records of a vehicle unit file.
+func (r *Inspection) inspectVehicleUnitFile(data []byte) {
+	rawFile, err := vu.ScanRawVehicleUnitFile(data)
+	var offset int
+	for _, record := range rawFile.GetRecords() {
+		const headerSize = 2
+		segment := Segment{
+			Offset:     offset,
+			Tag:        record.GetTag(),
+			Name:       record.GetType().String(),
+			Generation: record.GetGeneration(),
+			Header:     data[offset : offset+headerSize],
+			Value:      record.GetValue(),
+		}
+		if opts := record.GetType().Descriptor().Values().ByNumber(record.GetType().Number()).Options(); proto.HasExtension(opts, ddv1.E_Version) {
+			segment.Version = proto.GetExtension(opts, ddv1.E_Version).(ddv1.Version)
+		}
+		r.Segments = append(r.Segments, segment)
+		offset += segment.Size()
+	}
+	if err != nil {
+		r.Err, r.ErrOffset = err, offset
+	}
+
+	opts := vu.VehicleUnitOptions{Layouts: r.layouts()}
+	_, errs, err := opts.ScanVehicleUnitFile(data)
+	var transferErr *vu.TransferError
+	if errors.As(err, &transferErr) {
+		errs = append(errs, transferErr)
+	}
+	for _, err := range errs {
+		if err.Record != nil && err.Index < len(r.Segments) {
+			r.Segments[err.Index].Err = newParseError(tachographv1.File_VEHICLE_UNIT, err)
+		}
+	}
+	r.setFields(opts.Layouts)
+}
+
+// inspectCardFile reads the TLV records of a card file.
+func (r *Inspection) inspectCardFile(data []byte) {
+	rawFile, err := card.ScanRawCardFile(data)
+	var offset int
+	for _, record := range rawFile.GetRecords() {
+		const headerSize = 5
+		segment := Segment{
+			Offset:     offset,
+			Tag:        uint32(record.GetTag()),
+			Name:       record.GetFile().String(),
+			Generation: record.GetGeneration(),
+			Signature:  record.GetContentType() == cardv1.ContentType_SIGNATURE,
+			Header:     data[offset : offset+headerSize],
+			Value:      record.GetValue(),
+		}
+		if record.GetFile() == cardv1.ElementaryFileType_ELEMENTARY_FILE_UNSPECIFIED {
+			segment.Name = fmt.Sprintf("unknown EF 0x%04X", record.GetTag()>>8)
+		}
+		r.Segments = append(r.Segments, segment)
+		offset += segment.Size()
+	}
+	if err != nil {
+		r.Err, r.ErrOffset = err, offset
+	}
+	if card.InferFileType(rawFile) != cardv1.CardType_DRIVER_CARD {
+		return
+	}
+	r.Type = tachographv1.File_DRIVER_CARD
+
+	opts := card.DriverCardOptions{Layouts: r.layouts()}
+	_, _, errs := opts.ScanDriverCardFile(rawFile)
+	for _, err := range errs {
+		if err.Index < len(r.Segments) {
+			r.Segments[err.Index].Err = newParseError(tachographv1.File_DRIVER_CARD, err)
+		}
+	}
+	r.setFields(opts.Layouts)
+}
+
+// layouts returns a function that returns a layout for each segment, in which
+// the parsers record the fields of the segment.
+func (r *Inspection) layouts() func(i int) *dd.Layout {
+	layouts := make([]*dd.Layout, len(r.Segments))
+	for i := range layouts {
+		layouts[i] = dd.NewLayout()
+	}
+	return func(i int) *dd.Layout {
+		if i < 0 || i >= len(layouts) {
+			return nil
+		}
+		return layouts[i]
+	}
+}
+
+// setFields sets the fields of the segments from their layouts.
+func (r *Inspection) setFields(layouts func(i int) *dd.Layout) {
+	for i := range r.Segments {
+		for _, field := range layouts(i).Fields() {
+			r.Segments[i].Fields = append(r.Segments[i].Fields, Field{
+				Path:   field.Path,
+				Offset: field.Offset,
+				Length: field.Length,
+			})
+		}
+	}
+}
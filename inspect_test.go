@@ -0,0 +1,80 @@
+package tachograph
+
+import (
+	"errors"
+	"testing"
+
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+)
+
+func TestInspectFile(t *testing.T) {
+	data := testDriverCardData(t)
+	inspection := InspectFile(data)
+	if inspection.Err != nil || inspection.ParseErr != nil {
+		t.Fatalf("InspectFile() errors = %v, %v", inspection.Err, inspection.ParseErr)
+	}
+	if inspection.Type != tachographv1.File_DRIVER_CARD || inspection.Generation != ddv1.Generation_GENERATION_1 {
+		t.Errorf("InspectFile() = %v %v, want DRIVER_CARD GENERATION_1", inspection.Type, inspection.Generation)
+	}
+	if got := len(inspection.Segments); got != 7 {
+		t.Fatalf("InspectFile() = %d segments, want 7", got)
+	}
+	var offset int
+	for _, segment := range inspection.Segments {
+		if segment.Offset != offset {
+			t.Errorf("%s offset = %d, want %d", segment.Name, segment.Offset, offset)
+		}
+		offset += segment.Size()
+	}
+	if offset != len(data) {
+		t.Errorf("segments end at %d, want %d", offset, len(data))
+	}
+	if got := inspection.Segments[0].Name; got != "EF_ICC" {
+		t.Errorf("first segment = %s, want EF_ICC", got)
+	}
+	for _, segment := range inspection.Segments {
+		if len(segment.Fields) == 0 {
+			t.Errorf("%s has no fields", segment.Name)
+		}
+		end := 0
+		for _, field := range segment.Fields {
+			if field.Offset < end || field.Offset+field.Length > len(segment.Value) {
+				t.Errorf("%s field %s at %d+%d overlaps or exceeds the value", segment.Name, field.Path, field.Offset, field.Length)
+			}
+			end = field.Offset + field.Length
+		}
+	}
+
+	last := inspection.Segments[len(inspection.Segments)-1]
+	truncated := InspectFile(data[:len(data)-1])
+	if truncated.Err == nil || truncated.ParseErr == nil {
+		t.Fatal("InspectFile() of truncated file has no error")
+	}
+	if truncated.ErrOffset != last.Offset {
+		t.Errorf("ErrOffset = %d, want %d", truncated.ErrOffset, last.Offset)
+	}
+	if got := len(truncated.Segments); got != 6 {
+		t.Errorf("InspectFile() of truncated file = %d segments, want 6", got)
+	}
+
+	// An events EF with an invalid event type fails to parse as a segment,
+	// after the file could be split into segments.
+	eventsOffset := len(data)
+	invalid := append(data[:len(data):len(data)], 0x05, 0x02, 0x00, 0x00, 0x30) // EF_EVENTS_DATA
+	invalid = append(invalid, make([]byte, 24)...)
+	invalid = append(invalid, 0xFF, 0x00, 0x00, 0x00, 0x01)
+	invalid = append(invalid, make([]byte, 19)...)
+	malformed := InspectFile(invalid)
+	if malformed.Err != nil || malformed.ParseErr == nil {
+		t.Fatalf("InspectFile() errors = %v, %v", malformed.Err, malformed.ParseErr)
+	}
+	events := malformed.Segments[len(malformed.Segments)-1]
+	var parseErr *ParseError
+	if !errors.As(events.Err, &parseErr) || parseErr.Field != "events[1].event_type" || parseErr.Offset != eventsOffset+5+24 {
+		t.Errorf("events segment error = %v, want events[1].event_type at offset %d", events.Err, eventsOffset+5+24)
+	}
+	if len(events.Fields) == 0 || events.Fields[0].Path != "events[0]" {
+		t.Errorf("events segment fields = %v, want the fields before the failure", events.Fields)
+	}
+}
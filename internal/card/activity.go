@@ -39,7 +39,7 @@ func (opts UnmarshalOptions) unmarshalDriverActivityData(data []byte) (*cardv1.D
 	)
 
 	if len(data) < lenCardDriverActivityHeader {
-		return nil, fmt.Errorf("insufficient data for activity data header")
+		return nil, fmt.Errorf("insufficient data for activity data header: %w", dd.ErrTruncated)
 	}
 
 	target := &cardv1.DriverActivityData{}
@@ -49,14 +49,16 @@ func (opts UnmarshalOptions) unmarshalDriverActivityData(data []byte) (*cardv1.D
 	var oldestDayRecordPointer uint16
 	var newestDayRecordPointer uint16
 	if err := binary.Read(r, binary.BigEndian, &oldestDayRecordPointer); err != nil {
-		return nil, fmt.Errorf("failed to read oldest day record pointer: %w", err)
+		return nil, dd.WrapField("oldest_day_record_index", 0, fmt.Errorf("failed to read oldest day record pointer: %w", err))
 	}
 	if err := binary.Read(r, binary.BigEndian, &newestDayRecordPointer); err != nil {
-		return nil, fmt.Errorf("failed to read newest day record pointer: %w", err)
+		return nil, dd.WrapField("newest_day_record_index", 2, fmt.Errorf("failed to read newest day record pointer: %w", err))
 	}
 
 	target.SetOldestDayRecordIndex(int32(oldestDayRecordPointer))
 	target.SetNewestDayRecordIndex(int32(newestDayRecordPointer))
+	opts.Layout.Field("oldest_day_record_index", 0, 2)
+	opts.Layout.Field("newest_day_record_index", 2, 2)
 
 	// The rest of the data is the cyclic buffer of daily records.
 	activityData := make([]byte, r.Len())
@@ -68,9 +70,11 @@ func (opts UnmarshalOptions) unmarshalDriverActivityData(data []byte) (*cardv1.D
 	target.SetRawData(activityData)
 
 	// Parse records using the iterator
-	dailyRecords, err := opts.parseActivityRecordsWithIterator(activityData, int(newestDayRecordPointer))
+	recordsOpts := opts
+	recordsOpts.Layout = opts.Layout.Nested("daily_records", lenCardDriverActivityHeader)
+	dailyRecords, err := recordsOpts.parseActivityRecordsWithIterator(activityData, int(newestDayRecordPointer))
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse cyclic activity daily records: %w", err)
+		return nil, dd.WrapField("daily_records", lenCardDriverActivityHeader, fmt.Errorf("failed to parse cyclic activity daily records: %w", err))
 	}
 	target.SetDailyRecords(dailyRecords)
 
@@ -82,10 +86,12 @@ func (opts UnmarshalOptions) unmarshalDriverActivityData(data []byte) (*cardv1.D
 // and enabling the buffer painting strategy for perfect round-trip fidelity.
 func (opts UnmarshalOptions) parseActivityRecordsWithIterator(buffer []byte, startPos int) ([]*cardv1.DriverActivityData_DailyRecord, error) {
 	var records []*cardv1.DriverActivityData_DailyRecord
+	var positions, lengths []int
 
 	iterator := NewCyclicRecordIterator(buffer, startPos)
 	for iterator.Next() {
-		recordBytes, _, _ := iterator.Record()
+		recordBytes, position, length := iterator.Record()
+		positions, lengths = append(positions, position), append(lengths, length)
 
 		// Try to parse the record semantically
 		parsedRecord, err := opts.parseSingleActivityDailyRecord(recordBytes)
@@ -118,6 +124,18 @@ func (opts UnmarshalOptions) parseActivityRecordsWithIterator(buffer []byte, sta
 	// Reverse to get chronological order (oldest to newest)
 	for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
 		records[i], records[j] = records[j], records[i]
+		positions[i], positions[j] = positions[j], positions[i]
+		lengths[i], lengths[j] = lengths[j], lengths[i]
+	}
+
+	// Records that wrap around the end of the cyclic buffer are laid out in
+	// two parts.
+	for i, position := range positions {
+		length := lengths[i]
+		opts.Layout.Element("", i, position, min(length, len(buffer)-position))
+		if position+length > len(buffer) {
+			opts.Layout.Element("", i, 0, position+length-len(buffer))
+		}
 	}
 
 	return records, nil
@@ -130,7 +148,7 @@ func (opts UnmarshalOptions) parseSingleActivityDailyRecord(data []byte) (*cardv
 	)
 
 	if len(data) < lenMinDailyRecord {
-		return nil, fmt.Errorf("insufficient data for daily record, got %d bytes", len(data))
+		return nil, fmt.Errorf("insufficient data for daily record, got %d bytes: %w", len(data), dd.ErrTruncated)
 	}
 
 	record := &cardv1.DriverActivityData_DailyRecord{}
@@ -146,7 +164,7 @@ func (opts UnmarshalOptions) parseSingleActivityDailyRecord(data []byte) (*cardv
 
 	// Read activity record date (4 bytes TimeReal)
 	if offset+4 > len(data) {
-		return nil, fmt.Errorf("insufficient data for activity record date")
+		return nil, fmt.Errorf("insufficient data for activity record date: %w", dd.ErrTruncated)
 	}
 	date, err := opts.UnmarshalTimeReal(data[offset : offset+4])
 	if err != nil {
@@ -157,7 +175,7 @@ func (opts UnmarshalOptions) parseSingleActivityDailyRecord(data []byte) (*cardv
 
 	// Read activity daily presence counter (2 bytes BCD)
 	if offset+2 > len(data) {
-		return nil, fmt.Errorf("insufficient data for presence counter")
+		return nil, fmt.Errorf("insufficient data for presence counter: %w", dd.ErrTruncated)
 	}
 	bcdCounter, err := opts.UnmarshalBcdString(data[offset : offset+2])
 	if err != nil {
@@ -168,7 +186,7 @@ func (opts UnmarshalOptions) parseSingleActivityDailyRecord(data []byte) (*cardv
 
 	// Read activity day distance (2 bytes)
 	if offset+2 > len(data) {
-		return nil, fmt.Errorf("insufficient data for day distance")
+		return nil, fmt.Errorf("insufficient data for day distance: %w", dd.ErrTruncated)
 	}
 	dayDistance := binary.BigEndian.Uint16(data[offset : offset+2])
 	record.SetActivityDayDistance(int32(dayDistance))
@@ -306,7 +324,7 @@ func (it *cyclicRecordIterator) Next() bool {
 	}
 	// Validate record length
 	if currentRecordLength < 4 {
-		it.err = fmt.Errorf("invalid record length %d at position %d", currentRecordLength, it.currentPos)
+		it.err = dd.WrapField("activity_record_length", it.currentPos+2, fmt.Errorf("invalid record length %d: %w", currentRecordLength, dd.ErrInvalidValue))
 		return false
 	}
 	// Store current record information
@@ -32,7 +32,7 @@ func (opts UnmarshalOptions) unmarshalApplicationIdentification(data []byte) (*c
 	)
 
 	if len(data) != lenEfApplicationIdentificationGen1 {
-		return nil, fmt.Errorf("invalid data length for Gen1 application identification: got %d bytes, want %d", len(data), lenEfApplicationIdentificationGen1)
+		return nil, fmt.Errorf("invalid data length for Gen1 application identification: got %d bytes, want %d: %w", len(data), lenEfApplicationIdentificationGen1, dd.ErrInvalidValue)
 	}
 
 	target := &cardv1.ApplicationIdentification{}
@@ -41,26 +41,28 @@ func (opts UnmarshalOptions) unmarshalApplicationIdentification(data []byte) (*c
 	// Read type of tachograph card ID (1 byte)
 	var cardType byte
 	if err := binary.Read(r, binary.BigEndian, &cardType); err != nil {
-		return nil, fmt.Errorf("failed to read card type: %w", err)
+		return nil, dd.WrapField("type_of_tachograph_card_id", 0, fmt.Errorf("failed to read card type: %w", err))
 	}
 	// Convert raw card type to enum using protocol annotations
 	if equipmentType, err := dd.UnmarshalEnum[ddv1.EquipmentType](cardType); err == nil {
 		target.SetTypeOfTachographCardId(equipmentType)
 	} else {
-		return nil, fmt.Errorf("invalid equipment type: %w", err)
+		return nil, dd.WrapField("type_of_tachograph_card_id", 0, fmt.Errorf("invalid equipment type: %w", err))
 	}
+	opts.Layout.Field("type_of_tachograph_card_id", 0, 1)
 
 	// Read card structure version (2 bytes)
 	structureVersionBytes := make([]byte, 2)
 	if _, err := r.Read(structureVersionBytes); err != nil {
-		return nil, fmt.Errorf("failed to read card structure version: %w", err)
+		return nil, dd.WrapField("card_structure_version", 1, fmt.Errorf("failed to read card structure version: %w", err))
 	}
 	// Parse BCD structure version using centralized helper
 	cardStructureVersion, err := opts.UnmarshalCardStructureVersion(structureVersionBytes)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal card structure version: %w", err)
+		return nil, dd.WrapField("card_structure_version", 1, fmt.Errorf("failed to unmarshal card structure version: %w", err))
 	}
 	target.SetCardStructureVersion(cardStructureVersion)
+	opts.Layout.Field("card_structure_version", 1, 2)
 
 	// For now, assume this is a driver card and create the driver data
 	driver := &cardv1.ApplicationIdentification_Driver{}
@@ -68,37 +70,42 @@ func (opts UnmarshalOptions) unmarshalApplicationIdentification(data []byte) (*c
 	// Read events per type count (1 byte)
 	var eventsPerType byte
 	if err := binary.Read(r, binary.BigEndian, &eventsPerType); err != nil {
-		return nil, fmt.Errorf("failed to read events per type count: %w", err)
+		return nil, dd.WrapField("driver.events_per_type_count", 3, fmt.Errorf("failed to read events per type count: %w", err))
 	}
 	driver.SetEventsPerTypeCount(int32(eventsPerType))
+	opts.Layout.Field("driver.events_per_type_count", 3, 1)
 
 	// Read faults per type count (1 byte)
 	var faultsPerType byte
 	if err := binary.Read(r, binary.BigEndian, &faultsPerType); err != nil {
-		return nil, fmt.Errorf("failed to read faults per type count: %w", err)
+		return nil, dd.WrapField("driver.faults_per_type_count", 4, fmt.Errorf("failed to read faults per type count: %w", err))
 	}
 	driver.SetFaultsPerTypeCount(int32(faultsPerType))
+	opts.Layout.Field("driver.faults_per_type_count", 4, 1)
 
 	// Read activity structure length (2 bytes)
 	var activityLength uint16
 	if err := binary.Read(r, binary.BigEndian, &activityLength); err != nil {
-		return nil, fmt.Errorf("failed to read activity structure length: %w", err)
+		return nil, dd.WrapField("driver.activity_structure_length", 5, fmt.Errorf("failed to read activity structure length: %w", err))
 	}
 	driver.SetActivityStructureLength(int32(activityLength))
+	opts.Layout.Field("driver.activity_structure_length", 5, 2)
 
 	// Read card vehicle records count (2 bytes in Gen1)
 	var vehicleRecords uint16
 	if err := binary.Read(r, binary.BigEndian, &vehicleRecords); err != nil {
-		return nil, fmt.Errorf("failed to read vehicle records count: %w", err)
+		return nil, dd.WrapField("driver.card_vehicle_records_count", 7, fmt.Errorf("failed to read vehicle records count: %w", err))
 	}
 	driver.SetCardVehicleRecordsCount(int32(vehicleRecords))
+	opts.Layout.Field("driver.card_vehicle_records_count", 7, 2)
 
 	// Read card place records count (1 byte in Gen1)
 	var placeRecords byte
 	if err := binary.Read(r, binary.BigEndian, &placeRecords); err != nil {
-		return nil, fmt.Errorf("failed to read place records count: %w", err)
+		return nil, dd.WrapField("driver.card_place_records_count", 9, fmt.Errorf("failed to read place records count: %w", err))
 	}
 	driver.SetCardPlaceRecordsCount(int32(placeRecords))
+	opts.Layout.Field("driver.card_place_records_count", 9, 1)
 
 	// Set the driver data and card type
 	target.SetDriver(driver)
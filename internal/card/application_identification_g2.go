@@ -35,7 +35,7 @@ func (opts UnmarshalOptions) unmarshalApplicationIdentificationG2(data []byte) (
 	)
 
 	if len(data) != lenEfApplicationIdentificationG2 {
-		return nil, fmt.Errorf("invalid data length for Gen2 application identification: got %d bytes, want %d", len(data), lenEfApplicationIdentificationG2)
+		return nil, fmt.Errorf("invalid data length for Gen2 application identification: got %d bytes, want %d: %w", len(data), lenEfApplicationIdentificationG2, dd.ErrInvalidValue)
 	}
 
 	target := &cardv1.ApplicationIdentificationG2{}
@@ -44,26 +44,28 @@ func (opts UnmarshalOptions) unmarshalApplicationIdentificationG2(data []byte) (
 	// Read type of tachograph card ID (1 byte)
 	var cardType byte
 	if err := binary.Read(r, binary.BigEndian, &cardType); err != nil {
-		return nil, fmt.Errorf("failed to read card type: %w", err)
+		return nil, dd.WrapField("type_of_tachograph_card_id", 0, fmt.Errorf("failed to read card type: %w", err))
 	}
 	// Convert raw card type to enum using protocol annotations
 	if equipmentType, err := dd.UnmarshalEnum[ddv1.EquipmentType](cardType); err == nil {
 		target.SetTypeOfTachographCardId(equipmentType)
 	} else {
-		return nil, fmt.Errorf("invalid equipment type: %w", err)
+		return nil, dd.WrapField("type_of_tachograph_card_id", 0, fmt.Errorf("invalid equipment type: %w", err))
 	}
+	opts.Layout.Field("type_of_tachograph_card_id", 0, 1)
 
 	// Read card structure version (2 bytes)
 	structureVersionBytes := make([]byte, 2)
 	if _, err := r.Read(structureVersionBytes); err != nil {
-		return nil, fmt.Errorf("failed to read card structure version: %w", err)
+		return nil, dd.WrapField("card_structure_version", 1, fmt.Errorf("failed to read card structure version: %w", err))
 	}
 	// Parse BCD structure version using centralized helper
 	cardStructureVersion, err := opts.UnmarshalCardStructureVersion(structureVersionBytes)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal card structure version: %w", err)
+		return nil, dd.WrapField("card_structure_version", 1, fmt.Errorf("failed to unmarshal card structure version: %w", err))
 	}
 	target.SetCardStructureVersion(cardStructureVersion)
+	opts.Layout.Field("card_structure_version", 1, 2)
 
 	// For now, assume this is a driver card and create the driver data
 	driver := &cardv1.ApplicationIdentificationG2_Driver{}
@@ -71,60 +73,68 @@ func (opts UnmarshalOptions) unmarshalApplicationIdentificationG2(data []byte) (
 	// Read events per type count (1 byte)
 	var eventsPerType byte
 	if err := binary.Read(r, binary.BigEndian, &eventsPerType); err != nil {
-		return nil, fmt.Errorf("failed to read events per type count: %w", err)
+		return nil, dd.WrapField("driver.events_per_type_count", 3, fmt.Errorf("failed to read events per type count: %w", err))
 	}
 	driver.SetEventsPerTypeCount(int32(eventsPerType))
+	opts.Layout.Field("driver.events_per_type_count", 3, 1)
 
 	// Read faults per type count (1 byte)
 	var faultsPerType byte
 	if err := binary.Read(r, binary.BigEndian, &faultsPerType); err != nil {
-		return nil, fmt.Errorf("failed to read faults per type count: %w", err)
+		return nil, dd.WrapField("driver.faults_per_type_count", 4, fmt.Errorf("failed to read faults per type count: %w", err))
 	}
 	driver.SetFaultsPerTypeCount(int32(faultsPerType))
+	opts.Layout.Field("driver.faults_per_type_count", 4, 1)
 
 	// Read activity structure length (2 bytes)
 	var activityLength uint16
 	if err := binary.Read(r, binary.BigEndian, &activityLength); err != nil {
-		return nil, fmt.Errorf("failed to read activity structure length: %w", err)
+		return nil, dd.WrapField("driver.activity_structure_length", 5, fmt.Errorf("failed to read activity structure length: %w", err))
 	}
 	driver.SetActivityStructureLength(int32(activityLength))
+	opts.Layout.Field("driver.activity_structure_length", 5, 2)
 
 	// Read card vehicle records count (2 bytes in Gen2)
 	var vehicleRecords uint16
 	if err := binary.Read(r, binary.BigEndian, &vehicleRecords); err != nil {
-		return nil, fmt.Errorf("failed to read vehicle records count: %w", err)
+		return nil, dd.WrapField("driver.card_vehicle_records_count", 7, fmt.Errorf("failed to read vehicle records count: %w", err))
 	}
 	driver.SetCardVehicleRecordsCount(int32(vehicleRecords))
+	opts.Layout.Field("driver.card_vehicle_records_count", 7, 2)
 
 	// Read card place records count (2 bytes in Gen2)
 	var placeRecords uint16
 	if err := binary.Read(r, binary.BigEndian, &placeRecords); err != nil {
-		return nil, fmt.Errorf("failed to read place records count: %w", err)
+		return nil, dd.WrapField("driver.card_place_records_count", 9, fmt.Errorf("failed to read place records count: %w", err))
 	}
 	driver.SetCardPlaceRecordsCount(int32(placeRecords))
+	opts.Layout.Field("driver.card_place_records_count", 9, 2)
 
 	// Gen2-specific fields:
 
 	// Read GNSS AD records count (2 bytes)
 	var gnssAdRecords uint16
 	if err := binary.Read(r, binary.BigEndian, &gnssAdRecords); err != nil {
-		return nil, fmt.Errorf("failed to read GNSS AD records count: %w", err)
+		return nil, dd.WrapField("driver.gnss_ad_records_count", 11, fmt.Errorf("failed to read GNSS AD records count: %w", err))
 	}
 	driver.SetGnssAdRecordsCount(int32(gnssAdRecords))
+	opts.Layout.Field("driver.gnss_ad_records_count", 11, 2)
 
 	// Read specific condition records count (2 bytes)
 	var specificConditionRecords uint16
 	if err := binary.Read(r, binary.BigEndian, &specificConditionRecords); err != nil {
-		return nil, fmt.Errorf("failed to read specific condition records count: %w", err)
+		return nil, dd.WrapField("driver.specific_condition_records_count", 13, fmt.Errorf("failed to read specific condition records count: %w", err))
 	}
 	driver.SetSpecificConditionRecordsCount(int32(specificConditionRecords))
+	opts.Layout.Field("driver.specific_condition_records_count", 13, 2)
 
 	// Read card vehicle unit records count (2 bytes)
 	var vehicleUnitRecords uint16
 	if err := binary.Read(r, binary.BigEndian, &vehicleUnitRecords); err != nil {
-		return nil, fmt.Errorf("failed to read vehicle unit records count: %w", err)
+		return nil, dd.WrapField("driver.card_vehicle_unit_records_count", 15, fmt.Errorf("failed to read vehicle unit records count: %w", err))
 	}
 	driver.SetCardVehicleUnitRecordsCount(int32(vehicleUnitRecords))
+	opts.Layout.Field("driver.card_vehicle_unit_records_count", 15, 2)
 
 	// Set the driver data and card type
 	target.SetDriver(driver)
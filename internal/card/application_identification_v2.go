@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"fmt"
 
+	"github.com/way-platform/tachograph-go/internal/dd"
 	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
 )
 
@@ -26,7 +27,7 @@ func (opts UnmarshalOptions) unmarshalApplicationIdentificationV2(data []byte) (
 	)
 
 	if len(data) < lenEfApplicationIdentificationV2 {
-		return nil, fmt.Errorf("insufficient data for application identification V2: got %d bytes, need %d", len(data), lenEfApplicationIdentificationV2)
+		return nil, fmt.Errorf("insufficient data for application identification V2: got %d bytes, need %d: %w", len(data), lenEfApplicationIdentificationV2, dd.ErrTruncated)
 	}
 	var target cardv1.ApplicationIdentificationV2
 	r := bytes.NewReader(data)
@@ -37,30 +38,34 @@ func (opts UnmarshalOptions) unmarshalApplicationIdentificationV2(data []byte) (
 	// Read border crossing records count (1 byte)
 	var borderCrossingCount byte
 	if err := binary.Read(r, binary.BigEndian, &borderCrossingCount); err != nil {
-		return nil, fmt.Errorf("failed to read border crossing records count: %w", err)
+		return nil, dd.WrapField("driver.border_crossing_records_count", 0, fmt.Errorf("failed to read border crossing records count: %w", err))
 	}
 	driver.SetBorderCrossingRecordsCount(int32(borderCrossingCount))
+	opts.Layout.Field("driver.border_crossing_records_count", 0, 1)
 
 	// Read load/unload records count (1 byte)
 	var loadUnloadCount byte
 	if err := binary.Read(r, binary.BigEndian, &loadUnloadCount); err != nil {
-		return nil, fmt.Errorf("failed to read load/unload records count: %w", err)
+		return nil, dd.WrapField("driver.load_unload_records_count", 1, fmt.Errorf("failed to read load/unload records count: %w", err))
 	}
 	driver.SetLoadUnloadRecordsCount(int32(loadUnloadCount))
+	opts.Layout.Field("driver.load_unload_records_count", 1, 1)
 
 	// Read load type entry records count (1 byte)
 	var loadTypeCount byte
 	if err := binary.Read(r, binary.BigEndian, &loadTypeCount); err != nil {
-		return nil, fmt.Errorf("failed to read load type entry records count: %w", err)
+		return nil, dd.WrapField("driver.load_type_entry_records_count", 2, fmt.Errorf("failed to read load type entry records count: %w", err))
 	}
 	driver.SetLoadTypeEntryRecordsCount(int32(loadTypeCount))
+	opts.Layout.Field("driver.load_type_entry_records_count", 2, 1)
 
 	// Read VU configuration length range (1 byte)
 	var vuConfigRange byte
 	if err := binary.Read(r, binary.BigEndian, &vuConfigRange); err != nil {
-		return nil, fmt.Errorf("failed to read VU configuration length range: %w", err)
+		return nil, dd.WrapField("driver.vu_configuration_length_range", 3, fmt.Errorf("failed to read VU configuration length range: %w", err))
 	}
 	driver.SetVuConfigurationLengthRange(int32(vuConfigRange))
+	opts.Layout.Field("driver.vu_configuration_length_range", 3, 1)
 
 	// Set the driver data and card type
 	target.SetDriver(driver)
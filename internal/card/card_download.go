@@ -21,7 +21,7 @@ func (opts UnmarshalOptions) unmarshalCardDownload(data []byte) (*cardv1.CardDow
 	)
 
 	if len(data) < lenCardDownloadDriver {
-		return nil, fmt.Errorf("insufficient data for card download")
+		return nil, fmt.Errorf("insufficient data for card download: %w", dd.ErrTruncated)
 	}
 
 	var target cardv1.CardDownloadDriver
@@ -29,9 +29,10 @@ func (opts UnmarshalOptions) unmarshalCardDownload(data []byte) (*cardv1.CardDow
 	// Read timestamp (4 bytes)
 	timestamp, err := opts.UnmarshalTimeReal(data[:lenCardDownloadDriver])
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse timestamp: %w", err)
+		return nil, dd.WrapField("timestamp", 0, fmt.Errorf("failed to parse timestamp: %w", err))
 	}
 	target.SetTimestamp(timestamp)
+	opts.Layout.Field("timestamp", 0, lenCardDownloadDriver)
 
 	return &target, nil
 }
@@ -30,7 +30,7 @@ func (opts UnmarshalOptions) unmarshalControlActivityData(data []byte) (*cardv1.
 	)
 
 	if len(data) < lenCardControlActivityDataRecord {
-		return nil, fmt.Errorf("insufficient data for control activity data")
+		return nil, fmt.Errorf("insufficient data for control activity data: %w", dd.ErrTruncated)
 	}
 	var target cardv1.ControlActivityData
 	controlTime := binary.BigEndian.Uint32(data[1:5])
@@ -45,24 +45,26 @@ func (opts UnmarshalOptions) unmarshalControlActivityData(data []byte) (*cardv1.
 
 	// Read control type (1 byte)
 	if offset+1 > len(data) {
-		return nil, fmt.Errorf("insufficient data for control type")
+		return nil, dd.WrapField("control_type", offset, fmt.Errorf("insufficient data for control type: %w", dd.ErrTruncated))
 	}
 	controlType, err := opts.UnmarshalControlType(data[offset : offset+1])
 	if err != nil {
-		return nil, fmt.Errorf("failed to read control type: %w", err)
+		return nil, dd.WrapField("control_type", offset, fmt.Errorf("failed to read control type: %w", err))
 	}
 	target.SetControlType(controlType)
+	opts.Layout.Field("control_type", offset, 1)
 	offset++
 
 	// Read control time (4 bytes)
 	if offset+4 > len(data) {
-		return nil, fmt.Errorf("insufficient data for control time")
+		return nil, dd.WrapField("control_time", offset, fmt.Errorf("insufficient data for control time: %w", dd.ErrTruncated))
 	}
 	controlTimestamp, err2 := opts.UnmarshalTimeReal(data[offset : offset+4])
 	if err2 != nil {
-		return nil, fmt.Errorf("failed to parse control time: %w", err2)
+		return nil, dd.WrapField("control_time", offset, fmt.Errorf("failed to parse control time: %w", err2))
 	}
 	target.SetControlTime(controlTimestamp)
+	opts.Layout.Field("control_time", offset, 4)
 	offset += 4
 
 	// Read control card number (18 bytes) - this should be parsed as a proper FullCardNumberAndGeneration
@@ -74,12 +76,13 @@ func (opts UnmarshalOptions) unmarshalControlActivityData(data []byte) (*cardv1.
 
 	// Read the card number as IA5 string
 	if offset+18 > len(data) {
-		return nil, fmt.Errorf("insufficient data for control card number")
+		return nil, dd.WrapField("control_card_number", offset, fmt.Errorf("insufficient data for control card number: %w", dd.ErrTruncated))
 	}
 	cardNumberStr, err := opts.UnmarshalIa5StringValue(data[offset : offset+18])
 	if err != nil {
-		return nil, fmt.Errorf("failed to read control card number: %w", err)
+		return nil, dd.WrapField("control_card_number", offset, fmt.Errorf("failed to read control card number: %w", err))
 	}
+	opts.Layout.Field("control_card_number", offset, 18)
 	offset += 18
 
 	// Create driver identification with the card number
@@ -96,35 +99,38 @@ func (opts UnmarshalOptions) unmarshalControlActivityData(data []byte) (*cardv1.
 
 	// Read vehicle registration (15 bytes: 1 byte nation + 14 bytes number)
 	if offset+15 > len(data) {
-		return nil, fmt.Errorf("insufficient data for vehicle registration")
+		return nil, dd.WrapField("control_vehicle_registration", offset, fmt.Errorf("insufficient data for vehicle registration: %w", dd.ErrTruncated))
 	}
 	vehicleReg, err := opts.UnmarshalVehicleRegistration(data[offset : offset+15])
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse vehicle registration: %w", err)
+		return nil, dd.WrapField("control_vehicle_registration", offset, fmt.Errorf("failed to parse vehicle registration: %w", err))
 	}
+	opts.Layout.Field("control_vehicle_registration", offset, 15)
 	offset += 15
 	target.SetControlVehicleRegistration(vehicleReg)
 
 	// Read control download period begin (4 bytes)
 	if offset+4 > len(data) {
-		return nil, fmt.Errorf("insufficient data for control download period begin")
+		return nil, dd.WrapField("control_download_period_begin", offset, fmt.Errorf("insufficient data for control download period begin: %w", dd.ErrTruncated))
 	}
 	controlDownloadPeriodBegin, err3 := opts.UnmarshalTimeReal(data[offset : offset+4])
 	if err3 != nil {
-		return nil, fmt.Errorf("failed to parse control download period begin: %w", err3)
+		return nil, dd.WrapField("control_download_period_begin", offset, fmt.Errorf("failed to parse control download period begin: %w", err3))
 	}
 	target.SetControlDownloadPeriodBegin(controlDownloadPeriodBegin)
+	opts.Layout.Field("control_download_period_begin", offset, 4)
 	offset += 4
 
 	// Read control download period end (4 bytes)
 	if offset+4 > len(data) {
-		return nil, fmt.Errorf("insufficient data for control download period end")
+		return nil, dd.WrapField("control_download_period_end", offset, fmt.Errorf("insufficient data for control download period end: %w", dd.ErrTruncated))
 	}
 	controlDownloadPeriodEnd, err4 := opts.UnmarshalTimeReal(data[offset : offset+4])
 	if err4 != nil {
-		return nil, fmt.Errorf("failed to parse control download period end: %w", err4)
+		return nil, dd.WrapField("control_download_period_end", offset, fmt.Errorf("failed to parse control download period end: %w", err4))
 	}
 	target.SetControlDownloadPeriodEnd(controlDownloadPeriodEnd)
+	opts.Layout.Field("control_download_period_end", offset, 4)
 	// offset += 4 // Not needed as this is the last field
 
 	return &target, nil
@@ -24,30 +24,32 @@ func (opts UnmarshalOptions) unmarshalCurrentUsage(data []byte) (*cardv1.Current
 	)
 
 	if len(data) < lenCardCurrentUse {
-		return nil, fmt.Errorf("insufficient data for current usage")
+		return nil, fmt.Errorf("insufficient data for current usage: %w", dd.ErrTruncated)
 	}
 	var target cardv1.CurrentUsage
 	offset := 0
 
 	// Read session open time (4 bytes)
 	if offset+4 > len(data) {
-		return nil, fmt.Errorf("insufficient data for session open time")
+		return nil, dd.WrapField("session_open_time", offset, fmt.Errorf("insufficient data for session open time: %w", dd.ErrTruncated))
 	}
 	sessionOpenTime, err := opts.UnmarshalTimeReal(data[offset : offset+4])
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse session open time: %w", err)
+		return nil, dd.WrapField("session_open_time", offset, fmt.Errorf("failed to parse session open time: %w", err))
 	}
 	target.SetSessionOpenTime(sessionOpenTime)
+	opts.Layout.Field("session_open_time", offset, 4)
 	offset += 4
 
 	// Read session open vehicle registration (15 bytes: 1 byte nation + 14 bytes number)
 	if offset+15 > len(data) {
-		return nil, fmt.Errorf("insufficient data for vehicle registration")
+		return nil, dd.WrapField("session_open_vehicle", offset, fmt.Errorf("insufficient data for vehicle registration: %w", dd.ErrTruncated))
 	}
 	vehicleReg, err := opts.UnmarshalVehicleRegistration(data[offset : offset+15])
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse vehicle registration: %w", err)
+		return nil, dd.WrapField("session_open_vehicle", offset, fmt.Errorf("failed to parse vehicle registration: %w", err))
 	}
+	opts.Layout.Field("session_open_vehicle", offset, 15)
 	// offset += 15 // Not needed as this is the last field
 	target.SetSessionOpenVehicle(vehicleReg)
 	return &target, nil
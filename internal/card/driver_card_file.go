@@ -1,14 +1,18 @@
 package card
 
 import (
+	"cmp"
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 
+	"github.com/way-platform/tachograph-go/internal/dd"
 	"github.com/way-platform/tachograph-go/internal/security"
 	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
 	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
@@ -16,8 +20,93 @@ import (
 )
 
 // UnmarshalDriverCardFile parses driver card data into a protobuf DriverCardFile message.
+//
+// See [DriverCardOptions] if you need more control over the parsing.
 func UnmarshalDriverCardFile(rawCard *cardv1.RawCardFile) (*cardv1.DriverCardFile, error) {
-	return unmarshalDriverCardFile(rawCard)
+	output, _, err := DriverCardOptions{}.UnmarshalDriverCardFile(rawCard)
+	return output, err
+}
+
+// ScanDriverCardFile parses driver card data like [UnmarshalDriverCardFile],
+// but skips the records that fail to parse, and returns an error for each
+// skipped record.
+func ScanDriverCardFile(rawCard *cardv1.RawCardFile) (*cardv1.DriverCardFile, []*RecordError) {
+	output, _, errs := DriverCardOptions{}.ScanDriverCardFile(rawCard)
+	return output, errs
+}
+
+// DriverCardOptions configures the parsing of driver card files.
+type DriverCardOptions struct {
+	// ElementaryFiles restricts the parsing to these elementary files (EFs).
+	// If empty, all EFs are parsed.
+	//
+	// The application identification EFs are always parsed, since the card
+	// structure version they hold determines the layout of other EFs.
+	ElementaryFiles []cardv1.ElementaryFileType
+
+	// Version overrides the version read from the card structure version of
+	// the application identification EFs, if set.
+	Version ddv1.Version
+
+	// Layouts, if set, returns the layout in which to record the fields of
+	// the data EF at index i of the raw card file, or nil.
+	Layouts func(i int) *dd.Layout
+}
+
+// NewDriverCardParser returns a parser of the EFs of a driver card file.
+func (o DriverCardOptions) NewDriverCardParser() *DriverCardParser {
+	return &DriverCardParser{version: o.Version}
+}
+
+// UnmarshalDriverCardFile parses driver card data into a protobuf
+// DriverCardFile message.
+//
+// The records of the EFs that are not parsed are returned as a raw card file.
+func (o DriverCardOptions) UnmarshalDriverCardFile(rawCard *cardv1.RawCardFile) (*cardv1.DriverCardFile, *cardv1.RawCardFile, error) {
+	return o.unmarshalDriverCardFile(rawCard, nil)
+}
+
+// ScanDriverCardFile parses driver card data like
+// [DriverCardOptions.UnmarshalDriverCardFile], but skips the records that fail
+// to parse, and returns an error for each skipped record.
+//
+// The skipped records are returned, with the records of the EFs that are not
+// parsed, as a raw card file.
+func (o DriverCardOptions) ScanDriverCardFile(rawCard *cardv1.RawCardFile) (*cardv1.DriverCardFile, *cardv1.RawCardFile, []*RecordError) {
+	var errs []*RecordError
+	output, unparsed, _ := o.unmarshalDriverCardFile(rawCard, func(err *RecordError) {
+		errs = append(errs, err)
+	})
+	return output, unparsed, errs
+}
+
+// Parses reports whether an EF is parsed.
+func (o DriverCardOptions) Parses(file cardv1.ElementaryFileType) bool {
+	return len(o.ElementaryFiles) == 0 ||
+		file == cardv1.ElementaryFileType_EF_APPLICATION_IDENTIFICATION ||
+		slices.Contains(o.ElementaryFiles, file)
+}
+
+// RecordError is an error parsing a record of a raw card file.
+type RecordError struct {
+	// Index is the index of the record in the raw card file.
+	Index int
+	// Offset is the byte offset of the record in the card file.
+	Offset int
+	// Record is the record that failed to parse.
+	Record *cardv1.RawCardFile_Record
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("record %d (%v) at offset %d: %v", e.Index, e.Record.GetFile(), e.Offset, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *RecordError) Unwrap() error {
+	return e.Err
 }
 
 // MarshalDriverCardFile serializes a DriverCardFile into binary format.
@@ -43,581 +132,684 @@ func MarshalDriverCardFile(file *cardv1.DriverCardFile) ([]byte, error) {
 // The generation of each EF is determined by the TLV tag appendix byte:
 // - '00'/'01' indicates Gen1 (Tachograph DF)
 // - '02'/'03' indicates Gen2 (Tachograph_G2 DF)
-func unmarshalDriverCardFile(input *cardv1.RawCardFile) (*cardv1.DriverCardFile, error) {
-	// File-level version context (extracted from CardStructureVersion)
-	// This represents the card's overall version capability
-	var fileVersion ddv1.Version = ddv1.Version_VERSION_1
-	var output cardv1.DriverCardFile
-
-	// DF-level containers - we populate these as we encounter EFs
-	var tachographDF *cardv1.DriverCardFile_Tachograph
-	var tachographG2DF *cardv1.DriverCardFile_TachographG2
-
+//
+// If skip is nil, the first record that fails to parse fails the whole file
+// with a [RecordError].
+// Otherwise, the record and its signature are skipped and reported to skip.
+//
+// The records of the EFs that are not parsed, or that are skipped, are
+// returned as a raw card file.
+func (o DriverCardOptions) unmarshalDriverCardFile(input *cardv1.RawCardFile, skip func(*RecordError)) (*cardv1.DriverCardFile, *cardv1.RawCardFile, error) {
+	p := o.NewDriverCardParser()
+	var unparsed cardv1.RawCardFile
+	offsets := RecordOffsets(input)
 	for i := 0; i < len(input.GetRecords()); i++ {
 		record := input.GetRecords()[i]
-		if record.GetContentType() != cardv1.ContentType_DATA {
-			return nil, fmt.Errorf("record %d has unexpected content type", i)
-		}
-
-		// Use generation already parsed from the TLV tag appendix
-		// (set during unmarshalRawCardFileRecord)
-		efGeneration := record.GetGeneration()
-
-		// Create UnmarshalOptions with EF-specific generation and file-level version
-		opts := UnmarshalOptions{}
-		opts.Generation = efGeneration
-		opts.Version = fileVersion
-
+		index := i
 		var signature []byte
-		if i+1 < len(input.GetRecords()) {
+		if record.GetContentType() == cardv1.ContentType_DATA && i+1 < len(input.GetRecords()) {
 			nextRecord := input.GetRecords()[i+1]
 			if nextRecord.GetFile() == record.GetFile() && nextRecord.GetContentType() == cardv1.ContentType_SIGNATURE {
 				signature = nextRecord.GetValue()
 				i++
 			}
 		}
-
-		switch record.GetFile() {
-		case cardv1.ElementaryFileType_EF_ICC:
-			icc, err := opts.unmarshalIcc(record.GetValue())
-			if err != nil {
-				return nil, err
-			}
-			if signature != nil {
-				return nil, fmt.Errorf("unexpected signature for EF_ICC")
+		if !o.Parses(record.GetFile()) {
+			unparsed.SetRecords(append(unparsed.GetRecords(), input.GetRecords()[index:i+1]...))
+			continue
+		}
+		var layout *dd.Layout
+		if o.Layouts != nil {
+			layout = o.Layouts(index)
+		}
+		if err := p.parse(record, signature, layout); err != nil {
+			err := &RecordError{Index: index, Offset: offsets[index], Record: record, Err: err}
+			if skip == nil {
+				return nil, nil, err
 			}
-			output.SetIcc(icc)
+			skip(err)
+			unparsed.SetRecords(append(unparsed.GetRecords(), input.GetRecords()[index:i+1]...))
+		}
+	}
+	return p.file(), &unparsed, nil
+}
 
-		case cardv1.ElementaryFileType_EF_IC:
-			ic, err := opts.unmarshalIc(record.GetValue())
-			if err != nil {
-				return nil, err
-			}
-			if signature != nil {
-				return nil, fmt.Errorf("unexpected signature for EF_IC")
-			}
-			output.SetIc(ic)
+// DriverCardParser parses the elementary files (EFs) of a driver card file
+// one at a time.
+//
+// The layout of some EFs depends on the card structure version of the file,
+// which the parser reads from the application identification EFs, so the EFs
+// must be parsed in file order.
+type DriverCardParser struct {
+	// fileVersion is the file-level version context, extracted from the
+	// CardStructureVersion. It represents the card's overall version capability.
+	fileVersion ddv1.Version
+	// fileGeneration is the generation of the newest application
+	// identification EF parsed so far, if any.
+	fileGeneration ddv1.Generation
+	output         *cardv1.DriverCardFile
+
+	// version overrides the card structure version, if set.
+	version ddv1.Version
+
+	// versionErr is the error for an unsupported card structure version, which
+	// fails the EFs of versionErrGeneration that follow it.
+	versionErr           error
+	versionErrGeneration ddv1.Generation
 
-		case cardv1.ElementaryFileType_EF_IDENTIFICATION:
-			identification, err := opts.unmarshalIdentification(record.GetValue())
-			if err != nil {
-				return nil, err
-			}
-			if signature != nil {
-				identification.SetSignature(signature)
-			}
+	// DF-level containers - we populate these as we encounter EFs
+	tachographDF   *cardv1.DriverCardFile_Tachograph
+	tachographG2DF *cardv1.DriverCardFile_TachographG2
+}
 
-			// Route to appropriate DF based on generation
-			switch efGeneration {
-			case ddv1.Generation_GENERATION_1:
-				if tachographDF == nil {
-					tachographDF = &cardv1.DriverCardFile_Tachograph{}
-				}
-				tachographDF.SetIdentification(identification)
-			case ddv1.Generation_GENERATION_2:
-				if tachographG2DF == nil {
-					tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
-				}
-				tachographG2DF.SetIdentification(identification)
-			default:
-				return nil, fmt.Errorf("unexpected generation for EF_IDENTIFICATION: %v", efGeneration)
-			}
+// ParseRecord parses a data EF of a driver card file, and its signature if not
+// nil, into a driver card file holding only that EF.
+func (p *DriverCardParser) ParseRecord(record *cardv1.RawCardFile_Record, signature []byte) (*cardv1.DriverCardFile, error) {
+	p.output, p.tachographDF, p.tachographG2DF = nil, nil, nil
+	if err := p.parse(record, signature, nil); err != nil {
+		return nil, err
+	}
+	return p.file(), nil
+}
 
-		case cardv1.ElementaryFileType_EF_APPLICATION_IDENTIFICATION:
-			// Parse and route to appropriate DF based on generation
-			switch efGeneration {
-			case ddv1.Generation_GENERATION_1:
-				appId, err := opts.unmarshalApplicationIdentification(record.GetValue())
-				if err != nil {
-					return nil, err
-				}
-				if signature != nil {
-					appId.SetSignature(signature)
-				}
+// file returns the driver card file parsed so far.
+func (p *DriverCardParser) file() *cardv1.DriverCardFile {
+	if p.output == nil {
+		p.output = &cardv1.DriverCardFile{}
+	}
+	// Set the DFs on the output if they have content
+	if p.tachographDF != nil {
+		p.output.SetTachograph(p.tachographDF)
+	}
+	if p.tachographG2DF != nil {
+		p.output.SetTachographG2(p.tachographG2DF)
+	}
+	if p.fileGeneration != ddv1.Generation_GENERATION_UNSPECIFIED {
+		p.output.SetGeneration(p.fileGeneration)
+		p.output.SetVersion(p.fileVersion)
+	}
+	return p.output
+}
 
-				// Extract file-level version from CardStructureVersion for subsequent EFs
-				// Generation comes from TLV tag appendix, but version is file-level
-				if csv := appId.GetCardStructureVersion(); csv != nil {
-					var versionOpts UnmarshalOptions
-					versionOpts.SetFromCardStructureVersion(csv)
-					fileVersion = versionOpts.Version
-				}
+// setStructureVersion sets the file generation and version from the card
+// structure version of an application identification EF.
+//
+// An unsupported card structure version fails the EF, and the EFs of the same
+// generation that follow it, rather than parsing them with the wrong layout.
+// The card structure version is ignored if the version is overridden, and the
+// generation is then the generation of the EF.
+func (p *DriverCardParser) setStructureVersion(csv *ddv1.CardStructureVersion, efGeneration ddv1.Generation) error {
+	if p.version != ddv1.Version_VERSION_UNSPECIFIED {
+		p.fileGeneration, p.fileVersion = efGeneration, p.version
+		return nil
+	}
+	generation, version, err := dd.StructureVersion(csv.GetMajor(), csv.GetMinor())
+	if err != nil {
+		p.versionErr = fmt.Errorf("card structure version: %w", err)
+		p.versionErrGeneration = efGeneration
+		return p.versionErr
+	}
+	p.fileGeneration, p.fileVersion = generation, version
+	return nil
+}
 
-				if tachographDF == nil {
-					tachographDF = &cardv1.DriverCardFile_Tachograph{}
-				}
-				tachographDF.SetApplicationIdentification(appId)
+// parse parses a data EF and its signature into the driver card file,
+// recording the fields of the EF in layout if not nil.
+func (p *DriverCardParser) parse(record *cardv1.RawCardFile_Record, signature []byte, layout *dd.Layout) error {
+	if record.GetContentType() != cardv1.ContentType_DATA {
+		return fmt.Errorf("unexpected content type %v", record.GetContentType())
+	}
+	if p.output == nil {
+		p.output = &cardv1.DriverCardFile{}
+	}
+	if p.fileVersion == ddv1.Version_VERSION_UNSPECIFIED {
+		p.fileVersion = cmp.Or(p.version, ddv1.Version_VERSION_1)
+	}
 
-			case ddv1.Generation_GENERATION_2:
-				appIdG2, err := opts.unmarshalApplicationIdentificationG2(record.GetValue())
-				if err != nil {
-					return nil, err
-				}
-				if signature != nil {
-					appIdG2.SetSignature(signature)
-				}
+	// Use generation already parsed from the TLV tag appendix
+	// (set during UnmarshalRawRecord)
+	efGeneration := record.GetGeneration()
+	if p.versionErr != nil && efGeneration == p.versionErrGeneration {
+		return p.versionErr
+	}
 
-				// Extract file-level version from CardStructureVersion for subsequent EFs
-				if csv := appIdG2.GetCardStructureVersion(); csv != nil {
-					var versionOpts UnmarshalOptions
-					versionOpts.SetFromCardStructureVersion(csv)
-					fileVersion = versionOpts.Version
-				}
+	// Create UnmarshalOptions with EF-specific generation and file-level version
+	opts := UnmarshalOptions{}
+	opts.Generation = efGeneration
+	opts.Version = p.fileVersion
+	opts.Layout = layout
 
-				if tachographG2DF == nil {
-					tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
-				}
-				tachographG2DF.SetApplicationIdentification(appIdG2)
+	switch record.GetFile() {
+	case cardv1.ElementaryFileType_EF_ICC:
+		icc, err := opts.unmarshalIcc(record.GetValue())
+		if err != nil {
+			return err
+		}
+		if signature != nil {
+			return fmt.Errorf("unexpected signature for EF_ICC")
+		}
+		p.output.SetIcc(icc)
 
-			default:
-				return nil, fmt.Errorf("unexpected generation for EF_APPLICATION_IDENTIFICATION: %v", efGeneration)
-			}
+	case cardv1.ElementaryFileType_EF_IC:
+		ic, err := opts.unmarshalIc(record.GetValue())
+		if err != nil {
+			return err
+		}
+		if signature != nil {
+			return fmt.Errorf("unexpected signature for EF_IC")
+		}
+		p.output.SetIc(ic)
 
-		case cardv1.ElementaryFileType_EF_DRIVING_LICENCE_INFO:
-			drivingLicenceInfo, err := opts.unmarshalDrivingLicenceInfo(record.GetValue())
-			if err != nil {
-				return nil, err
-			}
-			if signature != nil {
-				drivingLicenceInfo.SetSignature(signature)
-			}
+	case cardv1.ElementaryFileType_EF_IDENTIFICATION:
+		identification, err := opts.unmarshalIdentification(record.GetValue())
+		if err != nil {
+			return err
+		}
+		if signature != nil {
+			identification.SetSignature(signature)
+		}
 
-			// Route to appropriate DF based on generation
-			switch efGeneration {
-			case ddv1.Generation_GENERATION_1:
-				if tachographDF == nil {
-					tachographDF = &cardv1.DriverCardFile_Tachograph{}
-				}
-				tachographDF.SetDrivingLicenceInfo(drivingLicenceInfo)
-			case ddv1.Generation_GENERATION_2:
-				if tachographG2DF == nil {
-					tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
-				}
-				tachographG2DF.SetDrivingLicenceInfo(drivingLicenceInfo)
-			default:
-				return nil, fmt.Errorf("unexpected generation for EF_DRIVING_LICENCE_INFO: %v", efGeneration)
+		// Route to appropriate DF based on generation
+		switch efGeneration {
+		case ddv1.Generation_GENERATION_1:
+			if p.tachographDF == nil {
+				p.tachographDF = &cardv1.DriverCardFile_Tachograph{}
+			}
+			p.tachographDF.SetIdentification(identification)
+		case ddv1.Generation_GENERATION_2:
+			if p.tachographG2DF == nil {
+				p.tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
 			}
+			p.tachographG2DF.SetIdentification(identification)
+		default:
+			return fmt.Errorf("unexpected generation for EF_IDENTIFICATION: %v", efGeneration)
+		}
 
-		case cardv1.ElementaryFileType_EF_EVENTS_DATA:
-			eventsData, err := opts.unmarshalEventsData(record.GetValue())
+	case cardv1.ElementaryFileType_EF_APPLICATION_IDENTIFICATION:
+		// Parse and route to appropriate DF based on generation
+		switch efGeneration {
+		case ddv1.Generation_GENERATION_1:
+			appId, err := opts.unmarshalApplicationIdentification(record.GetValue())
 			if err != nil {
-				return nil, err
+				return err
 			}
 			if signature != nil {
-				eventsData.SetSignature(signature)
+				appId.SetSignature(signature)
 			}
 
-			// Route to appropriate DF based on generation
-			switch efGeneration {
-			case ddv1.Generation_GENERATION_1:
-				if tachographDF == nil {
-					tachographDF = &cardv1.DriverCardFile_Tachograph{}
+			// Extract file-level version from CardStructureVersion for subsequent EFs
+			// Generation comes from TLV tag appendix, but version is file-level
+			if csv := appId.GetCardStructureVersion(); csv != nil {
+				if err := p.setStructureVersion(csv, efGeneration); err != nil {
+					return err
 				}
-				tachographDF.SetEventsData(eventsData)
-			case ddv1.Generation_GENERATION_2:
-				if tachographG2DF == nil {
-					tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
-				}
-				tachographG2DF.SetEventsData(eventsData)
-			default:
-				return nil, fmt.Errorf("unexpected generation for EF_EVENTS_DATA: %v", efGeneration)
 			}
 
-		case cardv1.ElementaryFileType_EF_FAULTS_DATA:
-			faultsData, err := opts.unmarshalFaultsData(record.GetValue())
-			if err != nil {
-				return nil, err
-			}
-			if signature != nil {
-				faultsData.SetSignature(signature)
+			if p.tachographDF == nil {
+				p.tachographDF = &cardv1.DriverCardFile_Tachograph{}
 			}
+			p.tachographDF.SetApplicationIdentification(appId)
 
-			// Route to appropriate DF based on generation
-			switch efGeneration {
-			case ddv1.Generation_GENERATION_1:
-				if tachographDF == nil {
-					tachographDF = &cardv1.DriverCardFile_Tachograph{}
-				}
-				tachographDF.SetFaultsData(faultsData)
-			case ddv1.Generation_GENERATION_2:
-				if tachographG2DF == nil {
-					tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
-				}
-				tachographG2DF.SetFaultsData(faultsData)
-			default:
-				return nil, fmt.Errorf("unexpected generation for EF_FAULTS_DATA: %v", efGeneration)
-			}
-
-		case cardv1.ElementaryFileType_EF_DRIVER_ACTIVITY_DATA:
-			activityData, err := opts.unmarshalDriverActivityData(record.GetValue())
+		case ddv1.Generation_GENERATION_2:
+			appIdG2, err := opts.unmarshalApplicationIdentificationG2(record.GetValue())
 			if err != nil {
-				return nil, err
+				return err
 			}
 			if signature != nil {
-				activityData.SetSignature(signature)
+				appIdG2.SetSignature(signature)
 			}
 
-			// Route to appropriate DF based on generation
-			switch efGeneration {
-			case ddv1.Generation_GENERATION_1:
-				if tachographDF == nil {
-					tachographDF = &cardv1.DriverCardFile_Tachograph{}
-				}
-				tachographDF.SetDriverActivityData(activityData)
-			case ddv1.Generation_GENERATION_2:
-				if tachographG2DF == nil {
-					tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
+			// Extract file-level version from CardStructureVersion for subsequent EFs
+			if csv := appIdG2.GetCardStructureVersion(); csv != nil {
+				if err := p.setStructureVersion(csv, efGeneration); err != nil {
+					return err
 				}
-				tachographG2DF.SetDriverActivityData(activityData)
-			default:
-				return nil, fmt.Errorf("unexpected generation for EF_DRIVER_ACTIVITY_DATA: %v", efGeneration)
 			}
 
-		case cardv1.ElementaryFileType_EF_VEHICLES_USED:
-			// Parse and route to appropriate DF based on generation
-			switch efGeneration {
-			case ddv1.Generation_GENERATION_1:
-				vehiclesUsed, err := opts.unmarshalVehiclesUsed(record.GetValue())
-				if err != nil {
-					return nil, err
-				}
-				if signature != nil {
-					vehiclesUsed.SetSignature(signature)
-				}
-				if tachographDF == nil {
-					tachographDF = &cardv1.DriverCardFile_Tachograph{}
-				}
-				tachographDF.SetVehiclesUsed(vehiclesUsed)
-
-			case ddv1.Generation_GENERATION_2:
-				vehiclesUsedG2, err := opts.unmarshalVehiclesUsedG2(record.GetValue())
-				if err != nil {
-					return nil, err
-				}
-				if signature != nil {
-					vehiclesUsedG2.SetSignature(signature)
-				}
-				if tachographG2DF == nil {
-					tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
-				}
-				tachographG2DF.SetVehiclesUsed(vehiclesUsedG2)
-
-			default:
-				return nil, fmt.Errorf("unexpected generation for EF_VEHICLES_USED: %v", efGeneration)
+			if p.tachographG2DF == nil {
+				p.tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
 			}
+			p.tachographG2DF.SetApplicationIdentification(appIdG2)
 
-		case cardv1.ElementaryFileType_EF_PLACES:
-			// Parse and route to appropriate DF based on generation
-			switch efGeneration {
-			case ddv1.Generation_GENERATION_1:
-				places, err := opts.unmarshalPlaces(record.GetValue())
-				if err != nil {
-					return nil, err
-				}
-				if signature != nil {
-					places.SetSignature(signature)
-				}
-				if tachographDF == nil {
-					tachographDF = &cardv1.DriverCardFile_Tachograph{}
-				}
-				tachographDF.SetPlaces(places)
-
-			case ddv1.Generation_GENERATION_2:
-				placesG2, err := opts.unmarshalPlacesG2(record.GetValue())
-				if err != nil {
-					return nil, err
-				}
-				if signature != nil {
-					placesG2.SetSignature(signature)
-				}
-				if tachographG2DF == nil {
-					tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
-				}
-				tachographG2DF.SetPlaces(placesG2)
+		default:
+			return fmt.Errorf("unexpected generation for EF_APPLICATION_IDENTIFICATION: %v", efGeneration)
+		}
 
-			default:
-				return nil, fmt.Errorf("unexpected generation for EF_PLACES: %v", efGeneration)
-			}
+	case cardv1.ElementaryFileType_EF_DRIVING_LICENCE_INFO:
+		drivingLicenceInfo, err := opts.unmarshalDrivingLicenceInfo(record.GetValue())
+		if err != nil {
+			return err
+		}
+		if signature != nil {
+			drivingLicenceInfo.SetSignature(signature)
+		}
 
-		case cardv1.ElementaryFileType_EF_CURRENT_USAGE:
-			currentUsage, err := opts.unmarshalCurrentUsage(record.GetValue())
-			if err != nil {
-				return nil, err
+		// Route to appropriate DF based on generation
+		switch efGeneration {
+		case ddv1.Generation_GENERATION_1:
+			if p.tachographDF == nil {
+				p.tachographDF = &cardv1.DriverCardFile_Tachograph{}
 			}
-			if signature != nil {
-				currentUsage.SetSignature(signature)
+			p.tachographDF.SetDrivingLicenceInfo(drivingLicenceInfo)
+		case ddv1.Generation_GENERATION_2:
+			if p.tachographG2DF == nil {
+				p.tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
 			}
+			p.tachographG2DF.SetDrivingLicenceInfo(drivingLicenceInfo)
+		default:
+			return fmt.Errorf("unexpected generation for EF_DRIVING_LICENCE_INFO: %v", efGeneration)
+		}
 
-			// Route to appropriate DF based on generation
-			switch efGeneration {
-			case ddv1.Generation_GENERATION_1:
-				if tachographDF == nil {
-					tachographDF = &cardv1.DriverCardFile_Tachograph{}
-				}
-				tachographDF.SetCurrentUsage(currentUsage)
-			case ddv1.Generation_GENERATION_2:
-				if tachographG2DF == nil {
-					tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
-				}
-				tachographG2DF.SetCurrentUsage(currentUsage)
-			default:
-				return nil, fmt.Errorf("unexpected generation for EF_CURRENT_USAGE: %v", efGeneration)
-			}
+	case cardv1.ElementaryFileType_EF_EVENTS_DATA:
+		eventsData, err := opts.unmarshalEventsData(record.GetValue())
+		if err != nil {
+			return err
+		}
+		if signature != nil {
+			eventsData.SetSignature(signature)
+		}
 
-		case cardv1.ElementaryFileType_EF_CONTROL_ACTIVITY_DATA:
-			controlActivity, err := opts.unmarshalControlActivityData(record.GetValue())
-			if err != nil {
-				return nil, err
+		// Route to appropriate DF based on generation
+		switch efGeneration {
+		case ddv1.Generation_GENERATION_1:
+			if p.tachographDF == nil {
+				p.tachographDF = &cardv1.DriverCardFile_Tachograph{}
 			}
-			if signature != nil {
-				controlActivity.SetSignature(signature)
+			p.tachographDF.SetEventsData(eventsData)
+		case ddv1.Generation_GENERATION_2:
+			if p.tachographG2DF == nil {
+				p.tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
 			}
+			p.tachographG2DF.SetEventsData(eventsData)
+		default:
+			return fmt.Errorf("unexpected generation for EF_EVENTS_DATA: %v", efGeneration)
+		}
 
-			// Route to appropriate DF based on generation
-			switch efGeneration {
-			case ddv1.Generation_GENERATION_1:
-				if tachographDF == nil {
-					tachographDF = &cardv1.DriverCardFile_Tachograph{}
-				}
-				tachographDF.SetControlActivityData(controlActivity)
-			case ddv1.Generation_GENERATION_2:
-				if tachographG2DF == nil {
-					tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
-				}
-				tachographG2DF.SetControlActivityData(controlActivity)
-			default:
-				return nil, fmt.Errorf("unexpected generation for EF_CONTROL_ACTIVITY_DATA: %v", efGeneration)
-			}
-
-		case cardv1.ElementaryFileType_EF_SPECIFIC_CONDITIONS:
-			// Parse and route to appropriate DF based on generation
-			switch efGeneration {
-			case ddv1.Generation_GENERATION_1:
-				specificConditions, err := opts.unmarshalSpecificConditions(record.GetValue())
-				if err != nil {
-					return nil, err
-				}
-				if signature != nil {
-					specificConditions.SetSignature(signature)
-				}
-
-				if tachographDF == nil {
-					tachographDF = &cardv1.DriverCardFile_Tachograph{}
-				}
-				tachographDF.SetSpecificConditions(specificConditions)
+	case cardv1.ElementaryFileType_EF_FAULTS_DATA:
+		faultsData, err := opts.unmarshalFaultsData(record.GetValue())
+		if err != nil {
+			return err
+		}
+		if signature != nil {
+			faultsData.SetSignature(signature)
+		}
 
-			case ddv1.Generation_GENERATION_2:
-				specificConditionsG2, err := opts.unmarshalSpecificConditionsG2(record.GetValue())
-				if err != nil {
-					return nil, err
-				}
-				if signature != nil {
-					specificConditionsG2.SetSignature(signature)
-				}
+		// Route to appropriate DF based on generation
+		switch efGeneration {
+		case ddv1.Generation_GENERATION_1:
+			if p.tachographDF == nil {
+				p.tachographDF = &cardv1.DriverCardFile_Tachograph{}
+			}
+			p.tachographDF.SetFaultsData(faultsData)
+		case ddv1.Generation_GENERATION_2:
+			if p.tachographG2DF == nil {
+				p.tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
+			}
+			p.tachographG2DF.SetFaultsData(faultsData)
+		default:
+			return fmt.Errorf("unexpected generation for EF_FAULTS_DATA: %v", efGeneration)
+		}
 
-				if tachographG2DF == nil {
-					tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
-				}
-				tachographG2DF.SetSpecificConditions(specificConditionsG2)
+	case cardv1.ElementaryFileType_EF_DRIVER_ACTIVITY_DATA:
+		activityData, err := opts.unmarshalDriverActivityData(record.GetValue())
+		if err != nil {
+			return err
+		}
+		if signature != nil {
+			activityData.SetSignature(signature)
+		}
 
-			default:
-				return nil, fmt.Errorf("unexpected generation for EF_SPECIFIC_CONDITIONS: %v", efGeneration)
+		// Route to appropriate DF based on generation
+		switch efGeneration {
+		case ddv1.Generation_GENERATION_1:
+			if p.tachographDF == nil {
+				p.tachographDF = &cardv1.DriverCardFile_Tachograph{}
 			}
+			p.tachographDF.SetDriverActivityData(activityData)
+		case ddv1.Generation_GENERATION_2:
+			if p.tachographG2DF == nil {
+				p.tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
+			}
+			p.tachographG2DF.SetDriverActivityData(activityData)
+		default:
+			return fmt.Errorf("unexpected generation for EF_DRIVER_ACTIVITY_DATA: %v", efGeneration)
+		}
 
-		case cardv1.ElementaryFileType_EF_CARD_DOWNLOAD_DRIVER:
-			cardDownload, err := opts.unmarshalCardDownload(record.GetValue())
+	case cardv1.ElementaryFileType_EF_VEHICLES_USED:
+		// Parse and route to appropriate DF based on generation
+		switch efGeneration {
+		case ddv1.Generation_GENERATION_1:
+			vehiclesUsed, err := opts.unmarshalVehiclesUsed(record.GetValue())
 			if err != nil {
-				return nil, err
+				return err
 			}
 			if signature != nil {
-				return nil, fmt.Errorf("unexpected signature for EF_CARD_DOWNLOAD_DRIVER")
+				vehiclesUsed.SetSignature(signature)
 			}
-
-			// Route to appropriate DF based on generation
-			switch efGeneration {
-			case ddv1.Generation_GENERATION_1:
-				if tachographDF == nil {
-					tachographDF = &cardv1.DriverCardFile_Tachograph{}
-				}
-				tachographDF.SetCardDownload(cardDownload)
-			case ddv1.Generation_GENERATION_2:
-				if tachographG2DF == nil {
-					tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
-				}
-				tachographG2DF.SetCardDownload(cardDownload)
-			default:
-				return nil, fmt.Errorf("unexpected generation for EF_CARD_DOWNLOAD_DRIVER: %v", efGeneration)
+			if p.tachographDF == nil {
+				p.tachographDF = &cardv1.DriverCardFile_Tachograph{}
 			}
+			p.tachographDF.SetVehiclesUsed(vehiclesUsed)
 
-		case cardv1.ElementaryFileType_EF_VEHICLE_UNITS_USED:
-			vehicleUnits, err := opts.unmarshalVehicleUnitsUsed(record.GetValue())
+		case ddv1.Generation_GENERATION_2:
+			vehiclesUsedG2, err := opts.unmarshalVehiclesUsedG2(record.GetValue())
 			if err != nil {
-				return nil, err
+				return err
 			}
 			if signature != nil {
-				vehicleUnits.SetSignature(signature)
+				vehiclesUsedG2.SetSignature(signature)
 			}
-
-			// Only Gen2
-			if tachographG2DF == nil {
-				tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
+			if p.tachographG2DF == nil {
+				p.tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
 			}
-			tachographG2DF.SetVehicleUnitsUsed(vehicleUnits)
+			p.tachographG2DF.SetVehiclesUsed(vehiclesUsedG2)
 
-		case cardv1.ElementaryFileType_EF_GNSS_PLACES:
-			gnssPlaces, err := opts.unmarshalGnssPlaces(record.GetValue())
+		default:
+			return fmt.Errorf("unexpected generation for EF_VEHICLES_USED: %v", efGeneration)
+		}
+
+	case cardv1.ElementaryFileType_EF_PLACES:
+		// Parse and route to appropriate DF based on generation
+		switch efGeneration {
+		case ddv1.Generation_GENERATION_1:
+			places, err := opts.unmarshalPlaces(record.GetValue())
 			if err != nil {
-				return nil, err
+				return err
 			}
 			if signature != nil {
-				gnssPlaces.SetSignature(signature)
+				places.SetSignature(signature)
 			}
-
-			// Only Gen2
-			if tachographG2DF == nil {
-				tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
+			if p.tachographDF == nil {
+				p.tachographDF = &cardv1.DriverCardFile_Tachograph{}
 			}
-			tachographG2DF.SetGnssPlaces(gnssPlaces)
+			p.tachographDF.SetPlaces(places)
 
-		case cardv1.ElementaryFileType_EF_APPLICATION_IDENTIFICATION_V2:
-			appIdV2, err := opts.unmarshalApplicationIdentificationV2(record.GetValue())
+		case ddv1.Generation_GENERATION_2:
+			placesG2, err := opts.unmarshalPlacesG2(record.GetValue())
 			if err != nil {
-				return nil, err
+				return err
 			}
 			if signature != nil {
-				appIdV2.SetSignature(signature)
+				placesG2.SetSignature(signature)
 			}
-
-			// Only Gen2
-			if tachographG2DF == nil {
-				tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
+			if p.tachographG2DF == nil {
+				p.tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
 			}
-			tachographG2DF.SetApplicationIdentificationV2(appIdV2)
+			p.tachographG2DF.SetPlaces(placesG2)
 
-		case cardv1.ElementaryFileType_EF_CARD_CERTIFICATE:
-			// Gen1: Card authentication certificate
-			// Only appears in Gen1 DF (Tachograph)
-			if efGeneration != ddv1.Generation_GENERATION_1 {
-				return nil, fmt.Errorf("EF_CARD_CERTIFICATE should only appear in Gen1 DF, got generation: %v", efGeneration)
-			}
-			if tachographDF == nil {
-				tachographDF = &cardv1.DriverCardFile_Tachograph{}
-			}
-			rsaCert, err := security.UnmarshalRsaCertificate(record.GetValue())
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse EF_CARD_CERTIFICATE: %w", err)
+		default:
+			return fmt.Errorf("unexpected generation for EF_PLACES: %v", efGeneration)
+		}
+
+	case cardv1.ElementaryFileType_EF_CURRENT_USAGE:
+		currentUsage, err := opts.unmarshalCurrentUsage(record.GetValue())
+		if err != nil {
+			return err
+		}
+		if signature != nil {
+			currentUsage.SetSignature(signature)
+		}
+
+		// Route to appropriate DF based on generation
+		switch efGeneration {
+		case ddv1.Generation_GENERATION_1:
+			if p.tachographDF == nil {
+				p.tachographDF = &cardv1.DriverCardFile_Tachograph{}
 			}
-			cert := &cardv1.CardCertificate{}
-			cert.SetRsaCertificate(rsaCert)
-			tachographDF.SetCardCertificate(cert)
-			if signature != nil {
-				return nil, fmt.Errorf("unexpected signature for EF_CARD_CERTIFICATE")
+			p.tachographDF.SetCurrentUsage(currentUsage)
+		case ddv1.Generation_GENERATION_2:
+			if p.tachographG2DF == nil {
+				p.tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
 			}
+			p.tachographG2DF.SetCurrentUsage(currentUsage)
+		default:
+			return fmt.Errorf("unexpected generation for EF_CURRENT_USAGE: %v", efGeneration)
+		}
+
+	case cardv1.ElementaryFileType_EF_CONTROL_ACTIVITY_DATA:
+		controlActivity, err := opts.unmarshalControlActivityData(record.GetValue())
+		if err != nil {
+			return err
+		}
+		if signature != nil {
+			controlActivity.SetSignature(signature)
+		}
 
-		case cardv1.ElementaryFileType_EF_CARD_MA_CERTIFICATE:
-			// Gen2: Card mutual authentication certificate (replaces Gen1 Card_Certificate)
-			// Only appears in Gen2 DF (Tachograph_G2)
-			if efGeneration != ddv1.Generation_GENERATION_2 {
-				return nil, fmt.Errorf("EF_CARD_MA_CERTIFICATE should only appear in Gen2 DF, got generation: %v", efGeneration)
+		// Route to appropriate DF based on generation
+		switch efGeneration {
+		case ddv1.Generation_GENERATION_1:
+			if p.tachographDF == nil {
+				p.tachographDF = &cardv1.DriverCardFile_Tachograph{}
 			}
-			if tachographG2DF == nil {
-				tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
+			p.tachographDF.SetControlActivityData(controlActivity)
+		case ddv1.Generation_GENERATION_2:
+			if p.tachographG2DF == nil {
+				p.tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
 			}
-			eccCert, err := security.UnmarshalEccCertificate(record.GetValue())
+			p.tachographG2DF.SetControlActivityData(controlActivity)
+		default:
+			return fmt.Errorf("unexpected generation for EF_CONTROL_ACTIVITY_DATA: %v", efGeneration)
+		}
+
+	case cardv1.ElementaryFileType_EF_SPECIFIC_CONDITIONS:
+		// Parse and route to appropriate DF based on generation
+		switch efGeneration {
+		case ddv1.Generation_GENERATION_1:
+			specificConditions, err := opts.unmarshalSpecificConditions(record.GetValue())
 			if err != nil {
-				return nil, fmt.Errorf("failed to parse EF_CARD_MA_CERTIFICATE: %w", err)
+				return err
 			}
-			cert := &cardv1.CardMaCertificate{}
-			cert.SetEccCertificate(eccCert)
-			tachographG2DF.SetCardMaCertificate(cert)
 			if signature != nil {
-				return nil, fmt.Errorf("unexpected signature for EF_CARD_MA_CERTIFICATE")
+				specificConditions.SetSignature(signature)
 			}
 
-		case cardv1.ElementaryFileType_EF_CARD_SIGN_CERTIFICATE:
-			// Gen2: Card signature certificate
-			// Only appears in Gen2 DF (Tachograph_G2) on driver and workshop cards
-			if efGeneration != ddv1.Generation_GENERATION_2 {
-				return nil, fmt.Errorf("EF_CARD_SIGN_CERTIFICATE should only appear in Gen2 DF, got generation: %v", efGeneration)
-			}
-			if tachographG2DF == nil {
-				tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
+			if p.tachographDF == nil {
+				p.tachographDF = &cardv1.DriverCardFile_Tachograph{}
 			}
-			eccCert, err := security.UnmarshalEccCertificate(record.GetValue())
+			p.tachographDF.SetSpecificConditions(specificConditions)
+
+		case ddv1.Generation_GENERATION_2:
+			specificConditionsG2, err := opts.unmarshalSpecificConditionsG2(record.GetValue())
 			if err != nil {
-				return nil, fmt.Errorf("failed to parse EF_CARD_SIGN_CERTIFICATE: %w", err)
+				return err
 			}
-			cert := &cardv1.CardSignCertificate{}
-			cert.SetEccCertificate(eccCert)
-			tachographG2DF.SetCardSignCertificate(cert)
 			if signature != nil {
-				return nil, fmt.Errorf("unexpected signature for EF_CARD_SIGN_CERTIFICATE")
+				specificConditionsG2.SetSignature(signature)
 			}
 
-		case cardv1.ElementaryFileType_EF_CA_CERTIFICATE:
-			// CA certificate - present in both Gen1 and Gen2
-			// Route to appropriate DF based on generation
-			switch efGeneration {
-			case ddv1.Generation_GENERATION_1:
-				if tachographDF == nil {
-					tachographDF = &cardv1.DriverCardFile_Tachograph{}
-				}
-				rsaCert, err := security.UnmarshalRsaCertificate(record.GetValue())
-				if err != nil {
-					return nil, fmt.Errorf("failed to parse EF_CA_CERTIFICATE (Gen1): %w", err)
-				}
-				cert := &cardv1.CaCertificate{}
-				cert.SetRsaCertificate(rsaCert)
-				tachographDF.SetCaCertificate(cert)
-			case ddv1.Generation_GENERATION_2:
-				if tachographG2DF == nil {
-					tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
-				}
-				eccCert, err := security.UnmarshalEccCertificate(record.GetValue())
-				if err != nil {
-					return nil, fmt.Errorf("failed to parse EF_CA_CERTIFICATE (Gen2): %w", err)
-				}
-				cert := &cardv1.CaCertificateG2{}
-				cert.SetEccCertificate(eccCert)
-				tachographG2DF.SetCaCertificate(cert)
-			default:
-				return nil, fmt.Errorf("unexpected generation for EF_CA_CERTIFICATE: %v", efGeneration)
+			if p.tachographG2DF == nil {
+				p.tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
 			}
-			if signature != nil {
-				return nil, fmt.Errorf("unexpected signature for EF_CA_CERTIFICATE")
+			p.tachographG2DF.SetSpecificConditions(specificConditionsG2)
+
+		default:
+			return fmt.Errorf("unexpected generation for EF_SPECIFIC_CONDITIONS: %v", efGeneration)
+		}
+
+	case cardv1.ElementaryFileType_EF_CARD_DOWNLOAD_DRIVER:
+		cardDownload, err := opts.unmarshalCardDownload(record.GetValue())
+		if err != nil {
+			return err
+		}
+		if signature != nil {
+			return fmt.Errorf("unexpected signature for EF_CARD_DOWNLOAD_DRIVER")
+		}
+
+		// Route to appropriate DF based on generation
+		switch efGeneration {
+		case ddv1.Generation_GENERATION_1:
+			if p.tachographDF == nil {
+				p.tachographDF = &cardv1.DriverCardFile_Tachograph{}
+			}
+			p.tachographDF.SetCardDownload(cardDownload)
+		case ddv1.Generation_GENERATION_2:
+			if p.tachographG2DF == nil {
+				p.tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
 			}
+			p.tachographG2DF.SetCardDownload(cardDownload)
+		default:
+			return fmt.Errorf("unexpected generation for EF_CARD_DOWNLOAD_DRIVER: %v", efGeneration)
+		}
+
+	case cardv1.ElementaryFileType_EF_VEHICLE_UNITS_USED:
+		vehicleUnits, err := opts.unmarshalVehicleUnitsUsed(record.GetValue())
+		if err != nil {
+			return err
+		}
+		if signature != nil {
+			vehicleUnits.SetSignature(signature)
+		}
+
+		// Only Gen2
+		if p.tachographG2DF == nil {
+			p.tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
+		}
+		p.tachographG2DF.SetVehicleUnitsUsed(vehicleUnits)
+
+	case cardv1.ElementaryFileType_EF_GNSS_PLACES:
+		gnssPlaces, err := opts.unmarshalGnssPlaces(record.GetValue())
+		if err != nil {
+			return err
+		}
+		if signature != nil {
+			gnssPlaces.SetSignature(signature)
+		}
+
+		// Only Gen2
+		if p.tachographG2DF == nil {
+			p.tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
+		}
+		p.tachographG2DF.SetGnssPlaces(gnssPlaces)
+
+	case cardv1.ElementaryFileType_EF_APPLICATION_IDENTIFICATION_V2:
+		appIdV2, err := opts.unmarshalApplicationIdentificationV2(record.GetValue())
+		if err != nil {
+			return err
+		}
+		if signature != nil {
+			appIdV2.SetSignature(signature)
+		}
+
+		// Only Gen2
+		if p.tachographG2DF == nil {
+			p.tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
+		}
+		p.tachographG2DF.SetApplicationIdentificationV2(appIdV2)
+
+	case cardv1.ElementaryFileType_EF_CARD_CERTIFICATE:
+		// Gen1: Card authentication certificate
+		// Only appears in Gen1 DF (Tachograph)
+		if efGeneration != ddv1.Generation_GENERATION_1 {
+			return fmt.Errorf("EF_CARD_CERTIFICATE should only appear in Gen1 DF, got generation: %v", efGeneration)
+		}
+		if p.tachographDF == nil {
+			p.tachographDF = &cardv1.DriverCardFile_Tachograph{}
+		}
+		rsaCert, err := security.UnmarshalRsaCertificate(record.GetValue())
+		if err != nil {
+			return fmt.Errorf("failed to parse EF_CARD_CERTIFICATE: %w", err)
+		}
+		cert := &cardv1.CardCertificate{}
+		cert.SetRsaCertificate(rsaCert)
+		p.tachographDF.SetCardCertificate(cert)
+		if signature != nil {
+			return fmt.Errorf("unexpected signature for EF_CARD_CERTIFICATE")
+		}
+
+	case cardv1.ElementaryFileType_EF_CARD_MA_CERTIFICATE:
+		// Gen2: Card mutual authentication certificate (replaces Gen1 Card_Certificate)
+		// Only appears in Gen2 DF (Tachograph_G2)
+		if efGeneration != ddv1.Generation_GENERATION_2 {
+			return fmt.Errorf("EF_CARD_MA_CERTIFICATE should only appear in Gen2 DF, got generation: %v", efGeneration)
+		}
+		if p.tachographG2DF == nil {
+			p.tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
+		}
+		eccCert, err := security.UnmarshalEccCertificate(record.GetValue())
+		if err != nil {
+			return fmt.Errorf("failed to parse EF_CARD_MA_CERTIFICATE: %w", err)
+		}
+		cert := &cardv1.CardMaCertificate{}
+		cert.SetEccCertificate(eccCert)
+		p.tachographG2DF.SetCardMaCertificate(cert)
+		if signature != nil {
+			return fmt.Errorf("unexpected signature for EF_CARD_MA_CERTIFICATE")
+		}
 
-		case cardv1.ElementaryFileType_EF_LINK_CERTIFICATE:
-			// Gen2: Link certificate for CA chaining
-			// Only appears in Gen2 DF (Tachograph_G2)
-			if efGeneration != ddv1.Generation_GENERATION_2 {
-				return nil, fmt.Errorf("EF_LINK_CERTIFICATE should only appear in Gen2 DF, got generation: %v", efGeneration)
+	case cardv1.ElementaryFileType_EF_CARD_SIGN_CERTIFICATE:
+		// Gen2: Card signature certificate
+		// Only appears in Gen2 DF (Tachograph_G2) on driver and workshop cards
+		if efGeneration != ddv1.Generation_GENERATION_2 {
+			return fmt.Errorf("EF_CARD_SIGN_CERTIFICATE should only appear in Gen2 DF, got generation: %v", efGeneration)
+		}
+		if p.tachographG2DF == nil {
+			p.tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
+		}
+		eccCert, err := security.UnmarshalEccCertificate(record.GetValue())
+		if err != nil {
+			return fmt.Errorf("failed to parse EF_CARD_SIGN_CERTIFICATE: %w", err)
+		}
+		cert := &cardv1.CardSignCertificate{}
+		cert.SetEccCertificate(eccCert)
+		p.tachographG2DF.SetCardSignCertificate(cert)
+		if signature != nil {
+			return fmt.Errorf("unexpected signature for EF_CARD_SIGN_CERTIFICATE")
+		}
+
+	case cardv1.ElementaryFileType_EF_CA_CERTIFICATE:
+		// CA certificate - present in both Gen1 and Gen2
+		// Route to appropriate DF based on generation
+		switch efGeneration {
+		case ddv1.Generation_GENERATION_1:
+			if p.tachographDF == nil {
+				p.tachographDF = &cardv1.DriverCardFile_Tachograph{}
+			}
+			rsaCert, err := security.UnmarshalRsaCertificate(record.GetValue())
+			if err != nil {
+				return fmt.Errorf("failed to parse EF_CA_CERTIFICATE (Gen1): %w", err)
 			}
-			if tachographG2DF == nil {
-				tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
+			cert := &cardv1.CaCertificate{}
+			cert.SetRsaCertificate(rsaCert)
+			p.tachographDF.SetCaCertificate(cert)
+		case ddv1.Generation_GENERATION_2:
+			if p.tachographG2DF == nil {
+				p.tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
 			}
 			eccCert, err := security.UnmarshalEccCertificate(record.GetValue())
 			if err != nil {
-				return nil, fmt.Errorf("failed to parse EF_LINK_CERTIFICATE: %w", err)
+				return fmt.Errorf("failed to parse EF_CA_CERTIFICATE (Gen2): %w", err)
 			}
-			cert := &cardv1.LinkCertificate{}
+			cert := &cardv1.CaCertificateG2{}
 			cert.SetEccCertificate(eccCert)
-			tachographG2DF.SetLinkCertificate(cert)
-			if signature != nil {
-				return nil, fmt.Errorf("unexpected signature for EF_LINK_CERTIFICATE")
-			}
+			p.tachographG2DF.SetCaCertificate(cert)
+		default:
+			return fmt.Errorf("unexpected generation for EF_CA_CERTIFICATE: %v", efGeneration)
+		}
+		if signature != nil {
+			return fmt.Errorf("unexpected signature for EF_CA_CERTIFICATE")
 		}
-	}
 
-	// Set the DFs on the output if they have content
-	if tachographDF != nil {
-		output.SetTachograph(tachographDF)
-	}
-	if tachographG2DF != nil {
-		output.SetTachographG2(tachographG2DF)
+	case cardv1.ElementaryFileType_EF_LINK_CERTIFICATE:
+		// Gen2: Link certificate for CA chaining
+		// Only appears in Gen2 DF (Tachograph_G2)
+		if efGeneration != ddv1.Generation_GENERATION_2 {
+			return fmt.Errorf("EF_LINK_CERTIFICATE should only appear in Gen2 DF, got generation: %v", efGeneration)
+		}
+		if p.tachographG2DF == nil {
+			p.tachographG2DF = &cardv1.DriverCardFile_TachographG2{}
+		}
+		eccCert, err := security.UnmarshalEccCertificate(record.GetValue())
+		if err != nil {
+			return fmt.Errorf("failed to parse EF_LINK_CERTIFICATE: %w", err)
+		}
+		cert := &cardv1.LinkCertificate{}
+		cert.SetEccCertificate(eccCert)
+		p.tachographG2DF.SetLinkCertificate(cert)
+		if signature != nil {
+			return fmt.Errorf("unexpected signature for EF_LINK_CERTIFICATE")
+		}
 	}
-
-	return &output, nil
+	return nil
 }
 
 // appendDriverCard orchestrates the writing of a driver card file.
@@ -649,22 +841,9 @@ func appendDriverCard(dst []byte, card *cardv1.DriverCardFile) ([]byte, error) {
 	}
 
 	// 4. EF_IDENTIFICATION (0x0520) - composite file
-	if identification := card.GetTachograph().GetIdentification(); identification != nil {
-		valBuf := make([]byte, 0, 143)
-		valBuf, err = appendCardIdentification(valBuf, identification.GetCard())
-		if err != nil {
-			return nil, err
-		}
-		valBuf, err = appendDriverCardHolderIdentification(valBuf, identification.GetDriverCardHolder())
-		if err != nil {
-			return nil, err
-		}
-		dst, err = appendTlv(dst, cardv1.ElementaryFileType_EF_IDENTIFICATION, &compositeMessage{data: valBuf}, func(dst []byte, msg *compositeMessage) ([]byte, error) {
-			return append(dst, msg.data...), nil
-		})
-		if err != nil {
-			return nil, err
-		}
+	dst, err = appendTlv(dst, cardv1.ElementaryFileType_EF_IDENTIFICATION, card.GetTachograph().GetIdentification(), appendIdentification)
+	if err != nil {
+		return nil, err
 	}
 
 	dst, err = appendTlv(dst, cardv1.ElementaryFileType_EF_EVENTS_DATA, card.GetTachograph().GetEventsData(), appendEventsData)
@@ -722,6 +901,31 @@ func appendDriverCard(dst []byte, card *cardv1.DriverCardFile) ([]byte, error) {
 			return nil, err
 		}
 
+		dst, err = appendTlvG2(dst, cardv1.ElementaryFileType_EF_DRIVING_LICENCE_INFO, tachographG2.GetDrivingLicenceInfo(), appendDrivingLicenceInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		dst, err = appendTlvG2(dst, cardv1.ElementaryFileType_EF_IDENTIFICATION, tachographG2.GetIdentification(), appendIdentification)
+		if err != nil {
+			return nil, err
+		}
+
+		dst, err = appendTlvG2(dst, cardv1.ElementaryFileType_EF_EVENTS_DATA, tachographG2.GetEventsData(), appendEventsData)
+		if err != nil {
+			return nil, err
+		}
+
+		dst, err = appendTlvG2(dst, cardv1.ElementaryFileType_EF_FAULTS_DATA, tachographG2.GetFaultsData(), appendFaultsData)
+		if err != nil {
+			return nil, err
+		}
+
+		dst, err = appendTlvG2(dst, cardv1.ElementaryFileType_EF_DRIVER_ACTIVITY_DATA, tachographG2.GetDriverActivityData(), appendDriverActivity)
+		if err != nil {
+			return nil, err
+		}
+
 		dst, err = appendTlvG2(dst, cardv1.ElementaryFileType_EF_VEHICLES_USED, tachographG2.GetVehiclesUsed(), appendVehiclesUsedG2)
 		if err != nil {
 			return nil, err
@@ -732,12 +936,27 @@ func appendDriverCard(dst []byte, card *cardv1.DriverCardFile) ([]byte, error) {
 			return nil, err
 		}
 
+		dst, err = appendTlvG2(dst, cardv1.ElementaryFileType_EF_CURRENT_USAGE, tachographG2.GetCurrentUsage(), appendCurrentUsage)
+		if err != nil {
+			return nil, err
+		}
+
+		dst, err = appendTlvG2(dst, cardv1.ElementaryFileType_EF_CONTROL_ACTIVITY_DATA, tachographG2.GetControlActivityData(), appendCardControlActivityData)
+		if err != nil {
+			return nil, err
+		}
+
 		// SpecificConditions (Gen2)
 		dst, err = appendTlvG2(dst, cardv1.ElementaryFileType_EF_SPECIFIC_CONDITIONS, tachographG2.GetSpecificConditions(), appendCardSpecificConditionsG2)
 		if err != nil {
 			return nil, err
 		}
 
+		dst, err = appendTlvUnsignedG2(dst, cardv1.ElementaryFileType_EF_CARD_DOWNLOAD_DRIVER, tachographG2.GetCardDownload(), appendCardDownload)
+		if err != nil {
+			return nil, err
+		}
+
 		// Marshal Gen2-exclusive EFs
 		dst, err = appendTlvG2(dst, cardv1.ElementaryFileType_EF_VEHICLE_UNITS_USED, tachographG2.GetVehicleUnitsUsed(), appendCardVehicleUnitsUsed)
 		if err != nil {
@@ -826,16 +1045,6 @@ func appendDriverCard(dst []byte, card *cardv1.DriverCardFile) ([]byte, error) {
 	return dst, nil
 }
 
-// compositeMessage is a helper type for marshalling composite TLV values
-type compositeMessage struct {
-	data []byte
-}
-
-// ProtoReflect implements proto.Message
-func (m *compositeMessage) ProtoReflect() protoreflect.Message {
-	return nil // Not needed for our use case
-}
-
 // appendCertificateEF appends a Gen1 certificate EF (which are not signed)
 // Uses appendix 0x00 for Gen1 DF (Tachograph)
 func appendCertificateEF(dst []byte, fileType cardv1.ElementaryFileType, certData []byte) ([]byte, error) {
@@ -913,15 +1122,24 @@ func appendTlv[T proto.Message](
 	// Update the length field
 	binary.BigEndian.PutUint16(dst[lenPos:], uint16(valLen))
 
-	// Add signature block (FID + appendix 0x01, 128 bytes of zeros for now)
-	dst = binary.BigEndian.AppendUint16(dst, uint16(tag))
-	dst = append(dst, 0x01)                       // appendix for signature
-	dst = binary.BigEndian.AppendUint16(dst, 128) // Signature length
-	// Add 128 bytes of signature data (zeros for now)
-	signature := make([]byte, 128)
-	dst = append(dst, signature...)
+	// Add signature block (FID + appendix 0x01), if the EF was signed
+	return appendTlvSignature(dst, tag, 0x01, msg), nil
+}
 
-	return dst, nil
+// appendTlvSignature appends the signature block of an EF with the given
+// appendix, if the message of the EF has a signature.
+func appendTlvSignature(dst []byte, tag int32, appendix byte, msg proto.Message) []byte {
+	signed, ok := msg.(interface {
+		HasSignature() bool
+		GetSignature() []byte
+	})
+	if !ok || !signed.HasSignature() {
+		return dst
+	}
+	dst = binary.BigEndian.AppendUint16(dst, uint16(tag))
+	dst = append(dst, appendix)
+	dst = binary.BigEndian.AppendUint16(dst, uint16(len(signed.GetSignature())))
+	return append(dst, signed.GetSignature()...)
 }
 
 // appendTlvUnsigned is like appendTlv but doesn't add a signature block
@@ -1002,14 +1220,48 @@ func appendTlvG2[T proto.Message](
 	// Update the length field
 	binary.BigEndian.PutUint16(dst[lenPos:], uint16(valLen))
 
-	// Add signature block (FID + appendix 0x03, 128 bytes of zeros for now) - Gen2 DF
+	// Add signature block (FID + appendix 0x03), if the EF was signed - Gen2 DF
+	return appendTlvSignature(dst, tag, 0x03, msg), nil
+}
+
+// appendTlvUnsignedG2 is like appendTlvUnsigned but uses Gen2 DF appendix (0x02 instead of 0x00)
+func appendTlvUnsignedG2[T proto.Message](
+	dst []byte,
+	fileType cardv1.ElementaryFileType,
+	msg T,
+	appenderFunc func([]byte, T) ([]byte, error),
+) ([]byte, error) {
+	// Use reflection to check if the message is nil
+	msgValue := reflect.ValueOf(msg)
+	if !msgValue.IsValid() || (msgValue.Kind() == reflect.Ptr && msgValue.IsNil()) {
+		return dst, nil // Don't write anything if the message is nil
+	}
+
+	opts := fileType.Descriptor().Values().ByNumber(protoreflect.EnumNumber(fileType)).Options()
+	tag := proto.GetExtension(opts, cardv1.E_FileId).(int32)
+
+	// Write data tag (FID + appendix 0x02) first - Gen2 DF
 	dst = binary.BigEndian.AppendUint16(dst, uint16(tag))
-	dst = append(dst, 0x03)                       // appendix for Gen2 signature
-	dst = binary.BigEndian.AppendUint16(dst, 128) // Signature length
-	// Add 128 bytes of signature data (zeros for now)
-	signature := make([]byte, 128)
-	dst = append(dst, signature...)
+	dst = append(dst, 0x02) // appendix for Gen2 data
+
+	// Placeholder for length
+	lenPos := len(dst)
+	dst = binary.BigEndian.AppendUint16(dst, 0) // Will be updated later
 
+	valPos := len(dst)
+
+	var err error
+	dst, err = appenderFunc(dst, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	valLen := len(dst) - valPos
+
+	// Update the length field
+	binary.BigEndian.PutUint16(dst[lenPos:], uint16(valLen))
+
+	// No signature block for unsigned EFs
 	return dst, nil
 }
 
@@ -1036,21 +1288,31 @@ type VerifyOptions struct {
 	CertificateResolver CertificateResolver
 }
 
-// VerifyDriverCardFile verifies the certificates in a driver card file.
+// VerifyDriverCardFile verifies the certificates and EF signatures in a driver card file.
 //
 // This function verifies:
-//   - Generation 1: Card certificate using the CA certificate
-//   - Generation 2: Card sign certificate using the CA certificate
+//   - Generation 1: CA certificate using the root certificate, card certificate
+//     using the CA certificate, and the EF signatures using the card certificate
+//   - Generation 2: CA certificate using the root certificate, card MA and card
+//     sign certificates using the CA certificate, and the EF signatures using the
+//     card sign certificate
 //
 // The verification process uses a certificate resolver to fetch CA certificates
 // by their Certificate Authority Reference (CAR). If no resolver is configured,
 // it falls back to using the embedded CA certificates from the card file itself,
-// which contain the public keys needed to verify the card's certificates.
+// which contain the public keys needed to verify the card's certificates, and
+// the embedded CA certificates are left unverified. The Generation 2 CA
+// certificate is also left unverified if the resolver doesn't provide the
+// Generation 2 root certificate.
+//
+// The EF signatures are verified over the marshaled EF data, so EFs that were
+// parsed without their raw data must re-encode to their original bytes.
 //
 // This function mutates the certificate structures by setting their signature_valid
-// fields to true or false based on the verification result.
+// fields, and the EFs by setting their signature_verified fields, to true or false
+// based on the verification result.
 //
-// Returns an error if verification fails for any certificate.
+// Returns an error if verification fails for any certificate or signature.
 func (o VerifyOptions) VerifyDriverCardFile(ctx context.Context, file *cardv1.DriverCardFile) error {
 	if file == nil {
 		return fmt.Errorf("driver card file cannot be nil")
@@ -1061,6 +1323,9 @@ func (o VerifyOptions) VerifyDriverCardFile(ctx context.Context, file *cardv1.Dr
 		if err := o.verifyGen1Certificates(ctx, tachograph); err != nil {
 			return fmt.Errorf("Gen1 certificate verification failed: %w", err)
 		}
+		if err := verifyGen1Signatures(tachograph); err != nil {
+			return fmt.Errorf("Gen1 signature verification failed: %w", err)
+		}
 	}
 
 	// Verify Generation 2 certificates (ECC)
@@ -1068,6 +1333,9 @@ func (o VerifyOptions) VerifyDriverCardFile(ctx context.Context, file *cardv1.Dr
 		if err := o.verifyGen2Certificates(ctx, tachographG2); err != nil {
 			return fmt.Errorf("Gen2 certificate verification failed: %w", err)
 		}
+		if err := verifyGen2Signatures(tachographG2); err != nil {
+			return fmt.Errorf("Gen2 signature verification failed: %w", err)
+		}
 	}
 
 	return nil
@@ -1087,6 +1355,17 @@ func (o VerifyOptions) verifyGen1Certificates(ctx context.Context, tachograph *c
 	var err error
 
 	if o.CertificateResolver != nil {
+		// Verify the CA certificate embedded in the card file against the root CA
+		if embeddedCACert := tachograph.GetCaCertificate().GetRsaCertificate(); embeddedCACert != nil {
+			rootCert, err := o.CertificateResolver.GetRootCertificate(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get root CA certificate: %w", err)
+			}
+			if err := security.VerifyRsaCertificateWithRoot(embeddedCACert, rootCert); err != nil {
+				return fmt.Errorf("embedded CA certificate verification failed: %w", err)
+			}
+		}
+
 		// Use certificate resolver to fetch CA certificate
 		car := cardCert.GetCertificateAuthorityReference()
 		caCert, err = o.CertificateResolver.GetRsaCertificate(ctx, car)
@@ -1139,6 +1418,17 @@ func (o VerifyOptions) verifyGen2Certificates(ctx context.Context, tachographG2
 	var err error
 
 	if o.CertificateResolver != nil {
+		// Verify the CA certificate embedded in the card file against the root CA,
+		// if the resolver provides it
+		if embeddedCACert := tachographG2.GetCaCertificate().GetEccCertificate(); embeddedCACert != nil {
+			rootCert, err := o.CertificateResolver.GetEccCertificate(ctx, embeddedCACert.GetCertificateAuthorityReference())
+			if err == nil {
+				if err := security.VerifyEccCertificateWithCA(embeddedCACert, rootCert); err != nil {
+					return fmt.Errorf("embedded CA certificate verification failed: %w", err)
+				}
+			}
+		}
+
 		// Use certificate resolver to fetch CA certificate
 		car := cardSignCert.GetCertificateAuthorityReference()
 		caCert, err = o.CertificateResolver.GetEccCertificate(ctx, car)
@@ -1158,5 +1448,108 @@ func (o VerifyOptions) verifyGen2Certificates(ctx context.Context, tachographG2
 		return fmt.Errorf("card sign certificate verification failed: %w", err)
 	}
 
+	// Verify the card MA certificate, which is issued by the same CA
+	if cardMaCert := tachographG2.GetCardMaCertificate().GetEccCertificate(); cardMaCert != nil {
+		if err := security.VerifyEccCertificateWithCA(cardMaCert, caCert); err != nil {
+			return fmt.Errorf("card MA certificate verification failed: %w", err)
+		}
+	}
+
 	return nil
 }
+
+// signedEF is a signed EF of a driver card file.
+type signedEF struct {
+	file        cardv1.ElementaryFileType
+	signature   []byte
+	appendData  func([]byte) ([]byte, error)
+	setVerified func(bool)
+}
+
+// newSignedEF returns the signed EF of msg, or nil if msg has no signature.
+func newSignedEF[T interface {
+	GetSignature() []byte
+	SetSignatureVerified(bool)
+}](
+	file cardv1.ElementaryFileType,
+	msg T,
+	appenderFunc func([]byte, T) ([]byte, error),
+) *signedEF {
+	if len(msg.GetSignature()) == 0 {
+		return nil
+	}
+	return &signedEF{
+		file:        file,
+		signature:   msg.GetSignature(),
+		appendData:  func(dst []byte) ([]byte, error) { return appenderFunc(dst, msg) },
+		setVerified: msg.SetSignatureVerified,
+	}
+}
+
+// verifyGen1Signatures verifies the signatures of the EFs in the Tachograph DF
+// with the public key of the verified card certificate.
+func verifyGen1Signatures(tachograph *cardv1.DriverCardFile_Tachograph) error {
+	cardCert := tachograph.GetCardCertificate().GetRsaCertificate()
+	return verifySignatures([]*signedEF{
+		newSignedEF(cardv1.ElementaryFileType_EF_APPLICATION_IDENTIFICATION, tachograph.GetApplicationIdentification(), appendCardApplicationIdentification),
+		newSignedEF(cardv1.ElementaryFileType_EF_DRIVING_LICENCE_INFO, tachograph.GetDrivingLicenceInfo(), appendDrivingLicenceInfo),
+		newSignedEF(cardv1.ElementaryFileType_EF_IDENTIFICATION, tachograph.GetIdentification(), appendIdentification),
+		newSignedEF(cardv1.ElementaryFileType_EF_EVENTS_DATA, tachograph.GetEventsData(), appendEventsData),
+		newSignedEF(cardv1.ElementaryFileType_EF_FAULTS_DATA, tachograph.GetFaultsData(), appendFaultsData),
+		newSignedEF(cardv1.ElementaryFileType_EF_DRIVER_ACTIVITY_DATA, tachograph.GetDriverActivityData(), appendDriverActivity),
+		newSignedEF(cardv1.ElementaryFileType_EF_VEHICLES_USED, tachograph.GetVehiclesUsed(), appendVehiclesUsed),
+		newSignedEF(cardv1.ElementaryFileType_EF_PLACES, tachograph.GetPlaces(), appendPlaces),
+		newSignedEF(cardv1.ElementaryFileType_EF_CURRENT_USAGE, tachograph.GetCurrentUsage(), appendCurrentUsage),
+		newSignedEF(cardv1.ElementaryFileType_EF_CONTROL_ACTIVITY_DATA, tachograph.GetControlActivityData(), appendCardControlActivityData),
+		newSignedEF(cardv1.ElementaryFileType_EF_SPECIFIC_CONDITIONS, tachograph.GetSpecificConditions(), appendCardSpecificConditions),
+		newSignedEF(cardv1.ElementaryFileType_EF_CARD_DOWNLOAD_DRIVER, tachograph.GetCardDownload(), appendCardDownload),
+	}, func(data, signature []byte) error {
+		return security.VerifyRsaSignature(data, signature, cardCert)
+	})
+}
+
+// verifyGen2Signatures verifies the signatures of the EFs in the Tachograph_G2 DF
+// with the public key of the verified card sign certificate.
+func verifyGen2Signatures(tachographG2 *cardv1.DriverCardFile_TachographG2) error {
+	cardSignCert := tachographG2.GetCardSignCertificate().GetEccCertificate()
+	return verifySignatures([]*signedEF{
+		newSignedEF(cardv1.ElementaryFileType_EF_APPLICATION_IDENTIFICATION, tachographG2.GetApplicationIdentification(), appendCardApplicationIdentificationG2),
+		newSignedEF(cardv1.ElementaryFileType_EF_DRIVING_LICENCE_INFO, tachographG2.GetDrivingLicenceInfo(), appendDrivingLicenceInfo),
+		newSignedEF(cardv1.ElementaryFileType_EF_IDENTIFICATION, tachographG2.GetIdentification(), appendIdentification),
+		newSignedEF(cardv1.ElementaryFileType_EF_EVENTS_DATA, tachographG2.GetEventsData(), appendEventsData),
+		newSignedEF(cardv1.ElementaryFileType_EF_FAULTS_DATA, tachographG2.GetFaultsData(), appendFaultsData),
+		newSignedEF(cardv1.ElementaryFileType_EF_DRIVER_ACTIVITY_DATA, tachographG2.GetDriverActivityData(), appendDriverActivity),
+		newSignedEF(cardv1.ElementaryFileType_EF_VEHICLES_USED, tachographG2.GetVehiclesUsed(), appendVehiclesUsedG2),
+		newSignedEF(cardv1.ElementaryFileType_EF_PLACES, tachographG2.GetPlaces(), appendPlacesG2),
+		newSignedEF(cardv1.ElementaryFileType_EF_CURRENT_USAGE, tachographG2.GetCurrentUsage(), appendCurrentUsage),
+		newSignedEF(cardv1.ElementaryFileType_EF_CONTROL_ACTIVITY_DATA, tachographG2.GetControlActivityData(), appendCardControlActivityData),
+		newSignedEF(cardv1.ElementaryFileType_EF_SPECIFIC_CONDITIONS, tachographG2.GetSpecificConditions(), appendCardSpecificConditionsG2),
+		newSignedEF(cardv1.ElementaryFileType_EF_CARD_DOWNLOAD_DRIVER, tachographG2.GetCardDownload(), appendCardDownload),
+		newSignedEF(cardv1.ElementaryFileType_EF_VEHICLE_UNITS_USED, tachographG2.GetVehicleUnitsUsed(), appendCardVehicleUnitsUsed),
+		newSignedEF(cardv1.ElementaryFileType_EF_GNSS_PLACES, tachographG2.GetGnssPlaces(), appendCardGnssPlaces),
+		newSignedEF(cardv1.ElementaryFileType_EF_APPLICATION_IDENTIFICATION_V2, tachographG2.GetApplicationIdentificationV2(), appendCardApplicationIdentificationV2),
+	}, func(data, signature []byte) error {
+		return security.VerifyEccSignature(data, signature, cardSignCert)
+	})
+}
+
+// verifySignatures verifies the signatures of the signed EFs and sets their
+// signature_verified fields. It reports every EF that fails verification.
+func verifySignatures(efs []*signedEF, verify func(data, signature []byte) error) error {
+	var errs []error
+	for _, ef := range efs {
+		if ef == nil {
+			continue
+		}
+		data, err := ef.appendData(nil)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %v: %w", ef.file, err)
+		}
+		err = verify(data, ef.signature)
+		ef.setVerified(err == nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%v: %w", ef.file, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,165 @@
+package card
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+	securityv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/security/v1"
+)
+
+// testDriverCardEF reads the base64 test data of a single EF.
+func testDriverCardEF(t *testing.T, name string) []byte {
+	t.Helper()
+	b64Data, err := os.ReadFile("testdata/" + name + ".b64")
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+	data, err := base64.StdEncoding.DecodeString(string(b64Data))
+	if err != nil {
+		t.Fatalf("Failed to decode base64: %v", err)
+	}
+	return data
+}
+
+// appendTestTlv appends an EF value as a TLV record with the given FID and appendix.
+func appendTestTlv(dst []byte, fid uint16, appendix byte, value []byte) []byte {
+	dst = binary.BigEndian.AppendUint16(dst, fid)
+	dst = append(dst, appendix)
+	dst = binary.BigEndian.AppendUint16(dst, uint16(len(value)))
+	return append(dst, value...)
+}
+
+// TestDriverCardFileRoundTrip_gen2 verifies that the EFs of the Gen2 DF
+// (appendix 0x02/0x03) are parsed into the Gen2 DF and marshaled back with
+// their Gen2 appendix and signature.
+func TestDriverCardFileRoundTrip_gen2(t *testing.T) {
+	signature := bytes.Repeat([]byte{0x5A}, 64)
+	var data []byte
+	data = appendTestTlv(data, 0x0002, 0x00, testDriverCardEF(t, "icc"))
+	data = appendTestTlv(data, 0x0005, 0x00, testDriverCardEF(t, "ic"))
+	for _, ef := range []struct {
+		fid  uint16
+		name string
+	}{
+		{fid: 0x0521, name: "driving_licence"},
+		{fid: 0x0520, name: "identification"},
+		{fid: 0x0502, name: "events"},
+		{fid: 0x0503, name: "faults"},
+		{fid: 0x0504, name: "activity"},
+		{fid: 0x0507, name: "current_usage"},
+		{fid: 0x0508, name: "control_activity"},
+	} {
+		data = appendTestTlv(data, ef.fid, 0x02, testDriverCardEF(t, ef.name))
+		data = appendTestTlv(data, ef.fid, 0x03, signature)
+	}
+	// EF_CARD_DOWNLOAD_DRIVER is not signed.
+	data = appendTestTlv(data, 0x050E, 0x02, []byte{0x65, 0xE1, 0x9A, 0x00})
+
+	rawFile, err := UnmarshalRawCardFile(data)
+	if err != nil {
+		t.Fatalf("UnmarshalRawCardFile() error = %v", err)
+	}
+	file, err := UnmarshalDriverCardFile(rawFile)
+	if err != nil {
+		t.Fatalf("UnmarshalDriverCardFile() error = %v", err)
+	}
+	if file.HasTachograph() {
+		t.Error("Gen2 EFs were parsed into the Gen1 DF")
+	}
+	g2 := file.GetTachographG2()
+	for name, ok := range map[string]bool{
+		"driving licence":  g2.HasDrivingLicenceInfo(),
+		"identification":   g2.HasIdentification(),
+		"events":           g2.HasEventsData(),
+		"faults":           g2.HasFaultsData(),
+		"driver activity":  g2.HasDriverActivityData(),
+		"current usage":    g2.HasCurrentUsage(),
+		"control activity": g2.HasControlActivityData(),
+		"card download":    g2.HasCardDownload(),
+	} {
+		if !ok {
+			t.Errorf("Gen2 DF has no %s", name)
+		}
+	}
+	if got := g2.GetIdentification().GetSignature(); !bytes.Equal(got, signature) {
+		t.Errorf("identification signature = %X, want %X", got, signature)
+	}
+
+	marshaled, err := MarshalDriverCardFile(file)
+	if err != nil {
+		t.Fatalf("MarshalDriverCardFile() error = %v", err)
+	}
+	if !bytes.Equal(marshaled, data) {
+		t.Errorf("MarshalDriverCardFile() = %X, want %X", marshaled, data)
+	}
+
+	// The marshaled file must decode to the same Gen2 DF.
+	rawFile, err = UnmarshalRawCardFile(marshaled)
+	if err != nil {
+		t.Fatalf("UnmarshalRawCardFile() error = %v", err)
+	}
+	file2, err := UnmarshalDriverCardFile(rawFile)
+	if err != nil {
+		t.Fatalf("UnmarshalDriverCardFile() error = %v", err)
+	}
+	if diff := cmp.Diff(file, file2, protocmp.Transform()); diff != "" {
+		t.Errorf("Round trip mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// TestVerifyGen1Signatures verifies that the EF signatures of the Tachograph DF
+// are checked against the card certificate's public key.
+func TestVerifyGen1Signatures(t *testing.T) {
+	data := testDriverCardEF(t, "identification")
+	identification, err := (UnmarshalOptions{}).unmarshalIdentification(data)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal identification: %v", err)
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	hash := sha1.Sum(data)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA1, hash[:])
+	if err != nil {
+		t.Fatalf("Failed to sign identification: %v", err)
+	}
+	identification.SetSignature(signature)
+
+	cardCert := &securityv1.RsaCertificate{}
+	cardCert.SetRsaModulus(key.N.Bytes())
+	cardCert.SetRsaExponent(big.NewInt(int64(key.E)).FillBytes(make([]byte, 8)))
+	certificate := &cardv1.CardCertificate{}
+	certificate.SetRsaCertificate(cardCert)
+	tachograph := &cardv1.DriverCardFile_Tachograph{}
+	tachograph.SetIdentification(identification)
+	tachograph.SetCardCertificate(certificate)
+
+	if err := verifyGen1Signatures(tachograph); err != nil {
+		t.Fatalf("verifyGen1Signatures() error = %v", err)
+	}
+	if !identification.HasSignatureVerified() || !identification.GetSignatureVerified() {
+		t.Error("identification signature_verified not set to true")
+	}
+
+	signature[0] ^= 0xff
+	if err := verifyGen1Signatures(tachograph); err == nil {
+		t.Fatal("verifyGen1Signatures() with a corrupted signature succeeded, want error")
+	}
+	if !identification.HasSignatureVerified() || identification.GetSignatureVerified() {
+		t.Error("identification signature_verified not set to false")
+	}
+}
@@ -34,18 +34,19 @@ func (opts UnmarshalOptions) unmarshalDrivingLicenceInfo(data []byte) (*cardv1.D
 
 	// Read driving licence issuing authority (36 bytes)
 	if offset+36 > len(data) {
-		return nil, fmt.Errorf("insufficient data for driving licence issuing authority")
+		return nil, dd.WrapField("driving_licence_issuing_authority", offset, fmt.Errorf("insufficient data for driving licence issuing authority: %w", dd.ErrTruncated))
 	}
 	authority, err := opts.UnmarshalStringValue(data[offset : offset+36])
 	if err != nil {
-		return nil, fmt.Errorf("failed to read driving licence issuing authority: %w", err)
+		return nil, dd.WrapField("driving_licence_issuing_authority", offset, fmt.Errorf("failed to read driving licence issuing authority: %w", err))
 	}
 	dli.SetDrivingLicenceIssuingAuthority(authority)
+	opts.Layout.Field("driving_licence_issuing_authority", offset, 36)
 	offset += 36
 
 	// Read driving licence issuing nation (1 byte)
 	if offset+1 > len(data) {
-		return nil, fmt.Errorf("insufficient data for driving licence issuing nation")
+		return nil, dd.WrapField("driving_licence_issuing_nation", offset, fmt.Errorf("insufficient data for driving licence issuing nation: %w", dd.ErrTruncated))
 	}
 	if nation, err := dd.UnmarshalEnum[ddv1.NationNumeric](data[offset]); err == nil {
 		dli.SetDrivingLicenceIssuingNation(nation)
@@ -53,17 +54,19 @@ func (opts UnmarshalOptions) unmarshalDrivingLicenceInfo(data []byte) (*cardv1.D
 		// Value not recognized - set UNRECOGNIZED (no unrecognized field for this type)
 		dli.SetDrivingLicenceIssuingNation(ddv1.NationNumeric_NATION_NUMERIC_UNRECOGNIZED)
 	}
+	opts.Layout.Field("driving_licence_issuing_nation", offset, 1)
 	offset++
 
 	// Read driving licence number (16 bytes)
 	if offset+16 > len(data) {
-		return nil, fmt.Errorf("insufficient data for driving licence number")
+		return nil, dd.WrapField("driving_licence_number", offset, fmt.Errorf("insufficient data for driving licence number: %w", dd.ErrTruncated))
 	}
 	licenceNumber, err := opts.UnmarshalIa5StringValue(data[offset : offset+16])
 	if err != nil {
-		return nil, fmt.Errorf("failed to read driving licence number: %w", err)
+		return nil, dd.WrapField("driving_licence_number", offset, fmt.Errorf("failed to read driving licence number: %w", err))
 	}
 	dli.SetDrivingLicenceNumber(licenceNumber)
+	opts.Layout.Field("driving_licence_number", offset, 16)
 	// offset += 16 // Not needed as this is the last field
 
 	return &dli, nil
@@ -48,7 +48,7 @@ func (opts UnmarshalOptions) unmarshalEventsData(data []byte) (*cardv1.EventsDat
 	scanner.Split(splitCardEventRecord)
 
 	var records []*cardv1.EventsData_Record
-	for scanner.Scan() {
+	for i := 0; scanner.Scan(); i++ {
 		recordData := scanner.Bytes()
 		// Check if this is a valid record by examining the event begin time (first 4 bytes after event type)
 		// Event type is 1 byte, so event begin time starts at byte 1
@@ -59,11 +59,14 @@ func (opts UnmarshalOptions) unmarshalEventsData(data []byte) (*cardv1.EventsDat
 			rec.SetValid(false)
 			rec.SetRawData(recordData)
 			records = append(records, rec)
+			opts.Layout.Element("events", i, i*cardEventRecordSize, cardEventRecordSize)
 		} else {
 			// Valid record: parse semantic data
-			rec, err := opts.unmarshalEventRecord(recordData)
+			recordOpts := opts
+			recordOpts.Layout = opts.Layout.NestedElement("events", i, i*cardEventRecordSize)
+			rec, err := recordOpts.unmarshalEventRecord(recordData)
 			if err != nil {
-				return nil, err
+				return nil, dd.WrapField(fmt.Sprintf("events[%d]", i), i*cardEventRecordSize, err)
 			}
 			rec.SetValid(true)
 			records = append(records, rec)
@@ -102,7 +105,7 @@ func (opts UnmarshalOptions) unmarshalEventRecord(data []byte) (*cardv1.EventsDa
 	)
 
 	if len(data) < lenCardEventRecord {
-		return nil, fmt.Errorf("insufficient data for event record: got %d bytes, need %d", len(data), lenCardEventRecord)
+		return nil, fmt.Errorf("insufficient data for event record: got %d bytes, need %d: %w", len(data), lenCardEventRecord, dd.ErrTruncated)
 	}
 
 	var rec cardv1.EventsData_Record
@@ -110,45 +113,49 @@ func (opts UnmarshalOptions) unmarshalEventRecord(data []byte) (*cardv1.EventsDa
 
 	// Read event type (1 byte) and convert using generic enum helper
 	if offset+1 > len(data) {
-		return nil, fmt.Errorf("insufficient data for event type")
+		return nil, dd.WrapField("event_type", offset, fmt.Errorf("insufficient data for event type: %w", dd.ErrTruncated))
 	}
 	if eventTypeEnum, err := dd.UnmarshalEnum[ddv1.EventFaultType](data[offset]); err == nil {
 		rec.SetEventType(eventTypeEnum)
 	} else {
-		return nil, fmt.Errorf("invalid event type: %w", err)
+		return nil, dd.WrapField("event_type", offset, fmt.Errorf("invalid event type: %w", err))
 	}
+	opts.Layout.Field("event_type", offset, 1)
 	offset++
 
 	// Read event begin time (4 bytes)
 	if offset+4 > len(data) {
-		return nil, fmt.Errorf("insufficient data for event begin time")
+		return nil, dd.WrapField("event_begin_time", offset, fmt.Errorf("insufficient data for event begin time: %w", dd.ErrTruncated))
 	}
 	eventBeginTime, err := opts.UnmarshalTimeReal(data[offset : offset+4])
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse event begin time: %w", err)
+		return nil, dd.WrapField("event_begin_time", offset, fmt.Errorf("failed to parse event begin time: %w", err))
 	}
 	rec.SetEventBeginTime(eventBeginTime)
+	opts.Layout.Field("event_begin_time", offset, 4)
 	offset += 4
 
 	// Read event end time (4 bytes)
 	if offset+4 > len(data) {
-		return nil, fmt.Errorf("insufficient data for event end time")
+		return nil, dd.WrapField("event_end_time", offset, fmt.Errorf("insufficient data for event end time: %w", dd.ErrTruncated))
 	}
 	eventEndTime, err := opts.UnmarshalTimeReal(data[offset : offset+4])
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse event end time: %w", err)
+		return nil, dd.WrapField("event_end_time", offset, fmt.Errorf("failed to parse event end time: %w", err))
 	}
 	rec.SetEventEndTime(eventEndTime)
+	opts.Layout.Field("event_end_time", offset, 4)
 	offset += 4
 
 	// Read vehicle registration (15 bytes: 1 byte nation + 14 bytes number)
 	if offset+15 > len(data) {
-		return nil, fmt.Errorf("insufficient data for vehicle registration")
+		return nil, dd.WrapField("event_vehicle_registration", offset, fmt.Errorf("insufficient data for vehicle registration: %w", dd.ErrTruncated))
 	}
 	vehicleReg, err := opts.UnmarshalVehicleRegistration(data[offset : offset+15])
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse vehicle registration: %w", err)
+		return nil, dd.WrapField("event_vehicle_registration", offset, fmt.Errorf("failed to parse vehicle registration: %w", err))
 	}
+	opts.Layout.Field("event_vehicle_registration", offset, 15)
 	// offset += 15 // Not needed as this is the last field
 	rec.SetEventVehicleRegistration(vehicleReg)
 	return &rec, nil
@@ -48,7 +48,7 @@ func (opts UnmarshalOptions) unmarshalFaultsData(data []byte) (*cardv1.FaultsDat
 	scanner.Split(splitCardFaultRecord)
 
 	var records []*cardv1.FaultsData_Record
-	for scanner.Scan() {
+	for i := 0; scanner.Scan(); i++ {
 		recordData := scanner.Bytes()
 		// Check if this is a valid record by examining the fault begin time (first 4 bytes after fault type)
 		// Fault type is 1 byte, so fault begin time starts at byte 1
@@ -60,11 +60,14 @@ func (opts UnmarshalOptions) unmarshalFaultsData(data []byte) (*cardv1.FaultsDat
 			// Non-valid record: preserve original bytes
 			rec.SetValid(false)
 			rec.SetRawData(recordData)
+			opts.Layout.Element("faults", i, i*cardFaultRecordSize, cardFaultRecordSize)
 		} else {
 			// Valid record: parse semantic data
 			rec.SetValid(true)
-			if err := opts.unmarshalFaultRecord(recordData, rec); err != nil {
-				return nil, err
+			recordOpts := opts
+			recordOpts.Layout = opts.Layout.NestedElement("faults", i, i*cardFaultRecordSize)
+			if err := recordOpts.unmarshalFaultRecord(recordData, rec); err != nil {
+				return nil, dd.WrapField(fmt.Sprintf("faults[%d]", i), i*cardFaultRecordSize, err)
 			}
 		}
 
@@ -103,52 +106,56 @@ func (opts UnmarshalOptions) unmarshalFaultRecord(data []byte, rec *cardv1.Fault
 	)
 
 	if len(data) < lenCardFaultRecord {
-		return fmt.Errorf("insufficient data for fault record: got %d bytes, need %d", len(data), lenCardFaultRecord)
+		return fmt.Errorf("insufficient data for fault record: got %d bytes, need %d: %w", len(data), lenCardFaultRecord, dd.ErrTruncated)
 	}
 
 	offset := 0
 
 	// Read fault type (1 byte) and convert using generic enum helper
 	if offset+1 > len(data) {
-		return fmt.Errorf("insufficient data for fault type")
+		return dd.WrapField("fault_type", offset, fmt.Errorf("insufficient data for fault type: %w", dd.ErrTruncated))
 	}
 	if faultTypeEnum, err := dd.UnmarshalEnum[ddv1.EventFaultType](data[offset]); err == nil {
 		rec.SetFaultType(faultTypeEnum)
 	} else {
-		return fmt.Errorf("invalid fault type: %w", err)
+		return dd.WrapField("fault_type", offset, fmt.Errorf("invalid fault type: %w", err))
 	}
+	opts.Layout.Field("fault_type", offset, 1)
 	offset++
 
 	// Read fault begin time (4 bytes)
 	if offset+4 > len(data) {
-		return fmt.Errorf("insufficient data for fault begin time")
+		return dd.WrapField("fault_begin_time", offset, fmt.Errorf("insufficient data for fault begin time: %w", dd.ErrTruncated))
 	}
 	faultBeginTime, err := opts.UnmarshalTimeReal(data[offset : offset+4])
 	if err != nil {
-		return fmt.Errorf("failed to parse fault begin time: %w", err)
+		return dd.WrapField("fault_begin_time", offset, fmt.Errorf("failed to parse fault begin time: %w", err))
 	}
 	rec.SetFaultBeginTime(faultBeginTime)
+	opts.Layout.Field("fault_begin_time", offset, 4)
 	offset += 4
 
 	// Read fault end time (4 bytes)
 	if offset+4 > len(data) {
-		return fmt.Errorf("insufficient data for fault end time")
+		return dd.WrapField("fault_end_time", offset, fmt.Errorf("insufficient data for fault end time: %w", dd.ErrTruncated))
 	}
 	faultEndTime, err := opts.UnmarshalTimeReal(data[offset : offset+4])
 	if err != nil {
-		return fmt.Errorf("failed to parse fault end time: %w", err)
+		return dd.WrapField("fault_end_time", offset, fmt.Errorf("failed to parse fault end time: %w", err))
 	}
 	rec.SetFaultEndTime(faultEndTime)
+	opts.Layout.Field("fault_end_time", offset, 4)
 	offset += 4
 
 	// Read vehicle registration (15 bytes: 1 byte nation + 14 bytes number)
 	if offset+15 > len(data) {
-		return fmt.Errorf("insufficient data for vehicle registration")
+		return dd.WrapField("fault_vehicle_registration", offset, fmt.Errorf("insufficient data for vehicle registration: %w", dd.ErrTruncated))
 	}
 	vehicleReg, err := opts.UnmarshalVehicleRegistration(data[offset : offset+15])
 	if err != nil {
-		return fmt.Errorf("failed to parse vehicle registration: %w", err)
+		return dd.WrapField("fault_vehicle_registration", offset, fmt.Errorf("failed to parse vehicle registration: %w", err))
 	}
+	opts.Layout.Field("fault_vehicle_registration", offset, 15)
 	// offset += 15 // Not needed as this is the last field
 	rec.SetFaultVehicleRegistration(vehicleReg)
 	return nil
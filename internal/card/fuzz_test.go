@@ -0,0 +1,117 @@
+package card
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+)
+
+// fuzzSeedEFs are the elementary files of the fuzz seeds, with their test data.
+var fuzzSeedEFs = []struct {
+	file     cardv1.ElementaryFileType
+	appendix byte
+	name     string
+}{
+	{file: cardv1.ElementaryFileType_EF_ICC, name: "icc"},
+	{file: cardv1.ElementaryFileType_EF_IC, name: "ic"},
+	{file: cardv1.ElementaryFileType_EF_APPLICATION_IDENTIFICATION, name: "application_identification"},
+	{file: cardv1.ElementaryFileType_EF_IDENTIFICATION, name: "identification"},
+	{file: cardv1.ElementaryFileType_EF_DRIVING_LICENCE_INFO, name: "driving_licence"},
+	{file: cardv1.ElementaryFileType_EF_EVENTS_DATA, name: "events"},
+	{file: cardv1.ElementaryFileType_EF_FAULTS_DATA, name: "faults"},
+	{file: cardv1.ElementaryFileType_EF_DRIVER_ACTIVITY_DATA, name: "activity"},
+	{file: cardv1.ElementaryFileType_EF_VEHICLES_USED, name: "vehicles"},
+	{file: cardv1.ElementaryFileType_EF_PLACES, name: "places"},
+	{file: cardv1.ElementaryFileType_EF_CURRENT_USAGE, name: "current_usage"},
+	{file: cardv1.ElementaryFileType_EF_CONTROL_ACTIVITY_DATA, name: "control_activity"},
+	{file: cardv1.ElementaryFileType_EF_SPECIFIC_CONDITIONS, name: "specific_conditions"},
+	{file: cardv1.ElementaryFileType_EF_PLACES, appendix: 0x02, name: "places_g2"},
+	{file: cardv1.ElementaryFileType_EF_SPECIFIC_CONDITIONS, appendix: 0x02, name: "specific_conditions_g2"},
+	{file: cardv1.ElementaryFileType_EF_VEHICLE_UNITS_USED, appendix: 0x02, name: "vehicle_units_used"},
+	{file: cardv1.ElementaryFileType_EF_GNSS_PLACES, appendix: 0x02, name: "gnss_places"},
+}
+
+// addCardFuzzSeeds adds each seed EF as a card file of its own, and all seed
+// EFs together as a complete card file.
+func addCardFuzzSeeds(f *testing.F) {
+	var all []byte
+	for _, ef := range fuzzSeedEFs {
+		b64, err := os.ReadFile("testdata/" + ef.name + ".b64")
+		if err != nil {
+			f.Fatal(err)
+		}
+		value, err := base64.StdEncoding.DecodeString(string(b64))
+		if err != nil {
+			f.Fatal(err)
+		}
+		fileID := proto.GetExtension(ef.file.Descriptor().Values().ByNumber(ef.file.Number()).Options(), cardv1.E_FileId).(int32)
+		var tlv []byte
+		tlv = binary.BigEndian.AppendUint16(tlv, uint16(fileID))
+		tlv = append(tlv, ef.appendix)
+		tlv = binary.BigEndian.AppendUint16(tlv, uint16(len(value)))
+		tlv = append(tlv, value...)
+		f.Add(tlv)
+		all = append(all, tlv...)
+	}
+	f.Add(all)
+}
+
+// FuzzUnmarshalRawCardFile checks that splitting card data into TLV records
+// never panics, and that the records marshal back to the same bytes.
+func FuzzUnmarshalRawCardFile(f *testing.F) {
+	addCardFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		rawFile, err := UnmarshalRawCardFile(data)
+		if err != nil {
+			return
+		}
+		marshaled, err := MarshalRawCardFile(rawFile)
+		if err != nil {
+			t.Fatalf("marshal parsed raw card file: %v", err)
+		}
+		if !bytes.Equal(data, marshaled) {
+			t.Errorf("round-trip mismatch:\n got %x\nwant %x", marshaled, data)
+		}
+	})
+}
+
+// FuzzUnmarshalDriverCardFile checks that parsing driver card data, strictly
+// or leniently, never panics, and that parsed files survive a marshal and parse
+// round-trip.
+func FuzzUnmarshalDriverCardFile(f *testing.F) {
+	addCardFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		rawFile, err := UnmarshalRawCardFile(data)
+		if err != nil {
+			return
+		}
+		ScanDriverCardFile(rawFile)
+		file, err := UnmarshalDriverCardFile(rawFile)
+		if err != nil {
+			return
+		}
+		marshaled, err := MarshalDriverCardFile(file)
+		if err != nil {
+			t.Fatalf("marshal parsed driver card file: %v", err)
+		}
+		reparsedRawFile, err := UnmarshalRawCardFile(marshaled)
+		if err != nil {
+			t.Fatalf("parse marshaled raw card file: %v", err)
+		}
+		reparsed, err := UnmarshalDriverCardFile(reparsedRawFile)
+		if err != nil {
+			t.Fatalf("parse marshaled driver card file: %v", err)
+		}
+		if !proto.Equal(file, reparsed) {
+			t.Errorf("round-trip mismatch (-parsed +reparsed):\n%s", cmp.Diff(file, reparsed, protocmp.Transform()))
+		}
+	})
+}
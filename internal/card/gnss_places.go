@@ -43,7 +43,7 @@ func (opts UnmarshalOptions) unmarshalGnssPlaces(data []byte) (*cardv1.GnssPlace
 	)
 
 	if len(data) < lenGNSSAccumulatedDrivingMinimum {
-		return nil, fmt.Errorf("invalid data length for GNSSAccumulatedDriving: got %d, want at least %d", len(data), lenGNSSAccumulatedDrivingMinimum)
+		return nil, fmt.Errorf("invalid data length for GNSSAccumulatedDriving: got %d, want at least %d: %w", len(data), lenGNSSAccumulatedDrivingMinimum, dd.ErrInvalidValue)
 	}
 
 	// Validate that the records section is a multiple of record size
@@ -57,11 +57,14 @@ func (opts UnmarshalOptions) unmarshalGnssPlaces(data []byte) (*cardv1.GnssPlace
 	// Parse newest record index
 	newestRecordIndex := binary.BigEndian.Uint16(data[idxNewestRecordIndex:])
 	target.SetNewestRecordIndex(int32(newestRecordIndex))
+	opts.Layout.Field("newest_record_index", idxNewestRecordIndex, lenNewestRecordIndex)
 
 	// Parse records using bufio.Scanner pattern
-	records, err := parseGNSSAccumulatedDrivingRecords(data[lenNewestRecordIndex:], opts)
+	recordsOpts := opts
+	recordsOpts.Layout = opts.Layout.Nested("records", lenNewestRecordIndex)
+	records, err := parseGNSSAccumulatedDrivingRecords(data[lenNewestRecordIndex:], recordsOpts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse GNSS accumulated driving records: %w", err)
+		return nil, dd.WrapField("records", lenNewestRecordIndex, fmt.Errorf("failed to parse GNSS accumulated driving records: %w", err))
 	}
 	target.SetRecords(records)
 
@@ -88,10 +91,12 @@ func parseGNSSAccumulatedDrivingRecords(data []byte, opts UnmarshalOptions) ([]*
 	scanner.Split(splitGNSSAccumulatedDrivingRecord)
 
 	var records []*cardv1.GnssPlaces_Record
-	for scanner.Scan() {
-		record, err := unmarshalGNSSAccumulatedDrivingRecord(scanner.Bytes(), opts)
+	for i := 0; scanner.Scan(); i++ {
+		recordOpts := opts
+		recordOpts.Layout = opts.Layout.NestedElement("", i, i*len(scanner.Bytes()))
+		record, err := unmarshalGNSSAccumulatedDrivingRecord(scanner.Bytes(), recordOpts)
 		if err != nil {
-			return nil, fmt.Errorf("failed to unmarshal GNSS accumulated driving record: %w", err)
+			return nil, dd.WrapField(fmt.Sprintf("[%d]", i), i*len(scanner.Bytes()), fmt.Errorf("failed to unmarshal GNSS accumulated driving record: %w", err))
 		}
 		records = append(records, record)
 	}
@@ -118,7 +123,7 @@ func unmarshalGNSSAccumulatedDrivingRecord(data []byte, opts UnmarshalOptions) (
 	)
 
 	if len(data) != lenRecord {
-		return nil, fmt.Errorf("invalid data length for GNSSAccumulatedDrivingRecord: got %d, want %d", len(data), lenRecord)
+		return nil, fmt.Errorf("invalid data length for GNSSAccumulatedDrivingRecord: got %d, want %d: %w", len(data), lenRecord, dd.ErrInvalidValue)
 	}
 
 	var record cardv1.GnssPlaces_Record
@@ -126,23 +131,26 @@ func unmarshalGNSSAccumulatedDrivingRecord(data []byte, opts UnmarshalOptions) (
 	// Parse timestamp (TimeReal - 4 bytes)
 	timestamp, err := opts.UnmarshalTimeReal(data[idxTimeStamp : idxTimeStamp+4])
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal timestamp: %w", err)
+		return nil, dd.WrapField("timestamp", idxTimeStamp, fmt.Errorf("failed to unmarshal timestamp: %w", err))
 	}
 	record.SetTimestamp(timestamp)
+	opts.Layout.Field("timestamp", idxTimeStamp, 4)
 
 	// Parse GNSS place record (11 bytes)
 	gnssPlaceRecord, err := opts.UnmarshalGNSSPlaceRecord(data[idxGnssPlaceRecord : idxGnssPlaceRecord+11])
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal GNSS place record: %w", err)
+		return nil, dd.WrapField("gnss_place_record", idxGnssPlaceRecord, fmt.Errorf("failed to unmarshal GNSS place record: %w", err))
 	}
 	record.SetGnssPlaceRecord(gnssPlaceRecord)
+	opts.Layout.Field("gnss_place_record", idxGnssPlaceRecord, 11)
 
 	// Parse vehicle odometer (OdometerShort - 3 bytes)
 	odometer, err := opts.UnmarshalOdometer(data[idxVehicleOdometer : idxVehicleOdometer+3])
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal vehicle odometer: %w", err)
+		return nil, dd.WrapField("vehicle_odometer_km", idxVehicleOdometer, fmt.Errorf("failed to unmarshal vehicle odometer: %w", err))
 	}
 	record.SetVehicleOdometerKm(int32(odometer))
+	opts.Layout.Field("vehicle_odometer_km", idxVehicleOdometer, 3)
 
 	return &record, nil
 }
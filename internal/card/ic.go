@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 
+	"github.com/way-platform/tachograph-go/internal/dd"
 	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
 )
 
@@ -25,7 +26,7 @@ func (opts UnmarshalOptions) unmarshalIc(data []byte) (*cardv1.Ic, error) {
 	)
 
 	if len(data) < lenCardChipIdentification {
-		return nil, fmt.Errorf("insufficient data for IC identification: got %d bytes, need %d", len(data), lenCardChipIdentification)
+		return nil, fmt.Errorf("insufficient data for IC identification: got %d bytes, need %d: %w", len(data), lenCardChipIdentification, dd.ErrTruncated)
 	}
 
 	var target cardv1.Ic
@@ -34,16 +35,18 @@ func (opts UnmarshalOptions) unmarshalIc(data []byte) (*cardv1.Ic, error) {
 	// Read IC Serial Number (4 bytes)
 	serialBytes := make([]byte, lenIcSerialNumber)
 	if _, err := r.Read(serialBytes); err != nil {
-		return nil, fmt.Errorf("failed to read IC serial number: %w", err)
+		return nil, dd.WrapField("ic_serial_number", 0, fmt.Errorf("failed to read IC serial number: %w", err))
 	}
 	target.SetIcSerialNumber(serialBytes)
+	opts.Layout.Field("ic_serial_number", 0, lenIcSerialNumber)
 
 	// Read IC Manufacturing References (4 bytes)
 	mfgBytes := make([]byte, lenIcManufacturingReferences)
 	if _, err := r.Read(mfgBytes); err != nil {
-		return nil, fmt.Errorf("failed to read IC manufacturing references: %w", err)
+		return nil, dd.WrapField("ic_manufacturing_references", lenIcSerialNumber, fmt.Errorf("failed to read IC manufacturing references: %w", err))
 	}
 	target.SetIcManufacturingReferences(mfgBytes)
+	opts.Layout.Field("ic_manufacturing_references", lenIcSerialNumber, lenIcManufacturingReferences)
 
 	return &target, nil
 }
@@ -44,23 +44,25 @@ func (opts UnmarshalOptions) unmarshalIcc(data []byte) (*cardv1.Icc, error) {
 
 	// Read clock stop (1 byte)
 	if offset+1 > len(data) {
-		return nil, fmt.Errorf("insufficient data for clock stop")
+		return nil, dd.WrapField("clock_stop", offset, fmt.Errorf("insufficient data for clock stop: %w", dd.ErrTruncated))
 	}
 	// Convert clock stop byte to ClockStopMode enum using generic helper
 	if clockStopMode, err := dd.UnmarshalEnum[ddv1.ClockStopMode](data[offset]); err == nil {
 		icc.SetClockStop(clockStopMode)
 	} else {
-		return nil, fmt.Errorf("invalid clock stop mode: %w", err)
+		return nil, dd.WrapField("clock_stop", offset, fmt.Errorf("invalid clock stop mode: %w", err))
 	}
+	opts.Layout.Field("clock_stop", offset, 1)
 	offset++
 
 	// Create ExtendedSerialNumber structure
 	esn := &ddv1.ExtendedSerialNumber{}
 	// Read the 8-byte extended serial number
 	if offset+lenCardExtendedSerialNumber > len(data) {
-		return nil, fmt.Errorf("insufficient data for card extended serial number")
+		return nil, dd.WrapField("card_extended_serial_number", offset, fmt.Errorf("insufficient data for card extended serial number: %w", dd.ErrTruncated))
 	}
 	serialBytes := data[offset : offset+lenCardExtendedSerialNumber]
+	opts.Layout.Field("card_extended_serial_number", offset, lenCardExtendedSerialNumber)
 	offset += lenCardExtendedSerialNumber
 	if len(serialBytes) >= lenCardExtendedSerialNumber {
 		// Parse the fields according to ExtendedSerialNumber structure
@@ -72,7 +74,7 @@ func (opts UnmarshalOptions) unmarshalIcc(data []byte) (*cardv1.Icc, error) {
 		if len(serialBytes) > 5 {
 			monthYear, err := opts.UnmarshalMonthYear(serialBytes[4:6])
 			if err != nil {
-				return nil, fmt.Errorf("failed to parse month/year: %w", err)
+				return nil, dd.WrapField("card_extended_serial_number.month_year", offset-lenCardExtendedSerialNumber+4, fmt.Errorf("failed to parse month/year: %w", err))
 			}
 			esn.SetMonthYear(monthYear)
 		}
@@ -82,7 +84,7 @@ func (opts UnmarshalOptions) unmarshalIcc(data []byte) (*cardv1.Icc, error) {
 			if equipmentType, err := dd.UnmarshalEnum[ddv1.EquipmentType](serialBytes[6]); err == nil {
 				esn.SetType(equipmentType)
 			} else {
-				return nil, fmt.Errorf("invalid equipment type in extended serial number: %w", err)
+				return nil, dd.WrapField("card_extended_serial_number.type", offset-lenCardExtendedSerialNumber+6, fmt.Errorf("invalid equipment type in extended serial number: %w", err))
 			}
 		}
 
@@ -95,42 +97,45 @@ func (opts UnmarshalOptions) unmarshalIcc(data []byte) (*cardv1.Icc, error) {
 
 	// Read card approval number (8 bytes)
 	if offset+lenCardApprovalNumber > len(data) {
-		return nil, fmt.Errorf("insufficient data for card approval number")
+		return nil, dd.WrapField("card_approval_number", offset, fmt.Errorf("insufficient data for card approval number: %w", dd.ErrTruncated))
 	}
 	cardApprovalNumber, err := opts.UnmarshalIa5StringValue(data[offset : offset+lenCardApprovalNumber])
 	if err != nil {
-		return nil, fmt.Errorf("failed to read card approval number: %w", err)
+		return nil, dd.WrapField("card_approval_number", offset, fmt.Errorf("failed to read card approval number: %w", err))
 	}
 	icc.SetCardApprovalNumber(cardApprovalNumber)
+	opts.Layout.Field("card_approval_number", offset, lenCardApprovalNumber)
 	offset += lenCardApprovalNumber
 
 	// Read card personaliser ID (1 byte)
 	if offset+1 > len(data) {
-		return nil, fmt.Errorf("insufficient data for card personaliser ID")
+		return nil, dd.WrapField("card_personaliser_id", offset, fmt.Errorf("insufficient data for card personaliser ID: %w", dd.ErrTruncated))
 	}
 	personaliser := data[offset]
 	icc.SetCardPersonaliserId(int32(personaliser))
+	opts.Layout.Field("card_personaliser_id", offset, 1)
 	offset++
 
 	// Create EmbedderIcAssemblerId structure (5 bytes)
 	if offset+lenEmbedderIcAssemblerId > len(data) {
-		return nil, fmt.Errorf("insufficient data for embedder IC assembler ID")
+		return nil, dd.WrapField("embedder_ic_assembler_id", offset, fmt.Errorf("insufficient data for embedder IC assembler ID: %w", dd.ErrTruncated))
 	}
 	embedder := data[offset : offset+lenEmbedderIcAssemblerId]
+	opts.Layout.Field("embedder_ic_assembler_id", offset, lenEmbedderIcAssemblerId)
 	offset += lenEmbedderIcAssemblerId
 	eia := &cardv1.Icc_EmbedderIcAssemblerId{}
 	if len(embedder) >= lenEmbedderIcAssemblerId {
 		// Country code (2 bytes, IA5String)
 		countryCode, err := opts.UnmarshalIa5StringValue(embedder[0:2])
 		if err != nil {
-			return nil, fmt.Errorf("failed to unmarshal country code: %w", err)
+			return nil, dd.WrapField("embedder_ic_assembler_id.country_code", offset-lenEmbedderIcAssemblerId, fmt.Errorf("failed to unmarshal country code: %w", err))
 		}
 		eia.SetCountryCode(countryCode)
 
 		// Module embedder (2 bytes, IA5String)
 		moduleEmbedder, err := opts.UnmarshalIa5StringValue(embedder[2:4])
 		if err != nil {
-			return nil, fmt.Errorf("failed to unmarshal module embedder: %w", err)
+			return nil, dd.WrapField("embedder_ic_assembler_id.module_embedder", offset-lenEmbedderIcAssemblerId+2, fmt.Errorf("failed to unmarshal module embedder: %w", err))
 		}
 		eia.SetModuleEmbedder(moduleEmbedder)
 
@@ -141,11 +146,12 @@ func (opts UnmarshalOptions) unmarshalIcc(data []byte) (*cardv1.Icc, error) {
 
 	// Read IC identifier (2 bytes)
 	if offset+lenIcIdentifier > len(data) {
-		return nil, fmt.Errorf("insufficient data for IC identifier")
+		return nil, dd.WrapField("ic_identifier", offset, fmt.Errorf("insufficient data for IC identifier: %w", dd.ErrTruncated))
 	}
 	icIdentifier := data[offset : offset+lenIcIdentifier]
 	// offset += lenIcIdentifier // Not needed as this is the last field
 	icc.SetIcIdentifier(icIdentifier)
+	opts.Layout.Field("ic_identifier", offset, lenIcIdentifier)
 	return &icc, nil
 }
 
@@ -58,7 +58,7 @@ func (opts UnmarshalOptions) unmarshalIdentification(data []byte) (*cardv1.Ident
 
 	// Read nation as byte and convert to NationNumeric
 	if offset+1 > len(data) {
-		return nil, fmt.Errorf("insufficient data for card issuing member state")
+		return nil, dd.WrapField("card.card_issuing_member_state", offset, fmt.Errorf("insufficient data for card issuing member state: %w", dd.ErrTruncated))
 	}
 	if nation, err := dd.UnmarshalEnum[ddv1.NationNumeric](data[offset]); err == nil {
 		cardId.SetCardIssuingMemberState(nation)
@@ -66,6 +66,7 @@ func (opts UnmarshalOptions) unmarshalIdentification(data []byte) (*cardv1.Ident
 		// Value not recognized - set UNRECOGNIZED (no unrecognized field for this type)
 		cardId.SetCardIssuingMemberState(ddv1.NationNumeric_NATION_NUMERIC_UNRECOGNIZED)
 	}
+	opts.Layout.Field("card.card_issuing_member_state", offset, 1)
 	offset++
 
 	// Handle the CardNumber CHOICE type (16 bytes total)
@@ -74,10 +75,11 @@ func (opts UnmarshalOptions) unmarshalIdentification(data []byte) (*cardv1.Ident
 	//     -- Other Cards: 13 bytes identification + 1 byte consecutive + 1 byte replacement + 1 byte renewal
 	// }
 	if offset+16 > len(data) {
-		return nil, fmt.Errorf("insufficient data for card number")
+		return nil, dd.WrapField("card.card_number", offset, fmt.Errorf("insufficient data for card number: %w", dd.ErrTruncated))
 	}
 
 	cardNumberData := data[offset : offset+16]
+	opts.Layout.Field("card.card_number", offset, 16)
 	offset += 16
 
 	// Determine card type based on the data structure
@@ -115,28 +117,28 @@ func (opts UnmarshalOptions) unmarshalIdentification(data []byte) (*cardv1.Ident
 			// Owner identification (13 bytes)
 			ownerIdentification, err := opts.UnmarshalIa5StringValue(cardNumberData[0:13])
 			if err != nil {
-				return nil, fmt.Errorf("failed to read owner identification: %w", err)
+				return nil, dd.WrapField("card.owner_identification.owner_identification", offset-16, fmt.Errorf("failed to read owner identification: %w", err))
 			}
 			ownerID.SetOwnerIdentification(ownerIdentification)
 
 			// Consecutive index (1 byte)
 			consecutiveIndex, err := opts.UnmarshalIa5StringValue(cardNumberData[13:14])
 			if err != nil {
-				return nil, fmt.Errorf("failed to read consecutive index: %w", err)
+				return nil, dd.WrapField("card.owner_identification.consecutive_index", offset-16+13, fmt.Errorf("failed to read consecutive index: %w", err))
 			}
 			ownerID.SetConsecutiveIndex(consecutiveIndex)
 
 			// Replacement index (1 byte)
 			replacementIndex, err := opts.UnmarshalIa5StringValue(cardNumberData[14:15])
 			if err != nil {
-				return nil, fmt.Errorf("failed to read replacement index: %w", err)
+				return nil, dd.WrapField("card.owner_identification.replacement_index", offset-16+14, fmt.Errorf("failed to read replacement index: %w", err))
 			}
 			ownerID.SetReplacementIndex(replacementIndex)
 
 			// Renewal index (1 byte)
 			renewalIndex, err := opts.UnmarshalIa5StringValue(cardNumberData[15:16])
 			if err != nil {
-				return nil, fmt.Errorf("failed to read renewal index: %w", err)
+				return nil, dd.WrapField("card.owner_identification.renewal_index", offset-16+15, fmt.Errorf("failed to read renewal index: %w", err))
 			}
 			ownerID.SetRenewalIndex(renewalIndex)
 
@@ -150,28 +152,28 @@ func (opts UnmarshalOptions) unmarshalIdentification(data []byte) (*cardv1.Ident
 		// Owner identification (13 bytes)
 		ownerIdentification, err := opts.UnmarshalIa5StringValue(cardNumberData[0:13])
 		if err != nil {
-			return nil, fmt.Errorf("failed to read owner identification: %w", err)
+			return nil, dd.WrapField("card.owner_identification.owner_identification", offset-16, fmt.Errorf("failed to read owner identification: %w", err))
 		}
 		ownerID.SetOwnerIdentification(ownerIdentification)
 
 		// Consecutive index (1 byte)
 		consecutiveIndex, err := opts.UnmarshalIa5StringValue(cardNumberData[13:14])
 		if err != nil {
-			return nil, fmt.Errorf("failed to read consecutive index: %w", err)
+			return nil, dd.WrapField("card.owner_identification.consecutive_index", offset-16+13, fmt.Errorf("failed to read consecutive index: %w", err))
 		}
 		ownerID.SetConsecutiveIndex(consecutiveIndex)
 
 		// Replacement index (1 byte)
 		replacementIndex, err := opts.UnmarshalIa5StringValue(cardNumberData[14:15])
 		if err != nil {
-			return nil, fmt.Errorf("failed to read replacement index: %w", err)
+			return nil, dd.WrapField("card.owner_identification.replacement_index", offset-16+14, fmt.Errorf("failed to read replacement index: %w", err))
 		}
 		ownerID.SetReplacementIndex(replacementIndex)
 
 		// Renewal index (1 byte)
 		renewalIndex, err := opts.UnmarshalIa5StringValue(cardNumberData[15:16])
 		if err != nil {
-			return nil, fmt.Errorf("failed to read renewal index: %w", err)
+			return nil, dd.WrapField("card.owner_identification.renewal_index", offset-16+15, fmt.Errorf("failed to read renewal index: %w", err))
 		}
 		ownerID.SetRenewalIndex(renewalIndex)
 
@@ -181,46 +183,50 @@ func (opts UnmarshalOptions) unmarshalIdentification(data []byte) (*cardv1.Ident
 
 	// Authority name (36 bytes)
 	if offset+36 > len(data) {
-		return nil, fmt.Errorf("insufficient data for card issuing authority name")
+		return nil, dd.WrapField("card.card_issuing_authority_name", offset, fmt.Errorf("insufficient data for card issuing authority name: %w", dd.ErrTruncated))
 	}
 	authorityName, err := opts.UnmarshalStringValue(data[offset : offset+36])
 	if err != nil {
-		return nil, fmt.Errorf("failed to read card issuing authority name: %w", err)
+		return nil, dd.WrapField("card.card_issuing_authority_name", offset, fmt.Errorf("failed to read card issuing authority name: %w", err))
 	}
 	cardId.SetCardIssuingAuthorityName(authorityName)
+	opts.Layout.Field("card.card_issuing_authority_name", offset, 36)
 	offset += 36
 
 	// Card issue date (4 bytes)
 	if offset+4 > len(data) {
-		return nil, fmt.Errorf("insufficient data for card issue date")
+		return nil, dd.WrapField("card.card_issue_date", offset, fmt.Errorf("insufficient data for card issue date: %w", dd.ErrTruncated))
 	}
 	cardIssueDate, err := opts.UnmarshalTimeReal(data[offset : offset+4])
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse card issue date: %w", err)
+		return nil, dd.WrapField("card.card_issue_date", offset, fmt.Errorf("failed to parse card issue date: %w", err))
 	}
 	cardId.SetCardIssueDate(cardIssueDate)
+	opts.Layout.Field("card.card_issue_date", offset, 4)
 	offset += 4
 
 	// Card validity begin (4 bytes)
 	if offset+4 > len(data) {
-		return nil, fmt.Errorf("insufficient data for card validity begin")
+		return nil, dd.WrapField("card.card_validity_begin", offset, fmt.Errorf("insufficient data for card validity begin: %w", dd.ErrTruncated))
 	}
 	cardValidityBegin, err := opts.UnmarshalTimeReal(data[offset : offset+4])
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse card validity begin: %w", err)
+		return nil, dd.WrapField("card.card_validity_begin", offset, fmt.Errorf("failed to parse card validity begin: %w", err))
 	}
 	cardId.SetCardValidityBegin(cardValidityBegin)
+	opts.Layout.Field("card.card_validity_begin", offset, 4)
 	offset += 4
 
 	// Card expiry date (4 bytes)
 	if offset+4 > len(data) {
-		return nil, fmt.Errorf("insufficient data for card expiry date")
+		return nil, dd.WrapField("card.card_expiry_date", offset, fmt.Errorf("insufficient data for card expiry date: %w", dd.ErrTruncated))
 	}
 	cardExpiryDate, err := opts.UnmarshalTimeReal(data[offset : offset+4])
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse card expiry date: %w", err)
+		return nil, dd.WrapField("card.card_expiry_date", offset, fmt.Errorf("failed to parse card expiry date: %w", err))
 	}
 	cardId.SetCardExpiryDate(cardExpiryDate)
+	opts.Layout.Field("card.card_expiry_date", offset, 4)
 	offset += 4
 
 	identification.SetCard(cardId)
@@ -230,46 +236,50 @@ func (opts UnmarshalOptions) unmarshalIdentification(data []byte) (*cardv1.Ident
 
 	// Card holder surname (36 bytes)
 	if offset+36 > len(data) {
-		return nil, fmt.Errorf("insufficient data for card holder surname")
+		return nil, dd.WrapField("driver_card_holder.card_holder_surname", offset, fmt.Errorf("insufficient data for card holder surname: %w", dd.ErrTruncated))
 	}
 	surname, err := opts.UnmarshalStringValue(data[offset : offset+36])
 	if err != nil {
-		return nil, fmt.Errorf("failed to read card holder surname: %w", err)
+		return nil, dd.WrapField("driver_card_holder.card_holder_surname", offset, fmt.Errorf("failed to read card holder surname: %w", err))
 	}
 	holderId.SetCardHolderSurname(surname)
+	opts.Layout.Field("driver_card_holder.card_holder_surname", offset, 36)
 	offset += 36
 
 	// Card holder first names (36 bytes)
 	if offset+36 > len(data) {
-		return nil, fmt.Errorf("insufficient data for card holder first names")
+		return nil, dd.WrapField("driver_card_holder.card_holder_first_names", offset, fmt.Errorf("insufficient data for card holder first names: %w", dd.ErrTruncated))
 	}
 	firstNames, err := opts.UnmarshalStringValue(data[offset : offset+36])
 	if err != nil {
-		return nil, fmt.Errorf("failed to read card holder first names: %w", err)
+		return nil, dd.WrapField("driver_card_holder.card_holder_first_names", offset, fmt.Errorf("failed to read card holder first names: %w", err))
 	}
 	holderId.SetCardHolderFirstNames(firstNames)
+	opts.Layout.Field("driver_card_holder.card_holder_first_names", offset, 36)
 	offset += 36
 
 	// Card holder birth date (4 bytes)
 	if offset+4 > len(data) {
-		return nil, fmt.Errorf("insufficient data for card holder birth date")
+		return nil, dd.WrapField("driver_card_holder.card_holder_birth_date", offset, fmt.Errorf("insufficient data for card holder birth date: %w", dd.ErrTruncated))
 	}
 	birthDate, err := opts.UnmarshalDate(data[offset : offset+4])
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse card holder birth date: %w", err)
+		return nil, dd.WrapField("driver_card_holder.card_holder_birth_date", offset, fmt.Errorf("failed to parse card holder birth date: %w", err))
 	}
 	holderId.SetCardHolderBirthDate(birthDate)
+	opts.Layout.Field("driver_card_holder.card_holder_birth_date", offset, 4)
 	offset += 4
 
 	// Card holder preferred language (2 bytes) - Language ::= IA5String(SIZE(2))
 	if offset+2 > len(data) {
-		return nil, fmt.Errorf("insufficient data for card holder preferred language")
+		return nil, dd.WrapField("driver_card_holder.card_holder_preferred_language", offset, fmt.Errorf("insufficient data for card holder preferred language: %w", dd.ErrTruncated))
 	}
 	preferredLanguage, err := opts.UnmarshalIa5StringValue(data[offset : offset+2])
 	if err != nil {
-		return nil, fmt.Errorf("failed to read card holder preferred language: %w", err)
+		return nil, dd.WrapField("driver_card_holder.card_holder_preferred_language", offset, fmt.Errorf("failed to read card holder preferred language: %w", err))
 	}
 	holderId.SetCardHolderPreferredLanguage(preferredLanguage)
+	opts.Layout.Field("driver_card_holder.card_holder_preferred_language", offset, 2)
 	// offset += 2 // Not needed as this is the last field
 
 	identification.SetDriverCardHolder(holderId)
@@ -277,6 +287,17 @@ func (opts UnmarshalOptions) unmarshalIdentification(data []byte) (*cardv1.Ident
 	return &identification, nil
 }
 
+// appendIdentification appends the binary representation of the composite
+// EF_IDENTIFICATION file, which is CardIdentification followed by
+// DriverCardHolderIdentification.
+func appendIdentification(dst []byte, id *cardv1.Identification) ([]byte, error) {
+	dst, err := appendCardIdentification(dst, id.GetCard())
+	if err != nil {
+		return nil, err
+	}
+	return appendDriverCardHolderIdentification(dst, id.GetDriverCardHolder())
+}
+
 // AppendCardIdentification appends the binary representation of CardIdentification to dst.
 //
 // The data type `CardIdentification` is specified in the Data Dictionary, Section 2.1.
@@ -17,7 +17,7 @@ import (
 // - placeRecords: N × 10 bytes (84-112 records for driver cards)
 func (opts UnmarshalOptions) unmarshalPlaces(data []byte) (*cardv1.Places, error) {
 	if len(data) < 1 {
-		return nil, fmt.Errorf("insufficient data for places: got %d bytes, need at least 1", len(data))
+		return nil, fmt.Errorf("insufficient data for places: got %d bytes, need at least 1: %w", len(data), dd.ErrTruncated)
 	}
 
 	target := &cardv1.Places{}
@@ -28,6 +28,7 @@ func (opts UnmarshalOptions) unmarshalPlaces(data []byte) (*cardv1.Places, error
 	// Read the newest record index (1 byte for Gen1)
 	newestRecordIndex := data[0]
 	target.SetNewestRecordIndex(int32(newestRecordIndex))
+	opts.Layout.Field("newest_record_index", 0, 1)
 
 	// Remaining data contains the circular buffer of place records
 	remainingData := data[1:]
@@ -36,6 +37,7 @@ func (opts UnmarshalOptions) unmarshalPlaces(data []byte) (*cardv1.Places, error
 	ddOpts := dd.UnmarshalOptions{
 		Generation: opts.Generation,
 		Version:    opts.Version,
+		Layout:     opts.Layout.Nested("records", 1),
 	}
 
 	// Parse Gen1 records (10 bytes each)
@@ -67,6 +69,7 @@ func parseCircularPlaceRecordsGen1(data []byte, newestIndex int, opts dd.Unmarsh
 		}
 
 		records = append(records, record)
+		opts.Layout.Element("", i, start, recordSize)
 	}
 
 	return records, trailingBytes
@@ -18,7 +18,7 @@ import (
 // - placeRecords: N × 21 bytes (112 records for driver cards)
 func (opts UnmarshalOptions) unmarshalPlacesG2(data []byte) (*cardv1.PlacesG2, error) {
 	if len(data) < 2 {
-		return nil, fmt.Errorf("insufficient data for places: got %d bytes, need at least 2", len(data))
+		return nil, fmt.Errorf("insufficient data for places: got %d bytes, need at least 2: %w", len(data), dd.ErrTruncated)
 	}
 
 	target := &cardv1.PlacesG2{}
@@ -29,6 +29,7 @@ func (opts UnmarshalOptions) unmarshalPlacesG2(data []byte) (*cardv1.PlacesG2, e
 	// Read the newest record index (2 bytes for Gen2)
 	newestRecordIndex := binary.BigEndian.Uint16(data[0:2])
 	target.SetNewestRecordIndex(int32(newestRecordIndex))
+	opts.Layout.Field("newest_record_index", 0, 2)
 
 	// Remaining data contains the circular buffer of place records
 	remainingData := data[2:]
@@ -37,6 +38,7 @@ func (opts UnmarshalOptions) unmarshalPlacesG2(data []byte) (*cardv1.PlacesG2, e
 	ddOpts := dd.UnmarshalOptions{
 		Generation: opts.Generation,
 		Version:    opts.Version,
+		Layout:     opts.Layout.Nested("records", 2),
 	}
 
 	// Parse Gen2 records (21 bytes each)
@@ -68,6 +70,7 @@ func parseCircularPlaceRecordsG2(data []byte, newestIndex int, opts dd.Unmarshal
 		}
 
 		records = append(records, record)
+		opts.Layout.Element("", i, start, recordSize)
 	}
 
 	return records, trailingBytes
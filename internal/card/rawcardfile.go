@@ -4,30 +4,54 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/binary"
-	"io"
 
+	"github.com/way-platform/tachograph-go/internal/dd"
 	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
 	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
 )
 
 // UnmarshalRawCardFile parses raw card data.
 func UnmarshalRawCardFile(input []byte) (*cardv1.RawCardFile, error) {
+	output, err := ScanRawCardFile(input)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// ScanRawCardFile parses raw card data like [UnmarshalRawCardFile], but on
+// error also returns the records that were parsed before the error.
+//
+// The records are contiguous, so the error occurred at the offset following
+// the last returned record.
+func ScanRawCardFile(input []byte) (*cardv1.RawCardFile, error) {
 	var output cardv1.RawCardFile
 	sc := bufio.NewScanner(bytes.NewReader(input))
-	sc.Split(scanCardFile)
+	sc.Split(ScanRecord)
 	for sc.Scan() {
-		record, err := unmarshalRawCardFileRecord(sc.Bytes())
+		record, err := UnmarshalRawRecord(sc.Bytes())
 		if err != nil {
-			return nil, err
+			return &output, err
 		}
 		output.SetRecords(append(output.GetRecords(), record))
 	}
 	if err := sc.Err(); err != nil {
-		return nil, err
+		return &output, err
 	}
 	return &output, nil
 }
 
+// RecordOffsets returns the byte offset of each record of a raw card file.
+func RecordOffsets(file *cardv1.RawCardFile) []int {
+	offsets := make([]int, len(file.GetRecords()))
+	offset := 0
+	for i, record := range file.GetRecords() {
+		offsets[i] = offset
+		offset += 5 + len(record.GetValue())
+	}
+	return offsets
+}
+
 // MarshalRawCardFile serializes a RawCardFile into binary format.
 func MarshalRawCardFile(file *cardv1.RawCardFile) ([]byte, error) {
 	var result []byte
@@ -43,8 +67,8 @@ func MarshalRawCardFile(file *cardv1.RawCardFile) ([]byte, error) {
 	return result, nil
 }
 
-// scanCardFile is a [bufio.SplitFunc] that splits a card file into separate TLV records.
-func scanCardFile(data []byte, atEOF bool) (advance int, token []byte, err error) {
+// ScanRecord is a [bufio.SplitFunc] that splits a card file into separate TLV records.
+func ScanRecord(data []byte, atEOF bool) (advance int, token []byte, err error) {
 	// Need at least 5 bytes for TLV header (3 bytes tag + 2 bytes length)
 	if len(data) < 5 {
 		if atEOF {
@@ -53,7 +77,7 @@ func scanCardFile(data []byte, atEOF bool) (advance int, token []byte, err error
 				return 0, nil, nil
 			}
 			// We have some data but not enough for a complete header
-			return 0, nil, io.ErrUnexpectedEOF
+			return 0, nil, dd.ErrTruncated
 		}
 		// Request more data
 		return 0, nil, nil
@@ -66,7 +90,7 @@ func scanCardFile(data []byte, atEOF bool) (advance int, token []byte, err error
 	if len(data) < totalSize {
 		if atEOF {
 			// We're at EOF but don't have enough data - this is an error condition
-			return 0, nil, io.ErrUnexpectedEOF
+			return 0, nil, dd.ErrTruncated
 		}
 		// Request more data
 		return 0, nil, nil
@@ -75,8 +99,8 @@ func scanCardFile(data []byte, atEOF bool) (advance int, token []byte, err error
 	return totalSize, data[:totalSize], nil
 }
 
-// unmarshalRawCardFileRecord unmarshals a single raw card file record
-func unmarshalRawCardFileRecord(input []byte) (*cardv1.RawCardFile_Record, error) {
+// UnmarshalRawRecord unmarshals a single TLV record, as split by [ScanRecord].
+func UnmarshalRawRecord(input []byte) (*cardv1.RawCardFile_Record, error) {
 	var output cardv1.RawCardFile_Record
 	// Parse tag: FID (2 bytes) + appendix (1 byte)
 	fid := binary.BigEndian.Uint16(input[0:2])
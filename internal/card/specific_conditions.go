@@ -43,6 +43,7 @@ func (opts UnmarshalOptions) unmarshalSpecificConditions(data []byte) (*cardv1.S
 		if err != nil {
 			break // Stop parsing on error, but return what we have
 		}
+		opts.Layout.Element("records", len(records), offset, lenSpecificConditionRecord)
 		records = append(records, record)
 		offset += lenSpecificConditionRecord
 	}
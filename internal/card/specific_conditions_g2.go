@@ -27,7 +27,7 @@ func (opts UnmarshalOptions) unmarshalSpecificConditionsG2(data []byte) (*cardv1
 	)
 
 	if len(data) < lenPointer {
-		return nil, fmt.Errorf("insufficient data for Gen2 specific conditions: got %d bytes, need at least %d", len(data), lenPointer)
+		return nil, fmt.Errorf("insufficient data for Gen2 specific conditions: got %d bytes, need at least %d: %w", len(data), lenPointer, dd.ErrTruncated)
 	}
 
 	target := &cardv1.SpecificConditionsG2{}
@@ -38,6 +38,7 @@ func (opts UnmarshalOptions) unmarshalSpecificConditionsG2(data []byte) (*cardv1
 	// Read newest record pointer (2 bytes)
 	newestRecordPointer := binary.BigEndian.Uint16(data[0:lenPointer])
 	target.SetNewestRecordIndex(int32(newestRecordPointer))
+	opts.Layout.Field("newest_record_index", 0, lenPointer)
 
 	// Parse records
 	recordsData := data[lenPointer:]
@@ -49,6 +50,7 @@ func (opts UnmarshalOptions) unmarshalSpecificConditionsG2(data []byte) (*cardv1
 		if err != nil {
 			break // Stop parsing on error, but return what we have
 		}
+		opts.Layout.Element("records", len(records), lenPointer+offset, lenSpecificConditionRecord)
 		records = append(records, record)
 		offset += lenSpecificConditionRecord
 	}
@@ -45,7 +45,7 @@ func (opts UnmarshalOptions) unmarshalVehicleUnitsUsed(data []byte) (*cardv1.Veh
 	)
 
 	if len(data) < lenCardVehicleUnitsUsedMinimum {
-		return nil, fmt.Errorf("invalid data length for CardVehicleUnitsUsed: got %d, want at least %d", len(data), lenCardVehicleUnitsUsedMinimum)
+		return nil, fmt.Errorf("invalid data length for CardVehicleUnitsUsed: got %d, want at least %d: %w", len(data), lenCardVehicleUnitsUsedMinimum, dd.ErrInvalidValue)
 	}
 
 	// Validate that the records section is a multiple of record size
@@ -59,11 +59,14 @@ func (opts UnmarshalOptions) unmarshalVehicleUnitsUsed(data []byte) (*cardv1.Veh
 	// Parse newest record pointer
 	newestRecordPointer := binary.BigEndian.Uint16(data[idxNewestRecordPointer:])
 	target.SetVehicleUnitPointerNewestRecord(int32(newestRecordPointer))
+	opts.Layout.Field("vehicle_unit_pointer_newest_record", idxNewestRecordPointer, lenNewestRecordPointer)
 
 	// Parse records using bufio.Scanner pattern
-	records, err := parseCardVehicleUnitRecords(data[lenNewestRecordPointer:], opts)
+	recordsOpts := opts
+	recordsOpts.Layout = opts.Layout.Nested("records", lenNewestRecordPointer)
+	records, err := parseCardVehicleUnitRecords(data[lenNewestRecordPointer:], recordsOpts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse vehicle unit records: %w", err)
+		return nil, dd.WrapField("records", lenNewestRecordPointer, fmt.Errorf("failed to parse vehicle unit records: %w", err))
 	}
 	target.SetRecords(records)
 
@@ -90,10 +93,12 @@ func parseCardVehicleUnitRecords(data []byte, opts UnmarshalOptions) ([]*cardv1.
 	scanner.Split(splitCardVehicleUnitRecord)
 
 	var records []*cardv1.VehicleUnitsUsed_Record
-	for scanner.Scan() {
-		record, err := unmarshalCardVehicleUnitRecord(scanner.Bytes(), opts)
+	for i := 0; scanner.Scan(); i++ {
+		recordOpts := opts
+		recordOpts.Layout = opts.Layout.NestedElement("", i, i*len(scanner.Bytes()))
+		record, err := unmarshalCardVehicleUnitRecord(scanner.Bytes(), recordOpts)
 		if err != nil {
-			return nil, fmt.Errorf("failed to unmarshal vehicle unit record: %w", err)
+			return nil, dd.WrapField(fmt.Sprintf("[%d]", i), i*len(scanner.Bytes()), fmt.Errorf("failed to unmarshal vehicle unit record: %w", err))
 		}
 		records = append(records, record)
 	}
@@ -122,7 +127,7 @@ func unmarshalCardVehicleUnitRecord(data []byte, opts UnmarshalOptions) (*cardv1
 	)
 
 	if len(data) != lenRecord {
-		return nil, fmt.Errorf("invalid data length for CardVehicleUnitRecord: got %d, want %d", len(data), lenRecord)
+		return nil, fmt.Errorf("invalid data length for CardVehicleUnitRecord: got %d, want %d: %w", len(data), lenRecord, dd.ErrInvalidValue)
 	}
 
 	var record cardv1.VehicleUnitsUsed_Record
@@ -130,18 +135,22 @@ func unmarshalCardVehicleUnitRecord(data []byte, opts UnmarshalOptions) (*cardv1
 	// Parse timestamp (TimeReal - 4 bytes)
 	timestamp, err := opts.UnmarshalTimeReal(data[idxTimeStamp : idxTimeStamp+4])
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal timestamp: %w", err)
+		return nil, dd.WrapField("timestamp", idxTimeStamp, fmt.Errorf("failed to unmarshal timestamp: %w", err))
 	}
 	record.SetTimestamp(timestamp)
+	opts.Layout.Field("timestamp", idxTimeStamp, 4)
 
 	// Parse manufacturer code (1 byte)
 	record.SetManufacturerCode(int32(data[idxManufacturerCode]))
+	opts.Layout.Field("manufacturer_code", idxManufacturerCode, 1)
 
 	// Parse device ID (1 byte)
 	record.SetDeviceId(data[idxDeviceID : idxDeviceID+1])
+	opts.Layout.Field("device_id", idxDeviceID, 1)
 
 	// Parse VU software version (4 bytes, IA5String)
 	record.SetVuSoftwareVersion(data[idxVuSoftwareVersion : idxVuSoftwareVersion+4])
+	opts.Layout.Field("vu_software_version", idxVuSoftwareVersion, 4)
 
 	return &record, nil
 }
@@ -18,7 +18,7 @@ func (opts UnmarshalOptions) unmarshalVehiclesUsedG2(data []byte) (*cardv1.Vehic
 	)
 
 	if len(data) < lenMinEfVehiclesUsed {
-		return nil, fmt.Errorf("insufficient data for vehicles used: got %d bytes, need at least %d", len(data), lenMinEfVehiclesUsed)
+		return nil, fmt.Errorf("insufficient data for vehicles used: got %d bytes, need at least %d: %w", len(data), lenMinEfVehiclesUsed, dd.ErrTruncated)
 	}
 
 	var target cardv1.VehiclesUsedG2
@@ -31,21 +31,23 @@ func (opts UnmarshalOptions) unmarshalVehiclesUsedG2(data []byte) (*cardv1.Vehic
 	// Read newest record pointer (2 bytes)
 	var newestRecordIndex uint16
 	if err := binary.Read(r, binary.BigEndian, &newestRecordIndex); err != nil {
-		return nil, fmt.Errorf("failed to read newest record index: %w", err)
+		return nil, dd.WrapField("newest_record_index", 0, fmt.Errorf("failed to read newest record index: %w", err))
 	}
 
 	target.SetNewestRecordIndex(int32(newestRecordIndex))
+	opts.Layout.Field("newest_record_index", 0, 2)
 
 	// Create dd.UnmarshalOptions from card-level UnmarshalOptions
 	ddOpts := dd.UnmarshalOptions{
 		Generation: opts.Generation,
 		Version:    opts.Version,
+		Layout:     opts.Layout.Nested("records", lenMinEfVehiclesUsed),
 	}
 
 	// Parse Gen2 vehicle records (48 bytes each)
 	records, err := parseVehicleRecordsGen2(r, ddOpts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse Gen2 vehicle records: %w", err)
+		return nil, dd.WrapField("records", lenMinEfVehiclesUsed, fmt.Errorf("failed to parse Gen2 vehicle records: %w", err))
 	}
 	target.SetRecords(records)
 
@@ -65,8 +67,9 @@ func parseVehicleRecordsGen2(r *bytes.Reader, opts dd.UnmarshalOptions) ([]*ddv1
 
 		record, err := opts.UnmarshalCardVehicleRecordG2(recordBytes)
 		if err != nil {
-			return records, fmt.Errorf("failed to parse Gen2 vehicle record: %w", err)
+			return records, dd.WrapField(fmt.Sprintf("[%d]", len(records)), len(records)*lenCardVehicleRecord, fmt.Errorf("failed to parse Gen2 vehicle record: %w", err))
 		}
+		opts.Layout.Element("", len(records), len(records)*lenCardVehicleRecord, lenCardVehicleRecord)
 		records = append(records, record)
 	}
 
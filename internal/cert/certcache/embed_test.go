@@ -0,0 +1,15 @@
+package certcache
+
+import "testing"
+
+func TestRead(t *testing.T) {
+	if _, ok := ReadG1("1316820541096591105"); !ok {
+		t.Error("ReadG1() did not find an embedded certificate")
+	}
+	if _, ok := ReadG2("1316820541130145537"); !ok {
+		t.Error("ReadG2() did not find an embedded certificate")
+	}
+	if _, ok := ReadG1("0"); ok {
+		t.Error("ReadG1() found a certificate that is not embedded")
+	}
+}
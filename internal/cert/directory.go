@@ -0,0 +1,69 @@
+package cert
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/way-platform/tachograph-go/internal/security"
+	securityv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/security/v1"
+)
+
+// DirectoryResolver resolves certificates from a local directory.
+//
+// The directory uses the same layout as the embedded certificate cache:
+//
+//	root/EC_PK.bin   the ERCA root certificate
+//	g1/<CHR>.bin     Gen1 (RSA) certificates
+//	g2/<CHR>.bin     Gen2 (ECC) certificates
+type DirectoryResolver struct {
+	dir string
+}
+
+var _ Resolver = &DirectoryResolver{}
+
+// NewDirectoryResolver creates a new [DirectoryResolver].
+func NewDirectoryResolver(dir string) *DirectoryResolver {
+	return &DirectoryResolver{
+		dir: dir,
+	}
+}
+
+// GetRootCertificate retrieves the European Root CA certificate.
+func (r *DirectoryResolver) GetRootCertificate(ctx context.Context) (*securityv1.RootCertificate, error) {
+	data, err := os.ReadFile(filepath.Join(r.dir, "root", "EC_PK.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("root certificate not found in directory: %w", err)
+	}
+	return security.UnmarshalRootCertificate(data)
+}
+
+// GetRsaCertificate retrieves an RSA certificate by its CHR.
+func (r *DirectoryResolver) GetRsaCertificate(ctx context.Context, chr string) (*securityv1.RsaCertificate, error) {
+	data, err := r.read("g1", chr)
+	if err != nil {
+		return nil, err
+	}
+	return security.UnmarshalRsaCertificate(data)
+}
+
+// GetEccCertificate retrieves an ECC certificate by its CHR.
+func (r *DirectoryResolver) GetEccCertificate(ctx context.Context, chr string) (*securityv1.EccCertificate, error) {
+	data, err := r.read("g2", chr)
+	if err != nil {
+		return nil, err
+	}
+	return security.UnmarshalEccCertificate(data)
+}
+
+func (r *DirectoryResolver) read(generation, chr string) ([]byte, error) {
+	if chr == "" || filepath.Base(chr) != chr {
+		return nil, fmt.Errorf("invalid CHR %q", chr)
+	}
+	data, err := os.ReadFile(filepath.Join(r.dir, generation, chr+".bin"))
+	if err != nil {
+		return nil, fmt.Errorf("certificate not found in directory: CHR %s: %w", chr, err)
+	}
+	return data, nil
+}
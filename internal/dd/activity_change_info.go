@@ -27,7 +27,7 @@ import (
 func (opts UnmarshalOptions) UnmarshalActivityChangeInfo(input []byte) (*ddv1.ActivityChangeInfo, error) {
 	const lenActivityChangeInfo = 2
 	if len(input) != lenActivityChangeInfo {
-		return nil, fmt.Errorf("invalid data length for ActivityChangeInfo: got %d, want %d", len(input), lenActivityChangeInfo)
+		return nil, fmt.Errorf("invalid data length for ActivityChangeInfo: got %d, want %d: %w", len(input), lenActivityChangeInfo, ErrInvalidValue)
 	}
 	var output ddv1.ActivityChangeInfo
 	output.SetRawData(bytes.Clone(input))
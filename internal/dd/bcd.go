@@ -14,7 +14,7 @@ func decodeBCD(b []byte) (int, error) {
 	s := hex.EncodeToString(b)
 	i, err := strconv.ParseInt(s, 10, 64)
 	if err != nil {
-		return 0, fmt.Errorf("invalid BCD value: %s", s)
+		return 0, fmt.Errorf("invalid BCD value: %s: %w", s, ErrInvalidValue)
 	}
 	return int(i), nil
 }
@@ -43,3 +43,17 @@ func appendBCD(dst []byte, value int, length int) ([]byte, error) {
 	}
 	return result, nil
 }
+
+// decodeBCDByte decodes a byte of two BCD digits, without validating the digits.
+func decodeBCDByte(b byte) int32 {
+	return int32(b>>4)*10 + int32(b&0x0F)
+}
+
+// paintBCDByte returns the BCD encoding of value, or b if b already decodes to
+// value, so that bytes with invalid digits survive a round-trip.
+func paintBCDByte(b byte, value int32) byte {
+	if decodeBCDByte(b) == value {
+		return b
+	}
+	return byte((value/10)%10<<4 | value%10)
+}
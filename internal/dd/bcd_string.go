@@ -2,6 +2,7 @@ package dd
 
 import (
 	"fmt"
+	"math"
 
 	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
 )
@@ -18,12 +19,15 @@ import (
 //   - BCD String (variable): BCD-encoded bytes
 func (opts UnmarshalOptions) UnmarshalBcdString(input []byte) (*ddv1.BcdString, error) {
 	if len(input) == 0 {
-		return nil, fmt.Errorf("insufficient data for BcdString: got %d, want at least 1", len(input))
+		return nil, fmt.Errorf("insufficient data for BcdString: got %d, want at least 1: %w", len(input), ErrTruncated)
 	}
 	value, err := decodeBCD(input)
 	if err != nil {
 		return nil, err
 	}
+	if value > math.MaxInt32 {
+		return nil, fmt.Errorf("BCD value %d overflows BcdString: %w", value, ErrInvalidValue)
+	}
 	var output ddv1.BcdString
 	output.SetValue(int32(value))
 	output.SetLength(int32(len(input)))
@@ -53,7 +57,7 @@ func AppendBcdString(dst []byte, bcdString *ddv1.BcdString) ([]byte, error) {
 	}
 	length := bcdString.GetLength()
 	if length <= 0 {
-		return nil, fmt.Errorf("invalid BCD string length: %d", length)
+		return nil, fmt.Errorf("invalid BCD string length: %d: %w", length, ErrInvalidValue)
 	}
 	return appendBCD(dst, int(value), int(length))
 }
@@ -24,12 +24,12 @@ import (
 func (opts UnmarshalOptions) UnmarshalCardStructureVersion(data []byte) (*ddv1.CardStructureVersion, error) {
 	const lenCardStructureVersion = 2
 	if len(data) != lenCardStructureVersion {
-		return nil, fmt.Errorf("invalid data length for CardStructureVersion: got %d, want %d", len(data), lenCardStructureVersion)
+		return nil, fmt.Errorf("invalid data length for CardStructureVersion: got %d, want %d: %w", len(data), lenCardStructureVersion, ErrInvalidValue)
 	}
 	var output ddv1.CardStructureVersion
 	output.SetRawData(bytes.Clone(data))
-	output.SetMajor(int32((data[0]&0xF0)>>4)*10 + int32(data[0]&0x0F))
-	output.SetMinor(int32((data[1]&0xF0)>>4)*10 + int32(data[1]&0x0F))
+	output.SetMajor(decodeBCDByte(data[0]))
+	output.SetMinor(decodeBCDByte(data[1]))
 	return &output, nil
 }
 
@@ -59,11 +59,7 @@ func AppendCardStructureVersion(dst []byte, csv *ddv1.CardStructureVersion) ([]b
 		}
 		copy(canvas[:], csv.GetRawData())
 	}
-	major := csv.GetMajor()
-	majorBCD := byte((major/10)<<4) | byte(major%10)
-	canvas[0] = majorBCD
-	minor := csv.GetMinor()
-	minorBCD := byte((minor/10)<<4) | byte(minor%10)
-	canvas[1] = minorBCD
+	canvas[0] = paintBCDByte(canvas[0], csv.GetMajor())
+	canvas[1] = paintBCDByte(canvas[1], csv.GetMinor())
 	return append(dst, canvas[:]...), nil
 }
@@ -34,7 +34,7 @@ func (opts UnmarshalOptions) UnmarshalCardVehicleRecord(data []byte) (*ddv1.Card
 	)
 
 	if len(data) != lenCardVehicleRecord {
-		return nil, fmt.Errorf("invalid data length for Gen1 CardVehicleRecord: got %d, want %d", len(data), lenCardVehicleRecord)
+		return nil, fmt.Errorf("invalid data length for Gen1 CardVehicleRecord: got %d, want %d: %w", len(data), lenCardVehicleRecord, ErrInvalidValue)
 	}
 
 	record := &ddv1.CardVehicleRecord{}
@@ -124,11 +124,9 @@ func AppendCardVehicleRecord(dst []byte, record *ddv1.CardVehicleRecord) ([]byte
 	copy(canvas[10:14], lastUseBytes)
 
 	// Vehicle registration (15 bytes)
-	vehicleRegBytes, err := AppendVehicleRegistration(nil, record.GetVehicleRegistration())
-	if err != nil {
+	if err := PaintVehicleRegistration(canvas[14:29], record.GetVehicleRegistration()); err != nil {
 		return nil, fmt.Errorf("failed to append vehicle registration: %w", err)
 	}
-	copy(canvas[14:29], vehicleRegBytes)
 
 	// VU data block counter (2 bytes as BCD)
 	vuDataBlockCounterBytes, err := AppendBcdString(nil, record.GetVuDataBlockCounter())
@@ -36,7 +36,7 @@ func (opts UnmarshalOptions) UnmarshalCardVehicleRecordG2(data []byte) (*ddv1.Ca
 	)
 
 	if len(data) != lenCardVehicleRecord {
-		return nil, fmt.Errorf("invalid data length for Gen2 CardVehicleRecord: got %d, want %d", len(data), lenCardVehicleRecord)
+		return nil, fmt.Errorf("invalid data length for Gen2 CardVehicleRecord: got %d, want %d: %w", len(data), lenCardVehicleRecord, ErrInvalidValue)
 	}
 
 	record := &ddv1.CardVehicleRecordG2{}
@@ -131,11 +131,9 @@ func AppendCardVehicleRecordG2(dst []byte, record *ddv1.CardVehicleRecordG2) ([]
 	copy(canvas[10:14], lastUseBytes)
 
 	// Vehicle registration (15 bytes)
-	vehicleRegBytes, err := AppendVehicleRegistration(nil, record.GetVehicleRegistration())
-	if err != nil {
+	if err := PaintVehicleRegistration(canvas[14:29], record.GetVehicleRegistration()); err != nil {
 		return nil, fmt.Errorf("failed to append vehicle registration: %w", err)
 	}
-	copy(canvas[14:29], vehicleRegBytes)
 
 	// VU data block counter (2 bytes as BCD)
 	vuDataBlockCounterBytes, err := AppendBcdString(nil, record.GetVuDataBlockCounter())
@@ -145,14 +143,17 @@ func AppendCardVehicleRecordG2(dst []byte, record *ddv1.CardVehicleRecordG2) ([]
 	copy(canvas[29:31], vuDataBlockCounterBytes)
 
 	// VIN (17 bytes IA5String)
+	// Keep the raw padding if the VIN is unchanged, since it is trimmed when parsed
 	vin := record.GetVehicleIdentificationNumber()
-	vinBytes := make([]byte, 17)
-	copy(vinBytes, []byte(vin))
-	// Pad with spaces if shorter than 17 bytes
-	for i := len(vin); i < 17; i++ {
-		vinBytes[i] = ' '
+	if strings.TrimRight(string(canvas[31:48]), "\x00 ") != vin {
+		vinBytes := make([]byte, 17)
+		copy(vinBytes, []byte(vin))
+		// Pad with spaces if shorter than 17 bytes
+		for i := len(vin); i < 17; i++ {
+			vinBytes[i] = ' '
+		}
+		copy(canvas[31:48], vinBytes)
 	}
-	copy(canvas[31:48], vinBytes)
 
 	return append(dst, canvas[:]...), nil
 }
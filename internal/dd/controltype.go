@@ -14,8 +14,9 @@ import (
 //
 //	ControlType ::= OCTET STRING (SIZE(1))
 func (opts UnmarshalOptions) UnmarshalControlType(input []byte) (*ddv1.ControlType, error) {
-	if len(input) == 0 {
-		return nil, fmt.Errorf("insufficient data for control type")
+	const lenControlType = 1
+	if len(input) != lenControlType {
+		return nil, fmt.Errorf("invalid data length for ControlType: got %d, want %d: %w", len(input), lenControlType, ErrInvalidValue)
 	}
 	b := input[0]
 	var output ddv1.ControlType
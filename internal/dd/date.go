@@ -21,16 +21,13 @@ import (
 func (opts UnmarshalOptions) UnmarshalDate(input []byte) (*ddv1.Date, error) {
 	const lenDatef = 4
 	if len(input) != lenDatef {
-		return nil, fmt.Errorf("invalid data length for Date: got %d, want %d", len(input), lenDatef)
+		return nil, fmt.Errorf("invalid data length for Date: got %d, want %d: %w", len(input), lenDatef, ErrInvalidValue)
 	}
 	var output ddv1.Date
 	output.SetRawData(input[:lenDatef])
-	year := int32(int32((input[0]&0xF0)>>4)*1000 + int32(input[0]&0x0F)*100 + int32((input[1]&0xF0)>>4)*10 + int32(input[1]&0x0F))
-	month := int32(int32((input[2]&0xF0)>>4)*10 + int32(input[2]&0x0F))
-	day := int32(int32((input[3]&0xF0)>>4)*10 + int32(input[3]&0x0F))
-	output.SetYear(year)
-	output.SetMonth(month)
-	output.SetDay(day)
+	output.SetYear(decodeBCDByte(input[0])*100 + decodeBCDByte(input[1]))
+	output.SetMonth(decodeBCDByte(input[2]))
+	output.SetDay(decodeBCDByte(input[3]))
 	return &output, nil
 }
 
@@ -58,12 +55,11 @@ func AppendDate(dst []byte, date *ddv1.Date) ([]byte, error) {
 		}
 		copy(canvas[:], date.GetRawData())
 	}
-	year := int(date.GetYear())
-	month := int(date.GetMonth())
-	day := int(date.GetDay())
-	canvas[0] = byte((year/1000)%10<<4 | (year/100)%10)
-	canvas[1] = byte((year/10)%10<<4 | year%10)
-	canvas[2] = byte((month/10)%10<<4 | month%10)
-	canvas[3] = byte((day/10)%10<<4 | day%10)
+	if year := date.GetYear(); decodeBCDByte(canvas[0])*100+decodeBCDByte(canvas[1]) != year {
+		canvas[0] = byte((year/1000)%10<<4 | (year/100)%10)
+		canvas[1] = byte((year/10)%10<<4 | year%10)
+	}
+	canvas[2] = paintBCDByte(canvas[2], date.GetMonth())
+	canvas[3] = paintBCDByte(canvas[3], date.GetDay())
 	return append(dst, canvas[:]...), nil
 }
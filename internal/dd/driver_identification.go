@@ -24,7 +24,7 @@ func (opts UnmarshalOptions) UnmarshalDriverIdentification(data []byte) (*ddv1.D
 	)
 
 	if len(data) != lenDriverIdentification {
-		return nil, fmt.Errorf("invalid data length for DriverIdentification: got %d, want %d", len(data), lenDriverIdentification)
+		return nil, fmt.Errorf("invalid data length for DriverIdentification: got %d, want %d: %w", len(data), lenDriverIdentification, ErrInvalidValue)
 	}
 
 	driverID := &ddv1.DriverIdentification{}
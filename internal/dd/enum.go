@@ -2,6 +2,7 @@ package dd
 
 import (
 	"fmt"
+	"sync"
 
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -22,20 +23,12 @@ func UnmarshalEnum[T interface {
 }](rawValue byte) (T, error) {
 	var zero T
 	enumDesc := zero.Descriptor()
-	values := enumDesc.Values()
-	for i := 0; i < values.Len(); i++ {
-		valueDesc := values.Get(i)
-		opts := valueDesc.Options()
-		if proto.HasExtension(opts, ddv1.E_ProtocolEnumValue) {
-			protocolValue := proto.GetExtension(opts, ddv1.E_ProtocolEnumValue).(int32)
-			if protocolValue == int32(rawValue) {
-				return T(valueDesc.Number()), nil
-			}
-		}
+	if number, ok := protocolEnumNumbers(enumDesc)[rawValue]; ok {
+		return T(number), nil
 	}
 	return zero, fmt.Errorf(
-		"no enum value in %s has protocol_enum_value=%d",
-		enumDesc.FullName(), rawValue,
+		"no enum value in %s has protocol_enum_value=%d: %w",
+		enumDesc.FullName(), rawValue, ErrInvalidValue,
 	)
 }
 
@@ -64,3 +57,33 @@ func MarshalEnum[T interface {
 		enumDesc.FullName(), value.Number(),
 	)
 }
+
+// protocolEnumNumbersCache caches the enum numbers by protocol value of each
+// enum type, keyed by enum full name.
+var protocolEnumNumbersCache sync.Map
+
+// protocolEnumNumbers returns the enum numbers of an enum type, by their
+// protocol_enum_value annotation. The first value with an annotation wins.
+func protocolEnumNumbers(enumDesc protoreflect.EnumDescriptor) map[byte]protoreflect.EnumNumber {
+	if numbers, ok := protocolEnumNumbersCache.Load(enumDesc.FullName()); ok {
+		return numbers.(map[byte]protoreflect.EnumNumber)
+	}
+	numbers := make(map[byte]protoreflect.EnumNumber)
+	values := enumDesc.Values()
+	for i := 0; i < values.Len(); i++ {
+		valueDesc := values.Get(i)
+		opts := valueDesc.Options()
+		if !proto.HasExtension(opts, ddv1.E_ProtocolEnumValue) {
+			continue
+		}
+		protocolValue := proto.GetExtension(opts, ddv1.E_ProtocolEnumValue).(int32)
+		if protocolValue < 0 || protocolValue > 0xFF {
+			continue
+		}
+		if _, ok := numbers[byte(protocolValue)]; !ok {
+			numbers[byte(protocolValue)] = valueDesc.Number()
+		}
+	}
+	actual, _ := protocolEnumNumbersCache.LoadOrStore(enumDesc.FullName(), numbers)
+	return actual.(map[byte]protoreflect.EnumNumber)
+}
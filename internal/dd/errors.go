@@ -0,0 +1,92 @@
+package dd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Sentinel errors that classify parse failures.
+//
+// These are re-exported by the root package, and are wrapped by the errors of
+// all parsing layers, so that they can be tested for with [errors.Is].
+var (
+	// ErrTruncated indicates that the data ended before a complete structure
+	// could be read. It wraps [io.ErrUnexpectedEOF].
+	ErrTruncated = fmt.Errorf("truncated data: %w", io.ErrUnexpectedEOF)
+
+	// ErrUnknownTag indicates an unknown file, EF or transfer tag.
+	ErrUnknownTag = errors.New("unknown tag")
+
+	// ErrInvalidValue indicates a value that does not conform to the data
+	// dictionary, such as a wrong length, a malformed BCD or an unknown enum.
+	ErrInvalidValue = errors.New("invalid value")
+
+	// ErrUnsupportedVersion indicates a generation or structure version that
+	// is newer than the versions known to the parser, so that its data can't
+	// be parsed reliably.
+	ErrUnsupportedVersion = errors.New("unsupported version")
+)
+
+// FieldError is an error parsing a field of a data structure.
+//
+// Parsers wrap the errors of the fields of their data structures with
+// [WrapField] as they return through nested structures, and [FieldPath]
+// joins the fields of the wrapped errors.
+type FieldError struct {
+	// Field is the name of the field, e.g. "daily_records[3]".
+	Field string
+	// Offset is the byte offset of the field in its parent structure.
+	Offset int
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s at offset %d: %v", e.Field, e.Offset, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// WrapField wraps an error parsing the field at offset of a data structure.
+// It returns nil if err is nil.
+func WrapField(field string, offset int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &FieldError{Field: field, Offset: offset, Err: err}
+}
+
+// FieldPath returns the path and the offset of the innermost field of an
+// error, by joining the fields of the [*FieldError] values in its chain and
+// adding up their offsets. It returns false if the chain has no field.
+func FieldPath(err error) (path string, offset int, ok bool) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		e, isField := err.(*FieldError)
+		if !isField {
+			continue
+		}
+		path = joinFieldPath(path, e.Field)
+		offset += e.Offset
+		ok = true
+	}
+	return path, offset, ok
+}
+
+// joinFieldPath appends a field to a field path, with a dot unless the field
+// is an index such as "[3]".
+func joinFieldPath(path, field string) string {
+	switch {
+	case path == "":
+		return field
+	case strings.HasPrefix(field, "["):
+		return path + field
+	default:
+		return path + "." + field
+	}
+}
@@ -0,0 +1,53 @@
+package dd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestFieldPath(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantPath   string
+		wantOffset int
+		wantOK     bool
+	}{
+		{
+			name: "no field",
+			err:  fmt.Errorf("parse: %w", ErrTruncated),
+		},
+		{
+			name:       "single field",
+			err:        WrapField("daily_records", 4, ErrInvalidValue),
+			wantPath:   "daily_records",
+			wantOffset: 4,
+			wantOK:     true,
+		},
+		{
+			name: "nested fields",
+			err: fmt.Errorf("parse: %w", WrapField("activities", 10,
+				fmt.Errorf("record: %w", WrapField("[2]", 6,
+					WrapField("begin_time", 1, ErrTruncated))))),
+			wantPath:   "activities[2].begin_time",
+			wantOffset: 17,
+			wantOK:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, offset, ok := FieldPath(tt.err)
+			if path != tt.wantPath || offset != tt.wantOffset || ok != tt.wantOK {
+				t.Errorf("FieldPath() = (%q, %d, %v), want (%q, %d, %v)", path, offset, ok, tt.wantPath, tt.wantOffset, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestErrTruncated(t *testing.T) {
+	if !errors.Is(WrapField("value", 0, ErrTruncated), io.ErrUnexpectedEOF) {
+		t.Error("ErrTruncated does not wrap io.ErrUnexpectedEOF")
+	}
+}
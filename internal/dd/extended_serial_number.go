@@ -33,7 +33,7 @@ func (opts UnmarshalOptions) UnmarshalExtendedSerialNumber(data []byte) (*ddv1.E
 	)
 
 	if len(data) != lenExtendedSerialNumber {
-		return nil, fmt.Errorf("invalid data length for ExtendedSerialNumber: got %d, want %d", len(data), lenExtendedSerialNumber)
+		return nil, fmt.Errorf("invalid data length for ExtendedSerialNumber: got %d, want %d: %w", len(data), lenExtendedSerialNumber, ErrInvalidValue)
 	}
 
 	esn := &ddv1.ExtendedSerialNumber{}
@@ -50,12 +50,12 @@ func (opts UnmarshalOptions) UnmarshalExtendedSerialNumber(data []byte) (*ddv1.E
 	esn.SetMonthYear(monthYear)
 
 	// Parse equipment type (1 byte)
-	if equipmentType, err := UnmarshalEnum[ddv1.EquipmentType](data[6]); err == nil {
-		esn.SetType(equipmentType)
-	} else {
-		// Return UNRECOGNIZED for unknown values
-		esn.SetType(ddv1.EquipmentType_EQUIPMENT_TYPE_UNRECOGNIZED)
+	// Unknown values are rejected, since there is no unrecognized field to preserve them for marshalling
+	equipmentType, err := UnmarshalEnum[ddv1.EquipmentType](data[6])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse equipment type: %w", err)
 	}
+	esn.SetType(equipmentType)
 
 	// Parse manufacturer code (1 byte)
 	esn.SetManufacturerCode(int32(data[7]))
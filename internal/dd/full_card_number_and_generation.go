@@ -22,7 +22,7 @@ import (
 //   - Generation (1 byte): Generation enum value
 func (opts UnmarshalOptions) UnmarshalFullCardNumberAndGeneration(data []byte) (*ddv1.FullCardNumberAndGeneration, error) {
 	if len(data) < 1 {
-		return nil, fmt.Errorf("insufficient data for FullCardNumberAndGeneration: got %d, want at least 1", len(data))
+		return nil, fmt.Errorf("insufficient data for FullCardNumberAndGeneration: got %d, want at least 1: %w", len(data), ErrTruncated)
 	}
 
 	fullCardNumberAndGen := &ddv1.FullCardNumberAndGeneration{}
@@ -32,7 +32,7 @@ func (opts UnmarshalOptions) UnmarshalFullCardNumberAndGeneration(data []byte) (
 	// For now, we'll assume it's the last 1 byte is the generation
 	// and everything before that is the FullCardNumber
 	if len(data) < 1 {
-		return nil, fmt.Errorf("insufficient data for FullCardNumberAndGeneration")
+		return nil, fmt.Errorf("insufficient data for FullCardNumberAndGeneration: %w", ErrTruncated)
 	}
 
 	// Parse generation (last byte)
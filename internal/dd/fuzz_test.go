@@ -0,0 +1,124 @@
+package dd
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+// fuzzCodec is a decoder and encoder of a data type under fuzzing.
+type fuzzCodec struct {
+	name string
+	// size is the size of the seed input.
+	size      int
+	unmarshal func(UnmarshalOptions, []byte) (any, error)
+	// marshal marshals a value parsed from data.
+	marshal func(data []byte, v any) ([]byte, error)
+}
+
+// newFuzzCodec adapts a typed decoder and encoder to a [fuzzCodec].
+func newFuzzCodec[T any](
+	name string,
+	size int,
+	unmarshal func(UnmarshalOptions, []byte) (T, error),
+	appendFunc func([]byte, T) ([]byte, error),
+) fuzzCodec {
+	return fuzzCodec{
+		name: name,
+		size: size,
+		unmarshal: func(opts UnmarshalOptions, data []byte) (any, error) {
+			return unmarshal(opts, data)
+		},
+		marshal: func(_ []byte, v any) ([]byte, error) {
+			return appendFunc(nil, v.(T))
+		},
+	}
+}
+
+// newFuzzPaintCodec adapts a typed decoder and painter to a [fuzzCodec], for
+// data types that are painted over the raw data of their parent structure.
+func newFuzzPaintCodec[T any](
+	name string,
+	size int,
+	unmarshal func(UnmarshalOptions, []byte) (T, error),
+	paintFunc func([]byte, T) error,
+) fuzzCodec {
+	codec := newFuzzCodec(name, size, unmarshal, nil)
+	codec.marshal = func(data []byte, v any) ([]byte, error) {
+		canvas := append([]byte(nil), data...)
+		if err := paintFunc(canvas, v.(T)); err != nil {
+			return nil, err
+		}
+		return canvas, nil
+	}
+	return codec
+}
+
+var fuzzCodecs = []fuzzCodec{
+	newFuzzCodec("ActivityChangeInfo", 2, UnmarshalOptions.UnmarshalActivityChangeInfo, AppendActivityChangeInfo),
+	newFuzzCodec("BcdString", 4, UnmarshalOptions.UnmarshalBcdString, AppendBcdString),
+	newFuzzCodec("CardStructureVersion", 2, UnmarshalOptions.UnmarshalCardStructureVersion, AppendCardStructureVersion),
+	newFuzzCodec("CardVehicleRecord", 31, UnmarshalOptions.UnmarshalCardVehicleRecord, AppendCardVehicleRecord),
+	newFuzzCodec("CardVehicleRecordG2", 48, UnmarshalOptions.UnmarshalCardVehicleRecordG2, AppendCardVehicleRecordG2),
+	newFuzzCodec("ControlType", 1, UnmarshalOptions.UnmarshalControlType, AppendControlType),
+	newFuzzCodec("Date", 4, UnmarshalOptions.UnmarshalDate, AppendDate),
+	newFuzzCodec("DriverIdentification", 14, UnmarshalOptions.UnmarshalDriverIdentification, AppendDriverIdentification),
+	newFuzzCodec("ExtendedSerialNumber", 8, UnmarshalOptions.UnmarshalExtendedSerialNumber, AppendExtendedSerialNumber),
+	newFuzzCodec("FullCardNumber", 18, UnmarshalOptions.UnmarshalFullCardNumber, AppendFullCardNumber),
+	newFuzzCodec("FullCardNumberAndGeneration", 19, UnmarshalOptions.UnmarshalFullCardNumberAndGeneration, AppendFullCardNumberAndGeneration),
+	newFuzzCodec("GeoCoordinates", 6, UnmarshalOptions.UnmarshalGeoCoordinates, AppendGeoCoordinates),
+	newFuzzCodec("GNSSPlaceAuthRecord", 12, UnmarshalOptions.UnmarshalGNSSPlaceAuthRecord, AppendGNSSPlaceAuthRecord),
+	newFuzzCodec("GNSSPlaceRecord", 11, UnmarshalOptions.UnmarshalGNSSPlaceRecord, AppendGNSSPlaceRecord),
+	newFuzzCodec("HolderName", 72, UnmarshalOptions.UnmarshalHolderName, AppendHolderName),
+	newFuzzCodec("Ia5StringValue", 8, UnmarshalOptions.UnmarshalIa5StringValue, AppendIa5StringValue),
+	newFuzzCodec("MonthYear", 2, UnmarshalOptions.UnmarshalMonthYear, AppendMonthYear),
+	newFuzzCodec("Odometer", 3, UnmarshalOptions.UnmarshalOdometer, func(dst []byte, v uint32) ([]byte, error) {
+		return AppendOdometer(dst, v), nil
+	}),
+	newFuzzCodec("OwnerIdentification", 16, UnmarshalOptions.UnmarshalOwnerIdentification, AppendOwnerIdentification),
+	newFuzzCodec("PlaceRecord", 10, UnmarshalOptions.UnmarshalPlaceRecord, AppendPlaceRecord),
+	newFuzzCodec("PlaceRecordG2", 21, UnmarshalOptions.UnmarshalPlaceRecordG2, AppendPlaceRecordG2),
+	newFuzzCodec("PreviousVehicleInfo", 19, UnmarshalOptions.UnmarshalPreviousVehicleInfo, AppendPreviousVehicleInfo),
+	newFuzzCodec("PreviousVehicleInfoG2", 20, UnmarshalOptions.UnmarshalPreviousVehicleInfoG2, AppendPreviousVehicleInfoG2),
+	newFuzzCodec("SpecificConditionRecord", 5, UnmarshalOptions.UnmarshalSpecificConditionRecord, AppendSpecificConditionRecord),
+	newFuzzCodec("StringValue", 10, UnmarshalOptions.UnmarshalStringValue, AppendStringValue),
+	newFuzzCodec("TimeReal", 4, UnmarshalOptions.UnmarshalTimeReal, AppendTimeReal),
+	newFuzzPaintCodec("VehicleRegistration", 15, UnmarshalOptions.UnmarshalVehicleRegistration, PaintVehicleRegistration),
+	newFuzzCodec("VuCardIWRecord", 129, UnmarshalOptions.UnmarshalVuCardIWRecord, AppendVuCardIWRecord),
+}
+
+// FuzzUnmarshal checks that the data dictionary decoders never panic, and
+// that the values they decode survive a marshal and parse round-trip.
+//
+// The first input byte selects the decoder from [fuzzCodecs].
+func FuzzUnmarshal(f *testing.F) {
+	for i, codec := range fuzzCodecs {
+		zeros := make([]byte, codec.size)
+		filled := make([]byte, codec.size)
+		for j := range filled {
+			filled[j] = 0x31 // a valid BCD digit pair and ASCII character
+		}
+		f.Add(uint8(i), zeros)
+		f.Add(uint8(i), filled)
+	}
+	f.Fuzz(func(t *testing.T, selector uint8, data []byte) {
+		codec := fuzzCodecs[int(selector)%len(fuzzCodecs)]
+		var opts UnmarshalOptions
+		value, err := codec.unmarshal(opts, data)
+		if err != nil {
+			return
+		}
+		marshaled, err := codec.marshal(data, value)
+		if err != nil {
+			t.Fatalf("%s: marshal parsed value: %v", codec.name, err)
+		}
+		reparsed, err := codec.unmarshal(opts, marshaled)
+		if err != nil {
+			t.Fatalf("%s: parse marshaled value %x: %v", codec.name, marshaled, err)
+		}
+		if diff := cmp.Diff(value, reparsed, protocmp.Transform()); diff != "" {
+			t.Errorf("%s: round-trip mismatch for %x (-parsed +reparsed):\n%s", codec.name, data, diff)
+		}
+	})
+}
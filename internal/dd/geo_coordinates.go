@@ -27,7 +27,7 @@ func (opts UnmarshalOptions) UnmarshalGeoCoordinates(data []byte) (*ddv1.GeoCoor
 		lenGeoCoordinates = 6 // 3 bytes latitude + 3 bytes longitude
 	)
 	if len(data) != lenGeoCoordinates {
-		return nil, fmt.Errorf("invalid data length for GeoCoordinates: got %d, want %d", len(data), lenGeoCoordinates)
+		return nil, fmt.Errorf("invalid data length for GeoCoordinates: got %d, want %d: %w", len(data), lenGeoCoordinates, ErrInvalidValue)
 	}
 	var output ddv1.GeoCoordinates
 	output.SetLatitude(readInt24(data[0:3]))
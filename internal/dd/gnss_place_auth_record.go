@@ -35,7 +35,7 @@ func (opts UnmarshalOptions) UnmarshalGNSSPlaceAuthRecord(data []byte) (*ddv1.GN
 	)
 
 	if len(data) != lenGNSSPlaceAuthRecord {
-		return nil, fmt.Errorf("invalid data length for GNSSPlaceAuthRecord: got %d, want %d", len(data), lenGNSSPlaceAuthRecord)
+		return nil, fmt.Errorf("invalid data length for GNSSPlaceAuthRecord: got %d, want %d: %w", len(data), lenGNSSPlaceAuthRecord, ErrInvalidValue)
 	}
 
 	record := &ddv1.GNSSPlaceAuthRecord{}
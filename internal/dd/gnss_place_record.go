@@ -34,7 +34,7 @@ func (opts UnmarshalOptions) UnmarshalGNSSPlaceRecord(data []byte) (*ddv1.GNSSPl
 	)
 
 	if len(data) != lenGNSSPlaceRecord {
-		return nil, fmt.Errorf("invalid data length for GNSSPlaceRecord: got %d, want %d", len(data), lenGNSSPlaceRecord)
+		return nil, fmt.Errorf("invalid data length for GNSSPlaceRecord: got %d, want %d: %w", len(data), lenGNSSPlaceRecord, ErrInvalidValue)
 	}
 
 	record := &ddv1.GNSSPlaceRecord{}
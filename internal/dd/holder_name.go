@@ -32,7 +32,7 @@ func (opts UnmarshalOptions) UnmarshalHolderName(data []byte) (*ddv1.HolderName,
 	)
 
 	if len(data) != lenHolderName {
-		return nil, fmt.Errorf("invalid data length for HolderName: got %d, want %d", len(data), lenHolderName)
+		return nil, fmt.Errorf("invalid data length for HolderName: got %d, want %d: %w", len(data), lenHolderName, ErrInvalidValue)
 	}
 
 	holderName := &ddv1.HolderName{}
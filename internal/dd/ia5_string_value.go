@@ -2,7 +2,6 @@ package dd
 
 import (
 	"fmt"
-	"io"
 	"strings"
 	"unicode/utf8"
 
@@ -19,7 +18,7 @@ import (
 //	IA5String ::= OCTET STRING (SIZE(0..255))
 func (opts UnmarshalOptions) UnmarshalIa5StringValue(input []byte) (*ddv1.Ia5StringValue, error) {
 	if len(input) == 0 {
-		return nil, fmt.Errorf("insufficient data for IA5 string value: %w", io.ErrUnexpectedEOF)
+		return nil, fmt.Errorf("insufficient data for IA5 string value: %w", ErrTruncated)
 	}
 
 	var output ddv1.Ia5StringValue
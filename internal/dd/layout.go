@@ -0,0 +1,87 @@
+package dd
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// Layout records the fields that parsers read from the binary data of a data
+// structure, to annotate the data with its fields.
+//
+// Parsers record their fields with the same paths and offsets as they wrap
+// the errors of the fields with [WrapField], so that a layout locates the
+// fields of a data structure like [FieldPath] locates its errors. The methods
+// of a nil *Layout do nothing, so that parsers record their fields
+// unconditionally.
+type Layout struct {
+	path   string
+	offset int
+	fields *[]LayoutField
+}
+
+// LayoutField is a field of a data structure, located in its binary data.
+type LayoutField struct {
+	// Path is the path of the field, e.g. "events[1].event_type".
+	Path string
+	// Offset is the byte offset of the field in the data structure.
+	Offset int
+	// Length is the length of the field in bytes.
+	Length int
+}
+
+// NewLayout returns an empty layout.
+func NewLayout() *Layout {
+	return &Layout{fields: new([]LayoutField)}
+}
+
+// Field records the field at offset of the data structure.
+func (l *Layout) Field(field string, offset, length int) {
+	if l == nil {
+		return
+	}
+	*l.fields = append(*l.fields, LayoutField{
+		Path:   joinFieldPath(l.path, field),
+		Offset: l.offset + offset,
+		Length: length,
+	})
+}
+
+// Element records the element at index i of a repeated field, at offset of
+// the data structure.
+func (l *Layout) Element(field string, i, offset, length int) {
+	if l == nil {
+		return
+	}
+	l.Field(fmt.Sprintf("%s[%d]", field, i), offset, length)
+}
+
+// Nested returns the layout of the data structure held by the field at offset,
+// which records its fields in l.
+func (l *Layout) Nested(field string, offset int) *Layout {
+	if l == nil {
+		return nil
+	}
+	return &Layout{path: joinFieldPath(l.path, field), offset: l.offset + offset, fields: l.fields}
+}
+
+// NestedElement returns the layout of the data structure held by the element
+// at index i of a repeated field, at offset, which records its fields in l.
+func (l *Layout) NestedElement(field string, i, offset int) *Layout {
+	if l == nil {
+		return nil
+	}
+	return l.Nested(fmt.Sprintf("%s[%d]", field, i), offset)
+}
+
+// Fields returns the recorded fields in the order of their offsets.
+func (l *Layout) Fields() []LayoutField {
+	if l == nil {
+		return nil
+	}
+	fields := slices.Clone(*l.fields)
+	slices.SortStableFunc(fields, func(a, b LayoutField) int {
+		return cmp.Compare(a.Offset, b.Offset)
+	})
+	return fields
+}
@@ -0,0 +1,36 @@
+package dd
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLayout(t *testing.T) {
+	layout := NewLayout()
+	layout.Field("newest_record_index", 0, 2)
+	records := layout.Nested("records", 2)
+	record := records.NestedElement("", 1, 10)
+	record.Field("begin_time", 1, 4)
+	records.Element("", 0, 0, 10)
+
+	want := []LayoutField{
+		{Path: "newest_record_index", Offset: 0, Length: 2},
+		{Path: "records[0]", Offset: 2, Length: 10},
+		{Path: "records[1].begin_time", Offset: 13, Length: 4},
+	}
+	if got := layout.Fields(); !slices.Equal(got, want) {
+		t.Errorf("Fields() = %v, want %v", got, want)
+	}
+
+	// The path and offset of a field match those of its error.
+	err := WrapField("records", 2, WrapField("[1]", 10, WrapField("begin_time", 1, ErrInvalidValue)))
+	if path, offset, _ := FieldPath(err); path != want[2].Path || offset != want[2].Offset {
+		t.Errorf("FieldPath() = %s, %d, want %s, %d", path, offset, want[2].Path, want[2].Offset)
+	}
+
+	var nilLayout *Layout
+	nilLayout.Nested("records", 2).Element("", 0, 0, 10)
+	if got := nilLayout.Fields(); got != nil {
+		t.Errorf("nil layout Fields() = %v, want nil", got)
+	}
+}
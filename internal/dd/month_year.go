@@ -21,7 +21,7 @@ func (opts UnmarshalOptions) UnmarshalMonthYear(data []byte) (*ddv1.MonthYear, e
 	const lenMonthYear = 2
 
 	if len(data) != lenMonthYear {
-		return nil, fmt.Errorf("invalid data length for MonthYear: got %d, want %d", len(data), lenMonthYear)
+		return nil, fmt.Errorf("invalid data length for MonthYear: got %d, want %d: %w", len(data), lenMonthYear, ErrInvalidValue)
 	}
 
 	monthYear := &ddv1.MonthYear{}
@@ -74,6 +74,11 @@ func AppendMonthYear(dst []byte, monthYear *ddv1.MonthYear) ([]byte, error) {
 	}
 	// Otherwise canvas is zero-initialized (Go default)
 
+	// Keep the raw data as is if it did not decode to a month and year
+	if monthYear.HasRawData() && !monthYear.HasMonth() && !monthYear.HasYear() {
+		return append(dst, canvas[:]...), nil
+	}
+
 	// Paint semantic values over the canvas
 	month := monthYear.GetMonth()
 	year := monthYear.GetYear()
@@ -35,7 +35,7 @@ func (opts UnmarshalOptions) UnmarshalOdometer(data []byte) (uint32, error) {
 	const lenOdometerShort = 3
 
 	if len(data) != lenOdometerShort {
-		return 0, fmt.Errorf("invalid data length for OdometerShort: got %d, want %d", len(data), lenOdometerShort)
+		return 0, fmt.Errorf("invalid data length for OdometerShort: got %d, want %d: %w", len(data), lenOdometerShort, ErrInvalidValue)
 	}
 
 	// Convert 3-byte big-endian to uint32
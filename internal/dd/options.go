@@ -32,6 +32,11 @@ type UnmarshalOptions struct {
 	//
 	// This field is reserved for future use as new versions are introduced.
 	Version ddv1.Version
+
+	// Layout records the fields read by the parsers of data structures, if
+	// set. The methods of UnmarshalOptions, which parse single values, don't
+	// record their fields.
+	Layout *Layout
 }
 
 // SetFromCardStructureVersion updates the generation and version fields based on
@@ -47,24 +52,8 @@ type UnmarshalOptions struct {
 // - {01 01} = Generation 2, Version 2
 //
 // This method can be used by card and VU packages to extract generation/version
-// context from CardStructureVersion fields.
+// context from CardStructureVersion fields. Unknown generations and versions
+// are set as unspecified; use [StructureVersion] to detect them.
 func (opts *UnmarshalOptions) SetFromCardStructureVersion(csv *ddv1.CardStructureVersion) {
-	switch csv.GetMajor() {
-	case 0:
-		opts.Generation = ddv1.Generation_GENERATION_1
-		opts.Version = ddv1.Version_VERSION_1
-	case 1:
-		opts.Generation = ddv1.Generation_GENERATION_2
-		switch csv.GetMinor() {
-		case 0:
-			opts.Version = ddv1.Version_VERSION_1
-		case 1:
-			opts.Version = ddv1.Version_VERSION_2
-		default:
-			opts.Version = ddv1.Version_VERSION_UNSPECIFIED
-		}
-	default:
-		opts.Generation = ddv1.Generation_GENERATION_UNSPECIFIED
-		opts.Version = ddv1.Version_VERSION_UNSPECIFIED
-	}
+	opts.Generation, opts.Version, _ = StructureVersion(csv.GetMajor(), csv.GetMinor())
 }
@@ -30,7 +30,7 @@ func (opts UnmarshalOptions) UnmarshalOwnerIdentification(data []byte) (*ddv1.Ow
 	)
 
 	if len(data) != lenOwnerIdentification {
-		return nil, fmt.Errorf("invalid data length for OwnerIdentification: got %d, want %d", len(data), lenOwnerIdentification)
+		return nil, fmt.Errorf("invalid data length for OwnerIdentification: got %d, want %d: %w", len(data), lenOwnerIdentification, ErrInvalidValue)
 	}
 
 	ownerID := &ddv1.OwnerIdentification{}
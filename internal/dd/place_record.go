@@ -32,7 +32,7 @@ func (opts UnmarshalOptions) UnmarshalPlaceRecord(data []byte) (*ddv1.PlaceRecor
 	)
 
 	if len(data) != lenPlaceRecord {
-		return nil, fmt.Errorf("invalid data length for Gen1 PlaceRecord: got %d, want %d", len(data), lenPlaceRecord)
+		return nil, fmt.Errorf("invalid data length for Gen1 PlaceRecord: got %d, want %d: %w", len(data), lenPlaceRecord, ErrInvalidValue)
 	}
 
 	record := &ddv1.PlaceRecord{}
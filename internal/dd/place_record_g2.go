@@ -33,7 +33,7 @@ func (opts UnmarshalOptions) UnmarshalPlaceRecordG2(data []byte) (*ddv1.PlaceRec
 	)
 
 	if len(data) != lenPlaceRecord {
-		return nil, fmt.Errorf("invalid data length for Gen2 PlaceRecord: got %d, want %d", len(data), lenPlaceRecord)
+		return nil, fmt.Errorf("invalid data length for Gen2 PlaceRecord: got %d, want %d: %w", len(data), lenPlaceRecord, ErrInvalidValue)
 	}
 
 	record := &ddv1.PlaceRecordG2{}
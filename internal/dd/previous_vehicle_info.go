@@ -24,7 +24,7 @@ func (opts UnmarshalOptions) UnmarshalPreviousVehicleInfo(data []byte) (*ddv1.Pr
 	)
 
 	if len(data) != lenPreviousVehicleInfo {
-		return nil, fmt.Errorf("invalid data length for Gen1 PreviousVehicleInfo: got %d, want %d", len(data), lenPreviousVehicleInfo)
+		return nil, fmt.Errorf("invalid data length for Gen1 PreviousVehicleInfo: got %d, want %d: %w", len(data), lenPreviousVehicleInfo, ErrInvalidValue)
 	}
 
 	info := &ddv1.PreviousVehicleInfo{}
@@ -64,11 +64,9 @@ func AppendPreviousVehicleInfo(dst []byte, info *ddv1.PreviousVehicleInfo) ([]by
 	var err error
 
 	// Vehicle registration (15 bytes)
-	vehicleRegBytes, err := AppendVehicleRegistration(nil, info.GetVehicleRegistration())
-	if err != nil {
+	if err := PaintVehicleRegistration(canvas[0:15], info.GetVehicleRegistration()); err != nil {
 		return nil, fmt.Errorf("failed to append vehicle registration: %w", err)
 	}
-	copy(canvas[0:15], vehicleRegBytes)
 
 	// Card withdrawal time (4 bytes)
 	timeBytes, err := AppendTimeReal(nil, info.GetCardWithdrawalTime())
@@ -24,7 +24,7 @@ func (opts UnmarshalOptions) UnmarshalSpecificConditionRecord(data []byte) (*ddv
 	)
 
 	if len(data) != lenSpecificConditionRecord {
-		return nil, fmt.Errorf("invalid data length for SpecificConditionRecord: got %d, want %d", len(data), lenSpecificConditionRecord)
+		return nil, fmt.Errorf("invalid data length for SpecificConditionRecord: got %d, want %d: %w", len(data), lenSpecificConditionRecord, ErrInvalidValue)
 	}
 
 	record := &ddv1.SpecificConditionRecord{}
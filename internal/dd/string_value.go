@@ -3,7 +3,6 @@ package dd
 import (
 	"bytes"
 	"fmt"
-	"io"
 	"strings"
 	"unicode/utf8"
 
@@ -25,7 +24,7 @@ import (
 //	}
 func (opts UnmarshalOptions) UnmarshalStringValue(input []byte) (*ddv1.StringValue, error) {
 	if len(input) < 2 {
-		return nil, fmt.Errorf("insufficient data for string value: %w", io.ErrUnexpectedEOF)
+		return nil, fmt.Errorf("insufficient data for string value: %w", ErrTruncated)
 	}
 
 	codePage := input[0]
@@ -100,7 +99,10 @@ func AppendStringValue(dst []byte, sv *ddv1.StringValue) ([]byte, error) {
 
 		// Paint only the code page byte at offset 0 (from semantic encoding field)
 		// The string data at offset 1+ is already correct in raw_data
-		canvas[0] = codePage
+		// An unrecognized code page has no encoding to paint, so keep the original byte
+		if sv.GetEncoding() != ddv1.Encoding_ENCODING_UNRECOGNIZED {
+			canvas[0] = codePage
+		}
 
 		// Note: We do NOT re-encode from the value field because:
 		// 1. The value field is UTF-8 (for display), while raw_data is in the original encoding
@@ -0,0 +1,62 @@
+package dd
+
+import (
+	"fmt"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+)
+
+// StructureVersion returns the generation and version of a structure version
+// coded as 'aabb'H, where 'aa' is the index of the generation and 'bb' the
+// index of the version within the generation.
+//
+// This coding is shared by the `CardStructureVersion` of cards (Data
+// Dictionary, Section 2.36) and the `DownloadInterfaceVersion` of vehicle
+// units (Data Dictionary, Section 2.60a):
+//   - {00 00} = Generation 1
+//   - {01 00} = Generation 2, Version 1
+//   - {01 01} = Generation 2, Version 2
+//
+// The known generations and versions are read from the [ddv1.Generation] and
+// [ddv1.Version] enums: generation index 'aa' is the generation with
+// protocol_enum_value 'aa'+1, and version index 'bb' is VERSION_<'bb'+1>.
+// An index beyond the known values returns an error wrapping
+// [ErrUnsupportedVersion].
+//
+// Generation 1 has a single version of the data structures: its 'bb' index
+// counts changes in the use of data elements, which don't change their
+// layout, so any Generation 1 structure version is VERSION_1.
+func StructureVersion(major, minor int32) (ddv1.Generation, ddv1.Version, error) {
+	generation, ok := knownGenerations()[major+1]
+	if !ok {
+		return ddv1.Generation_GENERATION_UNSPECIFIED, ddv1.Version_VERSION_UNSPECIFIED,
+			fmt.Errorf("structure version %02d.%02d has an unknown generation: %w", major, minor, ErrUnsupportedVersion)
+	}
+	if generation == ddv1.Generation_GENERATION_1 {
+		return generation, ddv1.Version_VERSION_1, nil
+	}
+	version := ddv1.Version(minor + 1)
+	if minor < 0 || ddv1.Version_VERSION_UNSPECIFIED.Descriptor().Values().ByNumber(protoreflect.EnumNumber(version)) == nil {
+		return generation, ddv1.Version_VERSION_UNSPECIFIED,
+			fmt.Errorf("structure version %02d.%02d has an unknown version: %w", major, minor, ErrUnsupportedVersion)
+	}
+	return generation, version, nil
+}
+
+// knownGenerations maps the protocol values of generations to the generations
+// annotated with them.
+var knownGenerations = sync.OnceValue(func() map[int32]ddv1.Generation {
+	result := make(map[int32]ddv1.Generation)
+	values := ddv1.Generation_GENERATION_UNSPECIFIED.Descriptor().Values()
+	for i := 0; i < values.Len(); i++ {
+		opts := values.Get(i).Options()
+		if proto.HasExtension(opts, ddv1.E_ProtocolEnumValue) {
+			result[proto.GetExtension(opts, ddv1.E_ProtocolEnumValue).(int32)] = ddv1.Generation(values.Get(i).Number())
+		}
+	}
+	return result
+})
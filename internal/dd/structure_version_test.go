@@ -0,0 +1,41 @@
+package dd
+
+import (
+	"errors"
+	"testing"
+
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+)
+
+func TestStructureVersion(t *testing.T) {
+	tests := []struct {
+		name           string
+		major, minor   int32
+		wantGeneration ddv1.Generation
+		wantVersion    ddv1.Version
+		wantErr        bool
+	}{
+		{name: "Gen1 (0000)", major: 0, minor: 0, wantGeneration: ddv1.Generation_GENERATION_1, wantVersion: ddv1.Version_VERSION_1},
+		{name: "Gen1 (0001)", major: 0, minor: 1, wantGeneration: ddv1.Generation_GENERATION_1, wantVersion: ddv1.Version_VERSION_1},
+		{name: "Gen1 (0002)", major: 0, minor: 2, wantGeneration: ddv1.Generation_GENERATION_1, wantVersion: ddv1.Version_VERSION_1},
+		{name: "Gen2 v1 (0100)", major: 1, minor: 0, wantGeneration: ddv1.Generation_GENERATION_2, wantVersion: ddv1.Version_VERSION_1},
+		{name: "Gen2 v2 (0101)", major: 1, minor: 1, wantGeneration: ddv1.Generation_GENERATION_2, wantVersion: ddv1.Version_VERSION_2},
+		{name: "unknown version (0102)", major: 1, minor: 2, wantGeneration: ddv1.Generation_GENERATION_2, wantErr: true},
+		{name: "unknown generation (0200)", major: 2, minor: 0, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generation, version, err := StructureVersion(tt.major, tt.minor)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnsupportedVersion) {
+					t.Errorf("StructureVersion() error = %v, want ErrUnsupportedVersion", err)
+				}
+			} else if err != nil {
+				t.Fatalf("StructureVersion() unexpected error: %v", err)
+			}
+			if generation != tt.wantGeneration || version != tt.wantVersion {
+				t.Errorf("StructureVersion() = %v, %v, want %v, %v", generation, version, tt.wantGeneration, tt.wantVersion)
+			}
+		})
+	}
+}
@@ -21,7 +21,7 @@ import (
 func (opts UnmarshalOptions) UnmarshalTimeReal(data []byte) (*timestamppb.Timestamp, error) {
 	const lenTimeReal = 4
 	if len(data) != lenTimeReal {
-		return nil, fmt.Errorf("invalid data length for TimeReal: got %d, want %d", len(data), lenTimeReal)
+		return nil, fmt.Errorf("invalid data length for TimeReal: got %d, want %d: %w", len(data), lenTimeReal, ErrInvalidValue)
 	}
 	timeVal := binary.BigEndian.Uint32(data[:lenTimeReal])
 	if timeVal == 0 {
@@ -24,7 +24,7 @@ func (opts UnmarshalOptions) UnmarshalVehicleRegistration(data []byte) (*ddv1.Ve
 	const lenVehicleRegistration = 15
 
 	if len(data) != lenVehicleRegistration {
-		return nil, fmt.Errorf("invalid data length for VehicleRegistrationIdentification: got %d, want %d", len(data), lenVehicleRegistration)
+		return nil, fmt.Errorf("invalid data length for VehicleRegistrationIdentification: got %d, want %d: %w", len(data), lenVehicleRegistration, ErrInvalidValue)
 	}
 
 	vehicleReg := &ddv1.VehicleRegistrationIdentification{}
@@ -82,6 +82,45 @@ func AppendVehicleRegistration(dst []byte, vehicleReg *ddv1.VehicleRegistrationI
 	dst = append(dst, nationByte)
 
 	// Append registration number (14 bytes: 1 byte code page + 13 bytes string data)
+	return AppendStringValue(dst, vehicleRegistrationNumber(vehicleReg))
+}
+
+// PaintVehicleRegistration paints a VehicleRegistrationIdentification over the
+// first 15 bytes of canvas.
+//
+// Unlike [AppendVehicleRegistration], an UNRECOGNIZED nation keeps the nation
+// byte of the canvas, so that unknown nations in raw data survive a round-trip.
+func PaintVehicleRegistration(canvas []byte, vehicleReg *ddv1.VehicleRegistrationIdentification) error {
+	const lenVehicleRegistration = 15
+	if len(canvas) < lenVehicleRegistration {
+		return fmt.Errorf("insufficient canvas for VehicleRegistrationIdentification: got %d, want %d", len(canvas), lenVehicleRegistration)
+	}
+	if vehicleReg == nil {
+		return fmt.Errorf("vehicleRegistration cannot be nil")
+	}
+	nationByte := canvas[0]
+	if nation := vehicleReg.GetNation(); nation != ddv1.NationNumeric_NATION_NUMERIC_UNRECOGNIZED {
+		var err error
+		nationByte, err = MarshalEnum(nation)
+		if err != nil {
+			return fmt.Errorf("failed to marshal nation: %w", err)
+		}
+	}
+	number, err := AppendStringValue(nil, vehicleRegistrationNumber(vehicleReg))
+	if err != nil {
+		return err
+	}
+	if len(number) != lenVehicleRegistration-1 {
+		return fmt.Errorf("invalid length for vehicle registration number: got %d, want %d", len(number), lenVehicleRegistration-1)
+	}
+	canvas[0] = nationByte
+	copy(canvas[1:lenVehicleRegistration], number)
+	return nil
+}
+
+// vehicleRegistrationNumber returns the registration number of a vehicle
+// registration, or an empty number if it has none.
+func vehicleRegistrationNumber(vehicleReg *ddv1.VehicleRegistrationIdentification) *ddv1.StringValue {
 	number := vehicleReg.GetNumber()
 	if number == nil {
 		// Create empty StringValue with correct length for VehicleRegistrationNumber
@@ -91,5 +130,5 @@ func AppendVehicleRegistration(dst []byte, vehicleReg *ddv1.VehicleRegistrationI
 		number.SetLength(13) // Length of the string data, not including code page byte
 		number.SetEncoding(ddv1.Encoding_ENCODING_DEFAULT)
 	}
-	return AppendStringValue(dst, number)
+	return number
 }
@@ -61,7 +61,7 @@ func (opts UnmarshalOptions) UnmarshalVuCardIWRecord(data []byte) (*ddv1.VuCardI
 	)
 
 	if len(data) != lenVuCardIWRecord {
-		return nil, fmt.Errorf("invalid data length for VuCardIWRecord: got %d, want %d", len(data), lenVuCardIWRecord)
+		return nil, fmt.Errorf("invalid data length for VuCardIWRecord: got %d, want %d: %w", len(data), lenVuCardIWRecord, ErrInvalidValue)
 	}
 
 	record := &ddv1.VuCardIWRecord{}
@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"fmt"
 
+	"github.com/way-platform/tachograph-go/internal/dd"
 	securityv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/security/v1"
 )
 
@@ -39,7 +40,7 @@ func UnmarshalEccCertificate(data []byte) (*securityv1.EccCertificate, error) {
 	)
 
 	if len(data) < minLenEccCertificate || len(data) > maxLenEccCertificate {
-		return nil, fmt.Errorf("invalid data length for EccCertificate: got %d, want %d-%d", len(data), minLenEccCertificate, maxLenEccCertificate)
+		return nil, fmt.Errorf("invalid data length for EccCertificate: got %d, want %d-%d: %w", len(data), minLenEccCertificate, maxLenEccCertificate, dd.ErrInvalidValue)
 	}
 
 	cert := &securityv1.EccCertificate{}
@@ -84,19 +84,9 @@ func VerifyEccCertificateWithCA(cert *securityv1.EccCertificate, caCert *securit
 	hashData := bodySeq.FullBytes
 
 	// Compute hash based on curve size
-	var hash []byte
-	switch hashBits {
-	case 256:
-		h := sha256.Sum256(hashData)
-		hash = h[:]
-	case 384:
-		h := sha512.Sum384(hashData)
-		hash = h[:]
-	case 512:
-		h := sha512.Sum512(hashData)
-		hash = h[:]
-	default:
-		return fmt.Errorf("unsupported hash size: %d bits", hashBits)
+	hash, err := eccHash(hashBits, hashData)
+	if err != nil {
+		return err
 	}
 
 	// Get signature components
@@ -131,6 +121,24 @@ func VerifyEccCertificateWithCA(cert *securityv1.EccCertificate, caCert *securit
 	return nil
 }
 
+// eccHash hashes data with SHA-256, SHA-384, or SHA-512, depending on the
+// hash size of the curve.
+func eccHash(hashBits int, data []byte) ([]byte, error) {
+	switch hashBits {
+	case 256:
+		h := sha256.Sum256(data)
+		return h[:], nil
+	case 384:
+		h := sha512.Sum384(data)
+		return h[:], nil
+	case 512:
+		h := sha512.Sum512(data)
+		return h[:], nil
+	default:
+		return nil, fmt.Errorf("unsupported hash size: %d bits", hashBits)
+	}
+}
+
 // parseCurveOID parses an elliptic curve OID and returns the hash size (in bits)
 // and the elliptic.Curve interface.
 //
@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"fmt"
 
+	"github.com/way-platform/tachograph-go/internal/dd"
 	securityv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/security/v1"
 )
 
@@ -47,7 +48,7 @@ func UnmarshalRsaCertificate(data []byte) (*securityv1.RsaCertificate, error) {
 	)
 
 	if len(data) != lenRsaCertificate {
-		return nil, fmt.Errorf("invalid data length for RsaCertificate: got %d, want %d", len(data), lenRsaCertificate)
+		return nil, fmt.Errorf("invalid data length for RsaCertificate: got %d, want %d: %w", len(data), lenRsaCertificate, dd.ErrInvalidValue)
 	}
 
 	// Extract CAR' (Certificate Authority Reference) from bytes 186-193
@@ -0,0 +1,88 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha1"
+	"fmt"
+	"math/big"
+
+	securityv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/security/v1"
+)
+
+// VerifyRsaSignature verifies a Generation 1 data signature, such as the
+// signature of a downloaded card EF, with the public key of an RSA certificate.
+//
+// The signature scheme is RSA PKCS#1 v1.5 over the SHA-1 hash of the data.
+//
+// The certificate must have its public key components (modulus and exponent)
+// populated, typically from a previous verification with [VerifyRsaCertificateWithCA].
+//
+// See Appendix 11, Section 6 (CSM_034) for the complete specification.
+func VerifyRsaSignature(data, signature []byte, cert *securityv1.RsaCertificate) error {
+	if cert == nil {
+		return fmt.Errorf("certificate cannot be nil")
+	}
+	modulus := cert.GetRsaModulus()
+	exponent := cert.GetRsaExponent()
+	if len(modulus) == 0 || len(exponent) == 0 {
+		return fmt.Errorf("certificate public key missing (modulus or exponent empty)")
+	}
+	e := new(big.Int).SetBytes(exponent)
+	if !e.IsInt64() || e.Int64() > 1<<31-1 {
+		return fmt.Errorf("unsupported RSA exponent: %v", e)
+	}
+	pub := &rsa.PublicKey{
+		N: new(big.Int).SetBytes(modulus),
+		E: int(e.Int64()),
+	}
+	hash := sha1.Sum(data)
+	if err := rsa.VerifyPKCS1v15(pub, crypto.SHA1, hash[:], signature); err != nil {
+		return fmt.Errorf("RSA signature verification failed: %w", err)
+	}
+	return nil
+}
+
+// VerifyEccSignature verifies a Generation 2 data signature, such as the
+// signature of a downloaded card EF, with the public key of an ECC certificate.
+//
+// The signature is ECDSA in plain format (r || s, each half as long as the
+// curve's key size) over the hash of the data, using the hash function that
+// matches the certificate's curve (see [VerifyEccCertificateWithCA]).
+//
+// See Appendix 11, Section 10.5 (CSM_216) for the complete specification.
+func VerifyEccSignature(data, signature []byte, cert *securityv1.EccCertificate) error {
+	if cert == nil {
+		return fmt.Errorf("certificate cannot be nil")
+	}
+	pubKey := cert.GetPublicKey()
+	pointX := pubKey.GetPublicPointX()
+	pointY := pubKey.GetPublicPointY()
+	if len(pointX) == 0 || len(pointY) == 0 {
+		return fmt.Errorf("certificate public key is incomplete")
+	}
+	hashBits, curve, err := parseCurveOID(pubKey.GetDomainParametersOid())
+	if err != nil {
+		return fmt.Errorf("failed to parse curve: %w", err)
+	}
+	keySize := (curve.Params().BitSize + 7) / 8
+	if len(signature) != 2*keySize {
+		return fmt.Errorf("invalid signature length: got %d, want %d", len(signature), 2*keySize)
+	}
+	hash, err := eccHash(hashBits, data)
+	if err != nil {
+		return err
+	}
+	pub := &ecdsa.PublicKey{
+		Curve: curve,
+		X:     new(big.Int).SetBytes(pointX),
+		Y:     new(big.Int).SetBytes(pointY),
+	}
+	r := new(big.Int).SetBytes(signature[:keySize])
+	s := new(big.Int).SetBytes(signature[keySize:])
+	if !ecdsa.Verify(pub, hash, r, s) {
+		return fmt.Errorf("ECDSA signature verification failed")
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	securityv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/security/v1"
+)
+
+func TestVerifyRsaSignature(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	cert := &securityv1.RsaCertificate{}
+	cert.SetRsaModulus(key.N.Bytes())
+	cert.SetRsaExponent(big.NewInt(int64(key.E)).FillBytes(make([]byte, 8)))
+
+	data := []byte("signed elementary file")
+	hash := sha1.Sum(data)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA1, hash[:])
+	if err != nil {
+		t.Fatalf("Failed to sign data: %v", err)
+	}
+
+	if err := VerifyRsaSignature(data, signature, cert); err != nil {
+		t.Errorf("VerifyRsaSignature() error = %v, want nil", err)
+	}
+	if err := VerifyRsaSignature([]byte("tampered elementary file"), signature, cert); err == nil {
+		t.Error("VerifyRsaSignature() with tampered data succeeded, want error")
+	}
+	if err := VerifyRsaSignature(data, signature, &securityv1.RsaCertificate{}); err == nil {
+		t.Error("VerifyRsaSignature() without public key succeeded, want error")
+	}
+}
+
+func TestVerifyEccSignature(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	publicKey := &securityv1.EccCertificate_PublicKey{}
+	publicKey.SetDomainParametersOid("1.2.840.10045.3.1.7")
+	publicKey.SetPublicPointX(key.X.FillBytes(make([]byte, 32)))
+	publicKey.SetPublicPointY(key.Y.FillBytes(make([]byte, 32)))
+	cert := &securityv1.EccCertificate{}
+	cert.SetPublicKey(publicKey)
+
+	data := []byte("signed elementary file")
+	hash := sha256.Sum256(data)
+	r, s, err := ecdsa.Sign(rand.Reader, key, hash[:])
+	if err != nil {
+		t.Fatalf("Failed to sign data: %v", err)
+	}
+	signature := append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+
+	if err := VerifyEccSignature(data, signature, cert); err != nil {
+		t.Errorf("VerifyEccSignature() error = %v, want nil", err)
+	}
+	if err := VerifyEccSignature([]byte("tampered elementary file"), signature, cert); err == nil {
+		t.Error("VerifyEccSignature() with tampered data succeeded, want error")
+	}
+	if err := VerifyEccSignature(data, signature[:63], cert); err == nil {
+		t.Error("VerifyEccSignature() with truncated signature succeeded, want error")
+	}
+}
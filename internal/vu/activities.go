@@ -57,8 +57,8 @@ func sizeOfActivitiesGen1(data []byte) (int, error) {
 	offset += 3 // OdometerValueMidnight
 
 	// VuCardIWData: 2 bytes count + variable records
-	if len(data[offset:]) < 2 {
-		return 0, fmt.Errorf("insufficient data for noOfIWRecords")
+	if len(data)-offset < 2 {
+		return 0, fmt.Errorf("insufficient data for noOfIWRecords: %w", dd.ErrTruncated)
 	}
 	noOfIWRecords := binary.BigEndian.Uint16(data[offset:])
 	offset += 2
@@ -71,8 +71,8 @@ func sizeOfActivitiesGen1(data []byte) (int, error) {
 	offset += int(noOfIWRecords) * vuCardIWRecordSize
 
 	// VuActivityDailyData: 2 bytes count + variable activity changes
-	if len(data[offset:]) < 2 {
-		return 0, fmt.Errorf("insufficient data for noOfActivityChanges")
+	if len(data)-offset < 2 {
+		return 0, fmt.Errorf("insufficient data for noOfActivityChanges: %w", dd.ErrTruncated)
 	}
 	noOfActivityChanges := binary.BigEndian.Uint16(data[offset:])
 	offset += 2
@@ -82,8 +82,8 @@ func sizeOfActivitiesGen1(data []byte) (int, error) {
 	offset += int(noOfActivityChanges) * activityChangeInfoSize
 
 	// VuPlaceDailyWorkPeriodData: 1 byte count + variable place records
-	if len(data[offset:]) < 1 {
-		return 0, fmt.Errorf("insufficient data for noOfPlaceRecords")
+	if len(data)-offset < 1 {
+		return 0, fmt.Errorf("insufficient data for noOfPlaceRecords: %w", dd.ErrTruncated)
 	}
 	noOfPlaceRecords := data[offset]
 	offset += 1
@@ -93,8 +93,8 @@ func sizeOfActivitiesGen1(data []byte) (int, error) {
 	offset += int(noOfPlaceRecords) * vuPlaceDailyWorkPeriodRecordSize
 
 	// VuSpecificConditionData: 2 bytes count + variable condition records
-	if len(data[offset:]) < 2 {
-		return 0, fmt.Errorf("insufficient data for noOfSpecificConditionRecords")
+	if len(data)-offset < 2 {
+		return 0, fmt.Errorf("insufficient data for noOfSpecificConditionRecords: %w", dd.ErrTruncated)
 	}
 	noOfSpecificConditionRecords := binary.BigEndian.Uint16(data[offset:])
 	offset += 2
@@ -516,7 +516,7 @@ func unmarshalVuCardIWRecord(data []byte) (*vuv1.Activities_CardIWRecord, error)
 	// Total: 130 bytes
 
 	if len(data) < 130 {
-		return nil, fmt.Errorf("insufficient data for card IW record: got %d, need 130", len(data))
+		return nil, fmt.Errorf("insufficient data for card IW record: got %d, need 130: %w", len(data), dd.ErrTruncated)
 	}
 
 	var opts dd.UnmarshalOptions
@@ -672,7 +672,7 @@ func unmarshalVuPlaceRecord(data []byte) (*vuv1.Activities_PlaceRecord, error) {
 	// }
 
 	if len(data) < 10 {
-		return nil, fmt.Errorf("insufficient data for place record: got %d, need 10", len(data))
+		return nil, fmt.Errorf("insufficient data for place record: got %d, need 10: %w", len(data), dd.ErrTruncated)
 	}
 
 	var opts dd.UnmarshalOptions
@@ -764,7 +764,7 @@ func parseDateOfDayDownloadedRecordArray(data []byte, offset int) ([]*timestampp
 
 	// Read record array header (6 bytes total)
 	if offset+6 > len(data) {
-		return nil, offset, fmt.Errorf("insufficient data for record array header: got %d, need 6", len(data)-offset)
+		return nil, offset, fmt.Errorf("insufficient data for record array header: got %d, need 6: %w", len(data)-offset, dd.ErrTruncated)
 	}
 
 	// Read recordType (2 bytes)
@@ -794,7 +794,7 @@ func parseDateOfDayDownloadedRecordArray(data []byte, offset int) ([]*timestampp
 	// Parse each TimeReal record
 	for i := 0; i < int(noOfRecords); i++ {
 		if offset+4 > len(data) {
-			return nil, offset, fmt.Errorf("insufficient data for TimeReal record %d: got %d, need 4", i, len(data)-offset)
+			return nil, offset, fmt.Errorf("insufficient data for TimeReal record %d: got %d, need 4: %w", i, len(data)-offset, dd.ErrTruncated)
 		}
 
 		timeValue, newOffset, err := readVuTimeRealFromBytes(data, offset)
@@ -819,7 +819,7 @@ func parseOdometerValueMidnightRecordArray(data []byte, offset int) ([]int32, in
 
 	// Read record array header (6 bytes total)
 	if offset+6 > len(data) {
-		return nil, offset, fmt.Errorf("insufficient data for record array header: got %d, need 6", len(data)-offset)
+		return nil, offset, fmt.Errorf("insufficient data for record array header: got %d, need 6: %w", len(data)-offset, dd.ErrTruncated)
 	}
 
 	// Read recordType (2 bytes)
@@ -871,7 +871,7 @@ func parseVuCardIWRecordArray(data []byte, offset int) ([]*vuv1.Activities_CardI
 
 	// Read record array header (6 bytes total)
 	if offset+6 > len(data) {
-		return nil, offset, fmt.Errorf("insufficient data for record array header: got %d, need 6", len(data)-offset)
+		return nil, offset, fmt.Errorf("insufficient data for record array header: got %d, need 6: %w", len(data)-offset, dd.ErrTruncated)
 	}
 
 	// Read recordType (2 bytes)
@@ -958,7 +958,7 @@ func parseVuCardIWRecordGen2(data []byte, offset int) (*vuv1.Activities_CardIWRe
 
 	// Parse cardExpiryDate (Datef - 4 bytes)
 	if offset+4 > len(data) {
-		return nil, offset, fmt.Errorf("insufficient data for card expiry date")
+		return nil, offset, fmt.Errorf("insufficient data for card expiry date: %w", dd.ErrTruncated)
 	}
 	cardExpiryDate, err := opts.UnmarshalDate(data[offset : offset+4])
 	if err != nil {
@@ -1050,7 +1050,7 @@ func parseVuActivityDailyRecordArray(data []byte, offset int) ([]*ddv1.ActivityC
 
 	// Read record array header (6 bytes total)
 	if offset+6 > len(data) {
-		return nil, offset, fmt.Errorf("insufficient data for record array header: got %d, need 6", len(data)-offset)
+		return nil, offset, fmt.Errorf("insufficient data for record array header: got %d, need 6: %w", len(data)-offset, dd.ErrTruncated)
 	}
 
 	// Read recordType (2 bytes)
@@ -1132,7 +1132,7 @@ func parseVuPlaceDailyWorkPeriodRecordArray(data []byte, offset int) ([]*vuv1.Ac
 
 	// Read record array header (6 bytes total)
 	if offset+6 > len(data) {
-		return nil, offset, fmt.Errorf("insufficient data for record array header: got %d, need 6", len(data)-offset)
+		return nil, offset, fmt.Errorf("insufficient data for record array header: got %d, need 6: %w", len(data)-offset, dd.ErrTruncated)
 	}
 
 	// Read recordType (2 bytes)
@@ -1213,7 +1213,7 @@ func parseVuGNSSADRecordArray(data []byte, offset int) ([]*vuv1.Activities_GnssR
 
 	// Read record array header (6 bytes total)
 	if offset+6 > len(data) {
-		return nil, offset, fmt.Errorf("insufficient data for GNSS record array header: got %d, need 6", len(data)-offset)
+		return nil, offset, fmt.Errorf("insufficient data for GNSS record array header: got %d, need 6: %w", len(data)-offset, dd.ErrTruncated)
 	}
 
 	// Read recordType (2 bytes)
@@ -1280,7 +1280,7 @@ func unmarshalVuGNSSADRecord(data []byte) (*vuv1.Activities_GnssRecord, error) {
 	// }
 
 	if len(data) < 14 {
-		return nil, fmt.Errorf("insufficient data for GNSS record: got %d, need 14", len(data))
+		return nil, fmt.Errorf("insufficient data for GNSS record: got %d, need 14: %w", len(data), dd.ErrTruncated)
 	}
 
 	record := &vuv1.Activities_GnssRecord{}
@@ -1342,7 +1342,7 @@ func parseVuSpecificConditionRecordArray(data []byte, offset int) ([]*ddv1.Speci
 
 	// Read record array header (6 bytes total)
 	if offset+6 > len(data) {
-		return nil, offset, fmt.Errorf("insufficient data for record array header: got %d, need 6", len(data)-offset)
+		return nil, offset, fmt.Errorf("insufficient data for record array header: got %d, need 6: %w", len(data)-offset, dd.ErrTruncated)
 	}
 
 	// Read recordType (2 bytes)
@@ -1424,7 +1424,7 @@ func parseVuBorderCrossingRecordArray(data []byte, offset int) ([]*vuv1.Activiti
 
 	// Read record array header (6 bytes total)
 	if offset+6 > len(data) {
-		return nil, offset, fmt.Errorf("insufficient data for border crossing record array header: got %d, need 6", len(data)-offset)
+		return nil, offset, fmt.Errorf("insufficient data for border crossing record array header: got %d, need 6: %w", len(data)-offset, dd.ErrTruncated)
 	}
 
 	// Read recordType (2 bytes)
@@ -1495,7 +1495,7 @@ func unmarshalVuBorderCrossingRecord(data []byte) (*vuv1.Activities_BorderCrossi
 
 	const vuBorderCrossingRecordSize = 57
 	if len(data) < vuBorderCrossingRecordSize {
-		return nil, fmt.Errorf("insufficient data for border crossing record: got %d, need %d", len(data), vuBorderCrossingRecordSize)
+		return nil, fmt.Errorf("insufficient data for border crossing record: got %d, need %d: %w", len(data), vuBorderCrossingRecordSize, dd.ErrTruncated)
 	}
 
 	var opts dd.UnmarshalOptions
@@ -1595,7 +1595,7 @@ func parseVuLoadUnloadRecordArray(data []byte, offset int) ([]*vuv1.Activities_L
 
 	// Read record array header (6 bytes total)
 	if offset+6 > len(data) {
-		return nil, offset, fmt.Errorf("insufficient data for load/unload record array header: got %d, need 6", len(data)-offset)
+		return nil, offset, fmt.Errorf("insufficient data for load/unload record array header: got %d, need 6: %w", len(data)-offset, dd.ErrTruncated)
 	}
 
 	// Read recordType (2 bytes)
@@ -1666,7 +1666,7 @@ func unmarshalVuLoadUnloadRecord(data []byte) (*vuv1.Activities_LoadUnloadRecord
 
 	const vuLoadUnloadRecordSize = 60
 	if len(data) < vuLoadUnloadRecordSize {
-		return nil, fmt.Errorf("insufficient data for load/unload record: got %d, need %d", len(data), vuLoadUnloadRecordSize)
+		return nil, fmt.Errorf("insufficient data for load/unload record: got %d, need %d: %w", len(data), vuLoadUnloadRecordSize, dd.ErrTruncated)
 	}
 
 	var opts dd.UnmarshalOptions
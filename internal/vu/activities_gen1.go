@@ -52,40 +52,42 @@ import (
 //
 // Note: This is a minimal implementation that validates the binary structure and stores raw_data.
 // Full semantic parsing of all nested records is TODO.
-func unmarshalActivitiesGen1(value []byte) (*vuv1.ActivitiesGen1, error) {
+func unmarshalActivitiesGen1(opts dd.UnmarshalOptions, value []byte) (*vuv1.ActivitiesGen1, error) {
 	activities := &vuv1.ActivitiesGen1{}
 	activities.SetRawData(value)
 
 	offset := 0
-	var opts dd.UnmarshalOptions
 
 	// TimeReal (4 bytes) - date of day downloaded
 	if offset+4 > len(value) {
-		return nil, fmt.Errorf("insufficient data for TimeReal")
+		return nil, dd.WrapField("date_of_day", offset, fmt.Errorf("insufficient data for TimeReal: %w", dd.ErrTruncated))
 	}
 	timeReal, err := opts.UnmarshalTimeReal(value[offset : offset+4])
 	if err != nil {
-		return nil, fmt.Errorf("unmarshal TimeReal: %w", err)
+		return nil, dd.WrapField("date_of_day", offset, fmt.Errorf("unmarshal TimeReal: %w", err))
 	}
 	activities.SetDateOfDay(timeReal)
+	opts.Layout.Field("date_of_day", offset, 4)
 	offset += 4
 
 	// OdometerValueMidnight (3 bytes - OdometerShort)
 	if offset+3 > len(value) {
-		return nil, fmt.Errorf("insufficient data for OdometerValueMidnight")
+		return nil, dd.WrapField("odometer_midnight_km", offset, fmt.Errorf("insufficient data for OdometerValueMidnight: %w", dd.ErrTruncated))
 	}
 	odometer, err := opts.UnmarshalOdometer(value[offset : offset+3])
 	if err != nil {
-		return nil, fmt.Errorf("unmarshal OdometerValueMidnight: %w", err)
+		return nil, dd.WrapField("odometer_midnight_km", offset, fmt.Errorf("unmarshal OdometerValueMidnight: %w", err))
 	}
 	activities.SetOdometerMidnightKm(int32(odometer))
+	opts.Layout.Field("odometer_midnight_km", offset, 3)
 	offset += 3
 
 	// VuCardIWData: 2 bytes (noOfIWRecords) + (noOfIWRecords * 129 bytes)
 	if offset+2 > len(value) {
-		return nil, fmt.Errorf("insufficient data for noOfIWRecords")
+		return nil, dd.WrapField("card_iw_data", offset, fmt.Errorf("insufficient data for noOfIWRecords: %w", dd.ErrTruncated))
 	}
 	noOfIWRecords := binary.BigEndian.Uint16(value[offset : offset+2])
+	opts.Layout.Field("no_of_iw_records", offset, 2)
 	offset += 2
 
 	// Parse each CardIWRecord (129 bytes each for Gen1)
@@ -93,24 +95,26 @@ func unmarshalActivitiesGen1(value []byte) (*vuv1.ActivitiesGen1, error) {
 	for i := uint16(0); i < noOfIWRecords; i++ {
 		const cardIWRecordSize = 129
 		if offset+cardIWRecordSize > len(value) {
-			return nil, fmt.Errorf("insufficient data for CardIWRecord %d", i)
+			return nil, dd.WrapField(fmt.Sprintf("card_iw_data[%d]", i), offset, fmt.Errorf("insufficient data for CardIWRecord %d: %w", i, dd.ErrTruncated))
 		}
 
 		record, err := opts.UnmarshalVuCardIWRecord(value[offset : offset+cardIWRecordSize])
 		if err != nil {
-			return nil, fmt.Errorf("unmarshal CardIWRecord %d: %w", i, err)
+			return nil, dd.WrapField(fmt.Sprintf("card_iw_data[%d]", i), offset, fmt.Errorf("unmarshal CardIWRecord %d: %w", i, err))
 		}
 
 		cardIWRecords[i] = record
+		opts.Layout.Element("card_iw_data", int(i), offset, cardIWRecordSize)
 		offset += cardIWRecordSize
 	}
 	activities.SetCardIwData(cardIWRecords)
 
 	// VuActivityDailyData: 2 bytes (noOfActivityChanges) + (noOfActivityChanges * 2 bytes)
 	if offset+2 > len(value) {
-		return nil, fmt.Errorf("insufficient data for noOfActivityChanges")
+		return nil, dd.WrapField("activity_changes", offset, fmt.Errorf("insufficient data for noOfActivityChanges: %w", dd.ErrTruncated))
 	}
 	noOfActivityChanges := binary.BigEndian.Uint16(value[offset : offset+2])
+	opts.Layout.Field("no_of_activity_changes", offset, 2)
 	offset += 2
 
 	// Parse each ActivityChangeInfo (2 bytes each)
@@ -118,14 +122,15 @@ func unmarshalActivitiesGen1(value []byte) (*vuv1.ActivitiesGen1, error) {
 	for i := uint16(0); i < noOfActivityChanges; i++ {
 		const activityChangeSize = 2
 		if offset+activityChangeSize > len(value) {
-			return nil, fmt.Errorf("insufficient data for ActivityChangeInfo %d", i)
+			return nil, dd.WrapField(fmt.Sprintf("activity_changes[%d]", i), offset, fmt.Errorf("insufficient data for ActivityChangeInfo %d: %w", i, dd.ErrTruncated))
 		}
 
 		activityChange, err := opts.UnmarshalActivityChangeInfo(value[offset : offset+activityChangeSize])
 		if err != nil {
-			return nil, fmt.Errorf("unmarshal activity change %d: %w", i, err)
+			return nil, dd.WrapField(fmt.Sprintf("activity_changes[%d]", i), offset, fmt.Errorf("unmarshal activity change %d: %w", i, err))
 		}
 		activityChanges[i] = activityChange
+		opts.Layout.Element("activity_changes", int(i), offset, activityChangeSize)
 		offset += activityChangeSize
 	}
 	activities.SetActivityChanges(activityChanges)
@@ -133,9 +138,10 @@ func unmarshalActivitiesGen1(value []byte) (*vuv1.ActivitiesGen1, error) {
 	// VuPlaceDailyWorkPeriodData: 1 byte (noOfPlaceRecords) + (noOfPlaceRecords * 28 bytes)
 	// Note: Each record is 28 bytes (18 FullCardNumber + 10 PlaceRecord)
 	if offset+1 > len(value) {
-		return nil, fmt.Errorf("insufficient data for noOfPlaceRecords")
+		return nil, dd.WrapField("places", offset, fmt.Errorf("insufficient data for noOfPlaceRecords: %w", dd.ErrTruncated))
 	}
 	noOfPlaceRecords := value[offset]
+	opts.Layout.Field("no_of_place_records", offset, 1)
 	offset += 1
 
 	// Parse each VuPlaceDailyWorkPeriodRecord (28 bytes each)
@@ -143,52 +149,59 @@ func unmarshalActivitiesGen1(value []byte) (*vuv1.ActivitiesGen1, error) {
 	for i := uint8(0); i < noOfPlaceRecords; i++ {
 		const placeRecordSize = 28 // 18 bytes FullCardNumber + 10 bytes PlaceRecord
 		if offset+placeRecordSize > len(value) {
-			return nil, fmt.Errorf("insufficient data for PlaceRecord %d", i)
+			return nil, dd.WrapField(fmt.Sprintf("places[%d]", i), offset, fmt.Errorf("insufficient data for PlaceRecord %d: %w", i, dd.ErrTruncated))
 		}
 
 		record := &vuv1.ActivitiesGen1_PlaceRecord{}
 		recordOffset := 0
+		placeLayout := opts.Layout.NestedElement("places", int(i), offset)
 
 		// Skip FullCardNumber (18 bytes) - not exposed in the proto for Gen1 PlaceRecord
 		// This is the card number associated with this place entry
+		placeLayout.Field("full_card_number", recordOffset, 18)
 		recordOffset += 18
 
 		// PlaceRecord (10 bytes)
 		// entryTime (4 bytes)
 		entryTime, err := opts.UnmarshalTimeReal(value[offset+recordOffset : offset+recordOffset+4])
 		if err != nil {
-			return nil, fmt.Errorf("unmarshal place entry time: %w", err)
+			return nil, dd.WrapField(fmt.Sprintf("places[%d].entry_time", i), offset+recordOffset, fmt.Errorf("unmarshal place entry time: %w", err))
 		}
 		record.SetEntryTime(entryTime)
+		placeLayout.Field("entry_time", recordOffset, 4)
 		recordOffset += 4
 
 		// entryTypeDailyWorkPeriod (1 byte)
 		entryType, err := dd.UnmarshalEnum[ddv1.EntryTypeDailyWorkPeriod](value[offset+recordOffset])
 		if err != nil {
-			return nil, fmt.Errorf("unmarshal entry type: %w", err)
+			return nil, dd.WrapField(fmt.Sprintf("places[%d].entry_type", i), offset+recordOffset, fmt.Errorf("unmarshal entry type: %w", err))
 		}
 		record.SetEntryType(entryType)
+		placeLayout.Field("entry_type", recordOffset, 1)
 		recordOffset += 1
 
 		// dailyWorkPeriodCountry (1 byte)
 		country, err := dd.UnmarshalEnum[ddv1.NationNumeric](value[offset+recordOffset])
 		if err != nil {
-			return nil, fmt.Errorf("unmarshal country: %w", err)
+			return nil, dd.WrapField(fmt.Sprintf("places[%d].country", i), offset+recordOffset, fmt.Errorf("unmarshal country: %w", err))
 		}
 		record.SetCountry(country)
+		placeLayout.Field("country", recordOffset, 1)
 		recordOffset += 1
 
 		// dailyWorkPeriodRegion (1 byte)
 		region := value[offset+recordOffset : offset+recordOffset+1]
 		record.SetRegion(region)
+		placeLayout.Field("region", recordOffset, 1)
 		recordOffset += 1
 
 		// vehicleOdometerValue (3 bytes)
 		odometerValue, err := opts.UnmarshalOdometer(value[offset+recordOffset : offset+recordOffset+3])
 		if err != nil {
-			return nil, fmt.Errorf("unmarshal place odometer: %w", err)
+			return nil, dd.WrapField(fmt.Sprintf("places[%d].odometer_km", i), offset+recordOffset, fmt.Errorf("unmarshal place odometer: %w", err))
 		}
 		record.SetOdometerKm(int32(odometerValue))
+		placeLayout.Field("odometer_km", recordOffset, 3)
 		recordOffset += 3
 
 		placeRecords[i] = record
@@ -198,9 +211,10 @@ func unmarshalActivitiesGen1(value []byte) (*vuv1.ActivitiesGen1, error) {
 
 	// VuSpecificConditionData: 2 bytes (noOfSpecificConditionRecords) + (noOfSpecificConditionRecords * 5 bytes)
 	if offset+2 > len(value) {
-		return nil, fmt.Errorf("insufficient data for noOfSpecificConditionRecords")
+		return nil, dd.WrapField("specific_conditions", offset, fmt.Errorf("insufficient data for noOfSpecificConditionRecords: %w", dd.ErrTruncated))
 	}
 	noOfSpecificConditionRecords := binary.BigEndian.Uint16(value[offset : offset+2])
+	opts.Layout.Field("no_of_specific_condition_records", offset, 2)
 	offset += 2
 
 	// Parse each SpecificConditionRecord (5 bytes each)
@@ -208,23 +222,25 @@ func unmarshalActivitiesGen1(value []byte) (*vuv1.ActivitiesGen1, error) {
 	for i := uint16(0); i < noOfSpecificConditionRecords; i++ {
 		const specificConditionSize = 5
 		if offset+specificConditionSize > len(value) {
-			return nil, fmt.Errorf("insufficient data for SpecificConditionRecord %d", i)
+			return nil, dd.WrapField(fmt.Sprintf("specific_conditions[%d]", i), offset, fmt.Errorf("insufficient data for SpecificConditionRecord %d: %w", i, dd.ErrTruncated))
 		}
 
 		specificCondition, err := opts.UnmarshalSpecificConditionRecord(value[offset : offset+specificConditionSize])
 		if err != nil {
-			return nil, fmt.Errorf("unmarshal specific condition %d: %w", i, err)
+			return nil, dd.WrapField(fmt.Sprintf("specific_conditions[%d]", i), offset, fmt.Errorf("unmarshal specific condition %d: %w", i, err))
 		}
 		specificConditions[i] = specificCondition
+		opts.Layout.Element("specific_conditions", int(i), offset, specificConditionSize)
 		offset += specificConditionSize
 	}
 	activities.SetSpecificConditions(specificConditions)
 
 	// Signature (128 bytes - RSA for Gen1)
 	if offset+128 > len(value) {
-		return nil, fmt.Errorf("insufficient data for Signature")
+		return nil, dd.WrapField("signature", offset, fmt.Errorf("insufficient data for Signature: %w", dd.ErrTruncated))
 	}
 	activities.SetSignature(value[offset : offset+128])
+	opts.Layout.Field("signature", offset, 128)
 	offset += 128
 
 	// Verify we consumed exactly the right amount of data
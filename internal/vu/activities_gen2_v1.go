@@ -3,6 +3,8 @@ package vu
 import (
 	"fmt"
 
+	"github.com/way-platform/tachograph-go/internal/dd"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
 	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
 )
 
@@ -13,11 +15,12 @@ import (
 // ASN.1 Definition:
 //
 //	VuActivitiesSecondGen ::= SEQUENCE {
-//	    timeRealRecordArray                   TimeRealRecordArray,
+//	    dateOfDayDownloadedRecordArray        DateOfDayDownloadedRecordArray,
 //	    odometerValueMidnightRecordArray      OdometerValueMidnightRecordArray,
 //	    vuCardIWRecordArray                   VuCardIWRecordArray,
 //	    vuActivityDailyRecordArray            VuActivityDailyRecordArray,
 //	    vuPlaceDailyWorkPeriodRecordArray     VuPlaceDailyWorkPeriodRecordArray,
+//	    vuGNSSADRecordArray                   VuGNSSADRecordArray,
 //	    vuSpecificConditionRecordArray        VuSpecificConditionRecordArray,
 //	    signatureRecordArray                  SignatureRecordArray
 //	}
@@ -26,69 +29,328 @@ import (
 //
 //	recordType (1 byte) + recordSize (2 bytes, big-endian) + noOfRecords (2 bytes, big-endian)
 //
-// Note: This is a minimal implementation that stores raw_data for round-trip fidelity.
-// Full semantic parsing of all RecordArrays is TODO.
-func unmarshalActivitiesGen2V1(value []byte) (*vuv1.ActivitiesGen2V1, error) {
+// The record arrays are dispatched on their record type, so that the parser
+// does not depend on the exact sequence of arrays in the transfer. Unknown
+// record arrays are skipped and preserved in raw_data.
+func unmarshalActivitiesGen2V1(opts dd.UnmarshalOptions, value []byte) (*vuv1.ActivitiesGen2V1, error) {
 	activities := &vuv1.ActivitiesGen2V1{}
 	activities.SetRawData(value)
 
-	// For now, store the raw data and validate structure by skipping through all record arrays
 	offset := 0
-
-	// Helper to skip a RecordArray
-	skipRecordArray := func(name string) error {
-		size, err := sizeOfRecordArray(value, offset)
+	for offset < len(value) {
+		ra, next, err := readRecordArray(value, offset)
 		if err != nil {
-			return fmt.Errorf("%s: %w", name, err)
+			return nil, fmt.Errorf("Activities Gen2 V1: %w", err)
 		}
-		offset += size
-		return nil
+		switch ra.recordType {
+		case recordTypeDateOfDayDownloaded:
+			recordArrayLayout(opts.Layout, "date_of_day", value, offset)
+			if err := ra.checkRecordSize("DateOfDayDownloaded", 4); err != nil {
+				return nil, ra.fieldError("date_of_day", err)
+			}
+			if len(ra.records) > 0 {
+				dateOfDay, err := opts.UnmarshalTimeReal(ra.records[0][:4])
+				if err != nil {
+					return nil, ra.fieldError("date_of_day", fmt.Errorf("unmarshal DateOfDayDownloaded: %w", err))
+				}
+				activities.SetDateOfDay(dateOfDay)
+			}
+
+		case recordTypeOdometerValueMidnight:
+			recordArrayLayout(opts.Layout, "odometer_midnight_km", value, offset)
+			if err := ra.checkRecordSize("OdometerValueMidnight", 3); err != nil {
+				return nil, ra.fieldError("odometer_midnight_km", err)
+			}
+			if len(ra.records) > 0 {
+				odometer, err := opts.UnmarshalOdometer(ra.records[0][:3])
+				if err != nil {
+					return nil, ra.fieldError("odometer_midnight_km", fmt.Errorf("unmarshal OdometerValueMidnight: %w", err))
+				}
+				activities.SetOdometerMidnightKm(int32(odometer))
+			}
+
+		case recordTypeVuCardIWRecord:
+			recordArrayLayout(opts.Layout, "card_iw_data", value, offset)
+			records := make([]*vuv1.ActivitiesGen2V1_CardIWRecord, 0, len(ra.records))
+			for i, data := range ra.records {
+				record, err := unmarshalCardIWRecordGen2V1(opts, data)
+				if err != nil {
+					return nil, ra.recordError("card_iw_data", i, err)
+				}
+				records = append(records, record)
+			}
+			activities.SetCardIwData(records)
+
+		case recordTypeActivityChangeInfo:
+			recordArrayLayout(opts.Layout, "activity_changes", value, offset)
+			if err := ra.checkRecordSize("ActivityChangeInfo", 2); err != nil {
+				return nil, ra.fieldError("activity_changes", err)
+			}
+			activityChanges := make([]*ddv1.ActivityChangeInfo, 0, len(ra.records))
+			for i, data := range ra.records {
+				activityChange, err := opts.UnmarshalActivityChangeInfo(data[:2])
+				if err != nil {
+					return nil, ra.recordError("activity_changes", i, err)
+				}
+				activityChanges = append(activityChanges, activityChange)
+			}
+			activities.SetActivityChanges(activityChanges)
+
+		case recordTypeVuPlaceDailyWorkPeriodRecord:
+			recordArrayLayout(opts.Layout, "places", value, offset)
+			places := make([]*vuv1.ActivitiesGen2V1_PlaceRecord, 0, len(ra.records))
+			for i, data := range ra.records {
+				place, err := unmarshalPlaceRecordGen2V1(opts, data)
+				if err != nil {
+					return nil, ra.recordError("places", i, err)
+				}
+				places = append(places, place)
+			}
+			activities.SetPlaces(places)
+
+		case recordTypeVuGNSSADRecord:
+			recordArrayLayout(opts.Layout, "gnss_accumulated_driving", value, offset)
+			gnssRecords := make([]*vuv1.ActivitiesGen2V1_GnssAccumulatedDrivingRecord, 0, len(ra.records))
+			for i, data := range ra.records {
+				gnssRecord, err := unmarshalGnssAccumulatedDrivingRecordGen2V1(opts, data)
+				if err != nil {
+					return nil, ra.recordError("gnss_accumulated_driving", i, err)
+				}
+				gnssRecords = append(gnssRecords, gnssRecord)
+			}
+			activities.SetGnssAccumulatedDriving(gnssRecords)
+
+		case recordTypeSpecificConditionRecord:
+			recordArrayLayout(opts.Layout, "specific_conditions", value, offset)
+			if err := ra.checkRecordSize("SpecificConditionRecord", 5); err != nil {
+				return nil, ra.fieldError("specific_conditions", err)
+			}
+			specificConditions := make([]*ddv1.SpecificConditionRecord, 0, len(ra.records))
+			for i, data := range ra.records {
+				specificCondition, err := opts.UnmarshalSpecificConditionRecord(data[:5])
+				if err != nil {
+					return nil, ra.recordError("specific_conditions", i, err)
+				}
+				specificConditions = append(specificConditions, specificCondition)
+			}
+			activities.SetSpecificConditions(specificConditions)
+
+		case recordTypeSignature:
+			recordArrayLayout(opts.Layout, "signature", value, offset)
+			if len(ra.records) > 0 {
+				activities.SetSignature(ra.records[0])
+			}
+		}
+		offset = next
+	}
+
+	return activities, nil
+}
+
+// unmarshalCardIWRecordGen2V1 parses a Gen2 VuCardIWRecord.
+//
+// The data type `VuCardIWRecord` is specified in the Data Dictionary, Section 2.177.
+//
+// Binary Layout (Gen2, 131 bytes):
+//   - Bytes 0-71: cardHolderName (HolderName)
+//   - Bytes 72-90: fullCardNumberAndGeneration (FullCardNumberAndGeneration)
+//   - Bytes 91-94: cardExpiryDate (Datef)
+//   - Bytes 95-98: cardInsertionTime (TimeReal)
+//   - Bytes 99-101: vehicleOdometerValueAtInsertion (OdometerShort)
+//   - Byte 102: cardSlotNumber (CardSlotNumber)
+//   - Bytes 103-106: cardWithdrawalTime (TimeReal)
+//   - Bytes 107-109: vehicleOdometerValueAtWithdrawal (OdometerShort)
+//   - Bytes 110-129: previousVehicleInfo (PreviousVehicleInfo)
+//   - Byte 130: manualInputFlag (ManualInputFlag)
+func unmarshalCardIWRecordGen2V1(opts dd.UnmarshalOptions, data []byte) (*vuv1.ActivitiesGen2V1_CardIWRecord, error) {
+	const (
+		idxCardHolderName       = 0
+		idxFullCardNumber       = 72
+		idxCardExpiryDate       = 91
+		idxCardInsertionTime    = 95
+		idxOdometerAtInsertion  = 99
+		idxCardSlotNumber       = 102
+		idxCardWithdrawalTime   = 103
+		idxOdometerAtWithdrawal = 107
+		idxPreviousVehicleInfo  = 110
+		idxManualInputFlag      = 130
+		lenVuCardIWRecord       = 131
+	)
+	if len(data) < lenVuCardIWRecord {
+		return nil, fmt.Errorf("invalid data length for VuCardIWRecord: got %d, want %d: %w", len(data), lenVuCardIWRecord, dd.ErrInvalidValue)
 	}
+	record := &vuv1.ActivitiesGen2V1_CardIWRecord{}
 
-	// TimeRealRecordArray
-	if err := skipRecordArray("TimeReal"); err != nil {
-		return nil, err
+	holderName, err := opts.UnmarshalHolderName(data[idxCardHolderName:idxFullCardNumber])
+	if err != nil {
+		return nil, dd.WrapField("card_holder_name", idxCardHolderName, fmt.Errorf("unmarshal card holder name: %w", err))
 	}
+	record.SetCardHolderName(holderName)
 
-	// OdometerValueMidnightRecordArray
-	if err := skipRecordArray("OdometerValueMidnight"); err != nil {
-		return nil, err
+	fullCardNumber, err := opts.UnmarshalFullCardNumberAndGeneration(data[idxFullCardNumber:idxCardExpiryDate])
+	if err != nil {
+		return nil, dd.WrapField("full_card_number_and_generation", idxFullCardNumber, fmt.Errorf("unmarshal full card number and generation: %w", err))
 	}
+	record.SetFullCardNumberAndGeneration(fullCardNumber)
 
-	// VuCardIWRecordArray
-	if err := skipRecordArray("VuCardIW"); err != nil {
-		return nil, err
+	expiryDate, err := opts.UnmarshalDate(data[idxCardExpiryDate:idxCardInsertionTime])
+	if err != nil {
+		return nil, dd.WrapField("card_expiry_date", idxCardExpiryDate, fmt.Errorf("unmarshal card expiry date: %w", err))
 	}
+	record.SetCardExpiryDate(expiryDate)
 
-	// VuActivityDailyRecordArray
-	if err := skipRecordArray("VuActivityDaily"); err != nil {
-		return nil, err
+	insertionTime, err := opts.UnmarshalTimeReal(data[idxCardInsertionTime:idxOdometerAtInsertion])
+	if err != nil {
+		return nil, dd.WrapField("card_insertion_time", idxCardInsertionTime, fmt.Errorf("unmarshal card insertion time: %w", err))
 	}
+	record.SetCardInsertionTime(insertionTime)
 
-	// VuPlaceDailyWorkPeriodRecordArray
-	if err := skipRecordArray("VuPlaceDailyWorkPeriod"); err != nil {
-		return nil, err
+	odometerAtInsertion, err := opts.UnmarshalOdometer(data[idxOdometerAtInsertion:idxCardSlotNumber])
+	if err != nil {
+		return nil, dd.WrapField("odometer_at_insertion_km", idxOdometerAtInsertion, fmt.Errorf("unmarshal odometer at insertion: %w", err))
 	}
+	record.SetOdometerAtInsertionKm(int32(odometerAtInsertion))
 
-	// VuSpecificConditionRecordArray
-	if err := skipRecordArray("VuSpecificCondition"); err != nil {
-		return nil, err
+	cardSlotNumber, err := dd.UnmarshalEnum[ddv1.CardSlotNumber](data[idxCardSlotNumber])
+	if err != nil {
+		return nil, dd.WrapField("card_slot_number", idxCardSlotNumber, fmt.Errorf("unmarshal card slot number: %w", err))
 	}
+	record.SetCardSlotNumber(cardSlotNumber)
 
-	// SignatureRecordArray (last)
-	if err := skipRecordArray("Signature"); err != nil {
-		return nil, err
+	withdrawalTime, err := opts.UnmarshalTimeReal(data[idxCardWithdrawalTime:idxOdometerAtWithdrawal])
+	if err != nil {
+		return nil, dd.WrapField("card_withdrawal_time", idxCardWithdrawalTime, fmt.Errorf("unmarshal card withdrawal time: %w", err))
 	}
+	record.SetCardWithdrawalTime(withdrawalTime)
 
-	// Verify we consumed exactly the right amount of data
-	if offset != len(value) {
-		return nil, fmt.Errorf("Activities Gen2 V1 parsing mismatch: parsed %d bytes, expected %d", offset, len(value))
+	odometerAtWithdrawal, err := opts.UnmarshalOdometer(data[idxOdometerAtWithdrawal:idxPreviousVehicleInfo])
+	if err != nil {
+		return nil, dd.WrapField("odometer_at_withdrawal_km", idxOdometerAtWithdrawal, fmt.Errorf("unmarshal odometer at withdrawal: %w", err))
 	}
+	record.SetOdometerAtWithdrawalKm(int32(odometerAtWithdrawal))
 
-	// TODO: Implement full semantic parsing of all record arrays
-	// For now, raw_data contains all the information needed for round-trip testing
+	previousVehicleInfo, err := opts.UnmarshalPreviousVehicleInfoG2(data[idxPreviousVehicleInfo:idxManualInputFlag])
+	if err != nil {
+		return nil, dd.WrapField("previous_vehicle_info", idxPreviousVehicleInfo, fmt.Errorf("unmarshal previous vehicle info: %w", err))
+	}
+	record.SetPreviousVehicleInfo(previousVehicleInfo)
 
-	return activities, nil
+	record.SetManualInputFlag(data[idxManualInputFlag] != 0)
+	return record, nil
+}
+
+// unmarshalPlaceRecordGen2V1 parses a Gen2 VuPlaceDailyWorkPeriodRecord.
+//
+// The data type `VuPlaceDailyWorkPeriodRecord` is specified in the Data Dictionary, Section 2.219.
+//
+// ASN.1 Definition:
+//
+//	VuPlaceDailyWorkPeriodRecord ::= SEQUENCE {
+//	    fullCardNumberAndGeneration FullCardNumberAndGeneration,  -- 19 bytes
+//	    placeRecord                 PlaceRecord                   -- 21 bytes
+//	}
+//
+// The card number is not exposed in the proto. Gen2 V2 place records carry a
+// GNSSPlaceAuthRecord, of which only the GNSSPlaceRecord prefix is kept.
+func unmarshalPlaceRecordGen2V1(opts dd.UnmarshalOptions, data []byte) (*vuv1.ActivitiesGen2V1_PlaceRecord, error) {
+	const (
+		idxEntryTime     = 19
+		idxEntryType     = 23
+		idxCountry       = 24
+		idxRegion        = 25
+		idxOdometer      = 26
+		idxGNSSPlace     = 29
+		lenGNSSPlace     = 11
+		lenVuPlaceRecord = idxGNSSPlace + lenGNSSPlace
+	)
+	if len(data) < lenVuPlaceRecord {
+		return nil, fmt.Errorf("invalid data length for VuPlaceDailyWorkPeriodRecord: got %d, want %d: %w", len(data), lenVuPlaceRecord, dd.ErrInvalidValue)
+	}
+	record := &vuv1.ActivitiesGen2V1_PlaceRecord{}
+
+	entryTime, err := opts.UnmarshalTimeReal(data[idxEntryTime:idxEntryType])
+	if err != nil {
+		return nil, dd.WrapField("entry_time", idxEntryTime, fmt.Errorf("unmarshal place entry time: %w", err))
+	}
+	record.SetEntryTime(entryTime)
+
+	entryType, err := dd.UnmarshalEnum[ddv1.EntryTypeDailyWorkPeriod](data[idxEntryType])
+	if err != nil {
+		return nil, dd.WrapField("entry_type", idxEntryType, fmt.Errorf("unmarshal entry type: %w", err))
+	}
+	record.SetEntryType(entryType)
+
+	country, err := dd.UnmarshalEnum[ddv1.NationNumeric](data[idxCountry])
+	if err != nil {
+		return nil, dd.WrapField("country", idxCountry, fmt.Errorf("unmarshal country: %w", err))
+	}
+	record.SetCountry(country)
+
+	record.SetRegion(data[idxRegion:idxOdometer])
+
+	odometer, err := opts.UnmarshalOdometer(data[idxOdometer:idxGNSSPlace])
+	if err != nil {
+		return nil, dd.WrapField("odometer_km", idxOdometer, fmt.Errorf("unmarshal place odometer: %w", err))
+	}
+	record.SetOdometerKm(int32(odometer))
+
+	gnssPlace, err := opts.UnmarshalGNSSPlaceRecord(data[idxGNSSPlace : idxGNSSPlace+lenGNSSPlace])
+	if err != nil {
+		return nil, dd.WrapField("gnss_place_record", idxGNSSPlace, fmt.Errorf("unmarshal GNSS place record: %w", err))
+	}
+	gnssPlaceRecord := &vuv1.ActivitiesGen2V1_GnssPlaceRecord{}
+	gnssPlaceRecord.SetTimestamp(gnssPlace.GetTimestamp())
+	gnssPlaceRecord.SetGnssAccuracy(gnssPlace.GetGnssAccuracy())
+	gnssPlaceRecord.SetGeoCoordinates(gnssPlace.GetGeoCoordinates())
+	record.SetGnssPlaceRecord(gnssPlaceRecord)
+
+	return record, nil
+}
+
+// unmarshalGnssAccumulatedDrivingRecordGen2V1 parses a VuGNSSADRecord.
+//
+// The data type `VuGNSSADRecord` is specified in the Data Dictionary, Section 2.203.
+//
+// ASN.1 Definition:
+//
+//	VuGNSSADRecord ::= SEQUENCE {
+//	    timeStamp                      TimeReal,                     -- 4 bytes
+//	    cardNumberAndGenDriverSlot     FullCardNumberAndGeneration,  -- 19 bytes
+//	    cardNumberAndGenCodriverSlot   FullCardNumberAndGeneration,  -- 19 bytes
+//	    gnssPlaceRecord                GNSSPlaceRecord,              -- 11 bytes
+//	    vehicleOdometerValue           OdometerShort                 -- 3 bytes
+//	}
+//
+// From Gen2 V2 onwards the place is a GNSSPlaceAuthRecord (12 bytes), which
+// carries the position authentication status.
+func unmarshalGnssAccumulatedDrivingRecordGen2V1(opts dd.UnmarshalOptions, data []byte) (*vuv1.ActivitiesGen2V1_GnssAccumulatedDrivingRecord, error) {
+	const (
+		idxGNSSPlace      = 42
+		lenGNSSPlace      = 11
+		lenVuGNSSADRecord = 56
+		lenVuGNSSADAuth   = 57
+	)
+	if len(data) < lenVuGNSSADRecord {
+		return nil, fmt.Errorf("invalid data length for VuGNSSADRecord: got %d, want %d: %w", len(data), lenVuGNSSADRecord, dd.ErrInvalidValue)
+	}
+	record := &vuv1.ActivitiesGen2V1_GnssAccumulatedDrivingRecord{}
+	gnssPlace, err := opts.UnmarshalGNSSPlaceRecord(data[idxGNSSPlace : idxGNSSPlace+lenGNSSPlace])
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal GNSS place record: %w", err)
+	}
+	record.SetTimestamp(gnssPlace.GetTimestamp())
+	record.SetGnssAccuracy(gnssPlace.GetGnssAccuracy())
+	record.SetGeoCoordinates(gnssPlace.GetGeoCoordinates())
+	if len(data) >= lenVuGNSSADAuth {
+		authByte := data[idxGNSSPlace+lenGNSSPlace]
+		if authStatus, err := dd.UnmarshalEnum[ddv1.PositionAuthenticationStatus](authByte); err == nil {
+			record.SetAuthenticationStatus(authStatus)
+		} else {
+			record.SetAuthenticationStatus(ddv1.PositionAuthenticationStatus_POSITION_AUTHENTICATION_STATUS_UNRECOGNIZED)
+			record.SetUnrecognizedAuthenticationStatus(int32(authByte))
+		}
+	}
+	return record, nil
 }
 
 // appendActivitiesGen2V1 marshals Gen2 V1 Activities data using raw data painting.
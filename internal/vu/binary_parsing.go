@@ -2,7 +2,9 @@ package vu
 
 import (
 	"encoding/binary"
-	"io"
+
+	"github.com/way-platform/tachograph-go/internal/dd"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
 )
 
 // VU-specific binary parsing functions for reading structured data from byte slices
@@ -11,7 +13,7 @@ import (
 // readUint8FromBytes reads a single byte from a byte slice at the given offset
 func readUint8FromBytes(data []byte, offset int) (uint8, int, error) {
 	if offset >= len(data) {
-		return 0, offset, io.ErrUnexpectedEOF
+		return 0, offset, dd.ErrTruncated
 	}
 	return data[offset], offset + 1, nil
 }
@@ -19,7 +21,7 @@ func readUint8FromBytes(data []byte, offset int) (uint8, int, error) {
 // readBytesFromBytes reads n bytes from a byte slice at the given offset
 func readBytesFromBytes(data []byte, offset int, n int) ([]byte, int, error) {
 	if offset+n > len(data) {
-		return nil, offset, io.ErrUnexpectedEOF
+		return nil, offset, dd.ErrTruncated
 	}
 	result := make([]byte, n)
 	copy(result, data[offset:offset+n])
@@ -29,9 +31,69 @@ func readBytesFromBytes(data []byte, offset int, n int) ([]byte, int, error) {
 // readVuTimeRealFromBytes reads a TimeReal value (4 bytes) and converts to Unix timestamp
 func readVuTimeRealFromBytes(data []byte, offset int) (int64, int, error) {
 	if offset+4 > len(data) {
-		return 0, offset, io.ErrUnexpectedEOF
+		return 0, offset, dd.ErrTruncated
 	}
 	value := binary.BigEndian.Uint32(data[offset:])
 	// TimeReal is seconds since 00:00:00 UTC, 1 January 1970
 	return int64(value), offset + 4, nil
 }
+
+// isEmptyCardNumber reports whether a FullCardNumber field is unused, which is
+// the case when no card was inserted in the slot.
+func isEmptyCardNumber(data []byte) bool {
+	return len(data) == 0 || data[0] == 0x00 || data[0] == 0xFF
+}
+
+// unmarshalOptionalFullCardNumber parses a FullCardNumber that may be unused.
+//
+// Unused card number fields are returned as nil.
+func unmarshalOptionalFullCardNumber(opts dd.UnmarshalOptions, data []byte) (*ddv1.FullCardNumber, error) {
+	if isEmptyCardNumber(data) {
+		return nil, nil
+	}
+	return opts.UnmarshalFullCardNumber(data)
+}
+
+// unmarshalOptionalFullCardNumberAndGeneration parses a FullCardNumberAndGeneration
+// that may be unused.
+//
+// Unused card number fields are returned as nil.
+func unmarshalOptionalFullCardNumberAndGeneration(opts dd.UnmarshalOptions, data []byte) (*ddv1.FullCardNumberAndGeneration, error) {
+	if isEmptyCardNumber(data) {
+		return nil, nil
+	}
+	return opts.UnmarshalFullCardNumberAndGeneration(data)
+}
+
+// unmarshalEventFaultType parses an EventFaultType, returning the raw value
+// alongside UNRECOGNIZED when the value has no enum mapping.
+func unmarshalEventFaultType(b byte) (ddv1.EventFaultType, int32) {
+	if eventType, err := dd.UnmarshalEnum[ddv1.EventFaultType](b); err == nil {
+		return eventType, 0
+	}
+	return ddv1.EventFaultType_EVENT_FAULT_TYPE_UNRECOGNIZED, int32(b)
+}
+
+// unmarshalEventFaultRecordPurpose parses an EventFaultRecordPurpose, returning
+// the raw value alongside UNRECOGNIZED when the value has no enum mapping.
+func unmarshalEventFaultRecordPurpose(b byte) (ddv1.EventFaultRecordPurpose, int32) {
+	if purpose, err := dd.UnmarshalEnum[ddv1.EventFaultRecordPurpose](b); err == nil {
+		return purpose, 0
+	}
+	return ddv1.EventFaultRecordPurpose_EVENT_FAULT_RECORD_PURPOSE_UNRECOGNIZED, int32(b)
+}
+
+// unmarshalCardSlotsStatus parses a CardSlotsStatus, with the slot card type
+// of the driver slot in the lower nibble and of the co-driver slot in the
+// upper nibble. Nibbles with no enum mapping are returned as UNRECOGNIZED.
+func unmarshalCardSlotsStatus(b byte) (driver, coDriver ddv1.SlotCardType) {
+	return unmarshalSlotCardType(b & 0x0F), unmarshalSlotCardType(b >> 4)
+}
+
+// unmarshalSlotCardType parses a SlotCardType nibble.
+func unmarshalSlotCardType(nibble byte) ddv1.SlotCardType {
+	if slotCardType, err := dd.UnmarshalEnum[ddv1.SlotCardType](nibble); err == nil {
+		return slotCardType
+	}
+	return ddv1.SlotCardType_SLOT_CARD_TYPE_UNRECOGNIZED
+}
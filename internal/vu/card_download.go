@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"fmt"
 
+	"github.com/way-platform/tachograph-go/internal/dd"
 	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
 )
 
@@ -25,7 +26,7 @@ func sizeOfCardDownload(data []byte, transferType vuv1.TransferType) (int, error
 	for offset < len(data) {
 		// Need at least 5 bytes for TLV header (3-byte tag + 2-byte length)
 		const tlvHeaderSize = 5
-		if len(data[offset:]) < tlvHeaderSize {
+		if len(data)-offset < tlvHeaderSize {
 			// If we have less than a full header, we've reached the end
 			break
 		}
@@ -38,7 +39,7 @@ func sizeOfCardDownload(data []byte, transferType vuv1.TransferType) (int, error
 
 		// Check if we have enough data for this record
 		if offset+recordSize > len(data) {
-			return 0, fmt.Errorf("incomplete TLV record at offset %d: need %d bytes, have %d", offset, recordSize, len(data)-offset)
+			return 0, fmt.Errorf("incomplete TLV record at offset %d: need %d bytes, have %d: %w", offset, recordSize, len(data)-offset, dd.ErrTruncated)
 		}
 
 		offset += recordSize
@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"fmt"
 
+	"github.com/way-platform/tachograph-go/internal/dd"
 	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
 	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
 )
@@ -37,8 +38,8 @@ func sizeOfDetailedSpeedGen1(data []byte) (int, error) {
 	offset := 0
 
 	// VuDetailedSpeedData: 2 bytes count + variable speed blocks
-	if len(data[offset:]) < 2 {
-		return 0, fmt.Errorf("insufficient data for noOfSpeedBlocks")
+	if len(data)-offset < 2 {
+		return 0, fmt.Errorf("insufficient data for noOfSpeedBlocks: %w", dd.ErrTruncated)
 	}
 	noOfSpeedBlocks := binary.BigEndian.Uint16(data[offset:])
 	offset += 2
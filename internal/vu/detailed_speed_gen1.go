@@ -1,40 +1,99 @@
 package vu
 
 import (
+	"encoding/binary"
 	"fmt"
 
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/way-platform/tachograph-go/internal/dd"
 	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
 )
 
 // unmarshalDetailedSpeedGen1 parses Gen1 Detailed Speed data from the complete transfer value.
 //
-// Gen1 Detailed Speed structure (from Data Dictionary and Appendix 7, Section 2.2.6.6):
+// Gen1 Detailed Speed structure (from Data Dictionary and Appendix 7, Section 2.2.6.5):
 //
 // ASN.1 Definition:
 //
 //	VuDetailedSpeedFirstGen ::= SEQUENCE {
-//	    vuDetailedSpeedBlocks      VuDetailedSpeedBlocksFirstGen,
-//	    signature                  SignatureFirstGen
+//	    vuDetailedSpeedData        VuDetailedSpeedData,    -- 2 + (N * 64) bytes
+//	    signature                  SignatureFirstGen       -- 128 bytes (RSA)
 //	}
 //
-// Note: This is a minimal implementation that stores raw_data for round-trip fidelity.
-func unmarshalDetailedSpeedGen1(value []byte) (*vuv1.DetailedSpeedGen1, error) {
+//	VuDetailedSpeedData ::= SEQUENCE {
+//	    noOfSpeedBlocks            INTEGER(0..2^16-1),
+//	    vuDetailedSpeedBlocks      SET SIZE(noOfSpeedBlocks) OF VuDetailedSpeedBlock
+//	}
+func unmarshalDetailedSpeedGen1(opts dd.UnmarshalOptions, value []byte) (*vuv1.DetailedSpeedGen1, error) {
+	const lenSignature = 128
 	detailedSpeed := &vuv1.DetailedSpeedGen1{}
 	detailedSpeed.SetRawData(value)
 
-	// TODO: Implement full semantic parsing
-	// For now, validate that we have enough data for the structure
-	if len(value) < 128 { // At minimum, signature is 128 bytes
-		return nil, fmt.Errorf("insufficient data for Detailed Speed Gen1")
+	if len(value) < 2+lenSignature {
+		return nil, fmt.Errorf("insufficient data for Detailed Speed Gen1: %w", dd.ErrTruncated)
+	}
+	noOfSpeedBlocks := int(binary.BigEndian.Uint16(value[0:2]))
+	opts.Layout.Field("no_of_speed_blocks", 0, 2)
+	offset := 2
+	if offset+noOfSpeedBlocks*lenVuDetailedSpeedBlock+lenSignature != len(value) {
+		return nil, dd.WrapField("speed_blocks", 0, fmt.Errorf(
+			"Detailed Speed Gen1 parsing mismatch: %d speed blocks need %d bytes, got %d",
+			noOfSpeedBlocks, offset+noOfSpeedBlocks*lenVuDetailedSpeedBlock+lenSignature, len(value),
+		))
 	}
 
-	// Store the signature (last 128 bytes)
-	signatureStart := len(value) - 128
-	detailedSpeed.SetSignature(value[signatureStart:])
+	speedBlocks := make([]*vuv1.DetailedSpeedGen1_DetailedSpeedBlock, 0, noOfSpeedBlocks)
+	for i := 0; i < noOfSpeedBlocks; i++ {
+		beginDate, speeds, err := unmarshalVuDetailedSpeedBlock(opts, value[offset:offset+lenVuDetailedSpeedBlock])
+		if err != nil {
+			return nil, dd.WrapField(fmt.Sprintf("speed_blocks[%d]", i), offset, fmt.Errorf("unmarshal speed block %d: %w", i, err))
+		}
+		blockLayout := opts.Layout.NestedElement("speed_blocks", i, offset)
+		blockLayout.Field("begin_date", 0, 4)
+		blockLayout.Field("speeds_kmh", 4, lenVuDetailedSpeedBlock-4)
+		block := &vuv1.DetailedSpeedGen1_DetailedSpeedBlock{}
+		block.SetBeginDate(beginDate)
+		block.SetSpeedsKmh(speeds)
+		speedBlocks = append(speedBlocks, block)
+		offset += lenVuDetailedSpeedBlock
+	}
+	detailedSpeed.SetSpeedBlocks(speedBlocks)
+	detailedSpeed.SetSignature(value[offset:])
+	opts.Layout.Field("signature", offset, lenSignature)
 
 	return detailedSpeed, nil
 }
 
+// lenVuDetailedSpeedBlock is the size of a VuDetailedSpeedBlock: 4 bytes
+// TimeReal + 60 bytes of speeds.
+const lenVuDetailedSpeedBlock = 64
+
+// unmarshalVuDetailedSpeedBlock parses a VuDetailedSpeedBlock.
+//
+// The data type `VuDetailedSpeedBlock` is specified in the Data Dictionary, Section 2.192.
+//
+// ASN.1 Definition:
+//
+//	VuDetailedSpeedBlock ::= SEQUENCE {
+//	    speedBlockBeginDate    TimeReal,
+//	    speedsPerSecond        SEQUENCE SIZE(60) OF Speed
+//	}
+func unmarshalVuDetailedSpeedBlock(opts dd.UnmarshalOptions, data []byte) (*timestamppb.Timestamp, []int32, error) {
+	if len(data) < lenVuDetailedSpeedBlock {
+		return nil, nil, fmt.Errorf("invalid data length for VuDetailedSpeedBlock: got %d, want %d: %w", len(data), lenVuDetailedSpeedBlock, dd.ErrInvalidValue)
+	}
+	beginDate, err := opts.UnmarshalTimeReal(data[0:4])
+	if err != nil {
+		return nil, nil, dd.WrapField("begin_date", 0, fmt.Errorf("unmarshal speed block begin date: %w", err))
+	}
+	speeds := make([]int32, 60)
+	for i := range speeds {
+		speeds[i] = int32(data[4+i])
+	}
+	return beginDate, speeds, nil
+}
+
 // appendDetailedSpeedGen1 marshals Gen1 Detailed Speed data using raw data painting.
 func appendDetailedSpeedGen1(dst []byte, detailedSpeed *vuv1.DetailedSpeedGen1) ([]byte, error) {
 	if detailedSpeed == nil {
@@ -3,42 +3,58 @@ package vu
 import (
 	"fmt"
 
+	"github.com/way-platform/tachograph-go/internal/dd"
 	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
 )
 
 // unmarshalDetailedSpeedGen2 parses Gen2 Detailed Speed data from the complete transfer value.
 //
-// Gen2 Detailed Speed structure uses RecordArray format.
+// Gen2 Detailed Speed structure uses RecordArray format (from Appendix 7, Section 2.2.6.5):
+//
+// ASN.1 Definition:
+//
+//	VuDetailedSpeedSecondGen ::= SEQUENCE {
+//	    vuDetailedSpeedBlockRecordArray   VuDetailedSpeedBlockRecordArray,
+//	    signatureRecordArray              SignatureRecordArray
+//	}
 //
-// Note: This is a minimal implementation that stores raw_data for round-trip fidelity.
 // Gen2 has no V2 variant - both V1 and V2 use the same structure.
-func unmarshalDetailedSpeedGen2(value []byte) (*vuv1.DetailedSpeedGen2, error) {
+func unmarshalDetailedSpeedGen2(opts dd.UnmarshalOptions, value []byte) (*vuv1.DetailedSpeedGen2, error) {
 	detailedSpeed := &vuv1.DetailedSpeedGen2{}
 	detailedSpeed.SetRawData(value)
 
-	// Validate structure by skipping through all record arrays
 	offset := 0
-	skipRecordArray := func(name string) error {
-		size, err := sizeOfRecordArray(value, offset)
+	for offset < len(value) {
+		ra, next, err := readRecordArray(value, offset)
 		if err != nil {
-			return fmt.Errorf("%s: %w", name, err)
+			return nil, fmt.Errorf("Detailed Speed Gen2: %w", err)
 		}
-		offset += size
-		return nil
-	}
-
-	// VuDetailedSpeedRecordArray
-	if err := skipRecordArray("VuDetailedSpeed"); err != nil {
-		return nil, err
-	}
-
-	// SignatureRecordArray
-	if err := skipRecordArray("Signature"); err != nil {
-		return nil, err
-	}
+		switch ra.recordType {
+		case recordTypeVuDetailedSpeedBlock:
+			recordArrayLayout(opts.Layout, "speed_blocks", value, offset)
+			if err := ra.checkRecordSize("VuDetailedSpeedBlock", lenVuDetailedSpeedBlock); err != nil {
+				return nil, ra.fieldError("speed_blocks", err)
+			}
+			speedBlocks := make([]*vuv1.DetailedSpeedGen2_DetailedSpeedBlock, 0, len(ra.records))
+			for i, data := range ra.records {
+				beginDate, speeds, err := unmarshalVuDetailedSpeedBlock(opts, data)
+				if err != nil {
+					return nil, ra.recordError("speed_blocks", i, err)
+				}
+				block := &vuv1.DetailedSpeedGen2_DetailedSpeedBlock{}
+				block.SetBeginDate(beginDate)
+				block.SetSpeedsKmh(speeds)
+				speedBlocks = append(speedBlocks, block)
+			}
+			detailedSpeed.SetSpeedBlocks(speedBlocks)
 
-	if offset != len(value) {
-		return nil, fmt.Errorf("Detailed Speed Gen2 parsing mismatch: parsed %d bytes, expected %d", offset, len(value))
+		case recordTypeSignature:
+			recordArrayLayout(opts.Layout, "signature", value, offset)
+			if len(ra.records) > 0 {
+				detailedSpeed.SetSignature(ra.records[0])
+			}
+		}
+		offset = next
 	}
 
 	return detailedSpeed, nil
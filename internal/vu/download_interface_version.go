@@ -1,8 +1,12 @@
 package vu
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 
+	"github.com/way-platform/tachograph-go/internal/dd"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
 	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
 )
 
@@ -19,7 +23,7 @@ import (
 func sizeOfDownloadInterfaceVersion(data []byte, transferType vuv1.TransferType) (int, error) {
 	const lenDownloadInterfaceVersion = 2
 	if len(data) < lenDownloadInterfaceVersion {
-		return 0, fmt.Errorf("insufficient data for DownloadInterfaceVersion: need %d, have %d", lenDownloadInterfaceVersion, len(data))
+		return 0, fmt.Errorf("insufficient data for DownloadInterfaceVersion: need %d, have %d: %w", lenDownloadInterfaceVersion, len(data), dd.ErrTruncated)
 	}
 	return lenDownloadInterfaceVersion, nil
 }
@@ -38,3 +42,48 @@ func sizeOfDownloadInterfaceVersion(data []byte, transferType vuv1.TransferType)
 // ASN.1 Definition:
 //
 //	DownloadInterfaceVersion ::= OCTET STRING (SIZE (2))
+//
+// The generation and version are decoded as a structure version, see
+// [dd.StructureVersion]. A newer version than the known versions fails with
+// [dd.ErrUnsupportedVersion], since the transfers that follow it can't be
+// parsed reliably, unless the version is overridden by the options.
+func (o VehicleUnitOptions) unmarshalDownloadInterfaceVersion(opts dd.UnmarshalOptions, value []byte) (*vuv1.DownloadInterfaceVersion, error) {
+	const lenDownloadInterfaceVersion = 2
+	if len(value) != lenDownloadInterfaceVersion {
+		return nil, fmt.Errorf("invalid data length for DownloadInterfaceVersion: got %d, want %d: %w", len(value), lenDownloadInterfaceVersion, dd.ErrInvalidValue)
+	}
+	generation, version, err := dd.StructureVersion(int32(value[0]), int32(value[1]))
+	switch {
+	case errors.Is(err, dd.ErrUnsupportedVersion) && o.Version != ddv1.Version_VERSION_UNSPECIFIED:
+		generation, version = ddv1.Generation_GENERATION_2, o.Version
+	case err != nil:
+		return nil, fmt.Errorf("download interface version: %w", err)
+	}
+	var output vuv1.DownloadInterfaceVersion
+	output.SetRawData(bytes.Clone(value))
+	output.SetGeneration(generation)
+	output.SetVersion(version)
+	return &output, nil
+}
+
+// ===== Marshal Functions =====
+
+// appendDownloadInterfaceVersion appends the download interface version.
+//
+// The raw data takes precedence, otherwise the generation and version are
+// coded as a structure version, see [dd.StructureVersion].
+func appendDownloadInterfaceVersion(dst []byte, downloadInterfaceVersion *vuv1.DownloadInterfaceVersion) ([]byte, error) {
+	const lenDownloadInterfaceVersion = 2
+	if raw := downloadInterfaceVersion.GetRawData(); len(raw) == lenDownloadInterfaceVersion {
+		return append(dst, raw...), nil
+	}
+	generation, err := dd.MarshalEnum(downloadInterfaceVersion.GetGeneration())
+	if err != nil {
+		return nil, fmt.Errorf("marshal download interface generation: %w", err)
+	}
+	version := downloadInterfaceVersion.GetVersion()
+	if generation == 0 || version == ddv1.Version_VERSION_UNSPECIFIED {
+		return nil, fmt.Errorf("download interface version %v %v: %w", downloadInterfaceVersion.GetGeneration(), version, dd.ErrInvalidValue)
+	}
+	return append(dst, generation-1, byte(version-1)), nil
+}
@@ -3,6 +3,7 @@ package vu
 import (
 	"fmt"
 
+	"github.com/way-platform/tachograph-go/internal/dd"
 	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
 )
 
@@ -77,8 +78,8 @@ func sizeOfEventsAndFaultsGen1(data []byte) (int, error) {
 	offset := 0
 
 	// VuFaultData: 1 byte count + variable fault records
-	if len(data[offset:]) < 1 {
-		return 0, fmt.Errorf("insufficient data for noOfVuFaults")
+	if len(data)-offset < 1 {
+		return 0, fmt.Errorf("insufficient data for noOfVuFaults: %w", dd.ErrTruncated)
 	}
 	noOfVuFaults := data[offset]
 	offset += 1
@@ -88,8 +89,8 @@ func sizeOfEventsAndFaultsGen1(data []byte) (int, error) {
 	offset += int(noOfVuFaults) * vuFaultRecordSize
 
 	// VuEventData: 1 byte count + variable event records
-	if len(data[offset:]) < 1 {
-		return 0, fmt.Errorf("insufficient data for noOfVuEvents")
+	if len(data)-offset < 1 {
+		return 0, fmt.Errorf("insufficient data for noOfVuEvents: %w", dd.ErrTruncated)
 	}
 	noOfVuEvents := data[offset]
 	offset += 1
@@ -102,8 +103,8 @@ func sizeOfEventsAndFaultsGen1(data []byte) (int, error) {
 	offset += 9
 
 	// VuOverSpeedingEventData: 1 byte count + variable overspeed records
-	if len(data[offset:]) < 1 {
-		return 0, fmt.Errorf("insufficient data for noOfVuOverSpeedingEvents")
+	if len(data)-offset < 1 {
+		return 0, fmt.Errorf("insufficient data for noOfVuOverSpeedingEvents: %w", dd.ErrTruncated)
 	}
 	noOfVuOverSpeedingEvents := data[offset]
 	offset += 1
@@ -113,8 +114,8 @@ func sizeOfEventsAndFaultsGen1(data []byte) (int, error) {
 	offset += int(noOfVuOverSpeedingEvents) * vuOverSpeedingEventRecordSize
 
 	// VuTimeAdjustmentData: 1 byte count + variable time adjustment records
-	if len(data[offset:]) < 1 {
-		return 0, fmt.Errorf("insufficient data for noOfVuTimeAdjRecords")
+	if len(data)-offset < 1 {
+		return 0, fmt.Errorf("insufficient data for noOfVuTimeAdjRecords: %w", dd.ErrTruncated)
 	}
 	noOfVuTimeAdjRecords := data[offset]
 	offset += 1
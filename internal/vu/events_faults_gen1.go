@@ -3,38 +3,282 @@ package vu
 import (
 	"fmt"
 
+	"github.com/way-platform/tachograph-go/internal/dd"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
 	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
 )
 
 // unmarshalEventsAndFaultsGen1 parses Gen1 Events and Faults data from the complete transfer value.
 //
-// Gen1 Events and Faults structure (from Data Dictionary and Appendix 7, Section 2.2.6.4 and 2.2.6.5):
+// Gen1 Events and Faults structure (from Data Dictionary and Appendix 7, Section 2.2.6.4):
 //
 // ASN.1 Definition:
 //
 //	VuEventsAndFaultsFirstGen ::= SEQUENCE {
-//	    vuFaultData          VuFaultDataFirstGen,
-//	    vuEventData          VuEventDataFirstGen,
-//	    vuOverSpeedingControlData    VuOverSpeedingControlData,
-//	    vuTimeAdjustmentData VuTimeAdjustmentDataFirstGen,
-//	    signature            SignatureFirstGen
+//	    vuFaultData                VuFaultDataFirstGen,                -- 1 + (N * 82) bytes
+//	    vuEventData                VuEventDataFirstGen,                -- 1 + (M * 83) bytes
+//	    vuOverSpeedingControlData  VuOverSpeedingControlData,          -- 9 bytes
+//	    vuOverSpeedingEventData    VuOverSpeedingEventDataFirstGen,    -- 1 + (P * 31) bytes
+//	    vuTimeAdjustmentData       VuTimeAdjustmentDataFirstGen,       -- 1 + (Q * 98) bytes
+//	    signature                  SignatureFirstGen                   -- 128 bytes (RSA)
 //	}
-//
-// Note: This is a minimal implementation that stores raw_data for round-trip fidelity.
-// Full semantic parsing is TODO.
-func unmarshalEventsAndFaultsGen1(value []byte) (*vuv1.EventsAndFaultsGen1, error) {
+func unmarshalEventsAndFaultsGen1(opts dd.UnmarshalOptions, value []byte) (*vuv1.EventsAndFaultsGen1, error) {
+	const (
+		lenFaultRecord          = 82 // 1 + 1 + 4 + 4 + 4*18
+		lenEventRecord          = 83 // 1 + 1 + 4 + 4 + 4*18 + 1
+		lenOverSpeedingControl  = 9  // 4 + 4 + 1
+		lenOverSpeedingEvent    = 31 // 1 + 1 + 4 + 4 + 1 + 1 + 18 + 1
+		lenTimeAdjustmentRecord = 98 // 4 + 4 + 36 + 36 + 18
+		lenSignature            = 128
+		lenFullCardNumber       = 18
+	)
 	eventsAndFaults := &vuv1.EventsAndFaultsGen1{}
 	eventsAndFaults.SetRawData(value)
 
-	// TODO: Implement full semantic parsing
-	// For now, validate that we have enough data for the structure
-	if len(value) < 128 { // At minimum, signature is 128 bytes
-		return nil, fmt.Errorf("insufficient data for Events and Faults Gen1")
+	offset := 0
+
+	// readCount reads a 1-byte record count and checks that the records of
+	// field fit.
+	readCount := func(field, name string, recordSize int) (int, error) {
+		if offset+1 > len(value) {
+			return 0, dd.WrapField(field, offset, fmt.Errorf("insufficient data for %s count: %w", name, dd.ErrTruncated))
+		}
+		n := int(value[offset])
+		opts.Layout.Field("no_of_"+field, offset, 1)
+		offset++
+		if offset+n*recordSize > len(value) {
+			return 0, dd.WrapField(field, offset-1, fmt.Errorf("insufficient data for %d %s records: %w", n, name, dd.ErrTruncated))
+		}
+		return n, nil
+	}
+
+	// readCardNumbers reads the consecutive optional FullCardNumbers of fields,
+	// starting at offset start of a record, and records them in the layout of
+	// the record.
+	readCardNumbers := func(layout *dd.Layout, data []byte, start int, fields ...string) ([]*ddv1.FullCardNumber, error) {
+		cardNumbers := make([]*ddv1.FullCardNumber, len(fields))
+		for i := range cardNumbers {
+			fieldOffset := start + i*lenFullCardNumber
+			cardNumber, err := unmarshalOptionalFullCardNumber(opts, data[fieldOffset:fieldOffset+lenFullCardNumber])
+			if err != nil {
+				return nil, dd.WrapField(fields[i], fieldOffset, fmt.Errorf("unmarshal card number %d: %w", i, err))
+			}
+			cardNumbers[i] = cardNumber
+			layout.Field(fields[i], fieldOffset, lenFullCardNumber)
+		}
+		return cardNumbers, nil
+	}
+
+	// VuFaultData
+	noOfFaults, err := readCount("faults", "VuFault", lenFaultRecord)
+	if err != nil {
+		return nil, err
+	}
+	faults := make([]*vuv1.EventsAndFaultsGen1_FaultRecord, 0, noOfFaults)
+	for i := 0; i < noOfFaults; i++ {
+		data := value[offset : offset+lenFaultRecord]
+		fault := &vuv1.EventsAndFaultsGen1_FaultRecord{}
+		faultLayout := opts.Layout.NestedElement("faults", i, offset)
+		faultLayout.Field("fault_type", 0, 1)
+		faultLayout.Field("record_purpose", 1, 1)
+		faultLayout.Field("begin_time", 2, 4)
+		faultLayout.Field("end_time", 6, 4)
+		faultType, unrecognizedFaultType := unmarshalEventFaultType(data[0])
+		fault.SetFaultType(faultType)
+		fault.SetUnrecognizedFaultType(unrecognizedFaultType)
+		purpose, unrecognizedPurpose := unmarshalEventFaultRecordPurpose(data[1])
+		fault.SetRecordPurpose(purpose)
+		fault.SetUnrecognizedRecordPurpose(unrecognizedPurpose)
+		beginTime, err := opts.UnmarshalTimeReal(data[2:6])
+		if err != nil {
+			return nil, dd.WrapField(fmt.Sprintf("faults[%d].begin_time", i), offset+2, fmt.Errorf("unmarshal fault %d begin time: %w", i, err))
+		}
+		fault.SetBeginTime(beginTime)
+		endTime, err := opts.UnmarshalTimeReal(data[6:10])
+		if err != nil {
+			return nil, dd.WrapField(fmt.Sprintf("faults[%d].end_time", i), offset+6, fmt.Errorf("unmarshal fault %d end time: %w", i, err))
+		}
+		fault.SetEndTime(endTime)
+		cardNumbers, err := readCardNumbers(faultLayout, data, 10, "card_number_driver_slot_begin", "card_number_codriver_slot_begin", "card_number_driver_slot_end", "card_number_codriver_slot_end")
+		if err != nil {
+			return nil, dd.WrapField(fmt.Sprintf("faults[%d]", i), offset, fmt.Errorf("unmarshal fault %d: %w", i, err))
+		}
+		fault.SetCardNumberDriverSlotBegin(cardNumbers[0])
+		fault.SetCardNumberCodriverSlotBegin(cardNumbers[1])
+		fault.SetCardNumberDriverSlotEnd(cardNumbers[2])
+		fault.SetCardNumberCodriverSlotEnd(cardNumbers[3])
+		faults = append(faults, fault)
+		offset += lenFaultRecord
+	}
+	eventsAndFaults.SetFaults(faults)
+
+	// VuEventData
+	noOfEvents, err := readCount("events", "VuEvent", lenEventRecord)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*vuv1.EventsAndFaultsGen1_EventRecord, 0, noOfEvents)
+	for i := 0; i < noOfEvents; i++ {
+		data := value[offset : offset+lenEventRecord]
+		event := &vuv1.EventsAndFaultsGen1_EventRecord{}
+		eventLayout := opts.Layout.NestedElement("events", i, offset)
+		eventLayout.Field("event_type", 0, 1)
+		eventLayout.Field("record_purpose", 1, 1)
+		eventLayout.Field("begin_time", 2, 4)
+		eventLayout.Field("end_time", 6, 4)
+		eventLayout.Field("similar_events_number", 82, 1)
+		eventType, unrecognizedEventType := unmarshalEventFaultType(data[0])
+		event.SetEventType(eventType)
+		event.SetUnrecognizedEventType(unrecognizedEventType)
+		purpose, unrecognizedPurpose := unmarshalEventFaultRecordPurpose(data[1])
+		event.SetRecordPurpose(purpose)
+		event.SetUnrecognizedRecordPurpose(unrecognizedPurpose)
+		beginTime, err := opts.UnmarshalTimeReal(data[2:6])
+		if err != nil {
+			return nil, dd.WrapField(fmt.Sprintf("events[%d].begin_time", i), offset+2, fmt.Errorf("unmarshal event %d begin time: %w", i, err))
+		}
+		event.SetBeginTime(beginTime)
+		endTime, err := opts.UnmarshalTimeReal(data[6:10])
+		if err != nil {
+			return nil, dd.WrapField(fmt.Sprintf("events[%d].end_time", i), offset+6, fmt.Errorf("unmarshal event %d end time: %w", i, err))
+		}
+		event.SetEndTime(endTime)
+		cardNumbers, err := readCardNumbers(eventLayout, data, 10, "card_number_driver_slot_begin", "card_number_codriver_slot_begin", "card_number_driver_slot_end", "card_number_codriver_slot_end")
+		if err != nil {
+			return nil, dd.WrapField(fmt.Sprintf("events[%d]", i), offset, fmt.Errorf("unmarshal event %d: %w", i, err))
+		}
+		event.SetCardNumberDriverSlotBegin(cardNumbers[0])
+		event.SetCardNumberCodriverSlotBegin(cardNumbers[1])
+		event.SetCardNumberDriverSlotEnd(cardNumbers[2])
+		event.SetCardNumberCodriverSlotEnd(cardNumbers[3])
+		event.SetSimilarEventsNumber(int32(data[82]))
+		events = append(events, event)
+		offset += lenEventRecord
+	}
+	eventsAndFaults.SetEvents(events)
+
+	// VuOverSpeedingControlData
+	if offset+lenOverSpeedingControl > len(value) {
+		return nil, dd.WrapField("overspeeding_control", offset, fmt.Errorf("insufficient data for VuOverSpeedingControlData: %w", dd.ErrTruncated))
+	}
+	{
+		data := value[offset : offset+lenOverSpeedingControl]
+		control := &vuv1.EventsAndFaultsGen1_OverSpeedingControlData{}
+		lastControlTime, err := opts.UnmarshalTimeReal(data[0:4])
+		if err != nil {
+			return nil, dd.WrapField("overspeeding_control.last_control_time", offset, fmt.Errorf("unmarshal last overspeed control time: %w", err))
+		}
+		control.SetLastControlTime(lastControlTime)
+		firstOverspeed, err := opts.UnmarshalTimeReal(data[4:8])
+		if err != nil {
+			return nil, dd.WrapField("overspeeding_control.first_overspeed_since_last_control", offset+4, fmt.Errorf("unmarshal first overspeed since last control: %w", err))
+		}
+		control.SetFirstOverspeedSinceLastControl(firstOverspeed)
+		control.SetNumberOfOverspeedSinceLastControl(int32(data[8]))
+		eventsAndFaults.SetOverspeedingControl(control)
+		controlLayout := opts.Layout.Nested("overspeeding_control", offset)
+		controlLayout.Field("last_control_time", 0, 4)
+		controlLayout.Field("first_overspeed_since_last_control", 4, 4)
+		controlLayout.Field("number_of_overspeed_since_last_control", 8, 1)
+		offset += lenOverSpeedingControl
+	}
+
+	// VuOverSpeedingEventData
+	noOfOverSpeedingEvents, err := readCount("overspeeding_events", "VuOverSpeedingEvent", lenOverSpeedingEvent)
+	if err != nil {
+		return nil, err
 	}
+	overSpeedingEvents := make([]*vuv1.EventsAndFaultsGen1_OverSpeedingEventRecord, 0, noOfOverSpeedingEvents)
+	for i := 0; i < noOfOverSpeedingEvents; i++ {
+		data := value[offset : offset+lenOverSpeedingEvent]
+		event := &vuv1.EventsAndFaultsGen1_OverSpeedingEventRecord{}
+		eventLayout := opts.Layout.NestedElement("overspeeding_events", i, offset)
+		eventLayout.Field("event_type", 0, 1)
+		eventLayout.Field("record_purpose", 1, 1)
+		eventLayout.Field("begin_time", 2, 4)
+		eventLayout.Field("end_time", 6, 4)
+		eventLayout.Field("max_speed_kmh", 10, 1)
+		eventLayout.Field("average_speed_kmh", 11, 1)
+		eventLayout.Field("similar_events_number", 30, 1)
+		eventType, unrecognizedEventType := unmarshalEventFaultType(data[0])
+		event.SetEventType(eventType)
+		event.SetUnrecognizedEventType(unrecognizedEventType)
+		purpose, unrecognizedPurpose := unmarshalEventFaultRecordPurpose(data[1])
+		event.SetRecordPurpose(purpose)
+		event.SetUnrecognizedRecordPurpose(unrecognizedPurpose)
+		beginTime, err := opts.UnmarshalTimeReal(data[2:6])
+		if err != nil {
+			return nil, dd.WrapField(fmt.Sprintf("overspeeding_events[%d].begin_time", i), offset+2, fmt.Errorf("unmarshal overspeeding event %d begin time: %w", i, err))
+		}
+		event.SetBeginTime(beginTime)
+		endTime, err := opts.UnmarshalTimeReal(data[6:10])
+		if err != nil {
+			return nil, dd.WrapField(fmt.Sprintf("overspeeding_events[%d].end_time", i), offset+6, fmt.Errorf("unmarshal overspeeding event %d end time: %w", i, err))
+		}
+		event.SetEndTime(endTime)
+		event.SetMaxSpeedKmh(int32(data[10]))
+		event.SetAverageSpeedKmh(int32(data[11]))
+		cardNumbers, err := readCardNumbers(eventLayout, data, 12, "card_number_driver_slot_begin")
+		if err != nil {
+			return nil, dd.WrapField(fmt.Sprintf("overspeeding_events[%d]", i), offset, fmt.Errorf("unmarshal overspeeding event %d: %w", i, err))
+		}
+		event.SetCardNumberDriverSlotBegin(cardNumbers[0])
+		event.SetSimilarEventsNumber(int32(data[30]))
+		overSpeedingEvents = append(overSpeedingEvents, event)
+		offset += lenOverSpeedingEvent
+	}
+	eventsAndFaults.SetOverspeedingEvents(overSpeedingEvents)
 
-	// Store the signature (last 128 bytes)
-	signatureStart := len(value) - 128
-	eventsAndFaults.SetSignature(value[signatureStart:])
+	// VuTimeAdjustmentData
+	noOfTimeAdjustments, err := readCount("time_adjustments", "VuTimeAdjustment", lenTimeAdjustmentRecord)
+	if err != nil {
+		return nil, err
+	}
+	timeAdjustments := make([]*vuv1.EventsAndFaultsGen1_TimeAdjustmentRecord, 0, noOfTimeAdjustments)
+	for i := 0; i < noOfTimeAdjustments; i++ {
+		data := value[offset : offset+lenTimeAdjustmentRecord]
+		record := &vuv1.EventsAndFaultsGen1_TimeAdjustmentRecord{}
+		recordLayout := opts.Layout.NestedElement("time_adjustments", i, offset)
+		recordLayout.Field("old_time", 0, 4)
+		recordLayout.Field("new_time", 4, 4)
+		recordLayout.Field("workshop_name", 8, 36)
+		recordLayout.Field("workshop_address", 44, 36)
+		oldTime, err := opts.UnmarshalTimeReal(data[0:4])
+		if err != nil {
+			return nil, dd.WrapField(fmt.Sprintf("time_adjustments[%d].old_time", i), offset, fmt.Errorf("unmarshal time adjustment %d old time: %w", i, err))
+		}
+		record.SetOldTime(oldTime)
+		newTime, err := opts.UnmarshalTimeReal(data[4:8])
+		if err != nil {
+			return nil, dd.WrapField(fmt.Sprintf("time_adjustments[%d].new_time", i), offset+4, fmt.Errorf("unmarshal time adjustment %d new time: %w", i, err))
+		}
+		record.SetNewTime(newTime)
+		workshopName, err := opts.UnmarshalStringValue(data[8:44])
+		if err != nil {
+			return nil, dd.WrapField(fmt.Sprintf("time_adjustments[%d].workshop_name", i), offset+8, fmt.Errorf("unmarshal time adjustment %d workshop name: %w", i, err))
+		}
+		record.SetWorkshopName(workshopName)
+		workshopAddress, err := opts.UnmarshalStringValue(data[44:80])
+		if err != nil {
+			return nil, dd.WrapField(fmt.Sprintf("time_adjustments[%d].workshop_address", i), offset+44, fmt.Errorf("unmarshal time adjustment %d workshop address: %w", i, err))
+		}
+		record.SetWorkshopAddress(workshopAddress)
+		cardNumbers, err := readCardNumbers(recordLayout, data, 80, "workshop_card_number")
+		if err != nil {
+			return nil, dd.WrapField(fmt.Sprintf("time_adjustments[%d]", i), offset, fmt.Errorf("unmarshal time adjustment %d: %w", i, err))
+		}
+		record.SetWorkshopCardNumber(cardNumbers[0])
+		timeAdjustments = append(timeAdjustments, record)
+		offset += lenTimeAdjustmentRecord
+	}
+	eventsAndFaults.SetTimeAdjustments(timeAdjustments)
+
+	// Signature (128 bytes)
+	if offset+lenSignature != len(value) {
+		return nil, dd.WrapField("signature", offset, fmt.Errorf("Events and Faults Gen1 parsing mismatch: parsed %d bytes, expected %d", offset+lenSignature, len(value)))
+	}
+	eventsAndFaults.SetSignature(value[offset:])
+	opts.Layout.Field("signature", offset, lenSignature)
 
 	return eventsAndFaults, nil
 }
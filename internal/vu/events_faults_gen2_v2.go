@@ -3,53 +3,244 @@ package vu
 import (
 	"fmt"
 
+	"github.com/way-platform/tachograph-go/internal/dd"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
 	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
 )
 
 // unmarshalEventsAndFaultsGen2V2 parses Gen2 V2 Events and Faults data from the complete transfer value.
 //
-// Gen2 V2 Events and Faults structure is identical to Gen2 V1.
+// Gen2 V2 Events and Faults structure uses RecordArray format (from Appendix 7, Section 2.2.6.4):
 //
-// Note: This is a minimal implementation that stores raw_data for round-trip fidelity.
-func unmarshalEventsAndFaultsGen2V2(value []byte) (*vuv1.EventsAndFaultsGen2V2, error) {
+// ASN.1 Definition:
+//
+//	VuEventsAndFaultsSecondGenV2 ::= SEQUENCE {
+//	    vuFaultRecordArray                    VuFaultRecordArray,
+//	    vuEventRecordArray                    VuEventRecordArray,
+//	    vuOverSpeedingControlDataRecordArray  VuOverSpeedingControlDataRecordArray,
+//	    vuOverSpeedingEventRecordArray        VuOverSpeedingEventRecordArray,
+//	    vuTimeAdjustmentRecordArray           VuTimeAdjustmentRecordArray,
+//	    signatureRecordArray                  SignatureRecordArray
+//	}
+//
+// The record arrays are dispatched on their record type. Unknown record arrays
+// are skipped and preserved in raw_data.
+func unmarshalEventsAndFaultsGen2V2(opts dd.UnmarshalOptions, value []byte) (*vuv1.EventsAndFaultsGen2V2, error) {
+	const (
+		lenFaultRecord          = 86 // 1 + 1 + 4 + 4 + 4*19, followed by manufacturer specific data
+		lenEventRecord          = 87 // 1 + 1 + 4 + 4 + 4*19 + 1, followed by manufacturer specific data
+		lenOverSpeedingControl  = 9  // 4 + 4 + 1
+		lenOverSpeedingEvent    = 32 // 1 + 1 + 4 + 4 + 1 + 1 + 19 + 1
+		lenTimeAdjustmentRecord = 99 // 4 + 4 + 36 + 36 + 19
+		lenCardNumber           = 19
+	)
 	eventsAndFaults := &vuv1.EventsAndFaultsGen2V2{}
 	eventsAndFaults.SetRawData(value)
 
-	// Validate structure by skipping through all record arrays
+	// readCardNumbers reads the consecutive optional
+	// FullCardNumberAndGenerations of fields, starting at offset start of a
+	// record.
+	readCardNumbers := func(data []byte, start int, fields ...string) ([]*ddv1.FullCardNumberAndGeneration, error) {
+		cardNumbers := make([]*ddv1.FullCardNumberAndGeneration, len(fields))
+		for i := range cardNumbers {
+			fieldOffset := start + i*lenCardNumber
+			cardNumber, err := unmarshalOptionalFullCardNumberAndGeneration(opts, data[fieldOffset:fieldOffset+lenCardNumber])
+			if err != nil {
+				return nil, dd.WrapField(fields[i], fieldOffset, fmt.Errorf("unmarshal card number %d: %w", i, err))
+			}
+			cardNumbers[i] = cardNumber
+		}
+		return cardNumbers, nil
+	}
+
 	offset := 0
-	skipRecordArray := func(name string) error {
-		size, err := sizeOfRecordArray(value, offset)
+	for offset < len(value) {
+		ra, next, err := readRecordArray(value, offset)
 		if err != nil {
-			return fmt.Errorf("%s: %w", name, err)
+			return nil, fmt.Errorf("Events and Faults Gen2 V2: %w", err)
 		}
-		offset += size
-		return nil
-	}
+		switch ra.recordType {
+		case recordTypeVuFaultRecord:
+			recordArrayLayout(opts.Layout, "faults", value, offset)
+			if err := ra.checkRecordSize("VuFaultRecord", lenFaultRecord); err != nil {
+				return nil, ra.fieldError("faults", err)
+			}
+			faults := make([]*vuv1.EventsAndFaultsGen2V2_FaultRecord, 0, len(ra.records))
+			for i, data := range ra.records {
+				fault := &vuv1.EventsAndFaultsGen2V2_FaultRecord{}
+				faultType, unrecognizedFaultType := unmarshalEventFaultType(data[0])
+				fault.SetFaultType(faultType)
+				fault.SetUnrecognizedFaultType(unrecognizedFaultType)
+				purpose, unrecognizedPurpose := unmarshalEventFaultRecordPurpose(data[1])
+				fault.SetRecordPurpose(purpose)
+				fault.SetUnrecognizedRecordPurpose(unrecognizedPurpose)
+				beginTime, err := opts.UnmarshalTimeReal(data[2:6])
+				if err != nil {
+					return nil, ra.recordError("faults", i, dd.WrapField("begin_time", 2, fmt.Errorf("unmarshal fault %d begin time: %w", i, err)))
+				}
+				fault.SetBeginTime(beginTime)
+				endTime, err := opts.UnmarshalTimeReal(data[6:10])
+				if err != nil {
+					return nil, ra.recordError("faults", i, dd.WrapField("end_time", 6, fmt.Errorf("unmarshal fault %d end time: %w", i, err)))
+				}
+				fault.SetEndTime(endTime)
+				cardNumbers, err := readCardNumbers(data, 10, "card_number_and_gen_driver_slot_begin", "card_number_and_gen_codriver_slot_begin", "card_number_and_gen_driver_slot_end", "card_number_and_gen_codriver_slot_end")
+				if err != nil {
+					return nil, ra.recordError("faults", i, fmt.Errorf("unmarshal fault %d: %w", i, err))
+				}
+				fault.SetCardNumberAndGenDriverSlotBegin(cardNumbers[0])
+				fault.SetCardNumberAndGenCodriverSlotBegin(cardNumbers[1])
+				fault.SetCardNumberAndGenDriverSlotEnd(cardNumbers[2])
+				fault.SetCardNumberAndGenCodriverSlotEnd(cardNumbers[3])
+				if len(data) > lenFaultRecord {
+					fault.SetManufacturerSpecificData(data[lenFaultRecord:])
+				}
+				faults = append(faults, fault)
+			}
+			eventsAndFaults.SetFaults(faults)
 
-	// Skip all record arrays
-	// VuFaultRecordArray
-	if err := skipRecordArray("VuFault"); err != nil {
-		return nil, err
-	}
-	// VuEventRecordArray
-	if err := skipRecordArray("VuEvent"); err != nil {
-		return nil, err
-	}
-	// VuOverSpeedingControlRecordArray
-	if err := skipRecordArray("VuOverSpeedingControl"); err != nil {
-		return nil, err
-	}
-	// VuTimeAdjustmentRecordArray
-	if err := skipRecordArray("VuTimeAdjustment"); err != nil {
-		return nil, err
-	}
-	// SignatureRecordArray
-	if err := skipRecordArray("Signature"); err != nil {
-		return nil, err
-	}
+		case recordTypeVuEventRecord:
+			recordArrayLayout(opts.Layout, "events", value, offset)
+			if err := ra.checkRecordSize("VuEventRecord", lenEventRecord); err != nil {
+				return nil, ra.fieldError("events", err)
+			}
+			events := make([]*vuv1.EventsAndFaultsGen2V2_EventRecord, 0, len(ra.records))
+			for i, data := range ra.records {
+				event := &vuv1.EventsAndFaultsGen2V2_EventRecord{}
+				eventType, unrecognizedEventType := unmarshalEventFaultType(data[0])
+				event.SetEventType(eventType)
+				event.SetUnrecognizedEventType(unrecognizedEventType)
+				purpose, unrecognizedPurpose := unmarshalEventFaultRecordPurpose(data[1])
+				event.SetRecordPurpose(purpose)
+				event.SetUnrecognizedRecordPurpose(unrecognizedPurpose)
+				beginTime, err := opts.UnmarshalTimeReal(data[2:6])
+				if err != nil {
+					return nil, ra.recordError("events", i, dd.WrapField("begin_time", 2, fmt.Errorf("unmarshal event %d begin time: %w", i, err)))
+				}
+				event.SetBeginTime(beginTime)
+				endTime, err := opts.UnmarshalTimeReal(data[6:10])
+				if err != nil {
+					return nil, ra.recordError("events", i, dd.WrapField("end_time", 6, fmt.Errorf("unmarshal event %d end time: %w", i, err)))
+				}
+				event.SetEndTime(endTime)
+				cardNumbers, err := readCardNumbers(data, 10, "card_number_and_gen_driver_slot_begin", "card_number_and_gen_codriver_slot_begin", "card_number_and_gen_driver_slot_end", "card_number_and_gen_codriver_slot_end")
+				if err != nil {
+					return nil, ra.recordError("events", i, fmt.Errorf("unmarshal event %d: %w", i, err))
+				}
+				event.SetCardNumberAndGenDriverSlotBegin(cardNumbers[0])
+				event.SetCardNumberAndGenCodriverSlotBegin(cardNumbers[1])
+				event.SetCardNumberAndGenDriverSlotEnd(cardNumbers[2])
+				event.SetCardNumberAndGenCodriverSlotEnd(cardNumbers[3])
+				event.SetSimilarEventsNumber(int32(data[86]))
+				if len(data) > lenEventRecord {
+					event.SetManufacturerSpecificData(data[lenEventRecord:])
+				}
+				events = append(events, event)
+			}
+			eventsAndFaults.SetEvents(events)
+
+		case recordTypeVuOverSpeedingControlData:
+			recordArrayLayout(opts.Layout, "overspeeding_control", value, offset)
+			if err := ra.checkRecordSize("VuOverSpeedingControlData", lenOverSpeedingControl); err != nil {
+				return nil, ra.fieldError("overspeeding_control", err)
+			}
+			if len(ra.records) > 0 {
+				data := ra.records[0]
+				control := &vuv1.EventsAndFaultsGen2V2_OverSpeedingControlData{}
+				lastControlTime, err := opts.UnmarshalTimeReal(data[0:4])
+				if err != nil {
+					return nil, ra.fieldError("overspeeding_control", dd.WrapField("last_control_time", 0, fmt.Errorf("unmarshal last overspeed control time: %w", err)))
+				}
+				control.SetLastControlTime(lastControlTime)
+				firstOverspeed, err := opts.UnmarshalTimeReal(data[4:8])
+				if err != nil {
+					return nil, ra.fieldError("overspeeding_control", dd.WrapField("first_overspeed_since_last_control", 4, fmt.Errorf("unmarshal first overspeed since last control: %w", err)))
+				}
+				control.SetFirstOverspeedSinceLastControl(firstOverspeed)
+				control.SetNumberOfOverspeedSinceLastControl(int32(data[8]))
+				eventsAndFaults.SetOverspeedingControl(control)
+			}
 
-	if offset != len(value) {
-		return nil, fmt.Errorf("Events and Faults Gen2 V2 parsing mismatch: parsed %d bytes, expected %d", offset, len(value))
+		case recordTypeVuOverSpeedingEventRecord:
+			recordArrayLayout(opts.Layout, "overspeeding_events", value, offset)
+			if err := ra.checkRecordSize("VuOverSpeedingEventRecord", lenOverSpeedingEvent); err != nil {
+				return nil, ra.fieldError("overspeeding_events", err)
+			}
+			events := make([]*vuv1.EventsAndFaultsGen2V2_OverSpeedingEventRecord, 0, len(ra.records))
+			for i, data := range ra.records {
+				event := &vuv1.EventsAndFaultsGen2V2_OverSpeedingEventRecord{}
+				eventType, unrecognizedEventType := unmarshalEventFaultType(data[0])
+				event.SetEventType(eventType)
+				event.SetUnrecognizedEventType(unrecognizedEventType)
+				purpose, unrecognizedPurpose := unmarshalEventFaultRecordPurpose(data[1])
+				event.SetRecordPurpose(purpose)
+				event.SetUnrecognizedRecordPurpose(unrecognizedPurpose)
+				beginTime, err := opts.UnmarshalTimeReal(data[2:6])
+				if err != nil {
+					return nil, ra.recordError("overspeeding_events", i, dd.WrapField("begin_time", 2, fmt.Errorf("unmarshal overspeeding event %d begin time: %w", i, err)))
+				}
+				event.SetBeginTime(beginTime)
+				endTime, err := opts.UnmarshalTimeReal(data[6:10])
+				if err != nil {
+					return nil, ra.recordError("overspeeding_events", i, dd.WrapField("end_time", 6, fmt.Errorf("unmarshal overspeeding event %d end time: %w", i, err)))
+				}
+				event.SetEndTime(endTime)
+				event.SetMaxSpeedKmh(int32(data[10]))
+				event.SetAverageSpeedKmh(int32(data[11]))
+				cardNumbers, err := readCardNumbers(data, 12, "card_number_and_gen_driver_slot_begin")
+				if err != nil {
+					return nil, ra.recordError("overspeeding_events", i, fmt.Errorf("unmarshal overspeeding event %d: %w", i, err))
+				}
+				event.SetCardNumberAndGenDriverSlotBegin(cardNumbers[0])
+				event.SetSimilarEventsNumber(int32(data[31]))
+				events = append(events, event)
+			}
+			eventsAndFaults.SetOverspeedingEvents(events)
+
+		case recordTypeVuTimeAdjustmentRecord:
+			recordArrayLayout(opts.Layout, "time_adjustments", value, offset)
+			if err := ra.checkRecordSize("VuTimeAdjustmentRecord", lenTimeAdjustmentRecord); err != nil {
+				return nil, ra.fieldError("time_adjustments", err)
+			}
+			timeAdjustments := make([]*vuv1.EventsAndFaultsGen2V2_TimeAdjustmentRecord, 0, len(ra.records))
+			for i, data := range ra.records {
+				record := &vuv1.EventsAndFaultsGen2V2_TimeAdjustmentRecord{}
+				oldTime, err := opts.UnmarshalTimeReal(data[0:4])
+				if err != nil {
+					return nil, ra.recordError("time_adjustments", i, dd.WrapField("old_time", 0, fmt.Errorf("unmarshal time adjustment %d old time: %w", i, err)))
+				}
+				record.SetOldTime(oldTime)
+				newTime, err := opts.UnmarshalTimeReal(data[4:8])
+				if err != nil {
+					return nil, ra.recordError("time_adjustments", i, dd.WrapField("new_time", 4, fmt.Errorf("unmarshal time adjustment %d new time: %w", i, err)))
+				}
+				record.SetNewTime(newTime)
+				workshopName, err := opts.UnmarshalStringValue(data[8:44])
+				if err != nil {
+					return nil, ra.recordError("time_adjustments", i, dd.WrapField("workshop_name", 8, fmt.Errorf("unmarshal time adjustment %d workshop name: %w", i, err)))
+				}
+				record.SetWorkshopName(workshopName)
+				workshopAddress, err := opts.UnmarshalStringValue(data[44:80])
+				if err != nil {
+					return nil, ra.recordError("time_adjustments", i, dd.WrapField("workshop_address", 44, fmt.Errorf("unmarshal time adjustment %d workshop address: %w", i, err)))
+				}
+				record.SetWorkshopAddress(workshopAddress)
+				cardNumbers, err := readCardNumbers(data, 80, "workshop_card_number_and_generation")
+				if err != nil {
+					return nil, ra.recordError("time_adjustments", i, fmt.Errorf("unmarshal time adjustment %d: %w", i, err))
+				}
+				record.SetWorkshopCardNumberAndGeneration(cardNumbers[0])
+				timeAdjustments = append(timeAdjustments, record)
+			}
+			eventsAndFaults.SetTimeAdjustments(timeAdjustments)
+
+		case recordTypeSignature:
+			recordArrayLayout(opts.Layout, "signature", value, offset)
+			if len(ra.records) > 0 {
+				eventsAndFaults.SetSignature(ra.records[0])
+			}
+		}
+		offset = next
 	}
 
 	return eventsAndFaults, nil
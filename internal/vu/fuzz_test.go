@@ -0,0 +1,97 @@
+package vu
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
+)
+
+// addVehicleUnitFuzzSeeds adds a zero-filled transfer of each transfer type,
+// and Gen1 and Gen2 detailed speed transfers, on their own and together, as
+// fuzz seeds.
+func addVehicleUnitFuzzSeeds(f *testing.F) {
+	values := vuv1.TransferType_TRANSFER_TYPE_UNSPECIFIED.Descriptor().Values()
+	for i := range values.Len() {
+		opts := values.Get(i).Options()
+		if !proto.HasExtension(opts, vuv1.E_TrepValue) {
+			continue
+		}
+		transferType := vuv1.TransferType(values.Get(i).Number())
+		size, err := sizeOfTransferValue(make([]byte, 4096), transferType)
+		if err != nil {
+			f.Fatalf("size of zero-filled %v: %v", transferType, err)
+		}
+		seed := []byte{0x76, byte(proto.GetExtension(opts, vuv1.E_TrepValue).(int32))}
+		f.Add(append(seed, make([]byte, size)...))
+	}
+	block := binary.BigEndian.AppendUint32(nil, uint32(1709280000))
+	for i := range 60 {
+		block = append(block, byte(i))
+	}
+	var gen1 []byte
+	gen1 = append(gen1, 0x76, 0x04)
+	gen1 = binary.BigEndian.AppendUint16(gen1, 1)
+	gen1 = append(gen1, block...)
+	gen1 = append(gen1, make([]byte, 128)...)
+	var gen2 []byte
+	gen2 = append(gen2, 0x76, 0x24)
+	gen2 = appendTestRecordArray(gen2, recordTypeVuDetailedSpeedBlock, lenVuDetailedSpeedBlock, block, block)
+	gen2 = appendTestRecordArray(gen2, recordTypeSignature, 64, make([]byte, 64))
+	f.Add(gen1)
+	f.Add(gen2)
+	f.Add(append(append([]byte(nil), gen1...), gen1...))
+	f.Add(append(append([]byte(nil), gen2...), gen2...))
+}
+
+// FuzzScanRawVehicleUnitFile checks that splitting vehicle unit data into
+// transfers never panics, and that the transfers concatenate back to the
+// input bytes.
+func FuzzScanRawVehicleUnitFile(f *testing.F) {
+	addVehicleUnitFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		rawFile, err := ScanRawVehicleUnitFile(data)
+		var reconstructed []byte
+		for _, record := range rawFile.GetRecords() {
+			reconstructed = binary.BigEndian.AppendUint16(reconstructed, uint16(record.GetTag()))
+			reconstructed = append(reconstructed, record.GetValue()...)
+		}
+		if err != nil {
+			if !bytes.HasPrefix(data, reconstructed) {
+				t.Errorf("sliced transfers are not a prefix of the input:\n got %x\nwant %x", reconstructed, data)
+			}
+			return
+		}
+		if !bytes.Equal(data, reconstructed) {
+			t.Errorf("round-trip mismatch:\n got %x\nwant %x", reconstructed, data)
+		}
+	})
+}
+
+// FuzzUnmarshalVehicleUnitFile checks that parsing vehicle unit data,
+// strictly or leniently, never panics, and that lenient parsing without
+// skipped transfers agrees with strict parsing.
+func FuzzUnmarshalVehicleUnitFile(f *testing.F) {
+	addVehicleUnitFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		scanned, errs, scanErr := ScanVehicleUnitFile(data)
+		file, err := UnmarshalVehicleUnitFile(data)
+		if scanErr != nil || len(errs) > 0 {
+			if err == nil {
+				t.Fatalf("strict parse succeeded, but lenient parse failed: %v %v", scanErr, errs)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("lenient parse succeeded, but strict parse failed: %v", err)
+		}
+		if !proto.Equal(file, scanned) {
+			t.Errorf("strict and lenient parse mismatch (-strict +lenient):\n%s", cmp.Diff(file, scanned, protocmp.Transform()))
+		}
+	})
+}
@@ -3,6 +3,7 @@ package vu
 import (
 	"fmt"
 
+	"github.com/way-platform/tachograph-go/internal/dd"
 	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
 )
 
@@ -58,8 +59,8 @@ func sizeOfOverviewGen1(data []byte) (int, error) {
 	offset += 58  // VuDownloadActivityData (4 + 18 + 36)
 
 	// VuCompanyLocksData: 1 byte count + variable records
-	if len(data[offset:]) < 1 {
-		return 0, fmt.Errorf("insufficient data for noOfLocks")
+	if len(data)-offset < 1 {
+		return 0, fmt.Errorf("insufficient data for noOfLocks: %w", dd.ErrTruncated)
 	}
 	noOfLocks := data[offset]
 	offset += 1
@@ -70,8 +71,8 @@ func sizeOfOverviewGen1(data []byte) (int, error) {
 	offset += int(noOfLocks) * vuCompanyLocksRecordSize
 
 	// VuControlActivityData: 1 byte count + variable records
-	if len(data[offset:]) < 1 {
-		return 0, fmt.Errorf("insufficient data for noOfControls")
+	if len(data)-offset < 1 {
+		return 0, fmt.Errorf("insufficient data for noOfControls: %w", dd.ErrTruncated)
 	}
 	noOfControls := data[offset]
 	offset += 1
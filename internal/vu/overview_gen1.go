@@ -58,94 +58,91 @@ import (
 //   - DownloadPeriodEndTime: 4 bytes
 //
 // - Signature: 128 bytes (RSA)
-func unmarshalOverviewGen1(value []byte) (*vuv1.OverviewGen1, error) {
+func unmarshalOverviewGen1(opts dd.UnmarshalOptions, value []byte) (*vuv1.OverviewGen1, error) {
 	overview := &vuv1.OverviewGen1{}
 	overview.SetRawData(value)
 
 	offset := 0
-	var opts dd.UnmarshalOptions
 
 	// MemberStateCertificate (194 bytes)
 	if offset+194 > len(value) {
-		return nil, fmt.Errorf("insufficient data for MemberStateCertificate")
+		return nil, dd.WrapField("member_state_certificate", offset, fmt.Errorf("insufficient data for MemberStateCertificate: %w", dd.ErrTruncated))
 	}
 	overview.SetMemberStateCertificate(value[offset : offset+194])
+	opts.Layout.Field("member_state_certificate", offset, 194)
 	offset += 194
 
 	// VuCertificate (194 bytes)
 	if offset+194 > len(value) {
-		return nil, fmt.Errorf("insufficient data for VuCertificate")
+		return nil, dd.WrapField("vu_certificate", offset, fmt.Errorf("insufficient data for VuCertificate: %w", dd.ErrTruncated))
 	}
 	overview.SetVuCertificate(value[offset : offset+194])
+	opts.Layout.Field("vu_certificate", offset, 194)
 	offset += 194
 
 	// VehicleIdentificationNumber (17 bytes)
 	if offset+17 > len(value) {
-		return nil, fmt.Errorf("insufficient data for VehicleIdentificationNumber")
+		return nil, dd.WrapField("vehicle_identification_number", offset, fmt.Errorf("insufficient data for VehicleIdentificationNumber: %w", dd.ErrTruncated))
 	}
 	vin, err := opts.UnmarshalIa5StringValue(value[offset : offset+17])
 	if err != nil {
-		return nil, fmt.Errorf("unmarshal VIN: %w", err)
+		return nil, dd.WrapField("vehicle_identification_number", offset, fmt.Errorf("unmarshal VIN: %w", err))
 	}
 	overview.SetVehicleIdentificationNumber(vin)
+	opts.Layout.Field("vehicle_identification_number", offset, 17)
 	offset += 17
 
 	// VehicleRegistrationIdentification (15 bytes)
 	if offset+15 > len(value) {
-		return nil, fmt.Errorf("insufficient data for VehicleRegistrationIdentification")
+		return nil, dd.WrapField("vehicle_registration_with_nation", offset, fmt.Errorf("insufficient data for VehicleRegistrationIdentification: %w", dd.ErrTruncated))
 	}
 	vrn, err := opts.UnmarshalVehicleRegistration(value[offset : offset+15])
 	if err != nil {
-		return nil, fmt.Errorf("unmarshal VehicleRegistrationIdentification: %w", err)
+		return nil, dd.WrapField("vehicle_registration_with_nation", offset, fmt.Errorf("unmarshal VehicleRegistrationIdentification: %w", err))
 	}
 	overview.SetVehicleRegistrationWithNation(vrn)
+	opts.Layout.Field("vehicle_registration_with_nation", offset, 15)
 	offset += 15
 
 	// CurrentDateTime (4 bytes)
 	if offset+4 > len(value) {
-		return nil, fmt.Errorf("insufficient data for CurrentDateTime")
+		return nil, dd.WrapField("current_date_time", offset, fmt.Errorf("insufficient data for CurrentDateTime: %w", dd.ErrTruncated))
 	}
 	currentTime, err := opts.UnmarshalTimeReal(value[offset : offset+4])
 	if err != nil {
-		return nil, fmt.Errorf("unmarshal CurrentDateTime: %w", err)
+		return nil, dd.WrapField("current_date_time", offset, fmt.Errorf("unmarshal CurrentDateTime: %w", err))
 	}
 	overview.SetCurrentDateTime(currentTime)
+	opts.Layout.Field("current_date_time", offset, 4)
 	offset += 4
 
 	// VuDownloadablePeriod (8 bytes: 2 x TimeReal)
 	if offset+8 > len(value) {
-		return nil, fmt.Errorf("insufficient data for VuDownloadablePeriod")
-	}
-	minTime, err := opts.UnmarshalTimeReal(value[offset : offset+4])
-	if err != nil {
-		return nil, fmt.Errorf("unmarshal DownloadablePeriod minTime: %w", err)
+		return nil, dd.WrapField("downloadable_period", offset, fmt.Errorf("insufficient data for VuDownloadablePeriod: %w", dd.ErrTruncated))
 	}
-	maxTime, err := opts.UnmarshalTimeReal(value[offset+4 : offset+8])
+	downloadablePeriod, err := unmarshalDownloadablePeriod(value[offset : offset+8])
 	if err != nil {
-		return nil, fmt.Errorf("unmarshal DownloadablePeriod maxTime: %w", err)
+		return nil, dd.WrapField("downloadable_period", offset, fmt.Errorf("unmarshal VuDownloadablePeriod: %w", err))
 	}
-	downloadablePeriod := &ddv1.DownloadablePeriod{}
-	downloadablePeriod.SetMinTime(minTime)
-	downloadablePeriod.SetMaxTime(maxTime)
 	overview.SetDownloadablePeriod(downloadablePeriod)
+	opts.Layout.Field("downloadable_period", offset, 8)
 	offset += 8
 
 	// CardSlotsStatus (1 byte)
 	// Lower 4 bits (0-3): driver slot
 	// Upper 4 bits (4-7): co-driver slot
 	if offset+1 > len(value) {
-		return nil, fmt.Errorf("insufficient data for CardSlotsStatus")
+		return nil, dd.WrapField("driver_slot_card", offset, fmt.Errorf("insufficient data for CardSlotsStatus: %w", dd.ErrTruncated))
 	}
-	cardSlotsStatus := value[offset]
-	driverSlot := ddv1.SlotCardType(cardSlotsStatus & 0x0F)
-	coDriverSlot := ddv1.SlotCardType((cardSlotsStatus >> 4) & 0x0F)
+	driverSlot, coDriverSlot := unmarshalCardSlotsStatus(value[offset])
 	overview.SetDriverSlotCard(driverSlot)
 	overview.SetCoDriverSlotCard(coDriverSlot)
+	opts.Layout.Field("card_slots_status", offset, 1)
 	offset += 1
 
 	// VuDownloadActivityData (58 bytes: 4 + 18 + 36)
 	if offset+58 > len(value) {
-		return nil, fmt.Errorf("insufficient data for VuDownloadActivityData")
+		return nil, dd.WrapField("download_activities[0]", offset, fmt.Errorf("insufficient data for VuDownloadActivityData: %w", dd.ErrTruncated))
 	}
 
 	downloadActivity := &vuv1.OverviewGen1_DownloadActivity{}
@@ -153,83 +150,93 @@ func unmarshalOverviewGen1(value []byte) (*vuv1.OverviewGen1, error) {
 	// DownloadingTime (4 bytes)
 	downloadingTime, err := opts.UnmarshalTimeReal(value[offset : offset+4])
 	if err != nil {
-		return nil, fmt.Errorf("unmarshal downloading time: %w", err)
+		return nil, dd.WrapField("download_activities[0].downloading_time", offset, fmt.Errorf("unmarshal downloading time: %w", err))
 	}
 	downloadActivity.SetDownloadingTime(downloadingTime)
+	opts.Layout.Field("download_activities[0].downloading_time", offset, 4)
 	offset += 4
 
 	// FullCardNumber (18 bytes)
 	fullCardNumber, err := opts.UnmarshalFullCardNumber(value[offset : offset+18])
 	if err != nil {
-		return nil, fmt.Errorf("unmarshal full card number: %w", err)
+		return nil, dd.WrapField("download_activities[0].full_card_number", offset, fmt.Errorf("unmarshal full card number: %w", err))
 	}
 	downloadActivity.SetFullCardNumber(fullCardNumber)
+	opts.Layout.Field("download_activities[0].full_card_number", offset, 18)
 	offset += 18
 
 	// CompanyOrWorkshopName (36 bytes: 1 code page + 35 name)
 	companyName, err := opts.UnmarshalStringValue(value[offset : offset+36])
 	if err != nil {
-		return nil, fmt.Errorf("unmarshal company name: %w", err)
+		return nil, dd.WrapField("download_activities[0].company_or_workshop_name", offset, fmt.Errorf("unmarshal company name: %w", err))
 	}
 	downloadActivity.SetCompanyOrWorkshopName(companyName)
+	opts.Layout.Field("download_activities[0].company_or_workshop_name", offset, 36)
 	offset += 36
 
 	overview.SetDownloadActivities([]*vuv1.OverviewGen1_DownloadActivity{downloadActivity})
 
 	// VuCompanyLocksData: 1 byte (noOfLocks) + (noOfLocks * 98 bytes per record)
 	if offset+1 > len(value) {
-		return nil, fmt.Errorf("insufficient data for VuCompanyLocksData noOfLocks")
+		return nil, dd.WrapField("company_locks", offset, fmt.Errorf("insufficient data for VuCompanyLocksData noOfLocks: %w", dd.ErrTruncated))
 	}
 	noOfLocks := value[offset]
+	opts.Layout.Field("no_of_locks", offset, 1)
 	offset += 1
 
 	const companyLockRecordSize = 98 // 4 + 4 + 36 + 36 + 18
 	if offset+int(noOfLocks)*companyLockRecordSize > len(value) {
-		return nil, fmt.Errorf("insufficient data for VuCompanyLocksData records")
+		return nil, dd.WrapField("company_locks", offset, fmt.Errorf("insufficient data for VuCompanyLocksData records: %w", dd.ErrTruncated))
 	}
 
 	companyLocks := make([]*vuv1.OverviewGen1_CompanyLock, noOfLocks)
 	for i := 0; i < int(noOfLocks); i++ {
+		lockLayout := opts.Layout.NestedElement("company_locks", i, offset)
 		lock := &vuv1.OverviewGen1_CompanyLock{}
 
 		// LockInTime (4 bytes)
 		lockInTime, err := opts.UnmarshalTimeReal(value[offset : offset+4])
 		if err != nil {
-			return nil, fmt.Errorf("unmarshal lockInTime: %w", err)
+			return nil, dd.WrapField(fmt.Sprintf("company_locks[%d].lock_in_time", i), offset, fmt.Errorf("unmarshal lockInTime: %w", err))
 		}
 		lock.SetLockInTime(lockInTime)
+		lockLayout.Field("lock_in_time", 0, 4)
 		offset += 4
 
 		// LockOutTime (4 bytes)
 		lockOutTime, err := opts.UnmarshalTimeReal(value[offset : offset+4])
 		if err != nil {
-			return nil, fmt.Errorf("unmarshal lockOutTime: %w", err)
+			return nil, dd.WrapField(fmt.Sprintf("company_locks[%d].lock_out_time", i), offset, fmt.Errorf("unmarshal lockOutTime: %w", err))
 		}
 		lock.SetLockOutTime(lockOutTime)
+		lockLayout.Field("lock_out_time", 4, 4)
 		offset += 4
 
 		// CompanyName (36 bytes)
 		companyName, err := opts.UnmarshalStringValue(value[offset : offset+36])
 		if err != nil {
-			return nil, fmt.Errorf("unmarshal company name: %w", err)
+			return nil, dd.WrapField(fmt.Sprintf("company_locks[%d].company_name", i), offset, fmt.Errorf("unmarshal company name: %w", err))
 		}
 		lock.SetCompanyName(companyName)
+		lockLayout.Field("company_name", 8, 36)
 		offset += 36
 
 		// CompanyAddress (36 bytes)
 		companyAddress, err := opts.UnmarshalStringValue(value[offset : offset+36])
 		if err != nil {
-			return nil, fmt.Errorf("unmarshal company address: %w", err)
+			return nil, dd.WrapField(fmt.Sprintf("company_locks[%d].company_address", i), offset, fmt.Errorf("unmarshal company address: %w", err))
 		}
 		lock.SetCompanyAddress(companyAddress)
+		lockLayout.Field("company_address", 44, 36)
 		offset += 36
 
 		// CompanyCardNumber (18 bytes)
 		companyCardNumber, err := opts.UnmarshalFullCardNumber(value[offset : offset+18])
 		if err != nil {
-			return nil, fmt.Errorf("unmarshal company card number: %w", err)
+			return nil, dd.WrapField(fmt.Sprintf("company_locks[%d].company_card_number", i), offset, fmt.Errorf("unmarshal company card number: %w", err))
 		}
 		lock.SetCompanyCardNumber(companyCardNumber)
+		lockLayout.Field("company_card_number", 80, 18)
 		offset += 18
 
 		companyLocks[i] = lock
@@ -238,58 +245,65 @@ func unmarshalOverviewGen1(value []byte) (*vuv1.OverviewGen1, error) {
 
 	// VuControlActivityData: 1 byte (noOfControls) + (noOfControls * 31 bytes per record)
 	if offset+1 > len(value) {
-		return nil, fmt.Errorf("insufficient data for VuControlActivityData noOfControls")
+		return nil, dd.WrapField("control_activities", offset, fmt.Errorf("insufficient data for VuControlActivityData noOfControls: %w", dd.ErrTruncated))
 	}
 	noOfControls := value[offset]
+	opts.Layout.Field("no_of_controls", offset, 1)
 	offset += 1
 
 	const controlActivityRecordSize = 31 // 1 + 4 + 18 + 4 + 4
 	if offset+int(noOfControls)*controlActivityRecordSize > len(value) {
-		return nil, fmt.Errorf("insufficient data for VuControlActivityData records")
+		return nil, dd.WrapField("control_activities", offset, fmt.Errorf("insufficient data for VuControlActivityData records: %w", dd.ErrTruncated))
 	}
 
 	controlActivities := make([]*vuv1.OverviewGen1_ControlActivity, noOfControls)
 	for i := 0; i < int(noOfControls); i++ {
+		controlLayout := opts.Layout.NestedElement("control_activities", i, offset)
 		control := &vuv1.OverviewGen1_ControlActivity{}
 
 		// ControlType (1 byte)
 		controlType, err := opts.UnmarshalControlType(value[offset : offset+1])
 		if err != nil {
-			return nil, fmt.Errorf("unmarshal control type: %w", err)
+			return nil, dd.WrapField(fmt.Sprintf("control_activities[%d].control_type", i), offset, fmt.Errorf("unmarshal control type: %w", err))
 		}
 		control.SetControlType(controlType)
+		controlLayout.Field("control_type", 0, 1)
 		offset += 1
 
 		// ControlTime (4 bytes)
 		controlTime, err := opts.UnmarshalTimeReal(value[offset : offset+4])
 		if err != nil {
-			return nil, fmt.Errorf("unmarshal control time: %w", err)
+			return nil, dd.WrapField(fmt.Sprintf("control_activities[%d].control_time", i), offset, fmt.Errorf("unmarshal control time: %w", err))
 		}
 		control.SetControlTime(controlTime)
+		controlLayout.Field("control_time", 1, 4)
 		offset += 4
 
 		// ControlCardNumber (18 bytes)
 		controlCardNumber, err := opts.UnmarshalFullCardNumber(value[offset : offset+18])
 		if err != nil {
-			return nil, fmt.Errorf("unmarshal control card number: %w", err)
+			return nil, dd.WrapField(fmt.Sprintf("control_activities[%d].control_card_number", i), offset, fmt.Errorf("unmarshal control card number: %w", err))
 		}
 		control.SetControlCardNumber(controlCardNumber)
+		controlLayout.Field("control_card_number", 5, 18)
 		offset += 18
 
 		// DownloadPeriodBeginTime (4 bytes)
 		downloadPeriodBeginTime, err := opts.UnmarshalTimeReal(value[offset : offset+4])
 		if err != nil {
-			return nil, fmt.Errorf("unmarshal download period begin time: %w", err)
+			return nil, dd.WrapField(fmt.Sprintf("control_activities[%d].download_period_begin_time", i), offset, fmt.Errorf("unmarshal download period begin time: %w", err))
 		}
 		control.SetDownloadPeriodBeginTime(downloadPeriodBeginTime)
+		controlLayout.Field("download_period_begin_time", 23, 4)
 		offset += 4
 
 		// DownloadPeriodEndTime (4 bytes)
 		downloadPeriodEndTime, err := opts.UnmarshalTimeReal(value[offset : offset+4])
 		if err != nil {
-			return nil, fmt.Errorf("unmarshal download period end time: %w", err)
+			return nil, dd.WrapField(fmt.Sprintf("control_activities[%d].download_period_end_time", i), offset, fmt.Errorf("unmarshal download period end time: %w", err))
 		}
 		control.SetDownloadPeriodEndTime(downloadPeriodEndTime)
+		controlLayout.Field("download_period_end_time", 27, 4)
 		offset += 4
 
 		controlActivities[i] = control
@@ -298,9 +312,10 @@ func unmarshalOverviewGen1(value []byte) (*vuv1.OverviewGen1, error) {
 
 	// Signature (128 bytes - RSA for Gen1)
 	if offset+128 > len(value) {
-		return nil, fmt.Errorf("insufficient data for Signature")
+		return nil, dd.WrapField("signature", offset, fmt.Errorf("insufficient data for Signature: %w", dd.ErrTruncated))
 	}
 	overview.SetSignature(value[offset : offset+128])
+	opts.Layout.Field("signature", offset, 128)
 	offset += 128
 
 	// Verify we consumed exactly the right amount of data
@@ -311,6 +326,36 @@ func unmarshalOverviewGen1(value []byte) (*vuv1.OverviewGen1, error) {
 	return overview, nil
 }
 
+// unmarshalDownloadablePeriod parses a VuDownloadablePeriod.
+//
+// The data type `VuDownloadablePeriod` is specified in the Data Dictionary, Section 2.193.
+//
+// ASN.1 Definition:
+//
+//	VuDownloadablePeriod ::= SEQUENCE {
+//	    minDownloadableTime TimeReal,
+//	    maxDownloadableTime TimeReal
+//	}
+func unmarshalDownloadablePeriod(data []byte) (*ddv1.DownloadablePeriod, error) {
+	const lenVuDownloadablePeriod = 8
+	if len(data) != lenVuDownloadablePeriod {
+		return nil, fmt.Errorf("invalid data length for VuDownloadablePeriod: got %d, want %d: %w", len(data), lenVuDownloadablePeriod, dd.ErrInvalidValue)
+	}
+	var opts dd.UnmarshalOptions
+	minTime, err := opts.UnmarshalTimeReal(data[0:4])
+	if err != nil {
+		return nil, dd.WrapField("min_time", 0, fmt.Errorf("unmarshal minDownloadableTime: %w", err))
+	}
+	maxTime, err := opts.UnmarshalTimeReal(data[4:8])
+	if err != nil {
+		return nil, dd.WrapField("max_time", 4, fmt.Errorf("unmarshal maxDownloadableTime: %w", err))
+	}
+	downloadablePeriod := &ddv1.DownloadablePeriod{}
+	downloadablePeriod.SetMinTime(minTime)
+	downloadablePeriod.SetMaxTime(maxTime)
+	return downloadablePeriod, nil
+}
+
 // appendOverviewGen1 marshals Gen1 Overview data using raw data painting.
 //
 // This function implements the raw data painting pattern: if raw_data is available
@@ -361,11 +406,9 @@ func appendOverviewGen1(dst []byte, overview *vuv1.OverviewGen1) ([]byte, error)
 	// VehicleRegistrationIdentification (15 bytes)
 	vrn := overview.GetVehicleRegistrationWithNation()
 	if vrn != nil {
-		vrnBytes, err := dd.AppendVehicleRegistration(nil, vrn)
-		if err != nil {
+		if err := dd.PaintVehicleRegistration(canvas[offset:offset+15], vrn); err != nil {
 			return nil, fmt.Errorf("append VRN: %w", err)
 		}
-		copy(canvas[offset:offset+15], vrnBytes)
 	}
 	offset += 15
 
@@ -401,13 +444,14 @@ func appendOverviewGen1(dst []byte, overview *vuv1.OverviewGen1) ([]byte, error)
 	}
 
 	// CardSlotsStatus (1 byte)
+	// Unrecognized slot card types keep the nibble of the canvas.
 	driverSlot, err := dd.MarshalEnum(overview.GetDriverSlotCard())
 	if err != nil {
-		driverSlot = 0
+		driverSlot = canvas[offset] & 0x0F
 	}
 	coDriverSlot, err := dd.MarshalEnum(overview.GetCoDriverSlotCard())
 	if err != nil {
-		coDriverSlot = 0
+		coDriverSlot = canvas[offset] >> 4
 	}
 	canvas[offset] = (coDriverSlot << 4) | (driverSlot & 0x0F)
 	offset += 1
@@ -3,6 +3,7 @@ package vu
 import (
 	"fmt"
 
+	"github.com/way-platform/tachograph-go/internal/dd"
 	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
 )
 
@@ -32,77 +33,138 @@ import (
 //
 //	recordType (1 byte) + recordSize (2 bytes, big-endian) + noOfRecords (2 bytes, big-endian)
 //
-// Note: This is a minimal implementation that stores raw_data for round-trip fidelity.
-// Full semantic parsing of all RecordArrays is TODO.
-func unmarshalOverviewGen2V2(value []byte) (*vuv1.OverviewGen2V2, error) {
+// Note: The vehicle identification, current time, downloadable period and card
+// slot status are parsed semantically. The remaining RecordArrays are only
+// validated and preserved in raw_data for round-trip fidelity.
+func unmarshalOverviewGen2V2(opts dd.UnmarshalOptions, value []byte) (*vuv1.OverviewGen2V2, error) {
 	overview := &vuv1.OverviewGen2V2{}
 	overview.SetRawData(value)
 
-	// For now, store the raw data and validate structure by skipping through all record arrays
+	// Parse the vehicle identification and download period, skip the remaining record arrays
 	offset := 0
 
-	// Helper to skip a RecordArray
-	skipRecordArray := func(name string) error {
+	// Helper to skip the RecordArray of a field
+	skipRecordArray := func(field, name string) error {
 		size, err := sizeOfRecordArray(value, offset)
 		if err != nil {
-			return fmt.Errorf("%s: %w", name, err)
+			return dd.WrapField(field, offset, fmt.Errorf("%s: %w", name, err))
 		}
+		recordArrayLayout(opts.Layout, field, value, offset)
 		offset += size
 		return nil
 	}
 
+	// Helper to read the RecordArray of a field, holding a single record of at
+	// least minSize bytes
+	readSingleRecord := func(field, name string, minSize int, parse func(record []byte) error) error {
+		ra, next, err := readRecordArray(value, offset)
+		if err != nil {
+			return dd.WrapField(field, offset, fmt.Errorf("%s: %w", name, err))
+		}
+		recordArrayLayout(opts.Layout, field, value, offset)
+		if err := ra.checkRecordSize(name, minSize); err != nil {
+			return dd.WrapField(field, offset, err)
+		}
+		if len(ra.records) > 0 {
+			if err := parse(ra.records[0]); err != nil {
+				return dd.WrapField(field, ra.offset, fmt.Errorf("unmarshal %s: %w", name, err))
+			}
+		}
+		offset = next
+		return nil
+	}
+
 	// MemberStateCertificateRecordArray
-	if err := skipRecordArray("MemberStateCertificate"); err != nil {
+	if err := skipRecordArray("member_state_certificate", "MemberStateCertificate"); err != nil {
 		return nil, err
 	}
 
 	// VUCertificateRecordArray
-	if err := skipRecordArray("VUCertificate"); err != nil {
+	if err := skipRecordArray("vu_certificate", "VUCertificate"); err != nil {
 		return nil, err
 	}
 
 	// VehicleIdentificationNumberRecordArray
-	if err := skipRecordArray("VehicleIdentificationNumber"); err != nil {
+	if err := readSingleRecord("vehicle_identification_number", "VehicleIdentificationNumber", 17, func(record []byte) error {
+		vin, err := opts.UnmarshalIa5StringValue(record[:17])
+		if err != nil {
+			return err
+		}
+		overview.SetVehicleIdentificationNumber(vin)
+		return nil
+	}); err != nil {
 		return nil, err
 	}
 
 	// VehicleRegistrationNumberRecordArray (Gen2 V2 addition)
-	if err := skipRecordArray("VehicleRegistrationNumber"); err != nil {
+	// VehicleRegistrationNumber: 1 byte code page + 13 bytes registration number,
+	// optionally preceded by the registering nation (VehicleRegistrationIdentification).
+	if err := readSingleRecord("vehicle_registration_number", "VehicleRegistrationNumber", 14, func(record []byte) error {
+		number := record[1:14]
+		if len(record) >= 15 {
+			number = record[2:15]
+		}
+		vrn, err := opts.UnmarshalIa5StringValue(number)
+		if err != nil {
+			return err
+		}
+		overview.SetVehicleRegistrationNumber(vrn)
+		return nil
+	}); err != nil {
 		return nil, err
 	}
 
 	// CurrentDateTimeRecordArray
-	if err := skipRecordArray("CurrentDateTime"); err != nil {
+	if err := readSingleRecord("current_date_time", "CurrentDateTime", 4, func(record []byte) error {
+		currentTime, err := opts.UnmarshalTimeReal(record[:4])
+		if err != nil {
+			return err
+		}
+		overview.SetCurrentDateTime(currentTime)
+		return nil
+	}); err != nil {
 		return nil, err
 	}
 
 	// VuDownloadablePeriodRecordArray
-	if err := skipRecordArray("VuDownloadablePeriod"); err != nil {
+	if err := readSingleRecord("downloadable_period", "VuDownloadablePeriod", 8, func(record []byte) error {
+		downloadablePeriod, err := unmarshalDownloadablePeriod(record[:8])
+		if err != nil {
+			return err
+		}
+		overview.SetDownloadablePeriod(downloadablePeriod)
+		return nil
+	}); err != nil {
 		return nil, err
 	}
 
 	// CardSlotsStatusRecordArray
-	if err := skipRecordArray("CardSlotsStatus"); err != nil {
+	if err := readSingleRecord("driver_slot_card", "CardSlotsStatus", 1, func(record []byte) error {
+		driverSlot, coDriverSlot := unmarshalCardSlotsStatus(record[0])
+		overview.SetDriverSlotCard(driverSlot)
+		overview.SetCoDriverSlotCard(coDriverSlot)
+		return nil
+	}); err != nil {
 		return nil, err
 	}
 
 	// VuDownloadActivityDataRecordArray
-	if err := skipRecordArray("VuDownloadActivityData"); err != nil {
+	if err := skipRecordArray("download_activities", "VuDownloadActivityData"); err != nil {
 		return nil, err
 	}
 
 	// VuCompanyLocksRecordArray
-	if err := skipRecordArray("VuCompanyLocks"); err != nil {
+	if err := skipRecordArray("company_locks", "VuCompanyLocks"); err != nil {
 		return nil, err
 	}
 
 	// VuControlActivityRecordArray
-	if err := skipRecordArray("VuControlActivity"); err != nil {
+	if err := skipRecordArray("control_activities", "VuControlActivity"); err != nil {
 		return nil, err
 	}
 
 	// SignatureRecordArray (last)
-	if err := skipRecordArray("Signature"); err != nil {
+	if err := skipRecordArray("signature", "Signature"); err != nil {
 		return nil, err
 	}
 
@@ -111,8 +173,8 @@ func unmarshalOverviewGen2V2(value []byte) (*vuv1.OverviewGen2V2, error) {
 		return nil, fmt.Errorf("Overview Gen2 V2 parsing mismatch: parsed %d bytes, expected %d", offset, len(value))
 	}
 
-	// TODO: Implement full semantic parsing of all record arrays
-	// For now, raw_data contains all the information needed for round-trip testing
+	// TODO: Implement semantic parsing of the download activity, company lock
+	// and control activity record arrays.
 
 	return overview, nil
 }
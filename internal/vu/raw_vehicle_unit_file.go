@@ -2,8 +2,11 @@ package vu
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
+	"sync"
 
+	"github.com/way-platform/tachograph-go/internal/dd"
 	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
 	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
 	"google.golang.org/protobuf/proto"
@@ -23,13 +26,27 @@ import (
 // The challenge of the TV format is that the length is not explicitly encoded - it must
 // be calculated by understanding the structure of each transfer type.
 func unmarshalRawVehicleUnitFile(data []byte) (*vuv1.RawVehicleUnitFile, error) {
+	rawFile, err := ScanRawVehicleUnitFile(data)
+	if err != nil {
+		return nil, err
+	}
+	return rawFile, nil
+}
+
+// ScanRawVehicleUnitFile slices vehicle unit data into TV records like
+// unmarshalRawVehicleUnitFile, but on error also returns the records that were
+// sliced before the error.
+//
+// The records are contiguous, so the error occurred at the offset following
+// the last returned record, which the error doesn't repeat.
+func ScanRawVehicleUnitFile(data []byte) (*vuv1.RawVehicleUnitFile, error) {
 	var rawFile vuv1.RawVehicleUnitFile
 	offset := 0
 
 	for offset < len(data) {
 		// Read tag (2 bytes)
 		if offset+2 > len(data) {
-			return nil, fmt.Errorf("insufficient data for tag at offset %d: need 2 bytes, have %d", offset, len(data)-offset)
+			return &rawFile, fmt.Errorf("insufficient data for tag: need 2 bytes, have %d: %w", len(data)-offset, dd.ErrTruncated)
 		}
 		tag := binary.BigEndian.Uint16(data[offset:])
 		offset += 2
@@ -37,18 +54,18 @@ func unmarshalRawVehicleUnitFile(data []byte) (*vuv1.RawVehicleUnitFile, error)
 		// Determine transfer type from tag
 		transferType := findTransferTypeByTag(tag)
 		if transferType == vuv1.TransferType_TRANSFER_TYPE_UNSPECIFIED {
-			return nil, fmt.Errorf("unknown tag: 0x%04X at offset %d", tag, offset-2)
+			return &rawFile, fmt.Errorf("unknown tag: 0x%04X: %w", tag, dd.ErrUnknownTag)
 		}
 
 		// Calculate size of value (including embedded signature)
 		valueSize, err := sizeOfTransferValue(data[offset:], transferType)
 		if err != nil {
-			return nil, fmt.Errorf("sizeOf failed for %v at offset %d: %w", transferType, offset, err)
+			return &rawFile, fmt.Errorf("sizeOf failed for %v: %w", transferType, err)
 		}
 
 		// Extract value
 		if offset+valueSize > len(data) {
-			return nil, fmt.Errorf("insufficient data for %v value: need %d bytes, have %d", transferType, valueSize, len(data)-offset)
+			return &rawFile, fmt.Errorf("insufficient data for %v value: need %d bytes, have %d: %w", transferType, valueSize, len(data)-offset, dd.ErrTruncated)
 		}
 		value := data[offset : offset+valueSize]
 		offset += valueSize
@@ -66,6 +83,65 @@ func unmarshalRawVehicleUnitFile(data []byte) (*vuv1.RawVehicleUnitFile, error)
 	return &rawFile, nil
 }
 
+// ScanTransfer is a [bufio.SplitFunc] that splits VU data into TV records,
+// for reading a VU file from a stream one transfer at a time.
+//
+// The size of a transfer is calculated from its structure, as in
+// [ScanRawVehicleUnitFile], so more data is requested until the structure is
+// complete. A card download transfer extends to the end of the data.
+func ScanTransfer(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if len(data) == 0 && atEOF {
+		return 0, nil, nil
+	}
+	if len(data) < 2 {
+		if atEOF {
+			return 0, nil, fmt.Errorf("insufficient data for tag: need 2 bytes, have %d: %w", len(data), dd.ErrTruncated)
+		}
+		return 0, nil, nil
+	}
+	tag := binary.BigEndian.Uint16(data)
+	transferType := findTransferTypeByTag(tag)
+	if transferType == vuv1.TransferType_TRANSFER_TYPE_UNSPECIFIED {
+		return 0, nil, fmt.Errorf("unknown tag: 0x%04X: %w", tag, dd.ErrUnknownTag)
+	}
+	if transferType == vuv1.TransferType_CARD_DOWNLOAD && !atEOF {
+		return 0, nil, nil
+	}
+	valueSize, err := sizeOfTransferValue(data[2:], transferType)
+	if err != nil {
+		if errors.Is(err, dd.ErrTruncated) && !atEOF {
+			return 0, nil, nil
+		}
+		return 0, nil, fmt.Errorf("sizeOf failed for %v: %w", transferType, err)
+	}
+	if len(data)-2 < valueSize {
+		if atEOF {
+			return 0, nil, fmt.Errorf("insufficient data for %v value: need %d bytes, have %d: %w", transferType, valueSize, len(data)-2, dd.ErrTruncated)
+		}
+		return 0, nil, nil
+	}
+	return 2 + valueSize, data[:2+valueSize], nil
+}
+
+// UnmarshalRawTransfer parses a single TV record, as split by [ScanTransfer].
+func UnmarshalRawTransfer(data []byte) (*vuv1.RawVehicleUnitFile_Record, error) {
+	if len(data) < 2 {
+		return nil, fmt.Errorf("insufficient data for tag: need 2 bytes, have %d: %w", len(data), dd.ErrTruncated)
+	}
+	tag := binary.BigEndian.Uint16(data)
+	transferType := findTransferTypeByTag(tag)
+	if transferType == vuv1.TransferType_TRANSFER_TYPE_UNSPECIFIED {
+		return nil, fmt.Errorf("unknown tag: 0x%04X: %w", tag, dd.ErrUnknownTag)
+	}
+	record := &vuv1.RawVehicleUnitFile_Record{}
+	record.SetTag(uint32(tag))
+	record.SetType(transferType)
+	record.SetGeneration(generationFromTransferType(transferType))
+	// Make a copy since the data may be reused by the scanner
+	record.SetValue(append([]byte(nil), data[2:]...))
+	return record, nil
+}
+
 // sizeOfTransferValue dispatches to type-specific sizeOf functions.
 // This function calculates the total byte size of a transfer's value including signature.
 func sizeOfTransferValue(data []byte, transferType vuv1.TransferType) (int, error) {
@@ -100,8 +176,10 @@ func sizeOfTransferValue(data []byte, transferType vuv1.TransferType) (int, erro
 // Total size = 5 + (recordSize * noOfRecords)
 func sizeOfRecordArray(data []byte, offset int) (int, error) {
 	const headerSize = 5
-	if len(data[offset:]) < headerSize {
-		return 0, fmt.Errorf("insufficient data for RecordArray header: need %d, have %d", headerSize, len(data[offset:]))
+	// The offset is past the end of the data if the preceding record arrays
+	// are truncated.
+	if remaining := max(len(data)-offset, 0); remaining < headerSize {
+		return 0, fmt.Errorf("insufficient data for RecordArray header: need %d, have %d: %w", headerSize, remaining, dd.ErrTruncated)
 	}
 
 	recordSize := binary.BigEndian.Uint16(data[offset+1:])
@@ -113,14 +191,41 @@ func sizeOfRecordArray(data []byte, offset int) (int, error) {
 
 // generationFromTransferType extracts generation from transfer type using protobuf reflection.
 func generationFromTransferType(transferType vuv1.TransferType) ddv1.Generation {
-	// Use protobuf reflection to get generation from enum options
-	valueDesc := transferType.Descriptor().Values().ByNumber(transferType.Number())
-	if valueDesc == nil {
-		return ddv1.Generation_GENERATION_UNSPECIFIED
+	return transferTypeGenerations()[transferType]
+}
+
+// versionFromTransferType extracts the Gen2 version from transfer type using
+// protobuf reflection. It returns VERSION_UNSPECIFIED for transfer types that
+// are shared by all versions of their generation.
+func versionFromTransferType(transferType vuv1.TransferType) ddv1.Version {
+	return transferTypeVersions()[transferType]
+}
+
+// transferTypeVersions maps transfer types to the version annotated on their
+// enum values.
+var transferTypeVersions = sync.OnceValue(func() map[vuv1.TransferType]ddv1.Version {
+	result := make(map[vuv1.TransferType]ddv1.Version)
+	values := vuv1.TransferType_TRANSFER_TYPE_UNSPECIFIED.Descriptor().Values()
+	for i := 0; i < values.Len(); i++ {
+		opts := values.Get(i).Options()
+		if proto.HasExtension(opts, ddv1.E_Version) {
+			result[vuv1.TransferType(values.Get(i).Number())] = proto.GetExtension(opts, ddv1.E_Version).(ddv1.Version)
+		}
 	}
-	opts := valueDesc.Options()
-	if proto.HasExtension(opts, ddv1.E_Generation) {
-		return proto.GetExtension(opts, ddv1.E_Generation).(ddv1.Generation)
+	return result
+})
+
+// transferTypeGenerations maps transfer types to the generation annotated on
+// their enum values.
+var transferTypeGenerations = sync.OnceValue(func() map[vuv1.TransferType]ddv1.Generation {
+	result := make(map[vuv1.TransferType]ddv1.Generation)
+	values := vuv1.TransferType_TRANSFER_TYPE_UNSPECIFIED.Descriptor().Values()
+	for i := 0; i < values.Len(); i++ {
+		// Use protobuf reflection to get generation from enum options
+		opts := values.Get(i).Options()
+		if proto.HasExtension(opts, ddv1.E_Generation) {
+			result[vuv1.TransferType(values.Get(i).Number())] = proto.GetExtension(opts, ddv1.E_Generation).(ddv1.Generation)
+		}
 	}
-	return ddv1.Generation_GENERATION_UNSPECIFIED
-}
+	return result
+})
@@ -159,3 +159,35 @@ func TestRawVehicleUnitFileRoundTrip(t *testing.T) {
 		})
 	}
 }
+
+func TestSizeOfRecordArray(t *testing.T) {
+	header := []byte{0x01, 0x00, 0x02, 0x00, 0x03}
+	for _, tt := range []struct {
+		name    string
+		data    []byte
+		offset  int
+		want    int
+		wantErr string
+	}{
+		{name: "header", data: header, want: 5 + 2*3},
+		{name: "truncated header", data: header[:3], wantErr: "need 5, have 3"},
+		{name: "offset at end", data: header, offset: 5, wantErr: "need 5, have 0"},
+		{name: "offset past end", data: header, offset: 97, wantErr: "need 5, have 0"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sizeOfRecordArray(tt.data, tt.offset)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("sizeOfRecordArray() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("sizeOfRecordArray() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
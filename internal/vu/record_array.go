@@ -0,0 +1,133 @@
+package vu
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/way-platform/tachograph-go/internal/dd"
+)
+
+// Record types used in Gen2 RecordArray headers.
+//
+// See Data Dictionary, Section 2.120, `RecordType`.
+const (
+	recordTypeActivityChangeInfo                byte = 0x01
+	recordTypeMemberStateCertificate            byte = 0x04
+	recordTypeOdometerValueMidnight             byte = 0x05
+	recordTypeDateOfDayDownloaded               byte = 0x06
+	recordTypeSignature                         byte = 0x08
+	recordTypeSpecificConditionRecord           byte = 0x09
+	recordTypeVehicleIdentificationNumber       byte = 0x0A
+	recordTypeVehicleRegistrationNumber         byte = 0x0B
+	recordTypeVuCalibrationRecord               byte = 0x0C
+	recordTypeVuCardIWRecord                    byte = 0x0D
+	recordTypeVuCardRecord                      byte = 0x0E
+	recordTypeVuCertificate                     byte = 0x0F
+	recordTypeVuCompanyLocksRecord              byte = 0x10
+	recordTypeVuControlActivityRecord           byte = 0x11
+	recordTypeVuDetailedSpeedBlock              byte = 0x12
+	recordTypeVuDownloadActivityData            byte = 0x14
+	recordTypeVuEventRecord                     byte = 0x15
+	recordTypeVuGNSSADRecord                    byte = 0x16
+	recordTypeVuITSConsentRecord                byte = 0x17
+	recordTypeVuFaultRecord                     byte = 0x18
+	recordTypeVuIdentification                  byte = 0x19
+	recordTypeVuOverSpeedingControlData         byte = 0x1A
+	recordTypeVuOverSpeedingEventRecord         byte = 0x1B
+	recordTypeVuPlaceDailyWorkPeriodRecord      byte = 0x1C
+	recordTypeVuTimeAdjustmentRecord            byte = 0x1E
+	recordTypeVuPowerSupplyInterruptionRecord   byte = 0x1F
+	recordTypeSensorPairedRecord                byte = 0x20
+	recordTypeSensorExternalGNSSCoupledRecord   byte = 0x21
+	recordTypeVuBorderCrossingRecord            byte = 0x22
+	recordTypeVuLoadUnloadRecord                byte = 0x23
+	recordTypeVehicleRegistrationIdentification byte = 0x24
+)
+
+// recordArray is a single Gen2 RecordArray, split into its records.
+//
+// ASN.1 Definition:
+//
+//	RecordArray ::= SEQUENCE {
+//	    recordType   RecordType,            -- 1 byte
+//	    recordSize   INTEGER(1..65535),     -- 2 bytes
+//	    noOfRecords  INTEGER(0..65535),     -- 2 bytes
+//	    records      SET SIZE(noOfRecords) OF <recordType>
+//	}
+type recordArray struct {
+	recordType byte
+	recordSize int
+	records    [][]byte
+	// offset is the offset of the first record in the data.
+	offset int
+}
+
+// readRecordArray reads the RecordArray at offset and returns it together with
+// the offset of the first byte after it.
+func readRecordArray(data []byte, offset int) (recordArray, int, error) {
+	const headerSize = 5
+	if offset < 0 || len(data)-offset < headerSize {
+		return recordArray{}, offset, fmt.Errorf("insufficient data for RecordArray header at offset %d: %w", offset, dd.ErrTruncated)
+	}
+	ra := recordArray{
+		recordType: data[offset],
+		recordSize: int(binary.BigEndian.Uint16(data[offset+1:])),
+	}
+	noOfRecords := int(binary.BigEndian.Uint16(data[offset+3:]))
+	offset += headerSize
+	ra.offset = offset
+	if len(data)-offset < ra.recordSize*noOfRecords {
+		return recordArray{}, offset, fmt.Errorf(
+			"insufficient data for RecordArray of type 0x%02X: need %d bytes, have %d: %w",
+			ra.recordType, ra.recordSize*noOfRecords, len(data)-offset, dd.ErrTruncated,
+		)
+	}
+	ra.records = make([][]byte, noOfRecords)
+	for i := range ra.records {
+		ra.records[i] = data[offset : offset+ra.recordSize]
+		offset += ra.recordSize
+	}
+	return ra, offset, nil
+}
+
+// checkRecordSize returns an error if the record array does not hold records
+// of at least the given size.
+func (ra recordArray) checkRecordSize(name string, minSize int) error {
+	if len(ra.records) > 0 && ra.recordSize < minSize {
+		return fmt.Errorf("%s: record size %d is smaller than %d", name, ra.recordSize, minSize)
+	}
+	return nil
+}
+
+// recordError wraps an error parsing the record at index i as an error of the
+// field with the given name, at the offset of the record.
+func (ra recordArray) recordError(field string, i int, err error) error {
+	return dd.WrapField(fmt.Sprintf("%s[%d]", field, i), ra.offset+i*ra.recordSize, err)
+}
+
+// fieldError wraps an error parsing the record array as an error of the field
+// with the given name, at the offset of its first record.
+func (ra recordArray) fieldError(field string, err error) error {
+	return dd.WrapField(field, ra.offset, err)
+}
+
+// recordArrayLayout records the header of the RecordArray at offset in data,
+// and its records as the elements of the field with the given name, in
+// layout. Records past the end of the data are not recorded.
+func recordArrayLayout(layout *dd.Layout, field string, data []byte, offset int) {
+	const headerSize = 5
+	if layout == nil || offset < 0 || len(data)-offset < headerSize {
+		return
+	}
+	header := layout.Nested(field, offset)
+	header.Field("record_type", 0, 1)
+	header.Field("record_size", 1, 2)
+	header.Field("no_of_records", 3, 2)
+	recordSize := int(binary.BigEndian.Uint16(data[offset+1:]))
+	noOfRecords := int(binary.BigEndian.Uint16(data[offset+3:]))
+	offset += headerSize
+	for i := 0; i < noOfRecords && len(data)-offset >= recordSize; i++ {
+		layout.Element(field, i, offset, recordSize)
+		offset += recordSize
+	}
+}
@@ -0,0 +1,158 @@
+package vu
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/way-platform/tachograph-go/internal/dd"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+)
+
+// appendTestRecordArray appends a RecordArray with the given records to dst.
+func appendTestRecordArray(dst []byte, recordType byte, recordSize int, records ...[]byte) []byte {
+	dst = append(dst, recordType)
+	dst = binary.BigEndian.AppendUint16(dst, uint16(recordSize))
+	dst = binary.BigEndian.AppendUint16(dst, uint16(len(records)))
+	for _, record := range records {
+		dst = append(dst, record...)
+	}
+	return dst
+}
+
+func TestReadRecordArray(t *testing.T) {
+	data := appendTestRecordArray(nil, recordTypeActivityChangeInfo, 2, []byte{0x01, 0x02}, []byte{0x03, 0x04})
+	data = append(data, 0xAA) // trailing byte belongs to the next structure
+	ra, next, err := readRecordArray(data, 0)
+	if err != nil {
+		t.Fatalf("readRecordArray() error = %v", err)
+	}
+	if ra.recordType != recordTypeActivityChangeInfo || ra.recordSize != 2 || len(ra.records) != 2 {
+		t.Errorf("readRecordArray() = %+v", ra)
+	}
+	if next != len(data)-1 {
+		t.Errorf("readRecordArray() next = %d, want %d", next, len(data)-1)
+	}
+	if _, _, err := readRecordArray(data[:8], 0); err == nil {
+		t.Error("readRecordArray() on truncated data: expected error")
+	}
+}
+
+func TestUnmarshalActivitiesGen2V1(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	var data []byte
+	data = appendTestRecordArray(data, recordTypeDateOfDayDownloaded, 4, binary.BigEndian.AppendUint32(nil, uint32(day.Unix())))
+	data = appendTestRecordArray(data, recordTypeOdometerValueMidnight, 3, []byte{0x01, 0x86, 0xA0}) // 100000 km
+	data = appendTestRecordArray(data, recordTypeVuCardIWRecord, 131)
+	// Driver slot, driving from 08:00 (480 minutes).
+	data = appendTestRecordArray(data, recordTypeActivityChangeInfo, 2, []byte{0x19, 0xE0})
+	data = appendTestRecordArray(data, recordTypeVuPlaceDailyWorkPeriodRecord, 40)
+	data = appendTestRecordArray(data, recordTypeVuGNSSADRecord, 56)
+	specificCondition := binary.BigEndian.AppendUint32(nil, uint32(day.Add(9*time.Hour).Unix()))
+	specificCondition = append(specificCondition, 0x01) // OUT OF SCOPE begin
+	data = appendTestRecordArray(data, recordTypeSpecificConditionRecord, 5, specificCondition)
+	data = appendTestRecordArray(data, recordTypeSignature, 4, []byte{0xDE, 0xAD, 0xBE, 0xEF})
+
+	activities, err := unmarshalActivitiesGen2V1(dd.UnmarshalOptions{}, data)
+	if err != nil {
+		t.Fatalf("unmarshalActivitiesGen2V1() error = %v", err)
+	}
+	if got := activities.GetDateOfDay().AsTime(); !got.Equal(day) {
+		t.Errorf("date of day = %v, want %v", got, day)
+	}
+	if got := activities.GetOdometerMidnightKm(); got != 100000 {
+		t.Errorf("odometer midnight = %d, want 100000", got)
+	}
+	if got := len(activities.GetActivityChanges()); got != 1 {
+		t.Fatalf("activity changes = %d, want 1", got)
+	}
+	change := activities.GetActivityChanges()[0]
+	if change.GetActivity() != ddv1.DriverActivityValue_DRIVING || change.GetTimeOfChangeMinutes() != 480 {
+		t.Errorf("activity change = %v", change)
+	}
+	if got := len(activities.GetSpecificConditions()); got != 1 {
+		t.Errorf("specific conditions = %d, want 1", got)
+	}
+	if got := len(activities.GetSignature()); got != 4 {
+		t.Errorf("signature length = %d, want 4", got)
+	}
+	if got, want := len(activities.GetRawData()), len(data); got != want {
+		t.Errorf("raw_data length = %d, want %d", got, want)
+	}
+}
+
+func TestUnmarshalDetailedSpeed(t *testing.T) {
+	begin := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	block := binary.BigEndian.AppendUint32(nil, uint32(begin.Unix()))
+	for i := range 60 {
+		block = append(block, byte(i))
+	}
+
+	t.Run("gen1", func(t *testing.T) {
+		data := binary.BigEndian.AppendUint16(nil, 1)
+		data = append(data, block...)
+		data = append(data, make([]byte, 128)...)
+		detailedSpeed, err := unmarshalDetailedSpeedGen1(dd.UnmarshalOptions{}, data)
+		if err != nil {
+			t.Fatalf("unmarshalDetailedSpeedGen1() error = %v", err)
+		}
+		if got := len(detailedSpeed.GetSpeedBlocks()); got != 1 {
+			t.Fatalf("speed blocks = %d, want 1", got)
+		}
+		speedBlock := detailedSpeed.GetSpeedBlocks()[0]
+		if !speedBlock.GetBeginDate().AsTime().Equal(begin) || speedBlock.GetSpeedsKmh()[59] != 59 {
+			t.Errorf("speed block = %v", speedBlock)
+		}
+		if _, err := unmarshalDetailedSpeedGen1(dd.UnmarshalOptions{}, data[:len(data)-1]); err == nil {
+			t.Error("unmarshalDetailedSpeedGen1() on truncated data: expected error")
+		}
+	})
+
+	t.Run("gen2", func(t *testing.T) {
+		data := appendTestRecordArray(nil, recordTypeVuDetailedSpeedBlock, lenVuDetailedSpeedBlock, block, block)
+		data = appendTestRecordArray(data, recordTypeSignature, 64, make([]byte, 64))
+		detailedSpeed, err := unmarshalDetailedSpeedGen2(dd.UnmarshalOptions{}, data)
+		if err != nil {
+			t.Fatalf("unmarshalDetailedSpeedGen2() error = %v", err)
+		}
+		if got := len(detailedSpeed.GetSpeedBlocks()); got != 2 {
+			t.Errorf("speed blocks = %d, want 2", got)
+		}
+		if got := len(detailedSpeed.GetSignature()); got != 64 {
+			t.Errorf("signature length = %d, want 64", got)
+		}
+	})
+}
+
+func TestUnmarshalActivitiesGen2V2BorderCrossings(t *testing.T) {
+	crossingTime := time.Date(2024, 3, 1, 14, 30, 0, 0, time.UTC)
+	record := make([]byte, 38)          // empty driver and co-driver card numbers
+	record = append(record, 0x0D, 0x01) // left Germany, entered Austria
+	record = binary.BigEndian.AppendUint32(record, uint32(crossingTime.Unix()))
+	record = append(record, 0x05)                               // accuracy
+	record = append(record, 0x07, 0x44, 0x1C, 0x01, 0x24, 0xF8) // coordinates
+	record = append(record, 0x01)                               // authenticated
+	record = append(record, 0x01, 0x86, 0xA0)                   // 100000 km
+	data := appendTestRecordArray(nil, recordTypeVuBorderCrossingRecord, len(record), record)
+
+	activities, err := unmarshalActivitiesGen2V2(dd.UnmarshalOptions{}, data)
+	if err != nil {
+		t.Fatalf("unmarshalActivitiesGen2V2() error = %v", err)
+	}
+	if got := len(activities.GetBorderCrossings()); got != 1 {
+		t.Fatalf("border crossings = %d, want 1", got)
+	}
+	crossing := activities.GetBorderCrossings()[0]
+	if crossing.GetCountryLeft() != ddv1.NationNumeric_GERMANY || crossing.GetCountryEntered() != ddv1.NationNumeric_AUSTRIA {
+		t.Errorf("countries = %v -> %v, want GERMANY -> AUSTRIA", crossing.GetCountryLeft(), crossing.GetCountryEntered())
+	}
+	if got := crossing.GetGnssPlaceAuthRecord().GetTimestamp().AsTime(); !got.Equal(crossingTime) {
+		t.Errorf("crossing time = %v, want %v", got, crossingTime)
+	}
+	if got := crossing.GetOdometerKm(); got != 100000 {
+		t.Errorf("odometer = %d, want 100000", got)
+	}
+	if crossing.HasCardNumberDriverSlot() {
+		t.Errorf("driver slot card number = %v, want none", crossing.GetCardNumberDriverSlot())
+	}
+}
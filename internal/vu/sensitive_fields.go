@@ -0,0 +1,350 @@
+package vu
+
+import (
+	"fmt"
+
+	"github.com/way-platform/tachograph-go/internal/dd"
+	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
+)
+
+// SensitiveFieldKind is the kind of personal or identifying data held by a
+// field of a transfer.
+type SensitiveFieldKind int
+
+const (
+	// SensitiveName is a name or an address, encoded as a StringValue.
+	SensitiveName SensitiveFieldKind = iota + 1
+	// SensitiveCardNumber is the driver identification of a driver card
+	// number, or the owner identification of other card numbers, encoded as
+	// an IA5String.
+	SensitiveCardNumber
+	// SensitiveVehicleIdentificationNumber is a VIN, encoded as an IA5String.
+	SensitiveVehicleIdentificationNumber
+	// SensitiveRegistrationNumber is a vehicle registration number, encoded
+	// as a StringValue.
+	SensitiveRegistrationNumber
+	// SensitiveSerialNumber is an ExtendedSerialNumber.
+	SensitiveSerialNumber
+	// SensitiveGeoCoordinates is a GeoCoordinates.
+	SensitiveGeoCoordinates
+	// SensitiveCertificate is a certificate, which references the equipment
+	// it was issued to.
+	SensitiveCertificate
+	// SensitiveSignature is a signature over the transfer data.
+	SensitiveSignature
+)
+
+// SensitiveField locates a field holding personal or identifying data in the
+// value of a transfer.
+type SensitiveField struct {
+	Kind SensitiveFieldKind
+	// Offset is the offset of the field in the transfer value.
+	Offset int
+	// Length is the length of the field in bytes.
+	Length int
+}
+
+// SensitiveFields returns the fields of a transfer value that hold personal or
+// identifying data, in the order of the value.
+//
+// The fields are located from the layouts of the Data Dictionary, so that they
+// can be replaced in place without re-encoding the transfer. Fields of card
+// numbers are only returned for card numbers that are present. Gen2 record
+// arrays of unknown record types are not inspected.
+func SensitiveFields(transferType vuv1.TransferType, value []byte) ([]SensitiveField, error) {
+	f := &sensitiveFields{data: value}
+	var err error
+	switch transferType {
+	case vuv1.TransferType_DOWNLOAD_INTERFACE_VERSION:
+	case vuv1.TransferType_OVERVIEW_GEN1:
+		err = f.overviewGen1()
+	case vuv1.TransferType_ACTIVITIES_GEN1:
+		err = f.activitiesGen1()
+	case vuv1.TransferType_EVENTS_AND_FAULTS_GEN1:
+		err = f.eventsAndFaultsGen1()
+	case vuv1.TransferType_DETAILED_SPEED_GEN1:
+		err = f.detailedSpeedGen1()
+	case vuv1.TransferType_TECHNICAL_DATA_GEN1:
+		err = f.technicalDataGen1()
+	case vuv1.TransferType_OVERVIEW_GEN2_V1, vuv1.TransferType_OVERVIEW_GEN2_V2,
+		vuv1.TransferType_ACTIVITIES_GEN2_V1, vuv1.TransferType_ACTIVITIES_GEN2_V2,
+		vuv1.TransferType_EVENTS_AND_FAULTS_GEN2_V1, vuv1.TransferType_EVENTS_AND_FAULTS_GEN2_V2,
+		vuv1.TransferType_DETAILED_SPEED_GEN2,
+		vuv1.TransferType_TECHNICAL_DATA_GEN2_V1, vuv1.TransferType_TECHNICAL_DATA_GEN2_V2:
+		err = f.recordArrays()
+	default:
+		return nil, fmt.Errorf("unsupported transfer type: %v", transferType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", transferType, err)
+	}
+	if f.offset > len(value) {
+		return nil, fmt.Errorf("%v: insufficient data: need %d bytes, have %d: %w", transferType, f.offset, len(value), dd.ErrTruncated)
+	}
+	return f.fields, nil
+}
+
+// Sizes of the sensitive data types, see the Data Dictionary.
+const (
+	lenName                   = 36
+	lenFullCardNumber         = 18
+	lenVIN                    = 17
+	lenRegistrationNumber     = 14
+	lenRegistrationWithNation = 15
+	lenExtendedSerialNumber   = 8
+	lenGeoCoordinates         = 6
+	lenCertificateGen1        = 194
+	lenSignatureGen1          = 128
+)
+
+// fieldLayout is the position of a sensitive field in a record.
+type fieldLayout struct {
+	kind   SensitiveFieldKind
+	offset int
+	length int
+}
+
+func nameAt(offset int) fieldLayout {
+	return fieldLayout{kind: SensitiveName, offset: offset, length: lenName}
+}
+
+// cardNumberAt is the position of a FullCardNumber, whose identification is
+// located when the record is read.
+func cardNumberAt(offset int) fieldLayout {
+	return fieldLayout{kind: SensitiveCardNumber, offset: offset, length: lenFullCardNumber}
+}
+
+func vinAt(offset int) fieldLayout {
+	return fieldLayout{kind: SensitiveVehicleIdentificationNumber, offset: offset, length: lenVIN}
+}
+
+func registrationNumberAt(offset int) fieldLayout {
+	return fieldLayout{kind: SensitiveRegistrationNumber, offset: offset, length: lenRegistrationNumber}
+}
+
+func serialNumberAt(offset int) fieldLayout {
+	return fieldLayout{kind: SensitiveSerialNumber, offset: offset, length: lenExtendedSerialNumber}
+}
+
+// gnssPlaceAt is the position of a GNSSPlaceRecord, whose coordinates follow
+// the timestamp and the accuracy.
+func gnssPlaceAt(offset int) fieldLayout {
+	return fieldLayout{kind: SensitiveGeoCoordinates, offset: offset + 5, length: lenGeoCoordinates}
+}
+
+// sensitiveFields collects the sensitive fields of a transfer value.
+type sensitiveFields struct {
+	data   []byte
+	offset int
+	fields []SensitiveField
+}
+
+// record adds the fields of a record of the given size at the current offset,
+// and advances past the record. Fields beyond the record size are ignored.
+func (f *sensitiveFields) record(size int, layout ...fieldLayout) {
+	for _, l := range layout {
+		if l.offset+l.length > size || f.offset+l.offset+l.length > len(f.data) {
+			continue
+		}
+		start := f.offset + l.offset
+		if l.kind == SensitiveCardNumber {
+			f.cardNumber(start)
+			continue
+		}
+		f.fields = append(f.fields, SensitiveField{Kind: l.kind, Offset: start, Length: l.length})
+	}
+	f.offset += size
+}
+
+// cardNumber adds the identification of the FullCardNumber at offset.
+//
+// The identification follows the card type (EquipmentType) and the issuing
+// member state. It is the 14-character driver identification of driver cards,
+// and the 13-character owner identification of workshop, control and company
+// cards.
+func (f *sensitiveFields) cardNumber(offset int) {
+	var length int
+	switch f.data[offset] {
+	case 0x01:
+		length = 14
+	case 0x02, 0x03, 0x04:
+		length = 13
+	default:
+		return
+	}
+	f.fields = append(f.fields, SensitiveField{Kind: SensitiveCardNumber, Offset: offset + 2, Length: length})
+}
+
+// records adds the fields of the records of a Gen1 record list, which starts
+// with a count of countSize bytes.
+func (f *sensitiveFields) records(name string, countSize, recordSize int, layout ...fieldLayout) error {
+	if len(f.data)-f.offset < countSize {
+		return fmt.Errorf("insufficient data for noOf%s: %w", name, dd.ErrTruncated)
+	}
+	count := 0
+	for _, b := range f.data[f.offset : f.offset+countSize] {
+		count = count<<8 | int(b)
+	}
+	f.offset += countSize
+	for range count {
+		f.record(recordSize, layout...)
+	}
+	return nil
+}
+
+// signatureGen1 adds the RSA signature that ends a Gen1 transfer.
+func (f *sensitiveFields) signatureGen1() {
+	f.record(lenSignatureGen1, fieldLayout{kind: SensitiveSignature, length: lenSignatureGen1})
+}
+
+// overviewGen1 adds the fields of a Gen1 Overview transfer, see
+// [sizeOfOverviewGen1] for its layout.
+func (f *sensitiveFields) overviewGen1() error {
+	certificate := fieldLayout{kind: SensitiveCertificate, length: lenCertificateGen1}
+	f.record(lenCertificateGen1, certificate) // MemberStateCertificate
+	f.record(lenCertificateGen1, certificate) // VuCertificate
+	f.record(lenVIN, vinAt(0))
+	f.record(lenRegistrationWithNation, registrationNumberAt(1))
+	f.record(4 + 8 + 1) // CurrentDateTime, VuDownloadablePeriod, CardSlotsStatus
+	f.record(4+lenFullCardNumber+lenName, cardNumberAt(4), nameAt(22))
+	if err := f.records("Locks", 1, 98, nameAt(8), nameAt(44), cardNumberAt(80)); err != nil {
+		return err
+	}
+	if err := f.records("Controls", 1, 31, cardNumberAt(5)); err != nil {
+		return err
+	}
+	f.signatureGen1()
+	return nil
+}
+
+// activitiesGen1 adds the fields of a Gen1 Activities transfer, see
+// [sizeOfActivitiesGen1] for its layout.
+func (f *sensitiveFields) activitiesGen1() error {
+	f.record(4 + 3) // TimeReal, OdometerValueMidnight
+	if err := f.records("IWRecords", 2, 129, nameAt(0), nameAt(36), cardNumberAt(72), registrationNumberAt(110)); err != nil {
+		return err
+	}
+	if err := f.records("ActivityChanges", 2, 2); err != nil {
+		return err
+	}
+	if err := f.records("PlaceRecords", 1, 28, cardNumberAt(0)); err != nil {
+		return err
+	}
+	if err := f.records("SpecificConditionRecords", 2, 5); err != nil {
+		return err
+	}
+	f.signatureGen1()
+	return nil
+}
+
+// eventsAndFaultsGen1 adds the fields of a Gen1 Events and Faults transfer, see
+// [sizeOfEventsAndFaultsGen1] for its layout.
+func (f *sensitiveFields) eventsAndFaultsGen1() error {
+	cards := []fieldLayout{cardNumberAt(10), cardNumberAt(28), cardNumberAt(46), cardNumberAt(64)}
+	if err := f.records("VuFaults", 1, 82, cards...); err != nil {
+		return err
+	}
+	if err := f.records("VuEvents", 1, 83, cards...); err != nil {
+		return err
+	}
+	f.record(9) // VuOverSpeedingControlData
+	if err := f.records("VuOverSpeedingEvents", 1, 31, cardNumberAt(12)); err != nil {
+		return err
+	}
+	if err := f.records("VuTimeAdjRecords", 1, 98, nameAt(8), nameAt(44), cardNumberAt(80)); err != nil {
+		return err
+	}
+	f.signatureGen1()
+	return nil
+}
+
+// detailedSpeedGen1 adds the fields of a Gen1 Detailed Speed transfer, see
+// [sizeOfDetailedSpeedGen1] for its layout.
+func (f *sensitiveFields) detailedSpeedGen1() error {
+	if err := f.records("SpeedBlocks", 2, 64); err != nil {
+		return err
+	}
+	f.signatureGen1()
+	return nil
+}
+
+// technicalDataGen1 adds the fields of a Gen1 Technical Data transfer, see
+// [sizeOfTechnicalDataGen1] for its layout.
+func (f *sensitiveFields) technicalDataGen1() error {
+	f.record(116, serialNumberAt(88)) // VuIdentification
+	f.record(20, serialNumberAt(0))   // SensorPaired
+	if err := f.records("VuCalibrationRecords", 1, 167,
+		nameAt(1), nameAt(37), cardNumberAt(73), vinAt(95), registrationNumberAt(113),
+	); err != nil {
+		return err
+	}
+	f.signatureGen1()
+	return nil
+}
+
+// recordLayoutsGen2 are the positions of the sensitive fields in the records
+// of Gen2 record arrays, by record type.
+//
+// Records hold a FullCardNumberAndGeneration where Gen1 records hold a
+// FullCardNumber, so the offsets differ from the Gen1 layouts.
+var recordLayoutsGen2 = map[byte][]fieldLayout{
+	recordTypeVehicleIdentificationNumber:  {vinAt(0)},
+	recordTypeVuDownloadActivityData:       {cardNumberAt(4), nameAt(23)},
+	recordTypeVuCompanyLocksRecord:         {nameAt(8), nameAt(44), cardNumberAt(80)},
+	recordTypeVuControlActivityRecord:      {cardNumberAt(5)},
+	recordTypeVuCardIWRecord:               {nameAt(0), nameAt(36), cardNumberAt(72), registrationNumberAt(111)},
+	recordTypeVuPlaceDailyWorkPeriodRecord: {cardNumberAt(0), gnssPlaceAt(29)},
+	recordTypeVuGNSSADRecord:               {cardNumberAt(4), cardNumberAt(23), gnssPlaceAt(42)},
+	recordTypeVuBorderCrossingRecord:       {cardNumberAt(0), cardNumberAt(19), gnssPlaceAt(40)},
+	recordTypeVuLoadUnloadRecord:           {cardNumberAt(5), cardNumberAt(24), gnssPlaceAt(43)},
+	recordTypeVuFaultRecord:                {cardNumberAt(10), cardNumberAt(29), cardNumberAt(48), cardNumberAt(67)},
+	recordTypeVuEventRecord:                {cardNumberAt(10), cardNumberAt(29), cardNumberAt(48), cardNumberAt(67)},
+	recordTypeVuOverSpeedingEventRecord:    {cardNumberAt(12)},
+	recordTypeVuTimeAdjustmentRecord:       {nameAt(8), nameAt(44), cardNumberAt(80)},
+	recordTypeVuPowerSupplyInterruptionRecord: {
+		cardNumberAt(10), cardNumberAt(29), cardNumberAt(48), cardNumberAt(67),
+	},
+	recordTypeVuIdentification:                {serialNumberAt(88)},
+	recordTypeSensorPairedRecord:              {serialNumberAt(0)},
+	recordTypeSensorExternalGNSSCoupledRecord: {serialNumberAt(0)},
+	recordTypeVuCalibrationRecord: {
+		nameAt(1), nameAt(37), cardNumberAt(73), vinAt(96), registrationNumberAt(114),
+		serialNumberAt(168), serialNumberAt(176), serialNumberAt(184),
+	},
+	recordTypeVuCardRecord:       {cardNumberAt(0), serialNumberAt(19)},
+	recordTypeVuITSConsentRecord: {cardNumberAt(0)},
+}
+
+// recordArrays adds the fields of a Gen2 transfer, which is a sequence of
+// record arrays.
+func (f *sensitiveFields) recordArrays() error {
+	for f.offset < len(f.data) {
+		ra, next, err := readRecordArray(f.data, f.offset)
+		if err != nil {
+			return err
+		}
+		f.offset = ra.offset
+		var layout []fieldLayout
+		switch ra.recordType {
+		case recordTypeMemberStateCertificate, recordTypeVuCertificate:
+			layout = []fieldLayout{{kind: SensitiveCertificate, length: ra.recordSize}}
+		case recordTypeSignature:
+			layout = []fieldLayout{{kind: SensitiveSignature, length: ra.recordSize}}
+		case recordTypeVehicleRegistrationNumber, recordTypeVehicleRegistrationIdentification:
+			// The registration number may be preceded by the registering
+			// nation, see unmarshalOverviewGen2V2.
+			if ra.recordSize >= lenRegistrationWithNation {
+				layout = []fieldLayout{registrationNumberAt(1)}
+			} else {
+				layout = []fieldLayout{registrationNumberAt(0)}
+			}
+		default:
+			layout = recordLayoutsGen2[ra.recordType]
+		}
+		for range ra.records {
+			f.record(ra.recordSize, layout...)
+		}
+		f.offset = next
+	}
+	return nil
+}
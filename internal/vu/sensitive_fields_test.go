@@ -0,0 +1,74 @@
+package vu
+
+import (
+	"bytes"
+	"testing"
+
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+)
+
+func TestSensitiveFields(t *testing.T) {
+	sensitive := [][]byte{
+		[]byte("MUSTERMANN"),
+		[]byte("D1234567890123"),
+		[]byte("C123456789012"),
+		[]byte("W123456789012"),
+		[]byte("WDB9634031L123456"),
+		[]byte("M-AB 1234"),
+		[]byte("M-XY 987"),
+		[]byte("Werkstatt Beispiel"),
+		[]byte("Hauptstrasse 1"),
+		[]byte("Spedition Muster"),
+		[]byte("Lagerweg 7"),
+		{0x00, 0x12, 0x34, 0x56}, // VU serial number
+		{0x00, 0x65, 0x43, 0x21}, // sensor serial number
+		{0x00, 0xBB, 0xD2},       // latitude
+		{0xC1, 0xC1, 0xC1, 0xC1}, // member state certificate
+		{0xC2, 0xC2, 0xC2, 0xC2}, // VU certificate
+		{0x5A, 0x5A, 0x5A, 0x5A}, // signature
+	}
+	for _, tt := range []struct {
+		name string
+		data []byte
+	}{
+		{name: "gen1", data: testVehicleUnitFileGen1()},
+		{name: "gen2_v1", data: testVehicleUnitFileGen2(ddv1.Version_VERSION_1)},
+		{name: "gen2_v2", data: testVehicleUnitFileGen2(ddv1.Version_VERSION_2)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rawFile, err := ScanRawVehicleUnitFile(tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			kinds := map[SensitiveFieldKind]int{}
+			var cleared []byte
+			for _, record := range rawFile.GetRecords() {
+				value := bytes.Clone(record.GetValue())
+				fields, err := SensitiveFields(record.GetType(), value)
+				if err != nil {
+					t.Fatalf("SensitiveFields(%v) error = %v", record.GetType(), err)
+				}
+				previousEnd := 0
+				for _, field := range fields {
+					if field.Offset < previousEnd {
+						t.Errorf("%v: field %+v overlaps the previous field", record.GetType(), field)
+					}
+					previousEnd = field.Offset + field.Length
+					kinds[field.Kind]++
+					clear(value[field.Offset : field.Offset+field.Length])
+				}
+				cleared = append(cleared, value...)
+			}
+			for _, s := range sensitive {
+				if bytes.Contains(cleared, s) {
+					t.Errorf("data without sensitive fields contains %q", s)
+				}
+			}
+			for kind := SensitiveName; kind <= SensitiveSignature; kind++ {
+				if kinds[kind] == 0 && !(tt.name == "gen1" && kind == SensitiveGeoCoordinates) {
+					t.Errorf("no fields of kind %d", kind)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,685 @@
+package vu
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
+)
+
+// Synthetic VU downloads.
+//
+// The synthetic files are built from the Data Dictionary layouts and checked
+// in under testdata/vu, so that the golden tests of this package and the tests
+// of the root package cover the Gen2 parsers without a real download.
+
+// Record types of the Gen2 record arrays that are only used by the tests.
+const (
+	testRecordTypeCardSlotsStatus            byte = 0x02
+	testRecordTypeCurrentDateTime            byte = 0x03
+	testRecordTypeVuDownloadablePeriod       byte = 0x13
+	testRecordTypeVuTimeAdjustmentGNSSRecord byte = 0x1D
+)
+
+var (
+	testDownloadTime = time.Date(2024, 3, 2, 9, 30, 0, 0, time.UTC)
+	testDay          = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func appendTestTime(dst []byte, t time.Time) []byte {
+	return binary.BigEndian.AppendUint32(dst, uint32(t.Unix()))
+}
+
+func appendTestOdometer(dst []byte, km uint32) []byte {
+	return append(dst, byte(km>>16), byte(km>>8), byte(km))
+}
+
+// appendTestStringValue appends a Latin-1 StringValue of the given length.
+func appendTestStringValue(dst []byte, s string, size int) []byte {
+	dst = append(dst, 0x01)
+	return append(dst, fmt.Sprintf("%-*s", size-1, s)...)
+}
+
+// appendTestCardNumber appends the FullCardNumberAndGeneration of a Gen2
+// driver card issued in Germany.
+func appendTestCardNumber(dst []byte, driverIdentification string) []byte {
+	dst = append(dst, 0x01, 0x0D)
+	dst = append(dst, driverIdentification+"01"...)
+	return append(dst, 0x02)
+}
+
+// appendTestGNSSPlace appends a GNSSPlaceRecord, optionally followed by an
+// authentication status.
+func appendTestGNSSPlace(dst []byte, t time.Time, authenticated *bool) []byte {
+	dst = appendTestTime(dst, t)
+	dst = append(dst, 0x05)                               // accuracy
+	dst = append(dst, 0x00, 0xBB, 0xD2, 0x00, 0x2C, 0x51) // 48°08.2'N 11°34.5'E
+	if authenticated != nil {
+		if *authenticated {
+			dst = append(dst, 0x01)
+		} else {
+			dst = append(dst, 0x00)
+		}
+	}
+	return dst
+}
+
+func testFilledBytes(b byte, n int) []byte {
+	return bytes.Repeat([]byte{b}, n)
+}
+
+// appendTestTransfer appends a transfer with the TREP of the transfer type.
+func appendTestTransfer(dst []byte, trep byte, value []byte) []byte {
+	dst = append(dst, 0x76, trep)
+	return append(dst, value...)
+}
+
+// testOverviewGen2 returns the value of a Gen2 Overview transfer.
+func testOverviewGen2(version ddv1.Version) []byte {
+	var data []byte
+	data = appendTestRecordArray(data, recordTypeMemberStateCertificate, 204, testFilledBytes(0xC1, 204))
+	data = appendTestRecordArray(data, recordTypeVuCertificate, 204, testFilledBytes(0xC2, 204))
+	data = appendTestRecordArray(data, recordTypeVehicleIdentificationNumber, 17, []byte("WDB9634031L123456"))
+	if version == ddv1.Version_VERSION_2 {
+		vrn := appendTestStringValue([]byte{0x0D}, "M-AB 1234", 14)
+		data = appendTestRecordArray(data, recordTypeVehicleRegistrationNumber, len(vrn), vrn)
+	} else {
+		vrn := appendTestStringValue([]byte{0x0D}, "M-AB 1234", 14)
+		data = appendTestRecordArray(data, recordTypeVehicleRegistrationIdentification, len(vrn), vrn)
+	}
+	data = appendTestRecordArray(data, testRecordTypeCurrentDateTime, 4, appendTestTime(nil, testDownloadTime))
+	period := appendTestTime(nil, testDay.AddDate(0, 0, -28))
+	period = appendTestTime(period, testDownloadTime)
+	data = appendTestRecordArray(data, testRecordTypeVuDownloadablePeriod, 8, period)
+	data = appendTestRecordArray(data, testRecordTypeCardSlotsStatus, 1, []byte{0x41}) // driver card, company card
+	downloadActivity := appendTestTime(nil, testDay.AddDate(0, 0, -28))
+	downloadActivity = appendTestCardNumber(downloadActivity, "C123456789012 ")
+	downloadActivity = appendTestStringValue(downloadActivity, "Spedition Muster GmbH", 36)
+	data = appendTestRecordArray(data, recordTypeVuDownloadActivityData, len(downloadActivity), downloadActivity)
+	data = appendTestRecordArray(data, recordTypeVuCompanyLocksRecord, 99)
+	data = appendTestRecordArray(data, recordTypeVuControlActivityRecord, 32)
+	return appendTestRecordArray(data, recordTypeSignature, 64, testFilledBytes(0x5A, 64))
+}
+
+// testActivitiesGen2 returns the value of a Gen2 Activities transfer.
+func testActivitiesGen2(version ddv1.Version) []byte {
+	v2 := version == ddv1.Version_VERSION_2
+	authenticated := true
+	var auth *bool
+	if v2 {
+		auth = &authenticated
+	}
+	var data []byte
+	data = appendTestRecordArray(data, recordTypeDateOfDayDownloaded, 4, appendTestTime(nil, testDay))
+	data = appendTestRecordArray(data, recordTypeOdometerValueMidnight, 3, appendTestOdometer(nil, 100000))
+
+	cardIW := appendTestStringValue(nil, "MUSTERMANN", 36)
+	cardIW = appendTestStringValue(cardIW, "MAX", 36)
+	cardIW = appendTestCardNumber(cardIW, "D1234567890123")
+	cardIW = append(cardIW, 0x20, 0x29, 0x12, 0x31) // expiry date 2029-12-31
+	cardIW = appendTestTime(cardIW, testDay.Add(7*time.Hour+45*time.Minute))
+	cardIW = appendTestOdometer(cardIW, 100010)
+	cardIW = append(cardIW, 0x00) // driver slot
+	cardIW = appendTestTime(cardIW, testDay.Add(17*time.Hour))
+	cardIW = appendTestOdometer(cardIW, 100420)
+	cardIW = appendTestStringValue(append(cardIW, 0x0D), "M-XY 987", 14)
+	cardIW = appendTestTime(cardIW, testDay.Add(-10*time.Hour))
+	cardIW = append(cardIW, 0x02) // previous VU generation
+	cardIW = append(cardIW, 0x00) // no manual input
+	data = appendTestRecordArray(data, recordTypeVuCardIWRecord, len(cardIW), cardIW)
+
+	// Driver slot: available from midnight, driving from 08:00, break from 12:30.
+	data = appendTestRecordArray(data, recordTypeActivityChangeInfo, 2,
+		[]byte{0x08, 0x00},
+		[]byte{0x19, 0xE0},
+		[]byte{0x02, 0xEE},
+	)
+
+	place := appendTestCardNumber(nil, "D1234567890123")
+	place = appendTestTime(place, testDay.Add(7*time.Hour+45*time.Minute))
+	place = append(place, 0x00, 0x0D, 0x00) // begin, Germany, no region
+	place = appendTestOdometer(place, 100010)
+	place = appendTestGNSSPlace(place, testDay.Add(7*time.Hour+44*time.Minute), auth)
+	data = appendTestRecordArray(data, recordTypeVuPlaceDailyWorkPeriodRecord, len(place), place)
+
+	gnssAD := appendTestTime(nil, testDay.Add(11*time.Hour))
+	gnssAD = appendTestCardNumber(gnssAD, "D1234567890123")
+	gnssAD = append(gnssAD, make([]byte, 19)...) // no co-driver card
+	gnssAD = appendTestGNSSPlace(gnssAD, testDay.Add(11*time.Hour), auth)
+	gnssAD = appendTestOdometer(gnssAD, 100230)
+	data = appendTestRecordArray(data, recordTypeVuGNSSADRecord, len(gnssAD), gnssAD)
+
+	specificCondition := appendTestTime(nil, testDay.Add(16*time.Hour))
+	specificCondition = append(specificCondition, 0x02) // out of scope end
+	data = appendTestRecordArray(data, recordTypeSpecificConditionRecord, 5, specificCondition)
+
+	if v2 {
+		borderCrossing := appendTestCardNumber(nil, "D1234567890123")
+		borderCrossing = append(borderCrossing, make([]byte, 19)...)
+		borderCrossing = append(borderCrossing, 0x0D, 0x01) // left Germany, entered Austria
+		borderCrossing = appendTestGNSSPlace(borderCrossing, testDay.Add(14*time.Hour+30*time.Minute), auth)
+		borderCrossing = appendTestOdometer(borderCrossing, 100310)
+		data = appendTestRecordArray(data, recordTypeVuBorderCrossingRecord, len(borderCrossing), borderCrossing)
+		data = appendTestRecordArray(data, recordTypeVuLoadUnloadRecord, 60)
+	}
+	return appendTestRecordArray(data, recordTypeSignature, 64, testFilledBytes(0x5B, 64))
+}
+
+// testEventsAndFaultsGen2 returns the value of a Gen2 Events and Faults transfer.
+func testEventsAndFaultsGen2(version ddv1.Version) []byte {
+	var data []byte
+
+	fault := []byte{0x30, 0x00} // recording equipment fault, ten most recent
+	fault = appendTestTime(fault, testDay.Add(6*time.Hour))
+	fault = appendTestTime(fault, testDay.Add(6*time.Hour+5*time.Minute))
+	fault = append(fault, make([]byte, 4*19)...) // no cards inserted
+	data = appendTestRecordArray(data, recordTypeVuFaultRecord, len(fault), fault)
+
+	event := []byte{0x05, 0x03} // card insertion while driving, last in last 10 days
+	event = appendTestTime(event, testDay.Add(7*time.Hour+45*time.Minute))
+	event = appendTestTime(event, testDay.Add(7*time.Hour+46*time.Minute))
+	event = appendTestCardNumber(event, "D1234567890123")
+	event = append(event, make([]byte, 19)...)
+	event = appendTestCardNumber(event, "D1234567890123")
+	event = append(event, make([]byte, 19)...)
+	event = append(event, 0x02) // similar events
+	data = appendTestRecordArray(data, recordTypeVuEventRecord, len(event), event)
+
+	control := appendTestTime(nil, testDay.AddDate(0, 0, -7))
+	control = appendTestTime(control, testDay.Add(13*time.Hour))
+	control = append(control, 0x01)
+	data = appendTestRecordArray(data, recordTypeVuOverSpeedingControlData, len(control), control)
+
+	overSpeeding := []byte{0x07, 0x01} // over speeding, longest in last 10 days
+	overSpeeding = appendTestTime(overSpeeding, testDay.Add(13*time.Hour))
+	overSpeeding = appendTestTime(overSpeeding, testDay.Add(13*time.Hour+2*time.Minute))
+	overSpeeding = append(overSpeeding, 97, 92)
+	overSpeeding = appendTestCardNumber(overSpeeding, "D1234567890123")
+	overSpeeding = append(overSpeeding, 0x01)
+	data = appendTestRecordArray(data, recordTypeVuOverSpeedingEventRecord, len(overSpeeding), overSpeeding)
+
+	timeAdjustment := appendTestTime(nil, testDay.AddDate(0, 0, -3).Add(10*time.Hour))
+	timeAdjustment = appendTestTime(timeAdjustment, testDay.AddDate(0, 0, -3).Add(10*time.Hour+2*time.Minute))
+	timeAdjustment = appendTestStringValue(timeAdjustment, "Werkstatt Beispiel", 36)
+	timeAdjustment = appendTestStringValue(timeAdjustment, "Hauptstrasse 1, Muenchen", 36)
+	timeAdjustment = append(timeAdjustment, 0x02, 0x0D)
+	timeAdjustment = append(timeAdjustment, "W1234567890120"+"01"...)
+	timeAdjustment = append(timeAdjustment, 0x02)
+	data = appendTestRecordArray(data, recordTypeVuTimeAdjustmentRecord, len(timeAdjustment), timeAdjustment)
+	if version == ddv1.Version_VERSION_2 {
+		data = appendTestRecordArray(data, testRecordTypeVuTimeAdjustmentGNSSRecord, 8)
+	}
+
+	return appendTestRecordArray(data, recordTypeSignature, 64, testFilledBytes(0x5C, 64))
+}
+
+// testDetailedSpeedGen2 returns the value of a Gen2 Detailed Speed transfer.
+func testDetailedSpeedGen2() []byte {
+	var blocks [][]byte
+	for i := range 2 {
+		block := appendTestTime(nil, testDay.Add(8*time.Hour+time.Duration(i)*time.Minute))
+		for s := range 60 {
+			block = append(block, byte(60+i*20+s/3))
+		}
+		blocks = append(blocks, block)
+	}
+	data := appendTestRecordArray(nil, recordTypeVuDetailedSpeedBlock, lenVuDetailedSpeedBlock, blocks...)
+	return appendTestRecordArray(data, recordTypeSignature, 64, testFilledBytes(0x5D, 64))
+}
+
+// testTechnicalDataGen2 returns the value of a Gen2 Technical Data transfer.
+func testTechnicalDataGen2(version ddv1.Version) []byte {
+	v2 := version == ddv1.Version_VERSION_2
+	identification := appendTestStringValue(nil, "Tacho Manufacturer", 36)
+	identification = appendTestStringValue(identification, "Industriestrasse 5, Villingen", 36)
+	identification = append(identification, fmt.Sprintf("%-16s", "1381.2050000")...)
+	identification = append(identification, 0x00, 0x12, 0x34, 0x56, 0x01, 0x24, 0x09, 0x21) // serial number
+	identification = append(identification, "0400"...)
+	identification = appendTestTime(identification, testDay.AddDate(-1, 0, 0))
+	identification = appendTestTime(identification, testDay.AddDate(-2, 0, 0))
+	identification = append(identification, fmt.Sprintf("%-16s", "e1-0084")...)
+	identification = append(identification, 0x02, 0x00) // Gen2, ability
+	if v2 {
+		identification = append(identification, fmt.Sprintf("%-12s", "EU-2024.1")...)
+	}
+	sensorPaired := []byte{0x00, 0x65, 0x43, 0x21, 0x01, 0x23, 0x09, 0x20}
+	sensorPaired = append(sensorPaired, fmt.Sprintf("%-16s", "e1-0085")...)
+	sensorPaired = appendTestTime(sensorPaired, testDay.AddDate(-1, 0, 0))
+
+	// Calibration records are 237 bytes: purpose, workshop name and address,
+	// workshop card, VIN, VRN and the calibration parameters.
+	calibration := []byte{0x03} // periodic inspection
+	calibration = appendTestStringValue(calibration, "Werkstatt Beispiel", 36)
+	calibration = appendTestStringValue(calibration, "Hauptstrasse 1, Muenchen", 36)
+	calibration = append(calibration, 0x02, 0x0D)
+	calibration = append(calibration, "W1234567890120"+"01"...)
+	calibration = append(calibration, 0x02)
+	calibration = append(calibration, 0x20, 0x28, 0x06, 0x30) // workshop card expiry
+	calibration = append(calibration, "WDB9634031L123456"...)
+	calibration = appendTestStringValue(append(calibration, 0x0D), "M-AB 1234", 14)
+	calibration = append(calibration, make([]byte, 237-len(calibration))...)
+
+	var data []byte
+	data = appendTestRecordArray(data, recordTypeVuIdentification, len(identification), identification)
+	data = appendTestRecordArray(data, recordTypeSensorPairedRecord, len(sensorPaired), sensorPaired)
+	if v2 {
+		data = appendTestRecordArray(data, recordTypeSensorExternalGNSSCoupledRecord, 28)
+	}
+	data = appendTestRecordArray(data, recordTypeVuCalibrationRecord, len(calibration), calibration)
+	if v2 {
+		data = appendTestRecordArray(data, recordTypeVuITSConsentRecord, 20)
+		data = appendTestRecordArray(data, recordTypeVuPowerSupplyInterruptionRecord, 82)
+	}
+	return appendTestRecordArray(data, recordTypeSignature, 64, testFilledBytes(0x5E, 64))
+}
+
+// testVehicleUnitFileGen2 returns a synthetic Gen2 VU download of the given version.
+func testVehicleUnitFileGen2(version ddv1.Version) []byte {
+	var data []byte
+	trep := byte(0x20)
+	if version == ddv1.Version_VERSION_2 {
+		trep = 0x30
+		data = appendTestTransfer(data, 0x00, []byte{0x01, 0x01})
+	}
+	data = appendTestTransfer(data, trep|0x01, testOverviewGen2(version))
+	data = appendTestTransfer(data, trep|0x02, testActivitiesGen2(version))
+	data = appendTestTransfer(data, trep|0x03, testEventsAndFaultsGen2(version))
+	data = appendTestTransfer(data, 0x24, testDetailedSpeedGen2())
+	return appendTestTransfer(data, trep|0x05, testTechnicalDataGen2(version))
+}
+
+// appendTestCardNumberGen1 appends the FullCardNumber of a Gen1 card issued in
+// Germany.
+func appendTestCardNumberGen1(dst []byte, cardType byte, identification string) []byte {
+	dst = append(dst, cardType, 0x0D)
+	return append(dst, fmt.Sprintf("%-16s", identification)...)
+}
+
+// testOverviewGen1 returns the value of a Gen1 Overview transfer.
+func testOverviewGen1() []byte {
+	var data []byte
+	data = append(data, testFilledBytes(0xC1, 194)...) // member state certificate
+	data = append(data, testFilledBytes(0xC2, 194)...) // VU certificate
+	data = append(data, "WDB9634031L123456"...)
+	data = appendTestStringValue(append(data, 0x0D), "M-AB 1234", 14)
+	data = appendTestTime(data, testDownloadTime)
+	data = appendTestTime(data, testDay.AddDate(0, 0, -28))
+	data = appendTestTime(data, testDownloadTime)
+	data = append(data, 0x41) // driver card, company card
+	data = appendTestTime(data, testDay.AddDate(0, 0, -28))
+	data = appendTestCardNumberGen1(data, 0x04, "C123456789012000")
+	data = appendTestStringValue(data, "Spedition Muster GmbH", 36)
+	data = append(data, 1) // company locks
+	data = appendTestTime(data, testDay.AddDate(-1, 0, 0))
+	data = append(data, 0x00, 0x00, 0x00, 0x00) // not locked out
+	data = appendTestStringValue(data, "Spedition Muster GmbH", 36)
+	data = appendTestStringValue(data, "Lagerweg 7, Augsburg", 36)
+	data = appendTestCardNumberGen1(data, 0x04, "C123456789012000")
+	data = append(data, 0) // control activities
+	return append(data, testFilledBytes(0x5A, 128)...)
+}
+
+// testActivitiesGen1 returns the value of a Gen1 Activities transfer.
+func testActivitiesGen1() []byte {
+	data := appendTestTime(nil, testDay)
+	data = appendTestOdometer(data, 100000)
+
+	data = binary.BigEndian.AppendUint16(data, 1) // card IW records
+	data = appendTestStringValue(data, "MUSTERMANN", 36)
+	data = appendTestStringValue(data, "MAX", 36)
+	data = appendTestCardNumberGen1(data, 0x01, "D1234567890123"+"01")
+	data = append(data, 0x20, 0x29, 0x12, 0x31) // expiry date 2029-12-31
+	data = appendTestTime(data, testDay.Add(7*time.Hour+45*time.Minute))
+	data = appendTestOdometer(data, 100010)
+	data = append(data, 0x00) // driver slot
+	data = appendTestTime(data, testDay.Add(17*time.Hour))
+	data = appendTestOdometer(data, 100420)
+	data = appendTestStringValue(append(data, 0x0D), "M-XY 987", 14)
+	data = appendTestTime(data, testDay.Add(-10*time.Hour))
+	data = append(data, 0x00) // no manual input
+
+	// Driver slot: available from midnight, driving from 08:00, break from 12:30.
+	data = binary.BigEndian.AppendUint16(data, 3)
+	data = append(data, 0x08, 0x00, 0x19, 0xE0, 0x02, 0xEE)
+
+	data = append(data, 1) // places
+	data = appendTestCardNumberGen1(data, 0x01, "D1234567890123"+"01")
+	data = appendTestTime(data, testDay.Add(7*time.Hour+45*time.Minute))
+	data = append(data, 0x00, 0x0D, 0x00) // begin, Germany, no region
+	data = appendTestOdometer(data, 100010)
+
+	data = binary.BigEndian.AppendUint16(data, 1) // specific conditions
+	data = appendTestTime(data, testDay.Add(16*time.Hour))
+	data = append(data, 0x02) // out of scope end
+	return append(data, testFilledBytes(0x5B, 128)...)
+}
+
+// testEventsAndFaultsGen1 returns the value of a Gen1 Events and Faults transfer.
+func testEventsAndFaultsGen1() []byte {
+	data := []byte{1, 0x30, 0x00} // a recording equipment fault
+	data = appendTestTime(data, testDay.Add(6*time.Hour))
+	data = appendTestTime(data, testDay.Add(6*time.Hour+5*time.Minute))
+	data = append(data, make([]byte, 4*18)...) // no cards inserted
+
+	data = append(data, 1, 0x05, 0x03) // a card insertion while driving
+	data = appendTestTime(data, testDay.Add(7*time.Hour+45*time.Minute))
+	data = appendTestTime(data, testDay.Add(7*time.Hour+46*time.Minute))
+	data = appendTestCardNumberGen1(data, 0x01, "D1234567890123"+"01")
+	data = append(data, make([]byte, 18)...)
+	data = appendTestCardNumberGen1(data, 0x01, "D1234567890123"+"01")
+	data = append(data, make([]byte, 18)...)
+	data = append(data, 0x02) // similar events
+
+	data = appendTestTime(data, testDay.AddDate(0, 0, -7))
+	data = appendTestTime(data, testDay.Add(13*time.Hour))
+	data = append(data, 0x01)
+
+	data = append(data, 1, 0x07, 0x01) // an over speeding event
+	data = appendTestTime(data, testDay.Add(13*time.Hour))
+	data = appendTestTime(data, testDay.Add(13*time.Hour+2*time.Minute))
+	data = append(data, 97, 92)
+	data = appendTestCardNumberGen1(data, 0x01, "D1234567890123"+"01")
+	data = append(data, 0x01)
+
+	data = append(data, 1) // time adjustments
+	data = appendTestTime(data, testDay.AddDate(0, 0, -3).Add(10*time.Hour))
+	data = appendTestTime(data, testDay.AddDate(0, 0, -3).Add(10*time.Hour+2*time.Minute))
+	data = appendTestStringValue(data, "Werkstatt Beispiel", 36)
+	data = appendTestStringValue(data, "Hauptstrasse 1, Muenchen", 36)
+	data = appendTestCardNumberGen1(data, 0x02, "W123456789012001")
+	return append(data, testFilledBytes(0x5C, 128)...)
+}
+
+// testDetailedSpeedGen1 returns the value of a Gen1 Detailed Speed transfer.
+func testDetailedSpeedGen1() []byte {
+	data := binary.BigEndian.AppendUint16(nil, 2)
+	for i := range 2 {
+		data = appendTestTime(data, testDay.Add(8*time.Hour+time.Duration(i)*time.Minute))
+		for s := range 60 {
+			data = append(data, byte(60+i*20+s/3))
+		}
+	}
+	return append(data, testFilledBytes(0x5D, 128)...)
+}
+
+// testTechnicalDataGen1 returns the value of a Gen1 Technical Data transfer.
+func testTechnicalDataGen1() []byte {
+	data := appendTestStringValue(nil, "Tacho Manufacturer", 36)
+	data = appendTestStringValue(data, "Industriestrasse 5, Villingen", 36)
+	data = append(data, fmt.Sprintf("%-16s", "1381.2050000")...)
+	data = append(data, 0x00, 0x12, 0x34, 0x56, 0x01, 0x24, 0x09, 0x21) // serial number
+	data = append(data, "0400"...)
+	data = appendTestTime(data, testDay.AddDate(-1, 0, 0))
+	data = appendTestTime(data, testDay.AddDate(-2, 0, 0))
+	data = append(data, fmt.Sprintf("%-8s", "e1-84")...)
+
+	data = append(data, 0x00, 0x65, 0x43, 0x21, 0x01, 0x23, 0x09, 0x20) // sensor serial number
+	data = append(data, fmt.Sprintf("%-8s", "e1-85")...)
+	data = appendTestTime(data, testDay.AddDate(-1, 0, 0))
+
+	data = append(data, 1)      // calibrations
+	calibration := []byte{0x03} // periodic inspection
+	calibration = appendTestStringValue(calibration, "Werkstatt Beispiel", 36)
+	calibration = appendTestStringValue(calibration, "Hauptstrasse 1, Muenchen", 36)
+	calibration = appendTestCardNumberGen1(calibration, 0x02, "W123456789012001")
+	calibration = append(calibration, 0x20, 0x28, 0x06, 0x30) // workshop card expiry
+	calibration = append(calibration, "WDB9634031L123456"...)
+	calibration = appendTestStringValue(append(calibration, 0x0D), "M-AB 1234", 14)
+	calibration = append(calibration, make([]byte, 167-len(calibration))...)
+	data = append(data, calibration...)
+	return append(data, testFilledBytes(0x5E, 128)...)
+}
+
+// testVehicleUnitFileGen1 returns a synthetic Gen1 VU download.
+func testVehicleUnitFileGen1() []byte {
+	var data []byte
+	data = appendTestTransfer(data, 0x01, testOverviewGen1())
+	data = appendTestTransfer(data, 0x02, testActivitiesGen1())
+	data = appendTestTransfer(data, 0x03, testEventsAndFaultsGen1())
+	data = appendTestTransfer(data, 0x04, testDetailedSpeedGen1())
+	return appendTestTransfer(data, 0x05, testTechnicalDataGen1())
+}
+
+func TestSyntheticVehicleUnitFiles(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		data       []byte
+		generation ddv1.Generation
+		version    ddv1.Version
+		check      func(*testing.T, *vuv1.VehicleUnitFile)
+	}{
+		{
+			name:       "synthetic_gen1",
+			data:       testVehicleUnitFileGen1(),
+			generation: ddv1.Generation_GENERATION_1,
+			check: func(t *testing.T, file *vuv1.VehicleUnitFile) {
+				checkSyntheticGen1(t, file.GetGen1())
+			},
+		},
+		{
+			name:       "synthetic_gen2_v1",
+			data:       testVehicleUnitFileGen2(ddv1.Version_VERSION_1),
+			generation: ddv1.Generation_GENERATION_2,
+			version:    ddv1.Version_VERSION_1,
+			check: func(t *testing.T, file *vuv1.VehicleUnitFile) {
+				checkSyntheticGen2V1(t, file.GetGen2V1())
+			},
+		},
+		{
+			name:       "synthetic_gen2_v2",
+			data:       testVehicleUnitFileGen2(ddv1.Version_VERSION_2),
+			generation: ddv1.Generation_GENERATION_2,
+			version:    ddv1.Version_VERSION_2,
+			check: func(t *testing.T, file *vuv1.VehicleUnitFile) {
+				checkSyntheticGen2V2(t, file.GetGen2V2())
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join("..", "..", "testdata", "vu", tt.name+".DDD")
+			if *update {
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, tt.data, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read synthetic file, run with -update to create it: %v", err)
+			}
+			if !bytes.Equal(tt.data, want) {
+				t.Fatalf("%s differs from the synthetic file, run with -update to regenerate it", path)
+			}
+
+			file, err := UnmarshalVehicleUnitFile(tt.data)
+			if err != nil {
+				t.Fatalf("UnmarshalVehicleUnitFile() error = %v", err)
+			}
+			if file.GetGeneration() != tt.generation || file.GetVersion() != tt.version {
+				t.Fatalf("generation and version = %v %v, want %v %v", file.GetGeneration(), file.GetVersion(), tt.generation, tt.version)
+			}
+			tt.check(t, file)
+		})
+	}
+}
+
+func checkSyntheticGen1(t *testing.T, file *vuv1.VehicleUnitFileGen1) {
+	t.Helper()
+	overview := file.GetOverview()
+	if got := overview.GetVehicleIdentificationNumber().GetValue(); got != "WDB9634031L123456" {
+		t.Errorf("VIN = %q", got)
+	}
+	if got := overview.GetVehicleRegistrationWithNation().GetNumber().GetValue(); got != "M-AB 1234" {
+		t.Errorf("VRN = %q", got)
+	}
+	if len(overview.GetCompanyLocks()) != 1 {
+		t.Errorf("company locks = %d, want 1", len(overview.GetCompanyLocks()))
+	}
+
+	if len(file.GetActivities()) != 1 {
+		t.Fatalf("activities = %d, want 1", len(file.GetActivities()))
+	}
+	activities := file.GetActivities()[0]
+	if len(activities.GetCardIwData()) != 1 {
+		t.Fatalf("card IW records = %d, want 1", len(activities.GetCardIwData()))
+	}
+	if got := activities.GetCardIwData()[0].GetCardHolderName().GetHolderSurname().GetValue(); got != "MUSTERMANN" {
+		t.Errorf("holder surname = %q", got)
+	}
+	if len(activities.GetActivityChanges()) != 3 || len(activities.GetPlaces()) != 1 || len(activities.GetSpecificConditions()) != 1 {
+		t.Errorf("activity changes, places, specific conditions = %d, %d, %d, want 3, 1, 1",
+			len(activities.GetActivityChanges()), len(activities.GetPlaces()), len(activities.GetSpecificConditions()))
+	}
+
+	if len(file.GetEventsAndFaults()) != 1 {
+		t.Fatalf("events and faults = %d, want 1", len(file.GetEventsAndFaults()))
+	}
+	eventsAndFaults := file.GetEventsAndFaults()[0]
+	if len(eventsAndFaults.GetFaults()) != 1 || len(eventsAndFaults.GetEvents()) != 1 || len(eventsAndFaults.GetOverspeedingEvents()) != 1 || len(eventsAndFaults.GetTimeAdjustments()) != 1 {
+		t.Errorf("events and faults = %v", eventsAndFaults)
+	}
+	if len(file.GetDetailedSpeed()) != 1 {
+		t.Errorf("detailed speed = %d, want 1", len(file.GetDetailedSpeed()))
+	}
+	if len(file.GetTechnicalData()) != 1 {
+		t.Errorf("technical data = %d, want 1", len(file.GetTechnicalData()))
+	}
+}
+
+func checkSyntheticGen2V1(t *testing.T, file *vuv1.VehicleUnitFileGen2V1) {
+	t.Helper()
+	overview := file.GetOverview()
+	if got := overview.GetVehicleIdentificationNumber().GetValue(); got != "WDB9634031L123456" {
+		t.Errorf("VIN = %q", got)
+	}
+	if got := overview.GetVehicleRegistrationWithNation().GetNumber().GetValue(); got != "M-AB 1234" {
+		t.Errorf("VRN = %q", got)
+	}
+	if overview.GetDriverSlotCard() != ddv1.SlotCardType_DRIVER_CARD_INSERTED || overview.GetCoDriverSlotCard() != ddv1.SlotCardType_COMPANY_CARD_INSERTED {
+		t.Errorf("card slots = %v %v, want driver and company card", overview.GetDriverSlotCard(), overview.GetCoDriverSlotCard())
+	}
+
+	if len(file.GetActivities()) != 1 {
+		t.Fatalf("activities = %d, want 1", len(file.GetActivities()))
+	}
+	activities := file.GetActivities()[0]
+	if !activities.GetDateOfDay().AsTime().Equal(testDay) || activities.GetOdometerMidnightKm() != 100000 {
+		t.Errorf("date of day and odometer = %v %d", activities.GetDateOfDay().AsTime(), activities.GetOdometerMidnightKm())
+	}
+	if len(activities.GetCardIwData()) != 1 {
+		t.Fatalf("card IW records = %d, want 1", len(activities.GetCardIwData()))
+	}
+	cardIW := activities.GetCardIwData()[0]
+	if got := cardIW.GetCardHolderName().GetHolderSurname().GetValue(); got != "MUSTERMANN" {
+		t.Errorf("holder surname = %q", got)
+	}
+	if got := cardIW.GetFullCardNumberAndGeneration().GetFullCardNumber().GetDriverIdentification().GetDriverIdentificationNumber().GetValue(); got != "D1234567890123" {
+		t.Errorf("driver identification = %q", got)
+	}
+	if !cardIW.GetCardWithdrawalTime().AsTime().Equal(testDay.Add(17*time.Hour)) || cardIW.GetOdometerAtWithdrawalKm() != 100420 {
+		t.Errorf("withdrawal = %v %d", cardIW.GetCardWithdrawalTime().AsTime(), cardIW.GetOdometerAtWithdrawalKm())
+	}
+	if got := len(activities.GetActivityChanges()); got != 3 {
+		t.Errorf("activity changes = %d, want 3", got)
+	}
+	if len(activities.GetPlaces()) != 1 || activities.GetPlaces()[0].GetCountry() != ddv1.NationNumeric_GERMANY {
+		t.Errorf("places = %v", activities.GetPlaces())
+	}
+	if len(activities.GetGnssAccumulatedDriving()) != 1 || activities.GetGnssAccumulatedDriving()[0].GetGeoCoordinates().GetLatitude() != 48082 {
+		t.Errorf("GNSS accumulated driving = %v", activities.GetGnssAccumulatedDriving())
+	}
+	if got := len(activities.GetSpecificConditions()); got != 1 {
+		t.Errorf("specific conditions = %d, want 1", got)
+	}
+
+	if len(file.GetEventsAndFaults()) != 1 {
+		t.Fatalf("events and faults = %d, want 1", len(file.GetEventsAndFaults()))
+	}
+	eventsAndFaults := file.GetEventsAndFaults()[0]
+	if len(eventsAndFaults.GetFaults()) != 1 || eventsAndFaults.GetFaults()[0].HasCardNumberAndGenDriverSlotBegin() {
+		t.Errorf("faults = %v", eventsAndFaults.GetFaults())
+	}
+	if len(eventsAndFaults.GetEvents()) != 1 {
+		t.Fatalf("events = %d, want 1", len(eventsAndFaults.GetEvents()))
+	}
+	event := eventsAndFaults.GetEvents()[0]
+	if event.GetEventType() != ddv1.EventFaultType_GENERAL_CARD_INSERTION_WHILE_DRIVING || event.GetSimilarEventsNumber() != 2 || !event.HasCardNumberAndGenDriverSlotBegin() {
+		t.Errorf("event = %v", event)
+	}
+	if len(eventsAndFaults.GetOverspeedingEvents()) != 1 || eventsAndFaults.GetOverspeedingEvents()[0].GetMaxSpeedKmh() != 97 {
+		t.Errorf("overspeeding events = %v", eventsAndFaults.GetOverspeedingEvents())
+	}
+	if eventsAndFaults.GetOverspeedingControl().GetNumberOfOverspeedSinceLastControl() != 1 {
+		t.Errorf("overspeeding control = %v", eventsAndFaults.GetOverspeedingControl())
+	}
+	if len(eventsAndFaults.GetTimeAdjustments()) != 1 || eventsAndFaults.GetTimeAdjustments()[0].GetWorkshopName().GetValue() != "Werkstatt Beispiel" {
+		t.Errorf("time adjustments = %v", eventsAndFaults.GetTimeAdjustments())
+	}
+
+	if len(file.GetDetailedSpeed()) != 1 || len(file.GetDetailedSpeed()[0].GetSpeedBlocks()) != 2 {
+		t.Fatalf("detailed speed = %v", file.GetDetailedSpeed())
+	}
+	if got := file.GetDetailedSpeed()[0].GetSpeedBlocks()[1].GetSpeedsKmh()[59]; got != 99 {
+		t.Errorf("last speed = %d, want 99", got)
+	}
+	if len(file.GetTechnicalData()) != 1 {
+		t.Errorf("technical data = %d, want 1", len(file.GetTechnicalData()))
+	}
+}
+
+func checkSyntheticGen2V2(t *testing.T, file *vuv1.VehicleUnitFileGen2V2) {
+	t.Helper()
+	if got := file.GetDownloadInterfaceVersion().GetVersion(); got != ddv1.Version_VERSION_2 {
+		t.Errorf("download interface version = %v", got)
+	}
+	overview := file.GetOverview()
+	if got := overview.GetVehicleRegistrationNumber().GetValue(); got != "M-AB 1234" {
+		t.Errorf("VRN = %q", got)
+	}
+	if overview.GetDriverSlotCard() != ddv1.SlotCardType_DRIVER_CARD_INSERTED || overview.GetCoDriverSlotCard() != ddv1.SlotCardType_COMPANY_CARD_INSERTED {
+		t.Errorf("card slots = %v %v, want driver and company card", overview.GetDriverSlotCard(), overview.GetCoDriverSlotCard())
+	}
+
+	if len(file.GetActivities()) != 1 {
+		t.Fatalf("activities = %d, want 1", len(file.GetActivities()))
+	}
+	activities := file.GetActivities()[0]
+	if len(activities.GetCardIwData()) != 1 || activities.GetCardIwData()[0].GetOdometerAtInsertionKm() != 100010 {
+		t.Errorf("card IW records = %v", activities.GetCardIwData())
+	}
+	if len(activities.GetPlaces()) != 1 || activities.GetPlaces()[0].GetOdometerKm() != 100010 {
+		t.Errorf("places = %v", activities.GetPlaces())
+	}
+	if len(activities.GetGnssAccumulatedDriving()) != 1 {
+		t.Fatalf("GNSS accumulated driving = %d, want 1", len(activities.GetGnssAccumulatedDriving()))
+	}
+	if got := activities.GetGnssAccumulatedDriving()[0].GetAuthenticationStatus(); got != ddv1.PositionAuthenticationStatus_AUTHENTICATED {
+		t.Errorf("GNSS authentication status = %v", got)
+	}
+	if len(activities.GetBorderCrossings()) != 1 {
+		t.Fatalf("border crossings = %d, want 1", len(activities.GetBorderCrossings()))
+	}
+	crossing := activities.GetBorderCrossings()[0]
+	if crossing.GetCountryLeft() != ddv1.NationNumeric_GERMANY || crossing.GetCountryEntered() != ddv1.NationNumeric_AUSTRIA || crossing.GetOdometerKm() != 100310 {
+		t.Errorf("border crossing = %v", crossing)
+	}
+
+	if len(file.GetEventsAndFaults()) != 1 {
+		t.Fatalf("events and faults = %d, want 1", len(file.GetEventsAndFaults()))
+	}
+	eventsAndFaults := file.GetEventsAndFaults()[0]
+	if len(eventsAndFaults.GetFaults()) != 1 || len(eventsAndFaults.GetEvents()) != 1 || len(eventsAndFaults.GetOverspeedingEvents()) != 1 || len(eventsAndFaults.GetTimeAdjustments()) != 1 {
+		t.Errorf("events and faults = %v", eventsAndFaults)
+	}
+	if len(file.GetDetailedSpeed()) != 1 || len(file.GetDetailedSpeed()[0].GetSpeedBlocks()) != 2 {
+		t.Errorf("detailed speed = %v", file.GetDetailedSpeed())
+	}
+}
@@ -3,6 +3,7 @@ package vu
 import (
 	"fmt"
 
+	"github.com/way-platform/tachograph-go/internal/dd"
 	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
 	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
 )
@@ -73,8 +74,8 @@ func sizeOfTechnicalDataGen1(data []byte) (int, error) {
 	offset += 20
 
 	// VuCalibrationData: 1 byte count + variable calibration records
-	if len(data[offset:]) < 1 {
-		return 0, fmt.Errorf("insufficient data for noOfVuCalibrationRecords")
+	if len(data)-offset < 1 {
+		return 0, fmt.Errorf("insufficient data for noOfVuCalibrationRecords: %w", dd.ErrTruncated)
 	}
 	noOfVuCalibrationRecords := data[offset]
 	offset += 1
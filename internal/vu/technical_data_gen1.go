@@ -3,6 +3,7 @@ package vu
 import (
 	"fmt"
 
+	"github.com/way-platform/tachograph-go/internal/dd"
 	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
 )
 
@@ -24,19 +25,20 @@ import (
 //	}
 //
 // Note: This is a minimal implementation that stores raw_data for round-trip fidelity.
-func unmarshalTechnicalDataGen1(value []byte) (*vuv1.TechnicalDataGen1, error) {
+func unmarshalTechnicalDataGen1(opts dd.UnmarshalOptions, value []byte) (*vuv1.TechnicalDataGen1, error) {
 	technicalData := &vuv1.TechnicalDataGen1{}
 	technicalData.SetRawData(value)
 
 	// TODO: Implement full semantic parsing
 	// For now, validate that we have enough data for the structure
 	if len(value) < 128 { // At minimum, signature is 128 bytes
-		return nil, fmt.Errorf("insufficient data for Technical Data Gen1")
+		return nil, fmt.Errorf("insufficient data for Technical Data Gen1: %w", dd.ErrTruncated)
 	}
 
 	// Store the signature (last 128 bytes)
 	signatureStart := len(value) - 128
 	technicalData.SetSignature(value[signatureStart:])
+	opts.Layout.Field("signature", signatureStart, 128)
 
 	return technicalData, nil
 }
@@ -3,6 +3,7 @@ package vu
 import (
 	"fmt"
 
+	"github.com/way-platform/tachograph-go/internal/dd"
 	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
 )
 
@@ -11,45 +12,19 @@ import (
 // Gen2 V2 Technical Data structure is identical to Gen2 V1.
 //
 // Note: This is a minimal implementation that stores raw_data for round-trip fidelity.
-func unmarshalTechnicalDataGen2V2(value []byte) (*vuv1.TechnicalDataGen2V2, error) {
+func unmarshalTechnicalDataGen2V2(opts dd.UnmarshalOptions, value []byte) (*vuv1.TechnicalDataGen2V2, error) {
 	technicalData := &vuv1.TechnicalDataGen2V2{}
 	technicalData.SetRawData(value)
 
-	// Validate structure by skipping through all record arrays
+	// Validate structure by skipping through all record arrays. The number of
+	// record arrays differs between versions, see sizeOfTechnicalDataGen2V2.
 	offset := 0
-	skipRecordArray := func(name string) error {
+	for offset < len(value) {
 		size, err := sizeOfRecordArray(value, offset)
 		if err != nil {
-			return fmt.Errorf("%s: %w", name, err)
+			return nil, fmt.Errorf("Technical Data Gen2 V2: %w", err)
 		}
 		offset += size
-		return nil
-	}
-
-	// Skip all record arrays
-	if err := skipRecordArray("VuApprovalNumber"); err != nil {
-		return nil, err
-	}
-	if err := skipRecordArray("VuSoftwareIdentification"); err != nil {
-		return nil, err
-	}
-	if err := skipRecordArray("VuManufacturerName"); err != nil {
-		return nil, err
-	}
-	if err := skipRecordArray("VuManufacturerAddress"); err != nil {
-		return nil, err
-	}
-	if err := skipRecordArray("VuPartNumber"); err != nil {
-		return nil, err
-	}
-	if err := skipRecordArray("VuSerialNumber"); err != nil {
-		return nil, err
-	}
-	if err := skipRecordArray("SensorPaired"); err != nil {
-		return nil, err
-	}
-	if err := skipRecordArray("Signature"); err != nil {
-		return nil, err
 	}
 
 	if offset != len(value) {
@@ -1,16 +1,95 @@
 package vu
 
 import (
+	"cmp"
+	"encoding/binary"
 	"fmt"
+	"slices"
+	"sync"
 
+	"github.com/way-platform/tachograph-go/internal/dd"
 	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
 	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
 	"google.golang.org/protobuf/proto"
 )
 
 // UnmarshalVehicleUnitFile parses VU file data into a protobuf VehicleUnitFile message.
+//
+// See [VehicleUnitOptions] if you need more control over the parsing.
 func UnmarshalVehicleUnitFile(data []byte) (*vuv1.VehicleUnitFile, error) {
-	return unmarshalVehicleUnitFile(data)
+	return VehicleUnitOptions{}.UnmarshalVehicleUnitFile(data)
+}
+
+// ScanVehicleUnitFile parses VU file data like [UnmarshalVehicleUnitFile], but
+// skips the transfers that fail to parse.
+//
+// See [VehicleUnitOptions.ScanVehicleUnitFile] for details.
+func ScanVehicleUnitFile(data []byte) (*vuv1.VehicleUnitFile, []*TransferError, error) {
+	return VehicleUnitOptions{}.ScanVehicleUnitFile(data)
+}
+
+// UnmarshalTransfer parses a single transfer of a VU file into a
+// VehicleUnitFile holding only that transfer.
+//
+// See [VehicleUnitOptions.UnmarshalTransfer] for details.
+func UnmarshalTransfer(record *vuv1.RawVehicleUnitFile_Record, version ddv1.Version) (*vuv1.VehicleUnitFile, error) {
+	return VehicleUnitOptions{}.UnmarshalTransfer(record, version)
+}
+
+// VehicleUnitOptions configures the parsing of vehicle unit files.
+type VehicleUnitOptions struct {
+	// Transfers restricts the parsing to these transfers (TREPs). If empty,
+	// all transfers are parsed.
+	//
+	// The transfers that are not parsed hold only their raw data.
+	Transfers []vuv1.TransferType
+
+	// Version overrides the version of Gen2 files detected from their
+	// transfers, if set.
+	Version ddv1.Version
+
+	// Layouts, if set, returns the layout in which to record the fields of
+	// the transfer at index i of the file, or nil.
+	Layouts func(i int) *dd.Layout
+
+	// raw keeps all transfers as raw data, such as transfers that are
+	// skipped after failing to parse.
+	raw bool
+
+	// layout records the fields of the transfer being parsed, if set.
+	layout *dd.Layout
+}
+
+// UnmarshalVehicleUnitFile parses VU file data into a protobuf VehicleUnitFile message.
+func (o VehicleUnitOptions) UnmarshalVehicleUnitFile(data []byte) (*vuv1.VehicleUnitFile, error) {
+	return o.unmarshalVehicleUnitFile(data)
+}
+
+// transfer returns the options for parsing the transfer at index i.
+func (o VehicleUnitOptions) transfer(i int) VehicleUnitOptions {
+	if o.Layouts != nil {
+		o.layout = o.Layouts(i)
+	}
+	return o
+}
+
+// parses reports whether a transfer is parsed.
+func (o VehicleUnitOptions) parses(transferType vuv1.TransferType) bool {
+	return !o.raw && (len(o.Transfers) == 0 || slices.Contains(o.Transfers, transferType))
+}
+
+// unmarshalTransferValue parses the value of a transfer with unmarshal, or
+// returns a message holding only its raw data if the transfer is not parsed.
+func unmarshalTransferValue[M any, P interface {
+	*M
+	SetRawData([]byte)
+}](o VehicleUnitOptions, record *vuv1.RawVehicleUnitFile_Record, unmarshal func(dd.UnmarshalOptions, []byte) (P, error)) (P, error) {
+	if o.parses(record.GetType()) {
+		return unmarshal(dd.UnmarshalOptions{Layout: o.layout}, record.GetValue())
+	}
+	output := P(new(M))
+	output.SetRawData(record.GetValue())
+	return output, nil
 }
 
 // MarshalVehicleUnitFile serializes a VehicleUnitFile into binary format.
@@ -19,11 +98,7 @@ func MarshalVehicleUnitFile(file *vuv1.VehicleUnitFile) ([]byte, error) {
 		return nil, fmt.Errorf("vehicle unit file is nil")
 	}
 
-	// Allocate a buffer large enough for the VU file
-	buf := make([]byte, 0, 1024*1024) // 1MB initial capacity
-
-	// Use the existing AppendVU function
-	return appendVU(buf, file)
+	return appendVU(nil, file)
 }
 
 // unmarshalVehicleUnitFile unmarshals a vehicle unit file from binary data.
@@ -45,26 +120,137 @@ func MarshalVehicleUnitFile(file *vuv1.VehicleUnitFile) ([]byte, error) {
 //	        technicalData             TechnicalData
 //	    }
 //	}
-func unmarshalVehicleUnitFile(input []byte) (*vuv1.VehicleUnitFile, error) {
+func (o VehicleUnitOptions) unmarshalVehicleUnitFile(input []byte) (*vuv1.VehicleUnitFile, error) {
 	// Pass 1: Slice into RawVehicleUnitFile
-	rawFile, err := unmarshalRawVehicleUnitFile(input)
+	rawFile, err := scanRawVehicleUnitFile(input)
 	if err != nil {
-		return nil, fmt.Errorf("raw unmarshal failed: %w", err)
+		return nil, err
 	}
 
+	// Pass 2: Parse the transfers
+	return o.unmarshalVehicleUnitFileRecords(rawFile, nil)
+}
+
+// ScanVehicleUnitFile parses VU file data like
+// [VehicleUnitOptions.UnmarshalVehicleUnitFile], but skips the transfers that
+// fail to parse, and returns an error for each skipped transfer.
+//
+// The skipped transfers hold only their raw data, like the transfers that are
+// not selected by [VehicleUnitOptions.Transfers].
+//
+// If the data cannot be sliced into transfers up to its end, the remaining
+// data is reported as a [TransferError] without a record. An error is
+// returned only if no file could be parsed at all.
+func (o VehicleUnitOptions) ScanVehicleUnitFile(data []byte) (*vuv1.VehicleUnitFile, []*TransferError, error) {
+	var errs []*TransferError
+	rawFile, sliceErr := scanRawVehicleUnitFile(data)
+	if sliceErr != nil {
+		errs = append(errs, sliceErr)
+	}
+	output, err := o.unmarshalVehicleUnitFileRecords(rawFile, func(err *TransferError) {
+		errs = append(errs, err)
+	})
+	if err != nil {
+		if len(errs) > 0 {
+			return nil, nil, errs[0]
+		}
+		return nil, nil, err
+	}
+	slices.SortFunc(errs, func(a, b *TransferError) int {
+		return a.Offset - b.Offset
+	})
+	return output, errs, nil
+}
+
+// TransferError is an error parsing a transfer of a vehicle unit file.
+type TransferError struct {
+	// Index is the index of the transfer in the raw vehicle unit file.
+	Index int
+	// Offset is the byte offset of the transfer in the vehicle unit file.
+	Offset int
+	// Record is the transfer that failed to parse, or nil if the data could
+	// not be sliced into a transfer.
+	Record *vuv1.RawVehicleUnitFile_Record
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *TransferError) Error() string {
+	if e.Record == nil {
+		return fmt.Sprintf("data at offset %d: %v", e.Offset, e.Err)
+	}
+	return fmt.Sprintf("transfer %d (%v) at offset %d: %v", e.Index, e.Record.GetType(), e.Offset, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *TransferError) Unwrap() error {
+	return e.Err
+}
+
+// scanRawVehicleUnitFile slices vehicle unit data like [ScanRawVehicleUnitFile],
+// and reports the data that could not be sliced as a [TransferError] without
+// a record.
+func scanRawVehicleUnitFile(data []byte) (*vuv1.RawVehicleUnitFile, *TransferError) {
+	rawFile, err := ScanRawVehicleUnitFile(data)
+	if err != nil {
+		offset := 0
+		for _, record := range rawFile.GetRecords() {
+			offset += 2 + len(record.GetValue())
+		}
+		return rawFile, &TransferError{Index: len(rawFile.GetRecords()), Offset: offset, Err: err}
+	}
+	return rawFile, nil
+}
+
+// TransferOffsets returns the byte offset of each transfer of a raw vehicle
+// unit file.
+func TransferOffsets(file *vuv1.RawVehicleUnitFile) []int {
+	offsets := make([]int, len(file.GetRecords()))
+	offset := 0
+	for i, record := range file.GetRecords() {
+		offsets[i] = offset
+		offset += 2 + len(record.GetValue())
+	}
+	return offsets
+}
+
+// unmarshalVehicleUnitFileRecords parses the transfers of a raw vehicle unit
+// file. If skip is nil, the first transfer that fails to parse fails the whole
+// file. Otherwise, the transfer is skipped and reported to skip, and kept
+// with only its raw data if its type belongs in the file.
+func (o VehicleUnitOptions) unmarshalVehicleUnitFileRecords(rawFile *vuv1.RawVehicleUnitFile, skip func(*TransferError)) (*vuv1.VehicleUnitFile, error) {
 	// Determine generation/version
 	if len(rawFile.GetRecords()) == 0 {
 		return nil, fmt.Errorf("empty VU file")
 	}
 
 	firstRecord := rawFile.GetRecords()[0]
+	return o.unmarshalVehicleUnitFileVersion(rawFile, firstRecord.GetGeneration(), cmp.Or(o.Version, transfersVersion(rawFile)), skip)
+}
 
+// UnmarshalTransfer parses a single transfer of a VU file into a
+// VehicleUnitFile holding only that transfer.
+//
+// Some Gen2 transfers, such as detailed speed, are shared by both versions
+// of Gen2 files, so version is the version of the Gen2 file the transfer
+// belongs to. It is ignored for Gen1 transfers.
+func (o VehicleUnitOptions) UnmarshalTransfer(record *vuv1.RawVehicleUnitFile_Record, version ddv1.Version) (*vuv1.VehicleUnitFile, error) {
+	var rawFile vuv1.RawVehicleUnitFile
+	rawFile.SetRecords([]*vuv1.RawVehicleUnitFile_Record{record})
+	version = cmp.Or(o.Version, max(version, transfersVersion(&rawFile)))
+	return o.unmarshalVehicleUnitFileVersion(&rawFile, record.GetGeneration(), version, nil)
+}
+
+// unmarshalVehicleUnitFileVersion parses the transfers of a raw vehicle unit
+// file of the given generation and version.
+func (o VehicleUnitOptions) unmarshalVehicleUnitFileVersion(rawFile *vuv1.RawVehicleUnitFile, generation ddv1.Generation, version ddv1.Version, skip func(*TransferError)) (*vuv1.VehicleUnitFile, error) {
 	// Dispatch to generation-specific unmarshaller
 	output := &vuv1.VehicleUnitFile{}
 
-	switch firstRecord.GetGeneration() {
+	switch generation {
 	case ddv1.Generation_GENERATION_1:
-		gen1File, err := unmarshalVehicleUnitFileGen1(rawFile)
+		gen1File, err := o.unmarshalVehicleUnitFileGen1(rawFile, skip)
 		if err != nil {
 			return nil, err
 		}
@@ -72,91 +258,105 @@ func unmarshalVehicleUnitFile(input []byte) (*vuv1.VehicleUnitFile, error) {
 		output.SetGen1(gen1File)
 
 	case ddv1.Generation_GENERATION_2:
-		if hasGen2V2Transfers(rawFile) {
-			gen2v2File, err := unmarshalVehicleUnitFileGen2V2(rawFile)
+		switch version {
+		case ddv1.Version_VERSION_2:
+			gen2v2File, err := o.unmarshalVehicleUnitFileGen2V2(rawFile, skip)
 			if err != nil {
 				return nil, err
 			}
 			output.SetGeneration(ddv1.Generation_GENERATION_2)
 			output.SetVersion(ddv1.Version_VERSION_2)
 			output.SetGen2V2(gen2v2File)
-		} else {
-			gen2v1File, err := unmarshalVehicleUnitFileGen2V1(rawFile)
+		case ddv1.Version_VERSION_UNSPECIFIED, ddv1.Version_VERSION_1:
+			gen2v1File, err := o.unmarshalVehicleUnitFileGen2V1(rawFile, skip)
 			if err != nil {
 				return nil, err
 			}
 			output.SetGeneration(ddv1.Generation_GENERATION_2)
 			output.SetVersion(ddv1.Version_VERSION_1)
 			output.SetGen2V1(gen2v1File)
+		default:
+			return nil, fmt.Errorf("Gen2 %v: %w", version, dd.ErrUnsupportedVersion)
 		}
 
 	default:
-		return nil, fmt.Errorf("unknown generation: %v", firstRecord.GetGeneration())
+		return nil, fmt.Errorf("unknown generation: %v", generation)
 	}
 
 	return output, nil
 }
 
-// hasGen2V2Transfers checks if the raw file contains Gen2 V2 transfers.
-// Gen2 V2 is identified by the presence of TREP 00 (DownloadInterfaceVersion)
-// or TREP 31-35 transfers.
-func hasGen2V2Transfers(rawFile *vuv1.RawVehicleUnitFile) bool {
+// transfersVersion returns the version of a Gen2 file from the versions
+// annotated on its transfer types: the file is of the latest version of any of
+// its transfers, or of version 1 if none of its transfers is annotated.
+//
+// Version 2 files are identified by TREP 00 (DownloadInterfaceVersion) or
+// TREP 31-35 transfers.
+func transfersVersion(rawFile *vuv1.RawVehicleUnitFile) ddv1.Version {
+	version := ddv1.Version_VERSION_1
 	for _, record := range rawFile.GetRecords() {
-		switch record.GetType() {
-		case vuv1.TransferType_DOWNLOAD_INTERFACE_VERSION,
-			vuv1.TransferType_OVERVIEW_GEN2_V2,
-			vuv1.TransferType_ACTIVITIES_GEN2_V2,
-			vuv1.TransferType_EVENTS_AND_FAULTS_GEN2_V2,
-			vuv1.TransferType_TECHNICAL_DATA_GEN2_V2:
-			return true
-		}
+		version = max(version, versionFromTransferType(record.GetType()))
 	}
-	return false
+	return version
 }
 
 // unmarshalVehicleUnitFileGen1 unmarshals a Gen1 VU file from raw records.
-func unmarshalVehicleUnitFileGen1(rawFile *vuv1.RawVehicleUnitFile) (*vuv1.VehicleUnitFileGen1, error) {
+func (o VehicleUnitOptions) unmarshalVehicleUnitFileGen1(rawFile *vuv1.RawVehicleUnitFile, skip func(*TransferError)) (*vuv1.VehicleUnitFileGen1, error) {
 	var output vuv1.VehicleUnitFileGen1
 
-	for _, record := range rawFile.GetRecords() {
-		switch record.GetType() {
-		case vuv1.TransferType_OVERVIEW_GEN1:
-			overview, err := unmarshalOverviewGen1(record.GetValue())
-			if err != nil {
-				return nil, fmt.Errorf("unmarshal Overview Gen1: %w", err)
-			}
-			output.SetOverview(overview)
-
-		case vuv1.TransferType_ACTIVITIES_GEN1:
-			activities, err := unmarshalActivitiesGen1(record.GetValue())
-			if err != nil {
-				return nil, fmt.Errorf("unmarshal Activities Gen1: %w", err)
-			}
-			output.SetActivities(append(output.GetActivities(), activities))
-
-		case vuv1.TransferType_EVENTS_AND_FAULTS_GEN1:
-			eventsAndFaults, err := unmarshalEventsAndFaultsGen1(record.GetValue())
-			if err != nil {
-				return nil, fmt.Errorf("unmarshal Events and Faults Gen1: %w", err)
-			}
-			output.SetEventsAndFaults(append(output.GetEventsAndFaults(), eventsAndFaults))
-
-		case vuv1.TransferType_DETAILED_SPEED_GEN1:
-			detailedSpeed, err := unmarshalDetailedSpeedGen1(record.GetValue())
-			if err != nil {
-				return nil, fmt.Errorf("unmarshal Detailed Speed Gen1: %w", err)
+	offsets := TransferOffsets(rawFile)
+	for i, record := range rawFile.GetRecords() {
+		unmarshal := func(o VehicleUnitOptions) error {
+			switch record.GetType() {
+			case vuv1.TransferType_OVERVIEW_GEN1:
+				overview, err := unmarshalTransferValue(o, record, unmarshalOverviewGen1)
+				if err != nil {
+					return fmt.Errorf("unmarshal Overview Gen1: %w", err)
+				}
+				output.SetOverview(overview)
+
+			case vuv1.TransferType_ACTIVITIES_GEN1:
+				activities, err := unmarshalTransferValue(o, record, unmarshalActivitiesGen1)
+				if err != nil {
+					return fmt.Errorf("unmarshal Activities Gen1: %w", err)
+				}
+				output.SetActivities(append(output.GetActivities(), activities))
+
+			case vuv1.TransferType_EVENTS_AND_FAULTS_GEN1:
+				eventsAndFaults, err := unmarshalTransferValue(o, record, unmarshalEventsAndFaultsGen1)
+				if err != nil {
+					return fmt.Errorf("unmarshal Events and Faults Gen1: %w", err)
+				}
+				output.SetEventsAndFaults(append(output.GetEventsAndFaults(), eventsAndFaults))
+
+			case vuv1.TransferType_DETAILED_SPEED_GEN1:
+				detailedSpeed, err := unmarshalTransferValue(o, record, unmarshalDetailedSpeedGen1)
+				if err != nil {
+					return fmt.Errorf("unmarshal Detailed Speed Gen1: %w", err)
+				}
+				output.SetDetailedSpeed(append(output.GetDetailedSpeed(), detailedSpeed))
+
+			case vuv1.TransferType_TECHNICAL_DATA_GEN1:
+				technicalData, err := unmarshalTransferValue(o, record, unmarshalTechnicalDataGen1)
+				if err != nil {
+					return fmt.Errorf("unmarshal Technical Data Gen1: %w", err)
+				}
+				output.SetTechnicalData(append(output.GetTechnicalData(), technicalData))
+
+			default:
+				return fmt.Errorf("unexpected transfer type %v in Gen1 file", record.GetType())
 			}
-			output.SetDetailedSpeed(append(output.GetDetailedSpeed(), detailedSpeed))
-
-		case vuv1.TransferType_TECHNICAL_DATA_GEN1:
-			technicalData, err := unmarshalTechnicalDataGen1(record.GetValue())
-			if err != nil {
-				return nil, fmt.Errorf("unmarshal Technical Data Gen1: %w", err)
+			return nil
+		}
+		if err := unmarshal(o.transfer(i)); err != nil {
+			err := &TransferError{Index: i, Offset: offsets[i], Record: record, Err: err}
+			if skip == nil {
+				return nil, err
 			}
-			output.SetTechnicalData(append(output.GetTechnicalData(), technicalData))
-
-		default:
-			return nil, fmt.Errorf("unexpected transfer type %v in Gen1 file", record.GetType())
+			skip(err)
+			// Keep the skipped transfer as raw data, so that it is not lost
+			// when the file is marshaled again.
+			_ = unmarshal(VehicleUnitOptions{raw: true})
 		}
 	}
 
@@ -164,48 +364,62 @@ func unmarshalVehicleUnitFileGen1(rawFile *vuv1.RawVehicleUnitFile) (*vuv1.Vehic
 }
 
 // unmarshalVehicleUnitFileGen2V1 unmarshals a Gen2 V1 VU file from raw records.
-func unmarshalVehicleUnitFileGen2V1(rawFile *vuv1.RawVehicleUnitFile) (*vuv1.VehicleUnitFileGen2V1, error) {
+func (o VehicleUnitOptions) unmarshalVehicleUnitFileGen2V1(rawFile *vuv1.RawVehicleUnitFile, skip func(*TransferError)) (*vuv1.VehicleUnitFileGen2V1, error) {
 	var output vuv1.VehicleUnitFileGen2V1
 
-	for _, record := range rawFile.GetRecords() {
-		switch record.GetType() {
-		case vuv1.TransferType_OVERVIEW_GEN2_V1:
-			overview, err := unmarshalOverviewGen2V1(record.GetValue())
-			if err != nil {
-				return nil, fmt.Errorf("unmarshal Overview Gen2 V1: %w", err)
-			}
-			output.SetOverview(overview)
-
-		case vuv1.TransferType_ACTIVITIES_GEN2_V1:
-			activities, err := unmarshalActivitiesGen2V1(record.GetValue())
-			if err != nil {
-				return nil, fmt.Errorf("unmarshal Activities Gen2 V1: %w", err)
-			}
-			output.SetActivities(append(output.GetActivities(), activities))
-
-		case vuv1.TransferType_EVENTS_AND_FAULTS_GEN2_V1:
-			eventsAndFaults, err := unmarshalEventsAndFaultsGen2V1(record.GetValue())
-			if err != nil {
-				return nil, fmt.Errorf("unmarshal Events and Faults Gen2 V1: %w", err)
-			}
-			output.SetEventsAndFaults(append(output.GetEventsAndFaults(), eventsAndFaults))
-
-		case vuv1.TransferType_DETAILED_SPEED_GEN2:
-			detailedSpeed, err := unmarshalDetailedSpeedGen2(record.GetValue())
-			if err != nil {
-				return nil, fmt.Errorf("unmarshal Detailed Speed Gen2: %w", err)
+	offsets := TransferOffsets(rawFile)
+	for i, record := range rawFile.GetRecords() {
+		unmarshal := func(o VehicleUnitOptions) error {
+			switch record.GetType() {
+			case vuv1.TransferType_OVERVIEW_GEN2_V1:
+				overview, err := unmarshalTransferValue(o, record, unmarshalOverviewGen2V1)
+				if err != nil {
+					return fmt.Errorf("unmarshal Overview Gen2 V1: %w", err)
+				}
+				output.SetOverview(overview)
+
+			case vuv1.TransferType_ACTIVITIES_GEN2_V1:
+				activities, err := unmarshalTransferValue(o, record, unmarshalActivitiesGen2V1)
+				if err != nil {
+					return fmt.Errorf("unmarshal Activities Gen2 V1: %w", err)
+				}
+				output.SetActivities(append(output.GetActivities(), activities))
+
+			case vuv1.TransferType_EVENTS_AND_FAULTS_GEN2_V1:
+				eventsAndFaults, err := unmarshalTransferValue(o, record, unmarshalEventsAndFaultsGen2V1)
+				if err != nil {
+					return fmt.Errorf("unmarshal Events and Faults Gen2 V1: %w", err)
+				}
+				output.SetEventsAndFaults(append(output.GetEventsAndFaults(), eventsAndFaults))
+
+			case vuv1.TransferType_DETAILED_SPEED_GEN2:
+				detailedSpeed, err := unmarshalTransferValue(o, record, unmarshalDetailedSpeedGen2)
+				if err != nil {
+					return fmt.Errorf("unmarshal Detailed Speed Gen2: %w", err)
+				}
+				output.SetDetailedSpeed(append(output.GetDetailedSpeed(), detailedSpeed))
+
+			case vuv1.TransferType_TECHNICAL_DATA_GEN2_V1:
+				technicalData, err := unmarshalTransferValue(o, record, unmarshalTechnicalDataGen2V1)
+				if err != nil {
+					return fmt.Errorf("unmarshal Technical Data Gen2 V1: %w", err)
+				}
+				output.SetTechnicalData(append(output.GetTechnicalData(), technicalData))
+
+			default:
+				return fmt.Errorf("unexpected transfer type %v in Gen2 V1 file", record.GetType())
 			}
-			output.SetDetailedSpeed(append(output.GetDetailedSpeed(), detailedSpeed))
-
-		case vuv1.TransferType_TECHNICAL_DATA_GEN2_V1:
-			technicalData, err := unmarshalTechnicalDataGen2V1(record.GetValue())
-			if err != nil {
-				return nil, fmt.Errorf("unmarshal Technical Data Gen2 V1: %w", err)
+			return nil
+		}
+		if err := unmarshal(o.transfer(i)); err != nil {
+			err := &TransferError{Index: i, Offset: offsets[i], Record: record, Err: err}
+			if skip == nil {
+				return nil, err
 			}
-			output.SetTechnicalData(append(output.GetTechnicalData(), technicalData))
-
-		default:
-			return nil, fmt.Errorf("unexpected transfer type %v in Gen2 V1 file", record.GetType())
+			skip(err)
+			// Keep the skipped transfer as raw data, so that it is not lost
+			// when the file is marshaled again.
+			_ = unmarshal(VehicleUnitOptions{raw: true})
 		}
 	}
 
@@ -213,53 +427,69 @@ func unmarshalVehicleUnitFileGen2V1(rawFile *vuv1.RawVehicleUnitFile) (*vuv1.Veh
 }
 
 // unmarshalVehicleUnitFileGen2V2 unmarshals a Gen2 V2 VU file from raw records.
-func unmarshalVehicleUnitFileGen2V2(rawFile *vuv1.RawVehicleUnitFile) (*vuv1.VehicleUnitFileGen2V2, error) {
+func (o VehicleUnitOptions) unmarshalVehicleUnitFileGen2V2(rawFile *vuv1.RawVehicleUnitFile, skip func(*TransferError)) (*vuv1.VehicleUnitFileGen2V2, error) {
 	var output vuv1.VehicleUnitFileGen2V2
 
-	for _, record := range rawFile.GetRecords() {
-		switch record.GetType() {
-		case vuv1.TransferType_DOWNLOAD_INTERFACE_VERSION:
-			// Download interface version can be parsed if needed
-			// For now, skip as it's mainly used for version detection
-			// output.SetDownloadInterfaceVersion(...)
-
-		case vuv1.TransferType_OVERVIEW_GEN2_V2:
-			overview, err := unmarshalOverviewGen2V2(record.GetValue())
-			if err != nil {
-				return nil, fmt.Errorf("unmarshal Overview Gen2 V2: %w", err)
-			}
-			output.SetOverview(overview)
-
-		case vuv1.TransferType_ACTIVITIES_GEN2_V2:
-			activities, err := unmarshalActivitiesGen2V2(record.GetValue())
-			if err != nil {
-				return nil, fmt.Errorf("unmarshal Activities Gen2 V2: %w", err)
-			}
-			output.SetActivities(append(output.GetActivities(), activities))
-
-		case vuv1.TransferType_EVENTS_AND_FAULTS_GEN2_V2:
-			eventsAndFaults, err := unmarshalEventsAndFaultsGen2V2(record.GetValue())
-			if err != nil {
-				return nil, fmt.Errorf("unmarshal Events and Faults Gen2 V2: %w", err)
-			}
-			output.SetEventsAndFaults(append(output.GetEventsAndFaults(), eventsAndFaults))
-
-		case vuv1.TransferType_DETAILED_SPEED_GEN2:
-			detailedSpeed, err := unmarshalDetailedSpeedGen2(record.GetValue())
-			if err != nil {
-				return nil, fmt.Errorf("unmarshal Detailed Speed Gen2: %w", err)
+	offsets := TransferOffsets(rawFile)
+	for i, record := range rawFile.GetRecords() {
+		unmarshal := func(o VehicleUnitOptions) error {
+			switch record.GetType() {
+			case vuv1.TransferType_DOWNLOAD_INTERFACE_VERSION:
+				downloadInterfaceVersion, err := unmarshalTransferValue(o, record, o.unmarshalDownloadInterfaceVersion)
+				if err != nil {
+					return fmt.Errorf("unmarshal Download Interface Version: %w", err)
+				}
+				output.SetDownloadInterfaceVersion(downloadInterfaceVersion)
+
+			case vuv1.TransferType_OVERVIEW_GEN2_V2:
+				overview, err := unmarshalTransferValue(o, record, unmarshalOverviewGen2V2)
+				if err != nil {
+					return fmt.Errorf("unmarshal Overview Gen2 V2: %w", err)
+				}
+				output.SetOverview(overview)
+
+			case vuv1.TransferType_ACTIVITIES_GEN2_V2:
+				activities, err := unmarshalTransferValue(o, record, unmarshalActivitiesGen2V2)
+				if err != nil {
+					return fmt.Errorf("unmarshal Activities Gen2 V2: %w", err)
+				}
+				output.SetActivities(append(output.GetActivities(), activities))
+
+			case vuv1.TransferType_EVENTS_AND_FAULTS_GEN2_V2:
+				eventsAndFaults, err := unmarshalTransferValue(o, record, unmarshalEventsAndFaultsGen2V2)
+				if err != nil {
+					return fmt.Errorf("unmarshal Events and Faults Gen2 V2: %w", err)
+				}
+				output.SetEventsAndFaults(append(output.GetEventsAndFaults(), eventsAndFaults))
+
+			case vuv1.TransferType_DETAILED_SPEED_GEN2:
+				detailedSpeed, err := unmarshalTransferValue(o, record, unmarshalDetailedSpeedGen2)
+				if err != nil {
+					return fmt.Errorf("unmarshal Detailed Speed Gen2: %w", err)
+				}
+				output.SetDetailedSpeed(append(output.GetDetailedSpeed(), detailedSpeed))
+
+			case vuv1.TransferType_TECHNICAL_DATA_GEN2_V2:
+				technicalData, err := unmarshalTransferValue(o, record, unmarshalTechnicalDataGen2V2)
+				if err != nil {
+					return fmt.Errorf("unmarshal Technical Data Gen2 V2: %w", err)
+				}
+				output.SetTechnicalData(append(output.GetTechnicalData(), technicalData))
+
+			default:
+				return fmt.Errorf("unexpected transfer type %v in Gen2 V2 file", record.GetType())
 			}
-			output.SetDetailedSpeed(append(output.GetDetailedSpeed(), detailedSpeed))
-
-		case vuv1.TransferType_TECHNICAL_DATA_GEN2_V2:
-			technicalData, err := unmarshalTechnicalDataGen2V2(record.GetValue())
-			if err != nil {
-				return nil, fmt.Errorf("unmarshal Technical Data Gen2 V2: %w", err)
+			return nil
+		}
+		if err := unmarshal(o.transfer(i)); err != nil {
+			err := &TransferError{Index: i, Offset: offsets[i], Record: record, Err: err}
+			if skip == nil {
+				return nil, err
 			}
-			output.SetTechnicalData(append(output.GetTechnicalData(), technicalData))
-
-		default:
-			return nil, fmt.Errorf("unexpected transfer type %v in Gen2 V2 file", record.GetType())
+			skip(err)
+			// Keep the skipped transfer as raw data, so that it is not lost
+			// when the file is marshaled again.
+			_ = unmarshal(VehicleUnitOptions{raw: true})
 		}
 	}
 
@@ -268,6 +498,13 @@ func unmarshalVehicleUnitFileGen2V2(rawFile *vuv1.RawVehicleUnitFile) (*vuv1.Veh
 
 // findTransferTypeByTag maps VU transfer tags to TransferType enum values
 func findTransferTypeByTag(tag uint16) vuv1.TransferType {
+	return transferTypesByTag()[tag]
+}
+
+// transferTypesByTag maps VU transfer tags to TransferType enum values, from
+// the TREP values annotated on the enum values.
+var transferTypesByTag = sync.OnceValue(func() map[uint16]vuv1.TransferType {
+	result := make(map[uint16]vuv1.TransferType)
 	values := vuv1.TransferType_TRANSFER_TYPE_UNSPECIFIED.Descriptor().Values()
 	for i := 0; i < values.Len(); i++ {
 		valueDesc := values.Get(i)
@@ -275,14 +512,14 @@ func findTransferTypeByTag(tag uint16) vuv1.TransferType {
 		if proto.HasExtension(opts, vuv1.E_TrepValue) {
 			trepValue := proto.GetExtension(opts, vuv1.E_TrepValue).(int32)
 			// VU tags are constructed as 0x76XX where XX is the TREP value
-			expectedTag := uint16(0x7600 | (uint16(trepValue) & 0xFF))
-			if expectedTag == tag {
-				return vuv1.TransferType(valueDesc.Number())
+			tag := uint16(0x7600 | (uint16(trepValue) & 0xFF))
+			if _, ok := result[tag]; !ok {
+				result[tag] = vuv1.TransferType(valueDesc.Number())
 			}
 		}
 	}
-	return vuv1.TransferType_TRANSFER_TYPE_UNSPECIFIED
-}
+	return result
+})
 
 // appendVU orchestrates writing a VU file in TV format
 //
@@ -303,16 +540,96 @@ func findTransferTypeByTag(tag uint16) vuv1.TransferType {
 //	        technicalData             TechnicalData
 //	    }
 //	}
+//
+// The transfers are written in the order of a complete download: the download
+// interface version (Gen2 V2 only), the overview, and then all transfers of
+// each of the activities, events and faults, detailed speed and technical
+// data types, in the order in which they are held by the file.
+//
+// Most transfers are written from their raw data, see the append functions of
+// the transfer types.
 func appendVU(dst []byte, vuFile *vuv1.VehicleUnitFile) ([]byte, error) {
 	if vuFile == nil {
 		return dst, nil
 	}
+	w := transferWriter{dst: dst}
+	switch vuFile.GetGeneration() {
+	case ddv1.Generation_GENERATION_1:
+		file := vuFile.GetGen1()
+		if file.HasOverview() {
+			writeTransfer(&w, vuv1.TransferType_OVERVIEW_GEN1, file.GetOverview(), appendOverviewGen1)
+		}
+		writeTransfers(&w, vuv1.TransferType_ACTIVITIES_GEN1, file.GetActivities(), appendActivitiesGen1)
+		writeTransfers(&w, vuv1.TransferType_EVENTS_AND_FAULTS_GEN1, file.GetEventsAndFaults(), appendEventsAndFaultsGen1)
+		writeTransfers(&w, vuv1.TransferType_DETAILED_SPEED_GEN1, file.GetDetailedSpeed(), appendDetailedSpeedGen1)
+		writeTransfers(&w, vuv1.TransferType_TECHNICAL_DATA_GEN1, file.GetTechnicalData(), appendTechnicalDataGen1)
+
+	case ddv1.Generation_GENERATION_2:
+		switch vuFile.GetVersion() {
+		case ddv1.Version_VERSION_2:
+			file := vuFile.GetGen2V2()
+			if file.HasDownloadInterfaceVersion() {
+				writeTransfer(&w, vuv1.TransferType_DOWNLOAD_INTERFACE_VERSION, file.GetDownloadInterfaceVersion(), appendDownloadInterfaceVersion)
+			}
+			if file.HasOverview() {
+				writeTransfer(&w, vuv1.TransferType_OVERVIEW_GEN2_V2, file.GetOverview(), appendOverviewGen2V2)
+			}
+			writeTransfers(&w, vuv1.TransferType_ACTIVITIES_GEN2_V2, file.GetActivities(), appendActivitiesGen2V2)
+			writeTransfers(&w, vuv1.TransferType_EVENTS_AND_FAULTS_GEN2_V2, file.GetEventsAndFaults(), appendEventsAndFaultsGen2V2)
+			writeTransfers(&w, vuv1.TransferType_DETAILED_SPEED_GEN2, file.GetDetailedSpeed(), appendDetailedSpeedGen2)
+			writeTransfers(&w, vuv1.TransferType_TECHNICAL_DATA_GEN2_V2, file.GetTechnicalData(), appendTechnicalDataGen2V2)
+
+		case ddv1.Version_VERSION_UNSPECIFIED, ddv1.Version_VERSION_1:
+			file := vuFile.GetGen2V1()
+			if file.HasOverview() {
+				writeTransfer(&w, vuv1.TransferType_OVERVIEW_GEN2_V1, file.GetOverview(), appendOverviewGen2V1)
+			}
+			writeTransfers(&w, vuv1.TransferType_ACTIVITIES_GEN2_V1, file.GetActivities(), appendActivitiesGen2V1)
+			writeTransfers(&w, vuv1.TransferType_EVENTS_AND_FAULTS_GEN2_V1, file.GetEventsAndFaults(), appendEventsAndFaultsGen2V1)
+			writeTransfers(&w, vuv1.TransferType_DETAILED_SPEED_GEN2, file.GetDetailedSpeed(), appendDetailedSpeedGen2)
+			writeTransfers(&w, vuv1.TransferType_TECHNICAL_DATA_GEN2_V1, file.GetTechnicalData(), appendTechnicalDataGen2V1)
+
+		default:
+			return nil, fmt.Errorf("Gen2 %v: %w", vuFile.GetVersion(), dd.ErrUnsupportedVersion)
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown generation: %v", vuFile.GetGeneration())
+	}
+	return w.dst, w.err
+}
 
-	// Dispatch to generation-specific marshaller
-	// Note: The plan specifies that VehicleUnitFile marshalling is NOT implemented,
-	// only RawVehicleUnitFile marshalling is used for round-tripping.
-	// Individual transfer marshalling is implemented for testing purposes.
-	return nil, fmt.Errorf("VehicleUnitFile marshalling is not implemented; use RawVehicleUnitFile for binary round-tripping")
+// transferWriter appends transfers to a buffer, and keeps the first error.
+type transferWriter struct {
+	dst []byte
+	err error
 }
 
-// getTagForTransferType returns the TV format tag for a given transfer type
+// writeTransfer appends the tag of the transfer type and the value of a transfer.
+func writeTransfer[T any](w *transferWriter, transferType vuv1.TransferType, transfer T, appendValue func([]byte, T) ([]byte, error)) {
+	if w.err != nil {
+		return
+	}
+	dst := binary.BigEndian.AppendUint16(w.dst, getTagForTransferType(transferType))
+	dst, err := appendValue(dst, transfer)
+	if err != nil {
+		w.err = fmt.Errorf("marshal %v: %w", transferType, err)
+		return
+	}
+	w.dst = dst
+}
+
+// writeTransfers appends all transfers of a transfer type.
+func writeTransfers[T any](w *transferWriter, transferType vuv1.TransferType, transfers []T, appendValue func([]byte, T) ([]byte, error)) {
+	for _, transfer := range transfers {
+		writeTransfer(w, transferType, transfer, appendValue)
+	}
+}
+
+// getTagForTransferType returns the TV format tag for a given transfer type,
+// from the TREP value annotated on the transfer type.
+func getTagForTransferType(transferType vuv1.TransferType) uint16 {
+	value := transferType.Descriptor().Values().ByNumber(transferType.Number())
+	trepValue := proto.GetExtension(value.Options(), vuv1.E_TrepValue).(int32)
+	return uint16(0x7600 | (uint16(trepValue) & 0xFF))
+}
@@ -1,6 +1,10 @@
 package vu
 
 import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -8,7 +12,9 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"google.golang.org/protobuf/encoding/protojson"
 
+	"github.com/way-platform/tachograph-go/internal/dd"
 	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
 )
 
 // TestUnmarshalVehicleUnitFile tests the full semantic parsing of VU files.
@@ -35,7 +41,7 @@ func TestUnmarshalVehicleUnitFile(t *testing.T) {
 			}
 
 			// Unmarshal to VehicleUnitFile (full semantic parsing)
-			vuFile, err := unmarshalVehicleUnitFile(data)
+			vuFile, err := VehicleUnitOptions{}.unmarshalVehicleUnitFile(data)
 			if err != nil {
 				t.Fatalf("unmarshalVehicleUnitFile failed: %v", err)
 			}
@@ -143,7 +149,7 @@ func TestVehicleUnitFileGolden(t *testing.T) {
 				t.Fatalf("failed to read test file: %v", err)
 			}
 
-			vuFile, err := unmarshalVehicleUnitFile(data)
+			vuFile, err := VehicleUnitOptions{}.unmarshalVehicleUnitFile(data)
 			if err != nil {
 				t.Fatalf("unmarshalVehicleUnitFile failed: %v", err)
 			}
@@ -180,7 +186,15 @@ func TestVehicleUnitFileGolden(t *testing.T) {
 				t.Fatalf("failed to read golden file %s: %v", goldenPath, err)
 			}
 
-			if diff := cmp.Diff(string(wantJSON), string(gotJSON)); diff != "" {
+			// Unmarshal both to compare structures, since protojson output is unstable.
+			var got, want map[string]any
+			if err := json.Unmarshal(gotJSON, &got); err != nil {
+				t.Fatalf("failed to unmarshal got JSON: %v", err)
+			}
+			if err := json.Unmarshal(wantJSON, &want); err != nil {
+				t.Fatalf("failed to unmarshal want JSON: %v", err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
 				t.Errorf("Golden file mismatch (-want +got):\n%s", diff)
 			}
 
@@ -188,3 +202,181 @@ func TestVehicleUnitFileGolden(t *testing.T) {
 		})
 	}
 }
+
+func TestScanVehicleUnitFile(t *testing.T) {
+	block := binary.BigEndian.AppendUint32(nil, uint32(1709280000))
+	block = append(block, make([]byte, 60)...)
+	valid := appendTestRecordArray(nil, recordTypeVuDetailedSpeedBlock, lenVuDetailedSpeedBlock, block)
+	valid = appendTestRecordArray(valid, recordTypeSignature, 64, make([]byte, 64))
+	// A speed block record array with a wrong record size slices, but fails to parse.
+	invalid := appendTestRecordArray(nil, recordTypeVuDetailedSpeedBlock, lenVuDetailedSpeedBlock-1, block[:lenVuDetailedSpeedBlock-1])
+	invalid = appendTestRecordArray(invalid, recordTypeSignature, 64, make([]byte, 64))
+	var data []byte
+	data = append(data, 0x76, 0x24)
+	data = append(data, valid...)
+	invalidOffset := len(data)
+	data = append(data, 0x76, 0x24)
+	data = append(data, invalid...)
+	data = append(data, 0x76, 0x24)
+	data = append(data, valid...)
+	trailingOffset := len(data)
+	data = append(data, 0x76)
+
+	if _, err := UnmarshalVehicleUnitFile(data); err == nil {
+		t.Fatal("UnmarshalVehicleUnitFile() on malformed data: expected error")
+	}
+	file, errs, err := ScanVehicleUnitFile(data)
+	if err != nil {
+		t.Fatalf("ScanVehicleUnitFile() error = %v", err)
+	}
+	detailedSpeed := file.GetGen2V1().GetDetailedSpeed()
+	if len(detailedSpeed) != 3 {
+		t.Fatalf("detailed speed transfers = %d, want 3", len(detailedSpeed))
+	}
+	if skipped := detailedSpeed[1]; len(skipped.GetSpeedBlocks()) != 0 || !bytes.Equal(skipped.GetRawData(), invalid) {
+		t.Errorf("skipped transfer = %v, want only its raw data", skipped)
+	}
+	marshaled, err := MarshalVehicleUnitFile(file)
+	if err != nil {
+		t.Fatalf("MarshalVehicleUnitFile() error = %v", err)
+	}
+	if !bytes.Equal(marshaled, data[:trailingOffset]) {
+		t.Errorf("MarshalVehicleUnitFile() = %x, want %x", marshaled, data[:trailingOffset])
+	}
+	if len(errs) != 2 {
+		t.Fatalf("errors = %v, want 2", errs)
+	}
+	if errs[0].Offset != invalidOffset || errs[0].Record.GetType() != vuv1.TransferType_DETAILED_SPEED_GEN2 {
+		t.Errorf("errs[0] = %v, want DETAILED_SPEED_GEN2 at offset %d", errs[0], invalidOffset)
+	}
+	if errs[1].Offset != trailingOffset || errs[1].Record != nil {
+		t.Errorf("errs[1] = %v, want trailing data at offset %d", errs[1], trailingOffset)
+	}
+}
+
+func TestVehicleUnitOptions_transfers(t *testing.T) {
+	block := binary.BigEndian.AppendUint32(nil, uint32(1709280000))
+	block = append(block, make([]byte, 60)...)
+	value := appendTestRecordArray(nil, recordTypeVuDetailedSpeedBlock, lenVuDetailedSpeedBlock, block)
+	value = appendTestRecordArray(value, recordTypeSignature, 64, make([]byte, 64))
+	data := append([]byte{0x76, 0x24}, value...)
+
+	for _, tt := range []struct {
+		name      string
+		transfers []vuv1.TransferType
+		wantRaw   bool
+	}{
+		{name: "all"},
+		{name: "selected", transfers: []vuv1.TransferType{vuv1.TransferType_DETAILED_SPEED_GEN2}},
+		{name: "not selected", transfers: []vuv1.TransferType{vuv1.TransferType_OVERVIEW_GEN2_V1}, wantRaw: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := VehicleUnitOptions{Transfers: tt.transfers}.UnmarshalVehicleUnitFile(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			detailedSpeed := file.GetGen2V1().GetDetailedSpeed()
+			if len(detailedSpeed) != 1 {
+				t.Fatalf("detailed speed transfers = %d, want 1", len(detailedSpeed))
+			}
+			if got := len(detailedSpeed[0].GetSpeedBlocks()) == 0; got != tt.wantRaw {
+				t.Errorf("detailed speed blocks not parsed = %v, want %v", got, tt.wantRaw)
+			}
+			if !bytes.Equal(detailedSpeed[0].GetRawData(), value) {
+				t.Error("raw data differs from the transfer value")
+			}
+		})
+	}
+}
+
+func TestUnmarshalVehicleUnitFile_downloadInterfaceVersion(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		opts        VehicleUnitOptions
+		value       []byte
+		wantVersion ddv1.Version
+		wantErr     error
+	}{
+		{name: "Gen2 v2", value: []byte{0x01, 0x01}, wantVersion: ddv1.Version_VERSION_2},
+		{name: "unsupported version", value: []byte{0x01, 0x02}, wantErr: dd.ErrUnsupportedVersion},
+		{
+			name:        "overridden version",
+			opts:        VehicleUnitOptions{Version: ddv1.Version_VERSION_2},
+			value:       []byte{0x01, 0x02},
+			wantVersion: ddv1.Version_VERSION_2,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			data := append([]byte{0x76, 0x00}, tt.value...)
+			file, err := tt.opts.UnmarshalVehicleUnitFile(data)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("UnmarshalVehicleUnitFile() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := file.GetVersion(); got != tt.wantVersion {
+				t.Errorf("version = %v, want %v", got, tt.wantVersion)
+			}
+			downloadInterfaceVersion := file.GetGen2V2().GetDownloadInterfaceVersion()
+			if got := downloadInterfaceVersion.GetVersion(); got != tt.wantVersion {
+				t.Errorf("download interface version = %v, want %v", got, tt.wantVersion)
+			}
+			if !bytes.Equal(downloadInterfaceVersion.GetRawData(), tt.value) {
+				t.Error("raw data differs from the transfer value")
+			}
+		})
+	}
+}
+
+func TestMarshalVehicleUnitFile_roundTrip(t *testing.T) {
+	testFiles, err := filepath.Glob("../../testdata/vu/*.DDD")
+	if err != nil {
+		t.Fatalf("failed to glob test files: %v", err)
+	}
+	if len(testFiles) == 0 {
+		t.Skip("no VU test files found")
+	}
+	for _, testFile := range testFiles {
+		t.Run(filepath.Base(testFile), func(t *testing.T) {
+			data, err := os.ReadFile(testFile)
+			if err != nil {
+				t.Fatalf("failed to read test file: %v", err)
+			}
+			file, err := UnmarshalVehicleUnitFile(data)
+			if err != nil {
+				t.Fatalf("UnmarshalVehicleUnitFile() error = %v", err)
+			}
+			got, err := MarshalVehicleUnitFile(file)
+			if err != nil {
+				t.Fatalf("MarshalVehicleUnitFile() error = %v", err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Errorf("MarshalVehicleUnitFile() differs from the original file")
+			}
+		})
+	}
+}
+
+func TestMarshalVehicleUnitFile_downloadInterfaceVersion(t *testing.T) {
+	downloadInterfaceVersion := &vuv1.DownloadInterfaceVersion{}
+	downloadInterfaceVersion.SetGeneration(ddv1.Generation_GENERATION_2)
+	downloadInterfaceVersion.SetVersion(ddv1.Version_VERSION_2)
+	gen2v2 := &vuv1.VehicleUnitFileGen2V2{}
+	gen2v2.SetDownloadInterfaceVersion(downloadInterfaceVersion)
+	file := &vuv1.VehicleUnitFile{}
+	file.SetGeneration(ddv1.Generation_GENERATION_2)
+	file.SetVersion(ddv1.Version_VERSION_2)
+	file.SetGen2V2(gen2v2)
+
+	got, err := MarshalVehicleUnitFile(file)
+	if err != nil {
+		t.Fatalf("MarshalVehicleUnitFile() error = %v", err)
+	}
+	if want := []byte{0x76, 0x00, 0x01, 0x01}; !bytes.Equal(got, want) {
+		t.Errorf("MarshalVehicleUnitFile() = % X, want % X", got, want)
+	}
+}
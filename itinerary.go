@@ -0,0 +1,135 @@
+package tachograph
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+)
+
+// BorderCrossing is a border crossing recorded by a Gen2 V2 tachograph.
+type BorderCrossing struct {
+	// Time is the time of the crossing.
+	Time time.Time
+	// CountryLeft is the country that was left.
+	CountryLeft ddv1.NationNumeric
+	// CountryEntered is the country that was entered.
+	CountryEntered ddv1.NationNumeric
+	// OdometerKm is the odometer value at the crossing.
+	OdometerKm int32
+	// Position is the GNSS position of the crossing.
+	Position *ddv1.GeoCoordinates
+	// AuthenticationStatus is the authentication status of the position.
+	AuthenticationStatus ddv1.PositionAuthenticationStatus
+}
+
+// CountryStay is a continuous stay in a single country.
+//
+// When a stay begins or ends with a recorded border crossing, its entry or
+// exit is the crossing. Otherwise, the entry and exit are the first and last
+// place recorded in the country, and the actual crossing happened somewhere
+// between the exit of one stay and the entry of the next.
+type CountryStay struct {
+	// Country is the country of the stay.
+	Country ddv1.NationNumeric
+	// Entry is where and when the stay began.
+	Entry CountryStayBoundary
+	// Exit is where and when the stay ended, as far as recorded.
+	Exit CountryStayBoundary
+}
+
+// Period returns the period from the entry to the exit of the stay.
+func (s CountryStay) Period() Period {
+	return Period{Start: s.Entry.Time, End: s.Exit.Time}
+}
+
+// CountryStayBoundary is the entry into or the exit from a country.
+type CountryStayBoundary struct {
+	// Time is the time of the entry or exit.
+	Time time.Time
+	// OdometerKm is the odometer value at the entry or exit.
+	OdometerKm int32
+	// Position is the GNSS position of the entry or exit, nil if not recorded.
+	Position *ddv1.GeoCoordinates
+	// BorderCrossing reports whether the boundary is a recorded border
+	// crossing, rather than a place recorded inside the country.
+	BorderCrossing bool
+}
+
+// CountryStays reconstructs the chronological itinerary of countries from the
+// places and border crossings recorded on a driver card.
+func CountryStays(card *cardv1.DriverCardFile) []CountryStay {
+	return countryStays(Places(card), BorderCrossings(card))
+}
+
+// CountryStays reconstructs the chronological itinerary of countries from the
+// places and border crossings recorded by the vehicle unit.
+func (h *VehicleHistory) CountryStays() []CountryStay {
+	return countryStays(h.Places, h.BorderCrossings)
+}
+
+// countryStays merges places and border crossings into country stays.
+func countryStays(places []Place, crossings []BorderCrossing) []CountryStay {
+	type observation struct {
+		country  ddv1.NationNumeric
+		boundary CountryStayBoundary
+	}
+	observations := make([]observation, 0, len(places)+len(crossings))
+	for _, p := range places {
+		if isKnownCountry(p.Country) {
+			observations = append(observations, observation{
+				country:  p.Country,
+				boundary: CountryStayBoundary{Time: p.Time, OdometerKm: p.OdometerKm, Position: p.Position},
+			})
+		}
+	}
+	for _, c := range crossings {
+		if isKnownCountry(c.CountryEntered) {
+			observations = append(observations, observation{
+				country:  c.CountryEntered,
+				boundary: CountryStayBoundary{Time: c.Time, OdometerKm: c.OdometerKm, Position: c.Position, BorderCrossing: true},
+			})
+		}
+	}
+	// Crossings sort before places at the same time, so that a place
+	// recorded at the moment of crossing belongs to the new country.
+	slices.SortStableFunc(observations, func(a, b observation) int {
+		return cmp.Or(a.boundary.Time.Compare(b.boundary.Time), compareBool(b.boundary.BorderCrossing, a.boundary.BorderCrossing))
+	})
+	var result []CountryStay
+	for _, o := range observations {
+		n := len(result)
+		if n > 0 && result[n-1].Country == o.country {
+			if !o.boundary.BorderCrossing {
+				result[n-1].Exit = o.boundary
+			}
+			continue
+		}
+		if n > 0 && o.boundary.BorderCrossing {
+			result[n-1].Exit = o.boundary
+		}
+		exit := o.boundary
+		exit.BorderCrossing = false
+		result = append(result, CountryStay{Country: o.country, Entry: o.boundary, Exit: exit})
+	}
+	return result
+}
+
+// isKnownCountry reports whether the nation identifies an actual country.
+func isKnownCountry(n ddv1.NationNumeric) bool {
+	return n > ddv1.NationNumeric_NATION_NUMERIC_EMPTY
+}
+
+// compareBool orders false before true.
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,46 @@
+package tachograph
+
+import (
+	"testing"
+	"time"
+
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+)
+
+func TestCountryStays(t *testing.T) {
+	at := func(hours int) time.Time { return testDay(0).Add(time.Duration(hours) * time.Hour) }
+	places := []Place{
+		{Time: at(6), EntryType: ddv1.EntryTypeDailyWorkPeriod_BEGIN, Country: ddv1.NationNumeric_GERMANY, OdometerKm: 1000},
+		{Time: at(18), EntryType: ddv1.EntryTypeDailyWorkPeriod_END, Country: ddv1.NationNumeric_AUSTRIA, OdometerKm: 1500},
+		{Time: at(30), EntryType: ddv1.EntryTypeDailyWorkPeriod_BEGIN, Country: ddv1.NationNumeric_AUSTRIA, OdometerKm: 1500},
+		// No border crossing recorded between Austria and Italy.
+		{Time: at(40), EntryType: ddv1.EntryTypeDailyWorkPeriod_END, Country: ddv1.NationNumeric_ITALY, OdometerKm: 1900},
+		{Time: at(41), EntryType: ddv1.EntryTypeDailyWorkPeriod_BEGIN, Country: ddv1.NationNumeric_NATION_NUMERIC_EMPTY},
+	}
+	crossings := []BorderCrossing{
+		{Time: at(12), CountryLeft: ddv1.NationNumeric_GERMANY, CountryEntered: ddv1.NationNumeric_AUSTRIA, OdometerKm: 1250},
+	}
+
+	got := countryStays(places, crossings)
+	want := []struct {
+		country               ddv1.NationNumeric
+		entry, exit           time.Time
+		entryKm, exitKm       int32
+		entryCross, exitCross bool
+	}{
+		{country: ddv1.NationNumeric_GERMANY, entry: at(6), exit: at(12), entryKm: 1000, exitKm: 1250, exitCross: true},
+		{country: ddv1.NationNumeric_AUSTRIA, entry: at(12), exit: at(30), entryKm: 1250, exitKm: 1500, entryCross: true},
+		{country: ddv1.NationNumeric_ITALY, entry: at(40), exit: at(40), entryKm: 1900, exitKm: 1900},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("countryStays() = %+v, want %d stays", got, len(want))
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.Country != w.country || !g.Entry.Time.Equal(w.entry) || !g.Exit.Time.Equal(w.exit) ||
+			g.Entry.OdometerKm != w.entryKm || g.Exit.OdometerKm != w.exitKm ||
+			g.Entry.BorderCrossing != w.entryCross || g.Exit.BorderCrossing != w.exitCross {
+			t.Errorf("stay %d = %+v, want %+v", i, g, w)
+		}
+	}
+}
@@ -2,17 +2,51 @@ package tachograph
 
 import (
 	"fmt"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
 
 	"github.com/way-platform/tachograph-go/internal/card"
 	"github.com/way-platform/tachograph-go/internal/vu"
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
 	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
 )
 
 // MarshalFile serializes a protobuf File message into the binary DDD file format.
+//
+// See [MarshalOptions] if you need more control over the serialization.
 func MarshalFile(file *tachographv1.File) ([]byte, error) {
+	return MarshalOptions{}.MarshalFile(file)
+}
+
+// MarshalOptions configures the serialization of tachograph files.
+type MarshalOptions struct {
+	// IgnoreRawData serializes the decoded messages from their parsed values
+	// alone, ignoring their raw data.
+	//
+	// By default, the raw data of some messages, such as strings, takes
+	// precedence over their parsed values, and the raw data of other messages
+	// keeps the bytes that have no parsed value, such as reserved bits.
+	// Ignoring raw data writes all changes made to the parsed values of a
+	// file, such as hand-edited values. Vehicle unit transfers and
+	// certificates can't be serialized from their values, and are still
+	// written from their raw data, as are the EFs that are not decoded.
+	IgnoreRawData bool
+}
+
+// MarshalFile serializes a protobuf File message into the binary DDD file format.
+//
+// The raw card records of a driver card file, as kept by
+// [UnmarshalOptions.ElementaryFiles], are written together with its decoded
+// EFs.
+func (o MarshalOptions) MarshalFile(file *tachographv1.File) ([]byte, error) {
+	if o.IgnoreRawData {
+		file = proto.CloneOf(file)
+		discardRawData(file.ProtoReflect())
+	}
 	switch file.GetType() {
 	case tachographv1.File_DRIVER_CARD:
-		return card.MarshalDriverCardFile(file.GetDriverCard())
+		return marshalDriverCardFile(file)
 	case tachographv1.File_VEHICLE_UNIT:
 		return vu.MarshalVehicleUnitFile(file.GetVehicleUnit())
 	case tachographv1.File_RAW_CARD:
@@ -21,3 +55,34 @@ func MarshalFile(file *tachographv1.File) ([]byte, error) {
 		return nil, fmt.Errorf("unsupported file type for marshaling: %v", file.GetType())
 	}
 }
+
+// marshalDriverCardFile serializes a driver card file, together with the EFs
+// kept as raw card records.
+//
+// The EFs are ordered by dedicated file: the EFs of the master file (EF_ICC
+// and EF_IC) first, so that the file can be recognized, then the EFs of the
+// Tachograph DF and of the Tachograph_G2 DF.
+func marshalDriverCardFile(file *tachographv1.File) ([]byte, error) {
+	data, err := card.MarshalDriverCardFile(file.GetDriverCard())
+	if err != nil || len(file.GetRawCard().GetRecords()) == 0 {
+		return data, err
+	}
+	decoded, err := card.UnmarshalRawCardFile(data)
+	if err != nil {
+		return nil, err
+	}
+	records := slices.Concat(decoded.GetRecords(), file.GetRawCard().GetRecords())
+	dedicatedFile := func(record *cardv1.RawCardFile_Record) int {
+		switch record.GetFile() {
+		case cardv1.ElementaryFileType_EF_ICC, cardv1.ElementaryFileType_EF_IC:
+			return 0
+		}
+		return int(record.GetGeneration())
+	}
+	slices.SortStableFunc(records, func(a, b *cardv1.RawCardFile_Record) int {
+		return dedicatedFile(a) - dedicatedFile(b)
+	})
+	var rawCardFile cardv1.RawCardFile
+	rawCardFile.SetRecords(records)
+	return card.MarshalRawCardFile(&rawCardFile)
+}
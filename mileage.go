@@ -0,0 +1,401 @@
+package tachograph
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+)
+
+// MileageAnomalyType is the type of a [MileageAnomaly].
+type MileageAnomalyType int
+
+const (
+	// MileageAnomalyDistanceWithoutCard is distance driven while no card was
+	// inserted in the vehicle unit.
+	MileageAnomalyDistanceWithoutCard MileageAnomalyType = iota + 1
+	// MileageAnomalyOdometerJump is an odometer increase between two
+	// consecutive uses of the same vehicle on a driver card, while no other
+	// driver card in the input used the vehicle.
+	MileageAnomalyOdometerJump
+	// MileageAnomalyOdometerDecrease is an odometer value that is lower than
+	// an earlier value recorded for the same vehicle.
+	MileageAnomalyOdometerDecrease
+	// MileageAnomalyOdometerMismatch is a vehicle use for which the driver card
+	// and the vehicle unit recorded different odometer values.
+	MileageAnomalyOdometerMismatch
+)
+
+// String returns a human-readable name for the anomaly type.
+func (t MileageAnomalyType) String() string {
+	switch t {
+	case MileageAnomalyDistanceWithoutCard:
+		return "distance without card"
+	case MileageAnomalyOdometerJump:
+		return "odometer jump"
+	case MileageAnomalyOdometerDecrease:
+		return "odometer decrease"
+	case MileageAnomalyOdometerMismatch:
+		return "odometer mismatch"
+	default:
+		return "unknown"
+	}
+}
+
+// MileageAnomaly is a distance that is not accounted for by the recorded
+// vehicle uses, or an inconsistency between recorded odometer values.
+type MileageAnomaly struct {
+	// Type is the type of the anomaly.
+	Type MileageAnomalyType
+	// Period is the period in which the anomaly occurred.
+	//
+	// For distance without card and odometer jumps, this is the period between
+	// the two vehicle uses. For odometer mismatches, this is the vehicle use.
+	Period Period
+	// VIN is the vehicle identification number, if known.
+	VIN string
+	// Registration is the vehicle registration, if known.
+	Registration VehicleRegistration
+	// CardNumber is the driver card involved, if any.
+	CardNumber string
+	// FromOdometerKm is the odometer value at the start of the period.
+	//
+	// For odometer mismatches, this is the value recorded on the driver card.
+	FromOdometerKm int32
+	// ToOdometerKm is the odometer value at the end of the period.
+	//
+	// For odometer mismatches, this is the value recorded by the vehicle unit.
+	ToOdometerKm int32
+}
+
+// DistanceKm returns the odometer difference of the anomaly, taking into
+// account that the odometer wraps from 9,999,999 to 0 km.
+func (a MileageAnomaly) DistanceKm() int32 {
+	return odometerDistanceKm(a.FromOdometerKm, a.ToOdometerKm)
+}
+
+// odometerRangeKm is the number of odometer values, as an OdometerShort
+// ranges from 0 to 9,999,999 km and then wraps to 0.
+const odometerRangeKm = 10_000_000
+
+// odometerDistanceKm returns the odometer difference from one value to
+// another. A difference of more than half the odometer range is taken as the
+// odometer wrapping, so 9,999,990 to 10 km is a distance of 20 km.
+func odometerDistanceKm(fromKm, toKm int32) int32 {
+	switch distance := toKm - fromKm; {
+	case distance < -odometerRangeKm/2:
+		return distance + odometerRangeKm
+	case distance > odometerRangeKm/2:
+		return distance - odometerRangeKm
+	default:
+		return distance
+	}
+}
+
+// MileageOptions configures the mileage checks.
+type MileageOptions struct {
+	// ToleranceKm is the largest odometer difference that is not reported.
+	ToleranceKm int32
+	// MatchWindow is the largest time difference between a driver card vehicle
+	// record and a vehicle unit card insertion that are considered the same
+	// vehicle use. If zero, this defaults to one minute.
+	MatchWindow time.Duration
+}
+
+// CheckMileage cross-checks the odometer values of a vehicle history and
+// driver cards, and returns the anomalies found ordered by time.
+//
+// See [MileageOptions] if you need more control over the checks.
+func CheckMileage(history *VehicleHistory, cards ...*cardv1.DriverCardFile) []MileageAnomaly {
+	return MileageOptions{}.CheckMileage(history, cards...)
+}
+
+// CheckMileage cross-checks the odometer values of a vehicle history and
+// driver cards, and returns the anomalies found ordered by time.
+//
+// The history may be nil to check driver cards only. The following checks are
+// made:
+//   - Vehicle unit: distance driven between a card withdrawal and the next
+//     card insertion in any slot. Periods not covered by a download are
+//     skipped, since card insertions in them are unknown. A card that was
+//     still inserted at download covers the period until the download; the
+//     distance driven after it is not checked, since its odometer value at
+//     download is unknown.
+//   - Driver card: odometer jumps between consecutive uses of the same
+//     vehicle, and odometer decreases within a single use. A jump is only
+//     reported if no other driver card used the vehicle in between, neither
+//     in the given cards nor in the card insertions of the history, since
+//     another driver accounts for the distance. With a single card, all
+//     jumps are reported.
+//   - Driver card and vehicle unit: odometer values at the beginning and end
+//     of a vehicle use that differ between the card and the vehicle unit.
+//
+// Odometer differences take into account that the odometer wraps from
+// 9,999,999 to 0 km.
+func (o MileageOptions) CheckMileage(history *VehicleHistory, cards ...*cardv1.DriverCardFile) []MileageAnomaly {
+	if o.MatchWindow == 0 {
+		o.MatchWindow = time.Minute
+	}
+	var result []MileageAnomaly
+	if history != nil {
+		result = append(result, o.checkVehicleHistory(history)...)
+	}
+	uses := make(map[string][]VehicleUse, len(cards))
+	for _, card := range cards {
+		cardNumber := DriverCardNumber(card)
+		uses[cardNumber] = append(uses[cardNumber], VehicleUses(card)...)
+	}
+	for _, card := range cards {
+		cardNumber := DriverCardNumber(card)
+		result = append(result, o.checkVehicleUses(cardNumber, VehicleUses(card), uses, history)...)
+		if history != nil {
+			result = append(result, o.compareVehicleUses(cardNumber, VehicleUses(card), history)...)
+		}
+	}
+	slices.SortStableFunc(result, func(a, b MileageAnomaly) int {
+		return cmp.Or(a.Period.Start.Compare(b.Period.Start), cmp.Compare(a.Type, b.Type))
+	})
+	return result
+}
+
+// checkVehicleHistory finds distance driven without a card in a vehicle history.
+func (o MileageOptions) checkVehicleHistory(h *VehicleHistory) []MileageAnomaly {
+	var result []MileageAnomaly
+	var covered time.Time
+	var coveredOdometerKm int32
+	var coveredCardNumber string
+	// coveredOdometerKnown is false after a card that was still inserted at
+	// download, whose odometer value at the end of the covered period is unknown.
+	coveredOdometerKnown := true
+	for _, c := range h.CardInsertions {
+		open := c.WithdrawalTime.IsZero()
+		if !open && -odometerDistanceKm(c.OdometerAtInsertionKm, c.OdometerAtWithdrawalKm) > o.ToleranceKm {
+			result = append(result, MileageAnomaly{
+				Type:           MileageAnomalyOdometerDecrease,
+				Period:         Period{Start: c.InsertionTime, End: c.WithdrawalTime},
+				VIN:            h.VIN,
+				Registration:   h.registrationAt(c.InsertionTime),
+				CardNumber:     c.CardNumber,
+				FromOdometerKm: c.OdometerAtInsertionKm,
+				ToOdometerKm:   c.OdometerAtWithdrawalKm,
+			})
+		}
+		if !covered.IsZero() && coveredOdometerKnown && c.InsertionTime.After(covered) {
+			gap := Period{Start: covered, End: c.InsertionTime}
+			if !overlapsAny(gap, h.Gaps) {
+				a := MileageAnomaly{
+					VIN:            h.VIN,
+					Registration:   h.registrationAt(gap.Start),
+					Period:         gap,
+					FromOdometerKm: coveredOdometerKm,
+					ToOdometerKm:   c.OdometerAtInsertionKm,
+				}
+				switch distance := a.DistanceKm(); {
+				case distance > o.ToleranceKm:
+					a.Type = MileageAnomalyDistanceWithoutCard
+					result = append(result, a)
+				case -distance > o.ToleranceKm:
+					a.Type = MileageAnomalyOdometerDecrease
+					a.CardNumber = coveredCardNumber
+					result = append(result, a)
+				}
+			}
+		}
+		end := c.WithdrawalTime
+		if open {
+			// The card was still inserted at download, so it covers the period
+			// until the download.
+			var ok bool
+			if end, ok = h.downloadTimeAt(c.InsertionTime); !ok {
+				continue
+			}
+		}
+		if end.After(covered) {
+			covered = end
+			coveredOdometerKm = c.OdometerAtWithdrawalKm
+			coveredCardNumber = c.CardNumber
+			coveredOdometerKnown = !open
+		}
+	}
+	return result
+}
+
+// downloadTimeAt returns the time of the first download made at or after t.
+func (h *VehicleHistory) downloadTimeAt(t time.Time) (time.Time, bool) {
+	var result time.Time
+	for _, d := range h.Downloads {
+		if !d.DownloadTime.Before(t) && (result.IsZero() || d.DownloadTime.Before(result)) {
+			result = d.DownloadTime
+		}
+	}
+	return result, !result.IsZero()
+}
+
+// registrationAt returns the vehicle registration of the first download made
+// at or after t, or of the latest download if there is none.
+func (h *VehicleHistory) registrationAt(t time.Time) VehicleRegistration {
+	var result VehicleRegistration
+	for _, d := range h.Downloads {
+		if d.Registration.Number == "" {
+			continue
+		}
+		result = d.Registration
+		if !t.After(d.DownloadTime) {
+			break
+		}
+	}
+	return result
+}
+
+// checkVehicleUses finds odometer jumps and decreases in the vehicle uses of a
+// driver card. The vehicle uses of all cards by card number, and the vehicle
+// history, which may be nil, account for the odometer jumps in the vehicles
+// that other cards used.
+func (o MileageOptions) checkVehicleUses(cardNumber string, uses []VehicleUse, allUses map[string][]VehicleUse, h *VehicleHistory) []MileageAnomaly {
+	var result []MileageAnomaly
+	last := map[string]VehicleUse{}
+	for _, u := range uses {
+		if u.LastUse.IsZero() {
+			// The vehicle is still in use, so the end odometer is not yet recorded.
+			u.OdometerEndKm = u.OdometerBeginKm
+		} else if -odometerDistanceKm(u.OdometerBeginKm, u.OdometerEndKm) > o.ToleranceKm {
+			result = append(result, u.anomaly(
+				MileageAnomalyOdometerDecrease,
+				cardNumber,
+				Period{Start: u.FirstUse, End: u.LastUse},
+				u.OdometerBeginKm,
+				u.OdometerEndKm,
+			))
+		}
+		key := strings.ToUpper(u.Registration.Number)
+		if prev, ok := last[key]; ok {
+			a := u.anomaly(0, cardNumber, Period{Start: prev.LastUse, End: u.FirstUse}, prev.OdometerEndKm, u.OdometerBeginKm)
+			switch distance := a.DistanceKm(); {
+			case distance > o.ToleranceKm:
+				if usedByOtherCard(cardNumber, u, a.Period, allUses, h) {
+					break
+				}
+				a.Type = MileageAnomalyOdometerJump
+				result = append(result, a)
+			case -distance > o.ToleranceKm:
+				a.Type = MileageAnomalyOdometerDecrease
+				result = append(result, a)
+			}
+		}
+		if !u.LastUse.IsZero() {
+			last[key] = u
+		}
+	}
+	return result
+}
+
+// usedByOtherCard reports whether a card other than the given one used the
+// vehicle of a vehicle use in the period, according to the vehicle uses of
+// the cards or the card insertions of the vehicle history, which may be nil.
+func usedByOtherCard(cardNumber string, u VehicleUse, p Period, uses map[string][]VehicleUse, h *VehicleHistory) bool {
+	for other, otherUses := range uses {
+		if other == cardNumber {
+			continue
+		}
+		for _, v := range otherUses {
+			end := v.LastUse
+			if end.IsZero() {
+				end = v.FirstUse
+			}
+			if strings.EqualFold(v.Registration.Number, u.Registration.Number) && overlapsAny(p, []Period{{Start: v.FirstUse, End: end}}) {
+				return true
+			}
+		}
+	}
+	if h == nil || !h.usedBy(u) {
+		return false
+	}
+	return slices.ContainsFunc(h.CardInsertions, func(c CardInsertion) bool {
+		end := c.WithdrawalTime
+		if end.IsZero() {
+			end = c.InsertionTime
+		}
+		return c.CardNumber != cardNumber && overlapsAny(p, []Period{{Start: c.InsertionTime, End: end}})
+	})
+}
+
+// usedBy reports whether a vehicle use of a driver card is of the vehicle of
+// the history, by its VIN or else its registrations.
+func (h *VehicleHistory) usedBy(u VehicleUse) bool {
+	if u.VIN != "" {
+		return strings.EqualFold(u.VIN, h.VIN)
+	}
+	registrations := h.Registrations()
+	return len(registrations) == 0 || slices.ContainsFunc(registrations, func(r VehicleRegistration) bool {
+		return strings.EqualFold(r.Number, u.Registration.Number)
+	})
+}
+
+// compareVehicleUses finds vehicle uses of a driver card for which the
+// vehicle unit recorded different odometer values.
+func (o MileageOptions) compareVehicleUses(cardNumber string, uses []VehicleUse, h *VehicleHistory) []MileageAnomaly {
+	var result []MileageAnomaly
+	for _, u := range uses {
+		if !h.usedBy(u) {
+			continue
+		}
+		i := slices.IndexFunc(h.CardInsertions, func(c CardInsertion) bool {
+			return c.CardNumber == cardNumber && absDuration(c.InsertionTime.Sub(u.FirstUse)) <= o.MatchWindow
+		})
+		if i < 0 {
+			continue
+		}
+		c := h.CardInsertions[i]
+		period := Period{Start: u.FirstUse, End: u.LastUse}
+		if absKm(odometerDistanceKm(u.OdometerBeginKm, c.OdometerAtInsertionKm)) > o.ToleranceKm {
+			a := u.anomaly(MileageAnomalyOdometerMismatch, cardNumber, period, u.OdometerBeginKm, c.OdometerAtInsertionKm)
+			a.VIN = h.VIN
+			result = append(result, a)
+		}
+		if u.LastUse.IsZero() || c.WithdrawalTime.IsZero() {
+			continue
+		}
+		if absKm(odometerDistanceKm(u.OdometerEndKm, c.OdometerAtWithdrawalKm)) > o.ToleranceKm {
+			a := u.anomaly(MileageAnomalyOdometerMismatch, cardNumber, period, u.OdometerEndKm, c.OdometerAtWithdrawalKm)
+			a.VIN = h.VIN
+			result = append(result, a)
+		}
+	}
+	return result
+}
+
+// anomaly returns a mileage anomaly of the given type for the vehicle use.
+func (u VehicleUse) anomaly(t MileageAnomalyType, cardNumber string, period Period, fromKm, toKm int32) MileageAnomaly {
+	return MileageAnomaly{
+		Type:           t,
+		Period:         period,
+		VIN:            u.VIN,
+		Registration:   u.Registration,
+		CardNumber:     cardNumber,
+		FromOdometerKm: fromKm,
+		ToOdometerKm:   toKm,
+	}
+}
+
+// overlapsAny reports whether p overlaps any of the periods.
+func overlapsAny(p Period, periods []Period) bool {
+	return slices.ContainsFunc(periods, func(q Period) bool {
+		return p.Start.Before(q.End) && q.Start.Before(p.End)
+	})
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+func absKm(km int32) int32 {
+	if km < 0 {
+		return -km
+	}
+	return km
+}
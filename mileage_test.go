@@ -0,0 +1,187 @@
+package tachograph
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+)
+
+// testDriverCardFile builds a Gen1 driver card holding the given vehicle records.
+func testDriverCardFile(cardNumber string, records ...*ddv1.CardVehicleRecord) *cardv1.DriverCardFile {
+	driverID := &ddv1.DriverIdentification{}
+	driverID.SetDriverIdentificationNumber(testIa5(cardNumber))
+	card := &cardv1.Identification_Card{}
+	card.SetDriverIdentification(driverID)
+	identification := &cardv1.Identification{}
+	identification.SetCard(card)
+	vehiclesUsed := &cardv1.VehiclesUsed{}
+	vehiclesUsed.SetRecords(records)
+	tachograph := &cardv1.DriverCardFile_Tachograph{}
+	tachograph.SetIdentification(identification)
+	tachograph.SetVehiclesUsed(vehiclesUsed)
+	file := &cardv1.DriverCardFile{}
+	file.SetTachograph(tachograph)
+	return file
+}
+
+func testCardVehicleRecord(registration string, use Period, beginKm, endKm int32) *ddv1.CardVehicleRecord {
+	number := &ddv1.StringValue{}
+	number.SetValue(registration)
+	vri := &ddv1.VehicleRegistrationIdentification{}
+	vri.SetNumber(number)
+	record := &ddv1.CardVehicleRecord{}
+	record.SetVehicleRegistration(vri)
+	record.SetVehicleFirstUse(timestamppb.New(use.Start))
+	record.SetVehicleLastUse(timestamppb.New(use.End))
+	record.SetVehicleOdometerBeginKm(beginKm)
+	record.SetVehicleOdometerEndKm(endKm)
+	return record
+}
+
+func TestCheckMileage(t *testing.T) {
+	const cardNumber = "DRIVER00000001"
+	morning := Period{Start: testDay(0).Add(6 * time.Hour), End: testDay(0).Add(10 * time.Hour)}
+	afternoon := Period{Start: testDay(0).Add(12 * time.Hour), End: testDay(0).Add(16 * time.Hour)}
+	nextDay := Period{Start: testDay(1).Add(6 * time.Hour), End: testDay(1).Add(10 * time.Hour)}
+	history := &VehicleHistory{
+		VIN: "WDB9634031L123456",
+		Downloads: []VehicleDownload{{
+			DownloadTime:       testDay(2),
+			DownloadablePeriod: Period{Start: testDay(0), End: testDay(2)},
+			Registration:       VehicleRegistration{Number: "AB123CD"},
+		}},
+		CardInsertions: []CardInsertion{
+			{CardNumber: cardNumber, InsertionTime: morning.Start, WithdrawalTime: morning.End, OdometerAtInsertionKm: 1000, OdometerAtWithdrawalKm: 1200},
+			// 30 km driven without a card over lunch.
+			{CardNumber: cardNumber, InsertionTime: afternoon.Start, WithdrawalTime: afternoon.End, OdometerAtInsertionKm: 1230, OdometerAtWithdrawalKm: 1400},
+			{CardNumber: cardNumber, InsertionTime: nextDay.Start, WithdrawalTime: nextDay.End, OdometerAtInsertionKm: 1400, OdometerAtWithdrawalKm: 1500},
+		},
+	}
+	card := testDriverCardFile(cardNumber,
+		testCardVehicleRecord("AB123CD", morning, 1000, 1200),
+		// The card records a different begin odometer than the vehicle unit.
+		testCardVehicleRecord("AB123CD", afternoon, 1235, 1400),
+		testCardVehicleRecord("AB123CD", nextDay, 1400, 1500),
+	)
+
+	got := CheckMileage(history, card)
+	want := []MileageAnomaly{
+		{Type: MileageAnomalyDistanceWithoutCard, Period: Period{Start: morning.End, End: afternoon.Start}, FromOdometerKm: 1200, ToOdometerKm: 1230},
+		{Type: MileageAnomalyOdometerJump, Period: Period{Start: morning.End, End: afternoon.Start}, FromOdometerKm: 1200, ToOdometerKm: 1235},
+		{Type: MileageAnomalyOdometerMismatch, Period: afternoon, FromOdometerKm: 1235, ToOdometerKm: 1230},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("CheckMileage() = %+v, want %d anomalies", got, len(want))
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.Type != w.Type || !g.Period.Start.Equal(w.Period.Start) || !g.Period.End.Equal(w.Period.End) ||
+			g.FromOdometerKm != w.FromOdometerKm || g.ToOdometerKm != w.ToOdometerKm {
+			t.Errorf("anomaly %d = %+v, want %+v", i, g, w)
+		}
+		if g.Registration.Number != "AB123CD" {
+			t.Errorf("anomaly %d registration = %q, want %q", i, g.Registration.Number, "AB123CD")
+		}
+	}
+
+	if got := (MileageOptions{ToleranceKm: 50}).CheckMileage(history, card); len(got) != 0 {
+		t.Errorf("CheckMileage() with tolerance = %+v, want none", got)
+	}
+}
+
+func TestCheckMileage_openInsertion(t *testing.T) {
+	const cardNumber = "DRIVER00000001"
+	history := &VehicleHistory{
+		Downloads: []VehicleDownload{
+			{DownloadTime: testDay(0).Add(18 * time.Hour), DownloadablePeriod: Period{Start: testDay(0), End: testDay(0).Add(18 * time.Hour)}},
+			{DownloadTime: testDay(2), DownloadablePeriod: Period{Start: testDay(0), End: testDay(2)}},
+		},
+		CardInsertions: []CardInsertion{
+			// Still inserted at the first download.
+			{CardNumber: cardNumber, InsertionTime: testDay(0).Add(12 * time.Hour), OdometerAtInsertionKm: 1000},
+			// A co-driver inserted while the first card covers the vehicle.
+			{CardNumber: "DRIVER00000002", InsertionTime: testDay(0).Add(14 * time.Hour), WithdrawalTime: testDay(0).Add(15 * time.Hour), OdometerAtInsertionKm: 1100, OdometerAtWithdrawalKm: 1150},
+			// The odometer at the end of the open insertion is unknown.
+			{CardNumber: cardNumber, InsertionTime: testDay(1).Add(6 * time.Hour), WithdrawalTime: testDay(1).Add(10 * time.Hour), OdometerAtInsertionKm: 1300, OdometerAtWithdrawalKm: 1500},
+			// 40 km driven without a card over lunch.
+			{CardNumber: cardNumber, InsertionTime: testDay(1).Add(12 * time.Hour), WithdrawalTime: testDay(1).Add(16 * time.Hour), OdometerAtInsertionKm: 1540, OdometerAtWithdrawalKm: 1700},
+		},
+	}
+
+	got := CheckMileage(history)
+	if len(got) != 1 {
+		t.Fatalf("CheckMileage() = %+v, want 1 anomaly", got)
+	}
+	want := MileageAnomaly{
+		Type:           MileageAnomalyDistanceWithoutCard,
+		Period:         Period{Start: testDay(1).Add(10 * time.Hour), End: testDay(1).Add(12 * time.Hour)},
+		FromOdometerKm: 1500,
+		ToOdometerKm:   1540,
+	}
+	if g := got[0]; g.Type != want.Type || !g.Period.Start.Equal(want.Period.Start) || !g.Period.End.Equal(want.Period.End) ||
+		g.FromOdometerKm != want.FromOdometerKm || g.ToOdometerKm != want.ToOdometerKm {
+		t.Errorf("anomaly = %+v, want %+v", g, want)
+	}
+}
+
+func TestCheckMileage_otherCard(t *testing.T) {
+	morning := Period{Start: testDay(0).Add(6 * time.Hour), End: testDay(0).Add(10 * time.Hour)}
+	noon := Period{Start: testDay(0).Add(11 * time.Hour), End: testDay(0).Add(12 * time.Hour)}
+	afternoon := Period{Start: testDay(0).Add(13 * time.Hour), End: testDay(0).Add(16 * time.Hour)}
+	driver := testDriverCardFile("DRIVER00000001",
+		testCardVehicleRecord("AB123CD", morning, 1000, 1200),
+		testCardVehicleRecord("AB123CD", afternoon, 1300, 1400),
+	)
+	// Another driver drove the 100 km between the uses of the first driver.
+	other := testDriverCardFile("DRIVER00000002", testCardVehicleRecord("ab123cd", noon, 1200, 1300))
+
+	got := CheckMileage(nil, driver)
+	if len(got) != 1 || got[0].Type != MileageAnomalyOdometerJump || got[0].DistanceKm() != 100 {
+		t.Errorf("CheckMileage() with a single card = %+v, want an odometer jump of 100 km", got)
+	}
+	if got := CheckMileage(nil, driver, other); len(got) != 0 {
+		t.Errorf("CheckMileage() with the other card = %+v, want none", got)
+	}
+	history := &VehicleHistory{
+		Downloads: []VehicleDownload{{
+			DownloadTime:       testDay(1),
+			DownloadablePeriod: Period{Start: testDay(0), End: testDay(1)},
+			Registration:       VehicleRegistration{Number: "AB123CD"},
+		}},
+		CardInsertions: []CardInsertion{
+			{CardNumber: "DRIVER00000001", InsertionTime: morning.Start, WithdrawalTime: morning.End, OdometerAtInsertionKm: 1000, OdometerAtWithdrawalKm: 1200},
+			{CardNumber: "DRIVER00000002", InsertionTime: noon.Start, WithdrawalTime: noon.End, OdometerAtInsertionKm: 1200, OdometerAtWithdrawalKm: 1300},
+			{CardNumber: "DRIVER00000001", InsertionTime: afternoon.Start, WithdrawalTime: afternoon.End, OdometerAtInsertionKm: 1300, OdometerAtWithdrawalKm: 1400},
+		},
+	}
+	if got := CheckMileage(history, driver); len(got) != 0 {
+		t.Errorf("CheckMileage() with the vehicle history = %+v, want none", got)
+	}
+}
+
+func TestCheckMileage_odometerWrap(t *testing.T) {
+	morning := Period{Start: testDay(0).Add(6 * time.Hour), End: testDay(0).Add(10 * time.Hour)}
+	afternoon := Period{Start: testDay(0).Add(12 * time.Hour), End: testDay(0).Add(16 * time.Hour)}
+	card := testDriverCardFile("DRIVER00000001",
+		// The odometer wraps from 9,999,999 to 0 km during the morning.
+		testCardVehicleRecord("AB123CD", morning, 9_999_900, 100),
+		testCardVehicleRecord("AB123CD", afternoon, 100, 300),
+	)
+	if got := CheckMileage(nil, card); len(got) != 0 {
+		t.Errorf("CheckMileage() = %+v, want none", got)
+	}
+
+	card = testDriverCardFile("DRIVER00000001",
+		testCardVehicleRecord("AB123CD", morning, 9_999_800, 9_999_990),
+		// 30 km driven without the card, over the wrap of the odometer.
+		testCardVehicleRecord("AB123CD", afternoon, 20, 300),
+	)
+	got := CheckMileage(nil, card)
+	if len(got) != 1 || got[0].Type != MileageAnomalyOdometerJump || got[0].DistanceKm() != 30 {
+		t.Errorf("CheckMileage() = %+v, want an odometer jump of 30 km", got)
+	}
+}
@@ -0,0 +1,56 @@
+package tachograph
+
+import (
+	"time"
+
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+)
+
+// LoadUnloadOperation is a load or unload operation recorded by a Gen2 V2
+// tachograph.
+type LoadUnloadOperation struct {
+	// Time is the time of the operation.
+	Time time.Time
+	// OperationType tells if the operation is a load, an unload, or both.
+	OperationType ddv1.OperationType
+	// OdometerKm is the odometer value at the operation.
+	OdometerKm int32
+	// Position is the GNSS position of the operation.
+	Position *ddv1.GeoCoordinates
+	// AuthenticationStatus is the authentication status of the position.
+	AuthenticationStatus ddv1.PositionAuthenticationStatus
+}
+
+// AccumulatedDrivingPosition is a GNSS position recorded by a Gen2 tachograph
+// each time the accumulated driving time reaches a multiple of three hours.
+type AccumulatedDrivingPosition struct {
+	// Time is the time of the position fix.
+	Time time.Time
+	// OdometerKm is the odometer value at the position, zero if not recorded.
+	OdometerKm int32
+	// Position is the GNSS position.
+	Position *ddv1.GeoCoordinates
+	// AuthenticationStatus is the authentication status of the position,
+	// unspecified if not recorded.
+	AuthenticationStatus ddv1.PositionAuthenticationStatus
+}
+
+// unknownGeoCoordinate is the value of an unknown latitude or longitude.
+const unknownGeoCoordinate = 0x7FFFFF
+
+// DecimalDegrees converts GNSS coordinates to WGS84 latitude and longitude in
+// decimal degrees.
+//
+// The result is false if the coordinates are nil, unknown or out of range.
+func DecimalDegrees(coordinates *ddv1.GeoCoordinates) (latitude, longitude float64, ok bool) {
+	if coordinates == nil ||
+		coordinates.GetLatitude() == unknownGeoCoordinate || coordinates.GetLongitude() == unknownGeoCoordinate {
+		return 0, 0, false
+	}
+	latitude = geoCoordinateDegrees(coordinates.GetLatitude())
+	longitude = geoCoordinateDegrees(coordinates.GetLongitude())
+	if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return 0, 0, false
+	}
+	return latitude, longitude, true
+}
@@ -0,0 +1,77 @@
+package tachograph
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func testGeoCoordinates(latitude, longitude int32) *ddv1.GeoCoordinates {
+	c := &ddv1.GeoCoordinates{}
+	c.SetLatitude(latitude)
+	c.SetLongitude(longitude)
+	return c
+}
+
+func TestDecimalDegrees(t *testing.T) {
+	for _, tt := range []struct {
+		coordinates *ddv1.GeoCoordinates
+		lat, lon    float64
+		ok          bool
+	}{
+		{coordinates: testGeoCoordinates(60305, 24561), lat: 60.508333, lon: 24.935, ok: true},
+		{coordinates: testGeoCoordinates(-33520, -70395), lat: -33.866667, lon: -70.658333, ok: true},
+		{coordinates: testGeoCoordinates(0x7FFFFF, 0x7FFFFF)},
+		{coordinates: testGeoCoordinates(95000, 0)},
+		{coordinates: nil},
+	} {
+		lat, lon, ok := DecimalDegrees(tt.coordinates)
+		if ok != tt.ok || math.Abs(lat-tt.lat) > 1e-6 || math.Abs(lon-tt.lon) > 1e-6 {
+			t.Errorf("DecimalDegrees(%v) = %v, %v, %v, want %v, %v, %v", tt.coordinates, lat, lon, ok, tt.lat, tt.lon, tt.ok)
+		}
+	}
+}
+
+func TestAccumulatedDrivingPositions(t *testing.T) {
+	at := func(hours int) *timestamppb.Timestamp {
+		return timestamppb.New(testDay(0).Add(time.Duration(hours) * time.Hour))
+	}
+	var records []*cardv1.GnssPlaces_Record
+	for _, hours := range []int{6, 3} {
+		place := &ddv1.GNSSPlaceRecord{}
+		place.SetTimestamp(at(hours))
+		place.SetGeoCoordinates(testGeoCoordinates(52300, 13240))
+		r := &cardv1.GnssPlaces_Record{}
+		r.SetTimestamp(at(hours))
+		r.SetGnssPlaceRecord(place)
+		r.SetVehicleOdometerKm(int32(1000 + hours))
+		records = append(records, r)
+	}
+	gnssPlaces := &cardv1.GnssPlaces{}
+	gnssPlaces.SetRecords(records)
+	authentication := &cardv1.GnssPlacesAuthentication_Record{}
+	authentication.SetTimestamp(at(6))
+	authentication.SetAuthenticationStatus(ddv1.PositionAuthenticationStatus_AUTHENTICATED)
+	gnssPlacesAuthentication := &cardv1.GnssPlacesAuthentication{}
+	gnssPlacesAuthentication.SetRecords([]*cardv1.GnssPlacesAuthentication_Record{authentication})
+	tachographG2 := &cardv1.DriverCardFile_TachographG2{}
+	tachographG2.SetGnssPlaces(gnssPlaces)
+	tachographG2.SetGnssPlacesAuthentication(gnssPlacesAuthentication)
+	card := &cardv1.DriverCardFile{}
+	card.SetTachographG2(tachographG2)
+
+	got := AccumulatedDrivingPositions(card)
+	if len(got) != 2 {
+		t.Fatalf("AccumulatedDrivingPositions() = %+v, want 2 positions", got)
+	}
+	if got[0].OdometerKm != 1003 || got[0].AuthenticationStatus != ddv1.PositionAuthenticationStatus_POSITION_AUTHENTICATION_STATUS_UNSPECIFIED {
+		t.Errorf("position 0 = %+v, want odometer 1003 without authentication status", got[0])
+	}
+	if got[1].OdometerKm != 1006 || got[1].AuthenticationStatus != ddv1.PositionAuthenticationStatus_AUTHENTICATED {
+		t.Errorf("position 1 = %+v, want odometer 1006 and authenticated", got[1])
+	}
+}
@@ -7,6 +7,7 @@
 package cardv1
 
 import (
+	v1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -38,6 +39,10 @@ type DriverCardFile struct {
 	xxx_hidden_Ic           *Ic                          `protobuf:"bytes,2,opt,name=ic"`
 	xxx_hidden_Tachograph   *DriverCardFile_Tachograph   `protobuf:"bytes,3,opt,name=tachograph"`
 	xxx_hidden_TachographG2 *DriverCardFile_TachographG2 `protobuf:"bytes,4,opt,name=tachograph_g2,json=tachographG2"`
+	xxx_hidden_Generation   v1.Generation                `protobuf:"varint,5,opt,name=generation,enum=wayplatform.connect.tachograph.dd.v1.Generation"`
+	xxx_hidden_Version      v1.Version                   `protobuf:"varint,6,opt,name=version,enum=wayplatform.connect.tachograph.dd.v1.Version"`
+	XXX_raceDetectHookData  protoimpl.RaceDetectHookData
+	XXX_presence            [1]uint32
 	unknownFields           protoimpl.UnknownFields
 	sizeCache               protoimpl.SizeCache
 }
@@ -95,6 +100,24 @@ func (x *DriverCardFile) GetTachographG2() *DriverCardFile_TachographG2 {
 	return nil
 }
 
+func (x *DriverCardFile) GetGeneration() v1.Generation {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 4) {
+			return x.xxx_hidden_Generation
+		}
+	}
+	return v1.Generation(0)
+}
+
+func (x *DriverCardFile) GetVersion() v1.Version {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 5) {
+			return x.xxx_hidden_Version
+		}
+	}
+	return v1.Version(0)
+}
+
 func (x *DriverCardFile) SetIcc(v *Icc) {
 	x.xxx_hidden_Icc = v
 }
@@ -111,6 +134,16 @@ func (x *DriverCardFile) SetTachographG2(v *DriverCardFile_TachographG2) {
 	x.xxx_hidden_TachographG2 = v
 }
 
+func (x *DriverCardFile) SetGeneration(v v1.Generation) {
+	x.xxx_hidden_Generation = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
+}
+
+func (x *DriverCardFile) SetVersion(v v1.Version) {
+	x.xxx_hidden_Version = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 6)
+}
+
 func (x *DriverCardFile) HasIcc() bool {
 	if x == nil {
 		return false
@@ -139,6 +172,20 @@ func (x *DriverCardFile) HasTachographG2() bool {
 	return x.xxx_hidden_TachographG2 != nil
 }
 
+func (x *DriverCardFile) HasGeneration() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *DriverCardFile) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
 func (x *DriverCardFile) ClearIcc() {
 	x.xxx_hidden_Icc = nil
 }
@@ -155,6 +202,16 @@ func (x *DriverCardFile) ClearTachographG2() {
 	x.xxx_hidden_TachographG2 = nil
 }
 
+func (x *DriverCardFile) ClearGeneration() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Generation = v1.Generation_GENERATION_UNSPECIFIED
+}
+
+func (x *DriverCardFile) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Version = v1.Version_VERSION_UNSPECIFIED
+}
+
 type DriverCardFile_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
@@ -172,6 +229,13 @@ type DriverCardFile_builder struct {
 	// Only present on Gen2 cards.
 	// In the TLV format, EFs from this DF use tag appendix '02' (data) and '03' (signature).
 	TachographG2 *DriverCardFile_TachographG2
+	// Generation of the newest application on the card, as detected from the
+	// card structure version of its application identification EF.
+	// See Data Dictionary, Section 2.36.
+	Generation *v1.Generation
+	// Version of the data structures used to parse the card.
+	// VERSION_1 for Gen1 cards, unless the version was overridden when parsing.
+	Version *v1.Version
 }
 
 func (b0 DriverCardFile_builder) Build() *DriverCardFile {
@@ -182,6 +246,14 @@ func (b0 DriverCardFile_builder) Build() *DriverCardFile {
 	x.xxx_hidden_Ic = b.Ic
 	x.xxx_hidden_Tachograph = b.Tachograph
 	x.xxx_hidden_TachographG2 = b.TachographG2
+	if b.Generation != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 6)
+		x.xxx_hidden_Generation = *b.Generation
+	}
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 6)
+		x.xxx_hidden_Version = *b.Version
+	}
 	return m0
 }
 
@@ -1441,14 +1513,18 @@ var File_wayplatform_connect_tachograph_card_v1_driver_card_file_proto protorefl
 
 const file_wayplatform_connect_tachograph_card_v1_driver_card_file_proto_rawDesc = "" +
 	"\n" +
-	"=wayplatform/connect/tachograph/card/v1/driver_card_file.proto\x12&wayplatform.connect.tachograph.card.v1\x1aGwayplatform/connect/tachograph/card/v1/application_identification.proto\x1aJwayplatform/connect/tachograph/card/v1/application_identification_g2.proto\x1aJwayplatform/connect/tachograph/card/v1/application_identification_v2.proto\x1a=wayplatform/connect/tachograph/card/v1/border_crossings.proto\x1a;wayplatform/connect/tachograph/card/v1/ca_certificate.proto\x1a>wayplatform/connect/tachograph/card/v1/ca_certificate_g2.proto\x1a=wayplatform/connect/tachograph/card/v1/card_certificate.proto\x1aAwayplatform/connect/tachograph/card/v1/card_download_driver.proto\x1a@wayplatform/connect/tachograph/card/v1/card_ma_certificate.proto\x1aBwayplatform/connect/tachograph/card/v1/card_sign_certificate.proto\x1aBwayplatform/connect/tachograph/card/v1/company_activity_data.proto\x1a=wayplatform/connect/tachograph/card/v1/link_certificate.proto\x1aBwayplatform/connect/tachograph/card/v1/control_activity_data.proto\x1a:wayplatform/connect/tachograph/card/v1/current_usage.proto\x1aAwayplatform/connect/tachograph/card/v1/driver_activity_data.proto\x1aAwayplatform/connect/tachograph/card/v1/driving_licence_info.proto\x1a8wayplatform/connect/tachograph/card/v1/events_data.proto\x1a8wayplatform/connect/tachograph/card/v1/faults_data.proto\x1a8wayplatform/connect/tachograph/card/v1/gnss_places.proto\x1aGwayplatform/connect/tachograph/card/v1/gnss_places_authentication.proto\x1a/wayplatform/connect/tachograph/card/v1/ic.proto\x1a0wayplatform/connect/tachograph/card/v1/icc.proto\x1a;wayplatform/connect/tachograph/card/v1/identification.proto\x1a>wayplatform/connect/tachograph/card/v1/load_type_entries.proto\x1aCwayplatform/connect/tachograph/card/v1/load_unload_operations.proto\x1a3wayplatform/connect/tachograph/card/v1/places.proto\x1aBwayplatform/connect/tachograph/card/v1/places_authentication.proto\x1a6wayplatform/connect/tachograph/card/v1/places_g2.proto\x1a@wayplatform/connect/tachograph/card/v1/specific_conditions.proto\x1aCwayplatform/connect/tachograph/card/v1/specific_conditions_g2.proto\x1a?wayplatform/connect/tachograph/card/v1/vehicle_units_used.proto\x1a:wayplatform/connect/tachograph/card/v1/vehicles_used.proto\x1a=wayplatform/connect/tachograph/card/v1/vehicles_used_g2.proto\x1a=wayplatform/connect/tachograph/card/v1/vu_configuration.proto\"\xfa\"\n" +
+	"=wayplatform/connect/tachograph/card/v1/driver_card_file.proto\x12&wayplatform.connect.tachograph.card.v1\x1aGwayplatform/connect/tachograph/card/v1/application_identification.proto\x1aJwayplatform/connect/tachograph/card/v1/application_identification_g2.proto\x1aJwayplatform/connect/tachograph/card/v1/application_identification_v2.proto\x1a=wayplatform/connect/tachograph/card/v1/border_crossings.proto\x1a;wayplatform/connect/tachograph/card/v1/ca_certificate.proto\x1a>wayplatform/connect/tachograph/card/v1/ca_certificate_g2.proto\x1a=wayplatform/connect/tachograph/card/v1/card_certificate.proto\x1aAwayplatform/connect/tachograph/card/v1/card_download_driver.proto\x1a@wayplatform/connect/tachograph/card/v1/card_ma_certificate.proto\x1aBwayplatform/connect/tachograph/card/v1/card_sign_certificate.proto\x1aBwayplatform/connect/tachograph/card/v1/company_activity_data.proto\x1a=wayplatform/connect/tachograph/card/v1/link_certificate.proto\x1aBwayplatform/connect/tachograph/card/v1/control_activity_data.proto\x1a:wayplatform/connect/tachograph/card/v1/current_usage.proto\x1aAwayplatform/connect/tachograph/card/v1/driver_activity_data.proto\x1aAwayplatform/connect/tachograph/card/v1/driving_licence_info.proto\x1a8wayplatform/connect/tachograph/card/v1/events_data.proto\x1a8wayplatform/connect/tachograph/card/v1/faults_data.proto\x1a8wayplatform/connect/tachograph/card/v1/gnss_places.proto\x1aGwayplatform/connect/tachograph/card/v1/gnss_places_authentication.proto\x1a/wayplatform/connect/tachograph/card/v1/ic.proto\x1a0wayplatform/connect/tachograph/card/v1/icc.proto\x1a;wayplatform/connect/tachograph/card/v1/identification.proto\x1a>wayplatform/connect/tachograph/card/v1/load_type_entries.proto\x1aCwayplatform/connect/tachograph/card/v1/load_unload_operations.proto\x1a3wayplatform/connect/tachograph/card/v1/places.proto\x1aBwayplatform/connect/tachograph/card/v1/places_authentication.proto\x1a6wayplatform/connect/tachograph/card/v1/places_g2.proto\x1a@wayplatform/connect/tachograph/card/v1/specific_conditions.proto\x1aCwayplatform/connect/tachograph/card/v1/specific_conditions_g2.proto\x1a?wayplatform/connect/tachograph/card/v1/vehicle_units_used.proto\x1a:wayplatform/connect/tachograph/card/v1/vehicles_used.proto\x1a=wayplatform/connect/tachograph/card/v1/vehicles_used_g2.proto\x1a=wayplatform/connect/tachograph/card/v1/vu_configuration.proto\x1a5wayplatform/connect/tachograph/dd/v1/generation.proto\x1a2wayplatform/connect/tachograph/dd/v1/version.proto\"\x95$\n" +
 	"\x0eDriverCardFile\x12=\n" +
 	"\x03icc\x18\x01 \x01(\v2+.wayplatform.connect.tachograph.card.v1.IccR\x03icc\x12:\n" +
 	"\x02ic\x18\x02 \x01(\v2*.wayplatform.connect.tachograph.card.v1.IcR\x02ic\x12a\n" +
 	"\n" +
 	"tachograph\x18\x03 \x01(\v2A.wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographR\n" +
 	"tachograph\x12h\n" +
-	"\rtachograph_g2\x18\x04 \x01(\v2C.wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2R\ftachographG2\x1a\xf4\n" +
+	"\rtachograph_g2\x18\x04 \x01(\v2C.wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2R\ftachographG2\x12P\n" +
+	"\n" +
+	"generation\x18\x05 \x01(\x0e20.wayplatform.connect.tachograph.dd.v1.GenerationR\n" +
+	"generation\x12G\n" +
+	"\aversion\x18\x06 \x01(\x0e2-.wayplatform.connect.tachograph.dd.v1.VersionR\aversion\x1a\xf4\n" +
 	"\n" +
 	"\n" +
 	"Tachograph\x12\x80\x01\n" +
@@ -1509,89 +1585,93 @@ var file_wayplatform_connect_tachograph_card_v1_driver_card_file_proto_goTypes =
 	(*DriverCardFile_TachographG2)(nil), // 2: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2
 	(*Icc)(nil),                         // 3: wayplatform.connect.tachograph.card.v1.Icc
 	(*Ic)(nil),                          // 4: wayplatform.connect.tachograph.card.v1.Ic
-	(*ApplicationIdentification)(nil),   // 5: wayplatform.connect.tachograph.card.v1.ApplicationIdentification
-	(*Identification)(nil),              // 6: wayplatform.connect.tachograph.card.v1.Identification
-	(*CardDownloadDriver)(nil),          // 7: wayplatform.connect.tachograph.card.v1.CardDownloadDriver
-	(*DrivingLicenceInfo)(nil),          // 8: wayplatform.connect.tachograph.card.v1.DrivingLicenceInfo
-	(*EventsData)(nil),                  // 9: wayplatform.connect.tachograph.card.v1.EventsData
-	(*FaultsData)(nil),                  // 10: wayplatform.connect.tachograph.card.v1.FaultsData
-	(*DriverActivityData)(nil),          // 11: wayplatform.connect.tachograph.card.v1.DriverActivityData
-	(*VehiclesUsed)(nil),                // 12: wayplatform.connect.tachograph.card.v1.VehiclesUsed
-	(*Places)(nil),                      // 13: wayplatform.connect.tachograph.card.v1.Places
-	(*CurrentUsage)(nil),                // 14: wayplatform.connect.tachograph.card.v1.CurrentUsage
-	(*ControlActivityData)(nil),         // 15: wayplatform.connect.tachograph.card.v1.ControlActivityData
-	(*SpecificConditions)(nil),          // 16: wayplatform.connect.tachograph.card.v1.SpecificConditions
-	(*CardCertificate)(nil),             // 17: wayplatform.connect.tachograph.card.v1.CardCertificate
-	(*CaCertificate)(nil),               // 18: wayplatform.connect.tachograph.card.v1.CaCertificate
-	(*ApplicationIdentificationG2)(nil), // 19: wayplatform.connect.tachograph.card.v1.ApplicationIdentificationG2
-	(*VehiclesUsedG2)(nil),              // 20: wayplatform.connect.tachograph.card.v1.VehiclesUsedG2
-	(*PlacesG2)(nil),                    // 21: wayplatform.connect.tachograph.card.v1.PlacesG2
-	(*SpecificConditionsG2)(nil),        // 22: wayplatform.connect.tachograph.card.v1.SpecificConditionsG2
-	(*VehicleUnitsUsed)(nil),            // 23: wayplatform.connect.tachograph.card.v1.VehicleUnitsUsed
-	(*GnssPlaces)(nil),                  // 24: wayplatform.connect.tachograph.card.v1.GnssPlaces
-	(*ApplicationIdentificationV2)(nil), // 25: wayplatform.connect.tachograph.card.v1.ApplicationIdentificationV2
-	(*PlacesAuthentication)(nil),        // 26: wayplatform.connect.tachograph.card.v1.PlacesAuthentication
-	(*GnssPlacesAuthentication)(nil),    // 27: wayplatform.connect.tachograph.card.v1.GnssPlacesAuthentication
-	(*BorderCrossings)(nil),             // 28: wayplatform.connect.tachograph.card.v1.BorderCrossings
-	(*LoadUnloadOperations)(nil),        // 29: wayplatform.connect.tachograph.card.v1.LoadUnloadOperations
-	(*LoadTypeEntries)(nil),             // 30: wayplatform.connect.tachograph.card.v1.LoadTypeEntries
-	(*CompanyActivityData)(nil),         // 31: wayplatform.connect.tachograph.card.v1.CompanyActivityData
-	(*VuConfiguration)(nil),             // 32: wayplatform.connect.tachograph.card.v1.VuConfiguration
-	(*CardMaCertificate)(nil),           // 33: wayplatform.connect.tachograph.card.v1.CardMaCertificate
-	(*CardSignCertificate)(nil),         // 34: wayplatform.connect.tachograph.card.v1.CardSignCertificate
-	(*CaCertificateG2)(nil),             // 35: wayplatform.connect.tachograph.card.v1.CaCertificateG2
-	(*LinkCertificate)(nil),             // 36: wayplatform.connect.tachograph.card.v1.LinkCertificate
+	(v1.Generation)(0),                  // 5: wayplatform.connect.tachograph.dd.v1.Generation
+	(v1.Version)(0),                     // 6: wayplatform.connect.tachograph.dd.v1.Version
+	(*ApplicationIdentification)(nil),   // 7: wayplatform.connect.tachograph.card.v1.ApplicationIdentification
+	(*Identification)(nil),              // 8: wayplatform.connect.tachograph.card.v1.Identification
+	(*CardDownloadDriver)(nil),          // 9: wayplatform.connect.tachograph.card.v1.CardDownloadDriver
+	(*DrivingLicenceInfo)(nil),          // 10: wayplatform.connect.tachograph.card.v1.DrivingLicenceInfo
+	(*EventsData)(nil),                  // 11: wayplatform.connect.tachograph.card.v1.EventsData
+	(*FaultsData)(nil),                  // 12: wayplatform.connect.tachograph.card.v1.FaultsData
+	(*DriverActivityData)(nil),          // 13: wayplatform.connect.tachograph.card.v1.DriverActivityData
+	(*VehiclesUsed)(nil),                // 14: wayplatform.connect.tachograph.card.v1.VehiclesUsed
+	(*Places)(nil),                      // 15: wayplatform.connect.tachograph.card.v1.Places
+	(*CurrentUsage)(nil),                // 16: wayplatform.connect.tachograph.card.v1.CurrentUsage
+	(*ControlActivityData)(nil),         // 17: wayplatform.connect.tachograph.card.v1.ControlActivityData
+	(*SpecificConditions)(nil),          // 18: wayplatform.connect.tachograph.card.v1.SpecificConditions
+	(*CardCertificate)(nil),             // 19: wayplatform.connect.tachograph.card.v1.CardCertificate
+	(*CaCertificate)(nil),               // 20: wayplatform.connect.tachograph.card.v1.CaCertificate
+	(*ApplicationIdentificationG2)(nil), // 21: wayplatform.connect.tachograph.card.v1.ApplicationIdentificationG2
+	(*VehiclesUsedG2)(nil),              // 22: wayplatform.connect.tachograph.card.v1.VehiclesUsedG2
+	(*PlacesG2)(nil),                    // 23: wayplatform.connect.tachograph.card.v1.PlacesG2
+	(*SpecificConditionsG2)(nil),        // 24: wayplatform.connect.tachograph.card.v1.SpecificConditionsG2
+	(*VehicleUnitsUsed)(nil),            // 25: wayplatform.connect.tachograph.card.v1.VehicleUnitsUsed
+	(*GnssPlaces)(nil),                  // 26: wayplatform.connect.tachograph.card.v1.GnssPlaces
+	(*ApplicationIdentificationV2)(nil), // 27: wayplatform.connect.tachograph.card.v1.ApplicationIdentificationV2
+	(*PlacesAuthentication)(nil),        // 28: wayplatform.connect.tachograph.card.v1.PlacesAuthentication
+	(*GnssPlacesAuthentication)(nil),    // 29: wayplatform.connect.tachograph.card.v1.GnssPlacesAuthentication
+	(*BorderCrossings)(nil),             // 30: wayplatform.connect.tachograph.card.v1.BorderCrossings
+	(*LoadUnloadOperations)(nil),        // 31: wayplatform.connect.tachograph.card.v1.LoadUnloadOperations
+	(*LoadTypeEntries)(nil),             // 32: wayplatform.connect.tachograph.card.v1.LoadTypeEntries
+	(*CompanyActivityData)(nil),         // 33: wayplatform.connect.tachograph.card.v1.CompanyActivityData
+	(*VuConfiguration)(nil),             // 34: wayplatform.connect.tachograph.card.v1.VuConfiguration
+	(*CardMaCertificate)(nil),           // 35: wayplatform.connect.tachograph.card.v1.CardMaCertificate
+	(*CardSignCertificate)(nil),         // 36: wayplatform.connect.tachograph.card.v1.CardSignCertificate
+	(*CaCertificateG2)(nil),             // 37: wayplatform.connect.tachograph.card.v1.CaCertificateG2
+	(*LinkCertificate)(nil),             // 38: wayplatform.connect.tachograph.card.v1.LinkCertificate
 }
 var file_wayplatform_connect_tachograph_card_v1_driver_card_file_proto_depIdxs = []int32{
 	3,  // 0: wayplatform.connect.tachograph.card.v1.DriverCardFile.icc:type_name -> wayplatform.connect.tachograph.card.v1.Icc
 	4,  // 1: wayplatform.connect.tachograph.card.v1.DriverCardFile.ic:type_name -> wayplatform.connect.tachograph.card.v1.Ic
 	1,  // 2: wayplatform.connect.tachograph.card.v1.DriverCardFile.tachograph:type_name -> wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph
 	2,  // 3: wayplatform.connect.tachograph.card.v1.DriverCardFile.tachograph_g2:type_name -> wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2
-	5,  // 4: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.application_identification:type_name -> wayplatform.connect.tachograph.card.v1.ApplicationIdentification
-	6,  // 5: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.identification:type_name -> wayplatform.connect.tachograph.card.v1.Identification
-	7,  // 6: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.card_download:type_name -> wayplatform.connect.tachograph.card.v1.CardDownloadDriver
-	8,  // 7: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.driving_licence_info:type_name -> wayplatform.connect.tachograph.card.v1.DrivingLicenceInfo
-	9,  // 8: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.events_data:type_name -> wayplatform.connect.tachograph.card.v1.EventsData
-	10, // 9: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.faults_data:type_name -> wayplatform.connect.tachograph.card.v1.FaultsData
-	11, // 10: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.driver_activity_data:type_name -> wayplatform.connect.tachograph.card.v1.DriverActivityData
-	12, // 11: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.vehicles_used:type_name -> wayplatform.connect.tachograph.card.v1.VehiclesUsed
-	13, // 12: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.places:type_name -> wayplatform.connect.tachograph.card.v1.Places
-	14, // 13: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.current_usage:type_name -> wayplatform.connect.tachograph.card.v1.CurrentUsage
-	15, // 14: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.control_activity_data:type_name -> wayplatform.connect.tachograph.card.v1.ControlActivityData
-	16, // 15: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.specific_conditions:type_name -> wayplatform.connect.tachograph.card.v1.SpecificConditions
-	17, // 16: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.card_certificate:type_name -> wayplatform.connect.tachograph.card.v1.CardCertificate
-	18, // 17: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.ca_certificate:type_name -> wayplatform.connect.tachograph.card.v1.CaCertificate
-	19, // 18: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.application_identification:type_name -> wayplatform.connect.tachograph.card.v1.ApplicationIdentificationG2
-	6,  // 19: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.identification:type_name -> wayplatform.connect.tachograph.card.v1.Identification
-	7,  // 20: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.card_download:type_name -> wayplatform.connect.tachograph.card.v1.CardDownloadDriver
-	8,  // 21: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.driving_licence_info:type_name -> wayplatform.connect.tachograph.card.v1.DrivingLicenceInfo
-	9,  // 22: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.events_data:type_name -> wayplatform.connect.tachograph.card.v1.EventsData
-	10, // 23: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.faults_data:type_name -> wayplatform.connect.tachograph.card.v1.FaultsData
-	11, // 24: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.driver_activity_data:type_name -> wayplatform.connect.tachograph.card.v1.DriverActivityData
-	20, // 25: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.vehicles_used:type_name -> wayplatform.connect.tachograph.card.v1.VehiclesUsedG2
-	21, // 26: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.places:type_name -> wayplatform.connect.tachograph.card.v1.PlacesG2
-	14, // 27: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.current_usage:type_name -> wayplatform.connect.tachograph.card.v1.CurrentUsage
-	15, // 28: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.control_activity_data:type_name -> wayplatform.connect.tachograph.card.v1.ControlActivityData
-	22, // 29: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.specific_conditions:type_name -> wayplatform.connect.tachograph.card.v1.SpecificConditionsG2
-	23, // 30: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.vehicle_units_used:type_name -> wayplatform.connect.tachograph.card.v1.VehicleUnitsUsed
-	24, // 31: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.gnss_places:type_name -> wayplatform.connect.tachograph.card.v1.GnssPlaces
-	25, // 32: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.application_identification_v2:type_name -> wayplatform.connect.tachograph.card.v1.ApplicationIdentificationV2
-	26, // 33: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.places_authentication:type_name -> wayplatform.connect.tachograph.card.v1.PlacesAuthentication
-	27, // 34: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.gnss_places_authentication:type_name -> wayplatform.connect.tachograph.card.v1.GnssPlacesAuthentication
-	28, // 35: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.border_crossings:type_name -> wayplatform.connect.tachograph.card.v1.BorderCrossings
-	29, // 36: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.load_unload_operations:type_name -> wayplatform.connect.tachograph.card.v1.LoadUnloadOperations
-	30, // 37: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.load_type_entries:type_name -> wayplatform.connect.tachograph.card.v1.LoadTypeEntries
-	31, // 38: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.company_activity_data:type_name -> wayplatform.connect.tachograph.card.v1.CompanyActivityData
-	32, // 39: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.vu_configuration:type_name -> wayplatform.connect.tachograph.card.v1.VuConfiguration
-	33, // 40: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.card_ma_certificate:type_name -> wayplatform.connect.tachograph.card.v1.CardMaCertificate
-	34, // 41: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.card_sign_certificate:type_name -> wayplatform.connect.tachograph.card.v1.CardSignCertificate
-	35, // 42: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.ca_certificate:type_name -> wayplatform.connect.tachograph.card.v1.CaCertificateG2
-	36, // 43: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.link_certificate:type_name -> wayplatform.connect.tachograph.card.v1.LinkCertificate
-	44, // [44:44] is the sub-list for method output_type
-	44, // [44:44] is the sub-list for method input_type
-	44, // [44:44] is the sub-list for extension type_name
-	44, // [44:44] is the sub-list for extension extendee
-	0,  // [0:44] is the sub-list for field type_name
+	5,  // 4: wayplatform.connect.tachograph.card.v1.DriverCardFile.generation:type_name -> wayplatform.connect.tachograph.dd.v1.Generation
+	6,  // 5: wayplatform.connect.tachograph.card.v1.DriverCardFile.version:type_name -> wayplatform.connect.tachograph.dd.v1.Version
+	7,  // 6: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.application_identification:type_name -> wayplatform.connect.tachograph.card.v1.ApplicationIdentification
+	8,  // 7: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.identification:type_name -> wayplatform.connect.tachograph.card.v1.Identification
+	9,  // 8: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.card_download:type_name -> wayplatform.connect.tachograph.card.v1.CardDownloadDriver
+	10, // 9: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.driving_licence_info:type_name -> wayplatform.connect.tachograph.card.v1.DrivingLicenceInfo
+	11, // 10: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.events_data:type_name -> wayplatform.connect.tachograph.card.v1.EventsData
+	12, // 11: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.faults_data:type_name -> wayplatform.connect.tachograph.card.v1.FaultsData
+	13, // 12: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.driver_activity_data:type_name -> wayplatform.connect.tachograph.card.v1.DriverActivityData
+	14, // 13: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.vehicles_used:type_name -> wayplatform.connect.tachograph.card.v1.VehiclesUsed
+	15, // 14: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.places:type_name -> wayplatform.connect.tachograph.card.v1.Places
+	16, // 15: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.current_usage:type_name -> wayplatform.connect.tachograph.card.v1.CurrentUsage
+	17, // 16: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.control_activity_data:type_name -> wayplatform.connect.tachograph.card.v1.ControlActivityData
+	18, // 17: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.specific_conditions:type_name -> wayplatform.connect.tachograph.card.v1.SpecificConditions
+	19, // 18: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.card_certificate:type_name -> wayplatform.connect.tachograph.card.v1.CardCertificate
+	20, // 19: wayplatform.connect.tachograph.card.v1.DriverCardFile.Tachograph.ca_certificate:type_name -> wayplatform.connect.tachograph.card.v1.CaCertificate
+	21, // 20: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.application_identification:type_name -> wayplatform.connect.tachograph.card.v1.ApplicationIdentificationG2
+	8,  // 21: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.identification:type_name -> wayplatform.connect.tachograph.card.v1.Identification
+	9,  // 22: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.card_download:type_name -> wayplatform.connect.tachograph.card.v1.CardDownloadDriver
+	10, // 23: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.driving_licence_info:type_name -> wayplatform.connect.tachograph.card.v1.DrivingLicenceInfo
+	11, // 24: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.events_data:type_name -> wayplatform.connect.tachograph.card.v1.EventsData
+	12, // 25: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.faults_data:type_name -> wayplatform.connect.tachograph.card.v1.FaultsData
+	13, // 26: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.driver_activity_data:type_name -> wayplatform.connect.tachograph.card.v1.DriverActivityData
+	22, // 27: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.vehicles_used:type_name -> wayplatform.connect.tachograph.card.v1.VehiclesUsedG2
+	23, // 28: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.places:type_name -> wayplatform.connect.tachograph.card.v1.PlacesG2
+	16, // 29: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.current_usage:type_name -> wayplatform.connect.tachograph.card.v1.CurrentUsage
+	17, // 30: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.control_activity_data:type_name -> wayplatform.connect.tachograph.card.v1.ControlActivityData
+	24, // 31: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.specific_conditions:type_name -> wayplatform.connect.tachograph.card.v1.SpecificConditionsG2
+	25, // 32: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.vehicle_units_used:type_name -> wayplatform.connect.tachograph.card.v1.VehicleUnitsUsed
+	26, // 33: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.gnss_places:type_name -> wayplatform.connect.tachograph.card.v1.GnssPlaces
+	27, // 34: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.application_identification_v2:type_name -> wayplatform.connect.tachograph.card.v1.ApplicationIdentificationV2
+	28, // 35: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.places_authentication:type_name -> wayplatform.connect.tachograph.card.v1.PlacesAuthentication
+	29, // 36: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.gnss_places_authentication:type_name -> wayplatform.connect.tachograph.card.v1.GnssPlacesAuthentication
+	30, // 37: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.border_crossings:type_name -> wayplatform.connect.tachograph.card.v1.BorderCrossings
+	31, // 38: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.load_unload_operations:type_name -> wayplatform.connect.tachograph.card.v1.LoadUnloadOperations
+	32, // 39: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.load_type_entries:type_name -> wayplatform.connect.tachograph.card.v1.LoadTypeEntries
+	33, // 40: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.company_activity_data:type_name -> wayplatform.connect.tachograph.card.v1.CompanyActivityData
+	34, // 41: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.vu_configuration:type_name -> wayplatform.connect.tachograph.card.v1.VuConfiguration
+	35, // 42: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.card_ma_certificate:type_name -> wayplatform.connect.tachograph.card.v1.CardMaCertificate
+	36, // 43: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.card_sign_certificate:type_name -> wayplatform.connect.tachograph.card.v1.CardSignCertificate
+	37, // 44: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.ca_certificate:type_name -> wayplatform.connect.tachograph.card.v1.CaCertificateG2
+	38, // 45: wayplatform.connect.tachograph.card.v1.DriverCardFile.TachographG2.link_certificate:type_name -> wayplatform.connect.tachograph.card.v1.LinkCertificate
+	46, // [46:46] is the sub-list for method output_type
+	46, // [46:46] is the sub-list for method input_type
+	46, // [46:46] is the sub-list for extension type_name
+	46, // [46:46] is the sub-list for extension extendee
+	0,  // [0:46] is the sub-list for field type_name
 }
 
 func init() { file_wayplatform_connect_tachograph_card_v1_driver_card_file_proto_init() }
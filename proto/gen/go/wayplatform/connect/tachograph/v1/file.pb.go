@@ -8,6 +8,7 @@ package tachographv1
 
 import (
 	v11 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+	v12 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
 	v1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -92,15 +93,16 @@ func (x File_Type) Number() protoreflect.EnumNumber {
 // for any kind of tachograph file. It uses a manually tagged union pattern, where the
 // `type` field indicates which of the specific file-type fields is populated.
 type File struct {
-	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Type        File_Type              `protobuf:"varint,1,opt,name=type,enum=wayplatform.connect.tachograph.v1.File_Type"`
-	xxx_hidden_VehicleUnit *v1.VehicleUnitFile    `protobuf:"bytes,2,opt,name=vehicle_unit,json=vehicleUnit"`
-	xxx_hidden_DriverCard  *v11.DriverCardFile    `protobuf:"bytes,3,opt,name=driver_card,json=driverCard"`
-	xxx_hidden_RawCard     *v11.RawCardFile       `protobuf:"bytes,7,opt,name=raw_card,json=rawCard"`
-	XXX_raceDetectHookData protoimpl.RaceDetectHookData
-	XXX_presence           [1]uint32
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	state                       protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Type             File_Type              `protobuf:"varint,1,opt,name=type,enum=wayplatform.connect.tachograph.v1.File_Type"`
+	xxx_hidden_VehicleUnit      *v1.VehicleUnitFile    `protobuf:"bytes,2,opt,name=vehicle_unit,json=vehicleUnit"`
+	xxx_hidden_DriverCard       *v11.DriverCardFile    `protobuf:"bytes,3,opt,name=driver_card,json=driverCard"`
+	xxx_hidden_RawCard          *v11.RawCardFile       `protobuf:"bytes,7,opt,name=raw_card,json=rawCard"`
+	xxx_hidden_StructureVersion *File_StructureVersion `protobuf:"bytes,8,opt,name=structure_version,json=structureVersion"`
+	XXX_raceDetectHookData      protoimpl.RaceDetectHookData
+	XXX_presence                [1]uint32
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
 }
 
 func (x *File) Reset() {
@@ -158,9 +160,16 @@ func (x *File) GetRawCard() *v11.RawCardFile {
 	return nil
 }
 
+func (x *File) GetStructureVersion() *File_StructureVersion {
+	if x != nil {
+		return x.xxx_hidden_StructureVersion
+	}
+	return nil
+}
+
 func (x *File) SetType(v File_Type) {
 	x.xxx_hidden_Type = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
 }
 
 func (x *File) SetVehicleUnit(v *v1.VehicleUnitFile) {
@@ -175,6 +184,10 @@ func (x *File) SetRawCard(v *v11.RawCardFile) {
 	x.xxx_hidden_RawCard = v
 }
 
+func (x *File) SetStructureVersion(v *File_StructureVersion) {
+	x.xxx_hidden_StructureVersion = v
+}
+
 func (x *File) HasType() bool {
 	if x == nil {
 		return false
@@ -203,6 +216,13 @@ func (x *File) HasRawCard() bool {
 	return x.xxx_hidden_RawCard != nil
 }
 
+func (x *File) HasStructureVersion() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_StructureVersion != nil
+}
+
 func (x *File) ClearType() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Type = File_TYPE_UNSPECIFIED
@@ -220,6 +240,10 @@ func (x *File) ClearRawCard() {
 	x.xxx_hidden_RawCard = nil
 }
 
+func (x *File) ClearStructureVersion() {
+	x.xxx_hidden_StructureVersion = nil
+}
+
 type File_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
@@ -234,8 +258,12 @@ type File_builder struct {
 	DriverCard *v11.DriverCardFile
 	// The raw, uninterpreted content of a card file. This can be used as a
 	// fallback or for applications that need to do their own detailed parsing.
-	// This field is populated if and only if `type` is `RAW_CARD`.
+	// This field is populated if `type` is `RAW_CARD`. If `type` is `DRIVER_CARD`,
+	// it holds the elementary files that were not decoded into `driver_card`, if
+	// the file was parsed with a restricted set of elementary files.
 	RawCard *v11.RawCardFile
+	// The structure version of the file, as detected when parsing it.
+	StructureVersion *File_StructureVersion
 }
 
 func (b0 File_builder) Build() *File {
@@ -243,12 +271,201 @@ func (b0 File_builder) Build() *File {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Type != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
 		x.xxx_hidden_Type = *b.Type
 	}
 	x.xxx_hidden_VehicleUnit = b.VehicleUnit
 	x.xxx_hidden_DriverCard = b.DriverCard
 	x.xxx_hidden_RawCard = b.RawCard
+	x.xxx_hidden_StructureVersion = b.StructureVersion
+	return m0
+}
+
+// The detected version of the data structures of a file.
+//
+// Structure versions are coded as 'aabb'H, where 'aa' is the index of the
+// generation and 'bb' the index of the version within the generation, by the
+// `CardStructureVersion` of cards (Data Dictionary, Section 2.36) and the
+// `DownloadInterfaceVersion` of vehicle units (Data Dictionary, Section 2.60a).
+type File_StructureVersion struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Generation  v12.Generation         `protobuf:"varint,1,opt,name=generation,enum=wayplatform.connect.tachograph.dd.v1.Generation"`
+	xxx_hidden_Version     v12.Version            `protobuf:"varint,2,opt,name=version,enum=wayplatform.connect.tachograph.dd.v1.Version"`
+	xxx_hidden_Major       int32                  `protobuf:"varint,3,opt,name=major"`
+	xxx_hidden_Minor       int32                  `protobuf:"varint,4,opt,name=minor"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *File_StructureVersion) Reset() {
+	*x = File_StructureVersion{}
+	mi := &file_wayplatform_connect_tachograph_v1_file_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *File_StructureVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*File_StructureVersion) ProtoMessage() {}
+
+func (x *File_StructureVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_wayplatform_connect_tachograph_v1_file_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *File_StructureVersion) GetGeneration() v12.Generation {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 0) {
+			return x.xxx_hidden_Generation
+		}
+	}
+	return v12.Generation(0)
+}
+
+func (x *File_StructureVersion) GetVersion() v12.Version {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 1) {
+			return x.xxx_hidden_Version
+		}
+	}
+	return v12.Version(0)
+}
+
+func (x *File_StructureVersion) GetMajor() int32 {
+	if x != nil {
+		return x.xxx_hidden_Major
+	}
+	return 0
+}
+
+func (x *File_StructureVersion) GetMinor() int32 {
+	if x != nil {
+		return x.xxx_hidden_Minor
+	}
+	return 0
+}
+
+func (x *File_StructureVersion) SetGeneration(v v12.Generation) {
+	x.xxx_hidden_Generation = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *File_StructureVersion) SetVersion(v v12.Version) {
+	x.xxx_hidden_Version = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *File_StructureVersion) SetMajor(v int32) {
+	x.xxx_hidden_Major = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *File_StructureVersion) SetMinor(v int32) {
+	x.xxx_hidden_Minor = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+}
+
+func (x *File_StructureVersion) HasGeneration() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *File_StructureVersion) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *File_StructureVersion) HasMajor() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *File_StructureVersion) HasMinor() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *File_StructureVersion) ClearGeneration() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Generation = v12.Generation_GENERATION_UNSPECIFIED
+}
+
+func (x *File_StructureVersion) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Version = v12.Version_VERSION_UNSPECIFIED
+}
+
+func (x *File_StructureVersion) ClearMajor() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Major = 0
+}
+
+func (x *File_StructureVersion) ClearMinor() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Minor = 0
+}
+
+type File_StructureVersion_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The generation of the data structures.
+	Generation *v12.Generation
+	// The version of the data structures within the generation.
+	//
+	// This is VERSION_1 for Gen1 files, whose minor version does not change
+	// the layout of the data structures.
+	Version *v12.Version
+	// The major version byte 'aa', as recorded in the file.
+	//
+	// Set for card files with an application identification EF, and for
+	// vehicle unit files with a download interface version transfer, which
+	// Gen1 and Gen2 V1 vehicle units don't have.
+	Major *int32
+	// The minor version byte 'bb', as recorded in the file.
+	//
+	// Set together with `major`.
+	Minor *int32
+}
+
+func (b0 File_StructureVersion_builder) Build() *File_StructureVersion {
+	m0 := &File_StructureVersion{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Generation != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Generation = *b.Generation
+	}
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_Version = *b.Version
+	}
+	if b.Major != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_Major = *b.Major
+	}
+	if b.Minor != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_Minor = *b.Minor
+	}
 	return m0
 }
 
@@ -256,13 +473,21 @@ var File_wayplatform_connect_tachograph_v1_file_proto protoreflect.FileDescripto
 
 const file_wayplatform_connect_tachograph_v1_file_proto_rawDesc = "" +
 	"\n" +
-	",wayplatform/connect/tachograph/v1/file.proto\x12!wayplatform.connect.tachograph.v1\x1a=wayplatform/connect/tachograph/card/v1/driver_card_file.proto\x1a:wayplatform/connect/tachograph/card/v1/raw_card_file.proto\x1a<wayplatform/connect/tachograph/vu/v1/vehicle_unit_file.proto\"\xd2\x03\n" +
+	",wayplatform/connect/tachograph/v1/file.proto\x12!wayplatform.connect.tachograph.v1\x1a=wayplatform/connect/tachograph/card/v1/driver_card_file.proto\x1a:wayplatform/connect/tachograph/card/v1/raw_card_file.proto\x1a5wayplatform/connect/tachograph/dd/v1/generation.proto\x1a2wayplatform/connect/tachograph/dd/v1/version.proto\x1a<wayplatform/connect/tachograph/vu/v1/vehicle_unit_file.proto\"\x95\x06\n" +
 	"\x04File\x12@\n" +
 	"\x04type\x18\x01 \x01(\x0e2,.wayplatform.connect.tachograph.v1.File.TypeR\x04type\x12X\n" +
 	"\fvehicle_unit\x18\x02 \x01(\v25.wayplatform.connect.tachograph.vu.v1.VehicleUnitFileR\vvehicleUnit\x12W\n" +
 	"\vdriver_card\x18\x03 \x01(\v26.wayplatform.connect.tachograph.card.v1.DriverCardFileR\n" +
 	"driverCard\x12N\n" +
-	"\braw_card\x18\a \x01(\v23.wayplatform.connect.tachograph.card.v1.RawCardFileR\arawCard\"\x84\x01\n" +
+	"\braw_card\x18\a \x01(\v23.wayplatform.connect.tachograph.card.v1.RawCardFileR\arawCard\x12e\n" +
+	"\x11structure_version\x18\b \x01(\v28.wayplatform.connect.tachograph.v1.File.StructureVersionR\x10structureVersion\x1a\xd9\x01\n" +
+	"\x10StructureVersion\x12P\n" +
+	"\n" +
+	"generation\x18\x01 \x01(\x0e20.wayplatform.connect.tachograph.dd.v1.GenerationR\n" +
+	"generation\x12G\n" +
+	"\aversion\x18\x02 \x01(\x0e2-.wayplatform.connect.tachograph.dd.v1.VersionR\aversion\x12\x14\n" +
+	"\x05major\x18\x03 \x01(\x05R\x05major\x12\x14\n" +
+	"\x05minor\x18\x04 \x01(\x05R\x05minor\"\x84\x01\n" +
 	"\x04Type\x12\x14\n" +
 	"\x10TYPE_UNSPECIFIED\x10\x00\x12\x10\n" +
 	"\fVEHICLE_UNIT\x10\x01\x12\x0f\n" +
@@ -274,24 +499,30 @@ const file_wayplatform_connect_tachograph_v1_file_proto_rawDesc = "" +
 	"%com.wayplatform.connect.tachograph.v1B\tFileProtoP\x01Zagithub.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1;tachographv1\xa2\x02\x03WCT\xaa\x02!Wayplatform.Connect.Tachograph.V1\xca\x02!Wayplatform\\Connect\\Tachograph\\V1\xe2\x02-Wayplatform\\Connect\\Tachograph\\V1\\GPBMetadata\xea\x02$Wayplatform::Connect::Tachograph::V1b\beditionsp\xe8\a"
 
 var file_wayplatform_connect_tachograph_v1_file_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_wayplatform_connect_tachograph_v1_file_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_wayplatform_connect_tachograph_v1_file_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_wayplatform_connect_tachograph_v1_file_proto_goTypes = []any{
-	(File_Type)(0),             // 0: wayplatform.connect.tachograph.v1.File.Type
-	(*File)(nil),               // 1: wayplatform.connect.tachograph.v1.File
-	(*v1.VehicleUnitFile)(nil), // 2: wayplatform.connect.tachograph.vu.v1.VehicleUnitFile
-	(*v11.DriverCardFile)(nil), // 3: wayplatform.connect.tachograph.card.v1.DriverCardFile
-	(*v11.RawCardFile)(nil),    // 4: wayplatform.connect.tachograph.card.v1.RawCardFile
+	(File_Type)(0),                // 0: wayplatform.connect.tachograph.v1.File.Type
+	(*File)(nil),                  // 1: wayplatform.connect.tachograph.v1.File
+	(*File_StructureVersion)(nil), // 2: wayplatform.connect.tachograph.v1.File.StructureVersion
+	(*v1.VehicleUnitFile)(nil),    // 3: wayplatform.connect.tachograph.vu.v1.VehicleUnitFile
+	(*v11.DriverCardFile)(nil),    // 4: wayplatform.connect.tachograph.card.v1.DriverCardFile
+	(*v11.RawCardFile)(nil),       // 5: wayplatform.connect.tachograph.card.v1.RawCardFile
+	(v12.Generation)(0),           // 6: wayplatform.connect.tachograph.dd.v1.Generation
+	(v12.Version)(0),              // 7: wayplatform.connect.tachograph.dd.v1.Version
 }
 var file_wayplatform_connect_tachograph_v1_file_proto_depIdxs = []int32{
 	0, // 0: wayplatform.connect.tachograph.v1.File.type:type_name -> wayplatform.connect.tachograph.v1.File.Type
-	2, // 1: wayplatform.connect.tachograph.v1.File.vehicle_unit:type_name -> wayplatform.connect.tachograph.vu.v1.VehicleUnitFile
-	3, // 2: wayplatform.connect.tachograph.v1.File.driver_card:type_name -> wayplatform.connect.tachograph.card.v1.DriverCardFile
-	4, // 3: wayplatform.connect.tachograph.v1.File.raw_card:type_name -> wayplatform.connect.tachograph.card.v1.RawCardFile
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	3, // 1: wayplatform.connect.tachograph.v1.File.vehicle_unit:type_name -> wayplatform.connect.tachograph.vu.v1.VehicleUnitFile
+	4, // 2: wayplatform.connect.tachograph.v1.File.driver_card:type_name -> wayplatform.connect.tachograph.card.v1.DriverCardFile
+	5, // 3: wayplatform.connect.tachograph.v1.File.raw_card:type_name -> wayplatform.connect.tachograph.card.v1.RawCardFile
+	2, // 4: wayplatform.connect.tachograph.v1.File.structure_version:type_name -> wayplatform.connect.tachograph.v1.File.StructureVersion
+	6, // 5: wayplatform.connect.tachograph.v1.File.StructureVersion.generation:type_name -> wayplatform.connect.tachograph.dd.v1.Generation
+	7, // 6: wayplatform.connect.tachograph.v1.File.StructureVersion.version:type_name -> wayplatform.connect.tachograph.dd.v1.Version
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_wayplatform_connect_tachograph_v1_file_proto_init() }
@@ -305,7 +536,7 @@ func file_wayplatform_connect_tachograph_v1_file_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_wayplatform_connect_tachograph_v1_file_proto_rawDesc), len(file_wayplatform_connect_tachograph_v1_file_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -707,17 +707,17 @@ func (b0 ActivitiesGen2V2_CardIWRecord_builder) Build() *ActivitiesGen2V2_CardIW
 
 // Represents a place record for the beginning or end of a daily work period.
 //
-// Binary Layout: 21 bytes (Gen2)
+// Binary Layout: 22 bytes (Gen2 V2)
 //
-// See Data Dictionary, Section 2.117, `PlaceRecord`.
+// See Data Dictionary, Section 2.117a, `PlaceAuthRecord`.
 type ActivitiesGen2V2_PlaceRecord struct {
-	state                      protoimpl.MessageState            `protogen:"opaque.v1"`
-	xxx_hidden_EntryTime       *timestamppb.Timestamp            `protobuf:"bytes,1,opt,name=entry_time,json=entryTime"`
-	xxx_hidden_EntryType       v1.EntryTypeDailyWorkPeriod       `protobuf:"varint,2,opt,name=entry_type,json=entryType,enum=wayplatform.connect.tachograph.dd.v1.EntryTypeDailyWorkPeriod"`
-	xxx_hidden_Country         v1.NationNumeric                  `protobuf:"varint,3,opt,name=country,enum=wayplatform.connect.tachograph.dd.v1.NationNumeric"`
-	xxx_hidden_Region          []byte                            `protobuf:"bytes,4,opt,name=region"`
-	xxx_hidden_OdometerKm      int32                             `protobuf:"varint,5,opt,name=odometer_km,json=odometerKm"`
-	xxx_hidden_GnssPlaceRecord *ActivitiesGen2V2_GnssPlaceRecord `protobuf:"bytes,6,opt,name=gnss_place_record,json=gnssPlaceRecord"`
+	state                      protoimpl.MessageState                `protogen:"opaque.v1"`
+	xxx_hidden_EntryTime       *timestamppb.Timestamp                `protobuf:"bytes,1,opt,name=entry_time,json=entryTime"`
+	xxx_hidden_EntryType       v1.EntryTypeDailyWorkPeriod           `protobuf:"varint,2,opt,name=entry_type,json=entryType,enum=wayplatform.connect.tachograph.dd.v1.EntryTypeDailyWorkPeriod"`
+	xxx_hidden_Country         v1.NationNumeric                      `protobuf:"varint,3,opt,name=country,enum=wayplatform.connect.tachograph.dd.v1.NationNumeric"`
+	xxx_hidden_Region          []byte                                `protobuf:"bytes,4,opt,name=region"`
+	xxx_hidden_OdometerKm      int32                                 `protobuf:"varint,5,opt,name=odometer_km,json=odometerKm"`
+	xxx_hidden_GnssPlaceRecord *ActivitiesGen2V2_GnssPlaceAuthRecord `protobuf:"bytes,6,opt,name=gnss_place_record,json=gnssPlaceRecord"`
 	XXX_raceDetectHookData     protoimpl.RaceDetectHookData
 	XXX_presence               [1]uint32
 	unknownFields              protoimpl.UnknownFields
@@ -788,7 +788,7 @@ func (x *ActivitiesGen2V2_PlaceRecord) GetOdometerKm() int32 {
 	return 0
 }
 
-func (x *ActivitiesGen2V2_PlaceRecord) GetGnssPlaceRecord() *ActivitiesGen2V2_GnssPlaceRecord {
+func (x *ActivitiesGen2V2_PlaceRecord) GetGnssPlaceRecord() *ActivitiesGen2V2_GnssPlaceAuthRecord {
 	if x != nil {
 		return x.xxx_hidden_GnssPlaceRecord
 	}
@@ -822,7 +822,7 @@ func (x *ActivitiesGen2V2_PlaceRecord) SetOdometerKm(v int32) {
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
 }
 
-func (x *ActivitiesGen2V2_PlaceRecord) SetGnssPlaceRecord(v *ActivitiesGen2V2_GnssPlaceRecord) {
+func (x *ActivitiesGen2V2_PlaceRecord) SetGnssPlaceRecord(v *ActivitiesGen2V2_GnssPlaceAuthRecord) {
 	x.xxx_hidden_GnssPlaceRecord = v
 }
 
@@ -919,10 +919,10 @@ type ActivitiesGen2V2_PlaceRecord_builder struct {
 	//
 	// See Data Dictionary, Section 2.113, `OdometerShort`.
 	OdometerKm *int32
-	// GNSS position at the time of entry (Gen2 addition).
+	// GNSS position at the time of entry, with its authentication status.
 	//
-	// See Data Dictionary, Section 2.79, `GNSSPlaceRecord`.
-	GnssPlaceRecord *ActivitiesGen2V2_GnssPlaceRecord
+	// See Data Dictionary, Section 2.79c, `GNSSPlaceAuthRecord`.
+	GnssPlaceRecord *ActivitiesGen2V2_GnssPlaceAuthRecord
 }
 
 func (b0 ActivitiesGen2V2_PlaceRecord_builder) Build() *ActivitiesGen2V2_PlaceRecord {
@@ -950,145 +950,6 @@ func (b0 ActivitiesGen2V2_PlaceRecord_builder) Build() *ActivitiesGen2V2_PlaceRe
 	return m0
 }
 
-// Represents a GNSS place record (without authentication status).
-//
-// Binary Layout: 11 bytes
-//
-// See Data Dictionary, Section 2.79, `GNSSPlaceRecord`.
-type ActivitiesGen2V2_GnssPlaceRecord struct {
-	state                     protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Timestamp      *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp"`
-	xxx_hidden_GnssAccuracy   int32                  `protobuf:"varint,2,opt,name=gnss_accuracy,json=gnssAccuracy"`
-	xxx_hidden_GeoCoordinates *v1.GeoCoordinates     `protobuf:"bytes,3,opt,name=geo_coordinates,json=geoCoordinates"`
-	XXX_raceDetectHookData    protoimpl.RaceDetectHookData
-	XXX_presence              [1]uint32
-	unknownFields             protoimpl.UnknownFields
-	sizeCache                 protoimpl.SizeCache
-}
-
-func (x *ActivitiesGen2V2_GnssPlaceRecord) Reset() {
-	*x = ActivitiesGen2V2_GnssPlaceRecord{}
-	mi := &file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_msgTypes[3]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *ActivitiesGen2V2_GnssPlaceRecord) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*ActivitiesGen2V2_GnssPlaceRecord) ProtoMessage() {}
-
-func (x *ActivitiesGen2V2_GnssPlaceRecord) ProtoReflect() protoreflect.Message {
-	mi := &file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_msgTypes[3]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-func (x *ActivitiesGen2V2_GnssPlaceRecord) GetTimestamp() *timestamppb.Timestamp {
-	if x != nil {
-		return x.xxx_hidden_Timestamp
-	}
-	return nil
-}
-
-func (x *ActivitiesGen2V2_GnssPlaceRecord) GetGnssAccuracy() int32 {
-	if x != nil {
-		return x.xxx_hidden_GnssAccuracy
-	}
-	return 0
-}
-
-func (x *ActivitiesGen2V2_GnssPlaceRecord) GetGeoCoordinates() *v1.GeoCoordinates {
-	if x != nil {
-		return x.xxx_hidden_GeoCoordinates
-	}
-	return nil
-}
-
-func (x *ActivitiesGen2V2_GnssPlaceRecord) SetTimestamp(v *timestamppb.Timestamp) {
-	x.xxx_hidden_Timestamp = v
-}
-
-func (x *ActivitiesGen2V2_GnssPlaceRecord) SetGnssAccuracy(v int32) {
-	x.xxx_hidden_GnssAccuracy = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
-}
-
-func (x *ActivitiesGen2V2_GnssPlaceRecord) SetGeoCoordinates(v *v1.GeoCoordinates) {
-	x.xxx_hidden_GeoCoordinates = v
-}
-
-func (x *ActivitiesGen2V2_GnssPlaceRecord) HasTimestamp() bool {
-	if x == nil {
-		return false
-	}
-	return x.xxx_hidden_Timestamp != nil
-}
-
-func (x *ActivitiesGen2V2_GnssPlaceRecord) HasGnssAccuracy() bool {
-	if x == nil {
-		return false
-	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
-}
-
-func (x *ActivitiesGen2V2_GnssPlaceRecord) HasGeoCoordinates() bool {
-	if x == nil {
-		return false
-	}
-	return x.xxx_hidden_GeoCoordinates != nil
-}
-
-func (x *ActivitiesGen2V2_GnssPlaceRecord) ClearTimestamp() {
-	x.xxx_hidden_Timestamp = nil
-}
-
-func (x *ActivitiesGen2V2_GnssPlaceRecord) ClearGnssAccuracy() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
-	x.xxx_hidden_GnssAccuracy = 0
-}
-
-func (x *ActivitiesGen2V2_GnssPlaceRecord) ClearGeoCoordinates() {
-	x.xxx_hidden_GeoCoordinates = nil
-}
-
-type ActivitiesGen2V2_GnssPlaceRecord_builder struct {
-	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
-
-	// Timestamp of the position fix.
-	//
-	// See Data Dictionary, Section 2.162, `TimeReal`.
-	Timestamp *timestamppb.Timestamp
-	// Accuracy of the GNSS fix.
-	//
-	// See Data Dictionary, Section 2.77, `GNSSAccuracy`.
-	GnssAccuracy *int32
-	// Geographic coordinates of the position.
-	//
-	// See Data Dictionary, Section 2.76, `GeoCoordinates`.
-	GeoCoordinates *v1.GeoCoordinates
-}
-
-func (b0 ActivitiesGen2V2_GnssPlaceRecord_builder) Build() *ActivitiesGen2V2_GnssPlaceRecord {
-	m0 := &ActivitiesGen2V2_GnssPlaceRecord{}
-	b, x := &b0, m0
-	_, _ = b, x
-	x.xxx_hidden_Timestamp = b.Timestamp
-	if b.GnssAccuracy != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
-		x.xxx_hidden_GnssAccuracy = *b.GnssAccuracy
-	}
-	x.xxx_hidden_GeoCoordinates = b.GeoCoordinates
-	return m0
-}
-
 // Represents a GNSS accumulated driving record (with authentication status).
 //
 // Binary Layout: 12 bytes
@@ -1109,7 +970,7 @@ type ActivitiesGen2V2_GnssAccumulatedDrivingRecord struct {
 
 func (x *ActivitiesGen2V2_GnssAccumulatedDrivingRecord) Reset() {
 	*x = ActivitiesGen2V2_GnssAccumulatedDrivingRecord{}
-	mi := &file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_msgTypes[4]
+	mi := &file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1121,7 +982,7 @@ func (x *ActivitiesGen2V2_GnssAccumulatedDrivingRecord) String() string {
 func (*ActivitiesGen2V2_GnssAccumulatedDrivingRecord) ProtoMessage() {}
 
 func (x *ActivitiesGen2V2_GnssAccumulatedDrivingRecord) ProtoReflect() protoreflect.Message {
-	mi := &file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_msgTypes[4]
+	mi := &file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1326,7 +1187,7 @@ type ActivitiesGen2V2_BorderCrossingRecord struct {
 
 func (x *ActivitiesGen2V2_BorderCrossingRecord) Reset() {
 	*x = ActivitiesGen2V2_BorderCrossingRecord{}
-	mi := &file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_msgTypes[5]
+	mi := &file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1338,7 +1199,7 @@ func (x *ActivitiesGen2V2_BorderCrossingRecord) String() string {
 func (*ActivitiesGen2V2_BorderCrossingRecord) ProtoMessage() {}
 
 func (x *ActivitiesGen2V2_BorderCrossingRecord) ProtoReflect() protoreflect.Message {
-	mi := &file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_msgTypes[5]
+	mi := &file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1558,7 +1419,7 @@ type ActivitiesGen2V2_GnssPlaceAuthRecord struct {
 
 func (x *ActivitiesGen2V2_GnssPlaceAuthRecord) Reset() {
 	*x = ActivitiesGen2V2_GnssPlaceAuthRecord{}
-	mi := &file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_msgTypes[6]
+	mi := &file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1570,7 +1431,7 @@ func (x *ActivitiesGen2V2_GnssPlaceAuthRecord) String() string {
 func (*ActivitiesGen2V2_GnssPlaceAuthRecord) ProtoMessage() {}
 
 func (x *ActivitiesGen2V2_GnssPlaceAuthRecord) ProtoReflect() protoreflect.Message {
-	mi := &file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_msgTypes[6]
+	mi := &file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1775,7 +1636,7 @@ type ActivitiesGen2V2_LoadUnloadRecord struct {
 
 func (x *ActivitiesGen2V2_LoadUnloadRecord) Reset() {
 	*x = ActivitiesGen2V2_LoadUnloadRecord{}
-	mi := &file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_msgTypes[7]
+	mi := &file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1787,7 +1648,7 @@ func (x *ActivitiesGen2V2_LoadUnloadRecord) String() string {
 func (*ActivitiesGen2V2_LoadUnloadRecord) ProtoMessage() {}
 
 func (x *ActivitiesGen2V2_LoadUnloadRecord) ProtoReflect() protoreflect.Message {
-	mi := &file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_msgTypes[7]
+	mi := &file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1980,7 +1841,7 @@ var File_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto protorefl
 
 const file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_rawDesc = "" +
 	"\n" +
-	"=wayplatform/connect/tachograph/vu/v1/activities_gen2_v2.proto\x12$wayplatform.connect.tachograph.vu.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a?wayplatform/connect/tachograph/dd/v1/activity_change_info.proto\x1a;wayplatform/connect/tachograph/dd/v1/card_slot_number.proto\x1a/wayplatform/connect/tachograph/dd/v1/date.proto\x1aGwayplatform/connect/tachograph/dd/v1/entry_type_daily_work_period.proto\x1aJwayplatform/connect/tachograph/dd/v1/full_card_number_and_generation.proto\x1a:wayplatform/connect/tachograph/dd/v1/geo_coordinates.proto\x1a6wayplatform/connect/tachograph/dd/v1/holder_name.proto\x1a9wayplatform/connect/tachograph/dd/v1/nation_numeric.proto\x1a9wayplatform/connect/tachograph/dd/v1/operation_type.proto\x1aIwayplatform/connect/tachograph/dd/v1/position_authentication_status.proto\x1aCwayplatform/connect/tachograph/dd/v1/previous_vehicle_info_g2.proto\x1aDwayplatform/connect/tachograph/dd/v1/specific_condition_record.proto\"\x99#\n" +
+	"=wayplatform/connect/tachograph/vu/v1/activities_gen2_v2.proto\x12$wayplatform.connect.tachograph.vu.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a?wayplatform/connect/tachograph/dd/v1/activity_change_info.proto\x1a;wayplatform/connect/tachograph/dd/v1/card_slot_number.proto\x1a/wayplatform/connect/tachograph/dd/v1/date.proto\x1aGwayplatform/connect/tachograph/dd/v1/entry_type_daily_work_period.proto\x1aJwayplatform/connect/tachograph/dd/v1/full_card_number_and_generation.proto\x1a:wayplatform/connect/tachograph/dd/v1/geo_coordinates.proto\x1a6wayplatform/connect/tachograph/dd/v1/holder_name.proto\x1a9wayplatform/connect/tachograph/dd/v1/nation_numeric.proto\x1a9wayplatform/connect/tachograph/dd/v1/operation_type.proto\x1aIwayplatform/connect/tachograph/dd/v1/position_authentication_status.proto\x1aCwayplatform/connect/tachograph/dd/v1/previous_vehicle_info_g2.proto\x1aDwayplatform/connect/tachograph/dd/v1/specific_condition_record.proto\"\xcb!\n" +
 	"\x10ActivitiesGen2V2\x12:\n" +
 	"\vdate_of_day\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tdateOfDay\x120\n" +
 	"\x14odometer_midnight_km\x18\x02 \x01(\x05R\x12odometerMidnightKm\x12e\n" +
@@ -2006,7 +1867,7 @@ const file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_rawDesc
 	"\x19odometer_at_withdrawal_km\x18\b \x01(\x05R\x16odometerAtWithdrawalKm\x12o\n" +
 	"\x15previous_vehicle_info\x18\t \x01(\v2;.wayplatform.connect.tachograph.dd.v1.PreviousVehicleInfoG2R\x13previousVehicleInfo\x12*\n" +
 	"\x11manual_input_flag\x18\n" +
-	" \x01(\bR\x0fmanualInputFlag\x1a\xa3\x03\n" +
+	" \x01(\bR\x0fmanualInputFlag\x1a\xa7\x03\n" +
 	"\vPlaceRecord\x129\n" +
 	"\n" +
 	"entry_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tentryTime\x12]\n" +
@@ -2015,12 +1876,8 @@ const file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_rawDesc
 	"\acountry\x18\x03 \x01(\x0e23.wayplatform.connect.tachograph.dd.v1.NationNumericR\acountry\x12\x16\n" +
 	"\x06region\x18\x04 \x01(\fR\x06region\x12\x1f\n" +
 	"\vodometer_km\x18\x05 \x01(\x05R\n" +
-	"odometerKm\x12r\n" +
-	"\x11gnss_place_record\x18\x06 \x01(\v2F.wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssPlaceRecordR\x0fgnssPlaceRecord\x1a\xcf\x01\n" +
-	"\x0fGnssPlaceRecord\x128\n" +
-	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12#\n" +
-	"\rgnss_accuracy\x18\x02 \x01(\x05R\fgnssAccuracy\x12]\n" +
-	"\x0fgeo_coordinates\x18\x03 \x01(\v24.wayplatform.connect.tachograph.dd.v1.GeoCoordinatesR\x0egeoCoordinates\x1a\xa3\x03\n" +
+	"odometerKm\x12v\n" +
+	"\x11gnss_place_record\x18\x06 \x01(\v2J.wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssPlaceAuthRecordR\x0fgnssPlaceRecord\x1a\xa3\x03\n" +
 	"\x1cGnssAccumulatedDrivingRecord\x128\n" +
 	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12#\n" +
 	"\rgnss_accuracy\x18\x02 \x01(\x05R\fgnssAccuracy\x12]\n" +
@@ -2051,73 +1908,70 @@ const file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_rawDesc
 	"odometerKmB\xd4\x02\n" +
 	"(com.wayplatform.connect.tachograph.vu.v1B\x15ActivitiesGen2V2ProtoP\x01Z\\github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1;vuv1\xa2\x02\x04WCTV\xaa\x02$Wayplatform.Connect.Tachograph.Vu.V1\xca\x02$Wayplatform\\Connect\\Tachograph\\Vu\\V1\xe2\x020Wayplatform\\Connect\\Tachograph\\Vu\\V1\\GPBMetadata\xea\x02(Wayplatform::Connect::Tachograph::Vu::V1b\beditionsp\xe8\a"
 
-var file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_goTypes = []any{
 	(*ActivitiesGen2V2)(nil),                              // 0: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2
 	(*ActivitiesGen2V2_CardIWRecord)(nil),                 // 1: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.CardIWRecord
 	(*ActivitiesGen2V2_PlaceRecord)(nil),                  // 2: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.PlaceRecord
-	(*ActivitiesGen2V2_GnssPlaceRecord)(nil),              // 3: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssPlaceRecord
-	(*ActivitiesGen2V2_GnssAccumulatedDrivingRecord)(nil), // 4: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssAccumulatedDrivingRecord
-	(*ActivitiesGen2V2_BorderCrossingRecord)(nil),         // 5: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.BorderCrossingRecord
-	(*ActivitiesGen2V2_GnssPlaceAuthRecord)(nil),          // 6: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssPlaceAuthRecord
-	(*ActivitiesGen2V2_LoadUnloadRecord)(nil),             // 7: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.LoadUnloadRecord
-	(*timestamppb.Timestamp)(nil),                         // 8: google.protobuf.Timestamp
-	(*v1.ActivityChangeInfo)(nil),                         // 9: wayplatform.connect.tachograph.dd.v1.ActivityChangeInfo
-	(*v1.SpecificConditionRecord)(nil),                    // 10: wayplatform.connect.tachograph.dd.v1.SpecificConditionRecord
-	(*v1.HolderName)(nil),                                 // 11: wayplatform.connect.tachograph.dd.v1.HolderName
-	(*v1.FullCardNumberAndGeneration)(nil),                // 12: wayplatform.connect.tachograph.dd.v1.FullCardNumberAndGeneration
-	(*v1.Date)(nil),                                       // 13: wayplatform.connect.tachograph.dd.v1.Date
-	(v1.CardSlotNumber)(0),                                // 14: wayplatform.connect.tachograph.dd.v1.CardSlotNumber
-	(*v1.PreviousVehicleInfoG2)(nil),                      // 15: wayplatform.connect.tachograph.dd.v1.PreviousVehicleInfoG2
-	(v1.EntryTypeDailyWorkPeriod)(0),                      // 16: wayplatform.connect.tachograph.dd.v1.EntryTypeDailyWorkPeriod
-	(v1.NationNumeric)(0),                                 // 17: wayplatform.connect.tachograph.dd.v1.NationNumeric
-	(*v1.GeoCoordinates)(nil),                             // 18: wayplatform.connect.tachograph.dd.v1.GeoCoordinates
-	(v1.PositionAuthenticationStatus)(0),                  // 19: wayplatform.connect.tachograph.dd.v1.PositionAuthenticationStatus
-	(v1.OperationType)(0),                                 // 20: wayplatform.connect.tachograph.dd.v1.OperationType
+	(*ActivitiesGen2V2_GnssAccumulatedDrivingRecord)(nil), // 3: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssAccumulatedDrivingRecord
+	(*ActivitiesGen2V2_BorderCrossingRecord)(nil),         // 4: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.BorderCrossingRecord
+	(*ActivitiesGen2V2_GnssPlaceAuthRecord)(nil),          // 5: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssPlaceAuthRecord
+	(*ActivitiesGen2V2_LoadUnloadRecord)(nil),             // 6: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.LoadUnloadRecord
+	(*timestamppb.Timestamp)(nil),                         // 7: google.protobuf.Timestamp
+	(*v1.ActivityChangeInfo)(nil),                         // 8: wayplatform.connect.tachograph.dd.v1.ActivityChangeInfo
+	(*v1.SpecificConditionRecord)(nil),                    // 9: wayplatform.connect.tachograph.dd.v1.SpecificConditionRecord
+	(*v1.HolderName)(nil),                                 // 10: wayplatform.connect.tachograph.dd.v1.HolderName
+	(*v1.FullCardNumberAndGeneration)(nil),                // 11: wayplatform.connect.tachograph.dd.v1.FullCardNumberAndGeneration
+	(*v1.Date)(nil),                                       // 12: wayplatform.connect.tachograph.dd.v1.Date
+	(v1.CardSlotNumber)(0),                                // 13: wayplatform.connect.tachograph.dd.v1.CardSlotNumber
+	(*v1.PreviousVehicleInfoG2)(nil),                      // 14: wayplatform.connect.tachograph.dd.v1.PreviousVehicleInfoG2
+	(v1.EntryTypeDailyWorkPeriod)(0),                      // 15: wayplatform.connect.tachograph.dd.v1.EntryTypeDailyWorkPeriod
+	(v1.NationNumeric)(0),                                 // 16: wayplatform.connect.tachograph.dd.v1.NationNumeric
+	(*v1.GeoCoordinates)(nil),                             // 17: wayplatform.connect.tachograph.dd.v1.GeoCoordinates
+	(v1.PositionAuthenticationStatus)(0),                  // 18: wayplatform.connect.tachograph.dd.v1.PositionAuthenticationStatus
+	(v1.OperationType)(0),                                 // 19: wayplatform.connect.tachograph.dd.v1.OperationType
 }
 var file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_depIdxs = []int32{
-	8,  // 0: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.date_of_day:type_name -> google.protobuf.Timestamp
+	7,  // 0: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.date_of_day:type_name -> google.protobuf.Timestamp
 	1,  // 1: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.card_iw_data:type_name -> wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.CardIWRecord
-	9,  // 2: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.activity_changes:type_name -> wayplatform.connect.tachograph.dd.v1.ActivityChangeInfo
+	8,  // 2: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.activity_changes:type_name -> wayplatform.connect.tachograph.dd.v1.ActivityChangeInfo
 	2,  // 3: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.places:type_name -> wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.PlaceRecord
-	4,  // 4: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.gnss_accumulated_driving:type_name -> wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssAccumulatedDrivingRecord
-	10, // 5: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.specific_conditions:type_name -> wayplatform.connect.tachograph.dd.v1.SpecificConditionRecord
-	5,  // 6: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.border_crossings:type_name -> wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.BorderCrossingRecord
-	7,  // 7: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.load_unload_operations:type_name -> wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.LoadUnloadRecord
-	11, // 8: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.CardIWRecord.card_holder_name:type_name -> wayplatform.connect.tachograph.dd.v1.HolderName
-	12, // 9: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.CardIWRecord.full_card_number_and_generation:type_name -> wayplatform.connect.tachograph.dd.v1.FullCardNumberAndGeneration
-	13, // 10: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.CardIWRecord.card_expiry_date:type_name -> wayplatform.connect.tachograph.dd.v1.Date
-	8,  // 11: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.CardIWRecord.card_insertion_time:type_name -> google.protobuf.Timestamp
-	14, // 12: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.CardIWRecord.card_slot_number:type_name -> wayplatform.connect.tachograph.dd.v1.CardSlotNumber
-	8,  // 13: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.CardIWRecord.card_withdrawal_time:type_name -> google.protobuf.Timestamp
-	15, // 14: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.CardIWRecord.previous_vehicle_info:type_name -> wayplatform.connect.tachograph.dd.v1.PreviousVehicleInfoG2
-	8,  // 15: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.PlaceRecord.entry_time:type_name -> google.protobuf.Timestamp
-	16, // 16: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.PlaceRecord.entry_type:type_name -> wayplatform.connect.tachograph.dd.v1.EntryTypeDailyWorkPeriod
-	17, // 17: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.PlaceRecord.country:type_name -> wayplatform.connect.tachograph.dd.v1.NationNumeric
-	3,  // 18: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.PlaceRecord.gnss_place_record:type_name -> wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssPlaceRecord
-	8,  // 19: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssPlaceRecord.timestamp:type_name -> google.protobuf.Timestamp
-	18, // 20: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssPlaceRecord.geo_coordinates:type_name -> wayplatform.connect.tachograph.dd.v1.GeoCoordinates
-	8,  // 21: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssAccumulatedDrivingRecord.timestamp:type_name -> google.protobuf.Timestamp
-	18, // 22: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssAccumulatedDrivingRecord.geo_coordinates:type_name -> wayplatform.connect.tachograph.dd.v1.GeoCoordinates
-	19, // 23: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssAccumulatedDrivingRecord.authentication_status:type_name -> wayplatform.connect.tachograph.dd.v1.PositionAuthenticationStatus
-	12, // 24: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.BorderCrossingRecord.card_number_driver_slot:type_name -> wayplatform.connect.tachograph.dd.v1.FullCardNumberAndGeneration
-	12, // 25: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.BorderCrossingRecord.card_number_codriver_slot:type_name -> wayplatform.connect.tachograph.dd.v1.FullCardNumberAndGeneration
-	17, // 26: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.BorderCrossingRecord.country_left:type_name -> wayplatform.connect.tachograph.dd.v1.NationNumeric
-	17, // 27: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.BorderCrossingRecord.country_entered:type_name -> wayplatform.connect.tachograph.dd.v1.NationNumeric
-	6,  // 28: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.BorderCrossingRecord.gnss_place_auth_record:type_name -> wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssPlaceAuthRecord
-	8,  // 29: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssPlaceAuthRecord.timestamp:type_name -> google.protobuf.Timestamp
-	18, // 30: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssPlaceAuthRecord.geo_coordinates:type_name -> wayplatform.connect.tachograph.dd.v1.GeoCoordinates
-	19, // 31: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssPlaceAuthRecord.authentication_status:type_name -> wayplatform.connect.tachograph.dd.v1.PositionAuthenticationStatus
-	8,  // 32: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.LoadUnloadRecord.timestamp:type_name -> google.protobuf.Timestamp
-	20, // 33: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.LoadUnloadRecord.operation_type:type_name -> wayplatform.connect.tachograph.dd.v1.OperationType
-	12, // 34: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.LoadUnloadRecord.card_number_driver_slot:type_name -> wayplatform.connect.tachograph.dd.v1.FullCardNumberAndGeneration
-	12, // 35: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.LoadUnloadRecord.card_number_codriver_slot:type_name -> wayplatform.connect.tachograph.dd.v1.FullCardNumberAndGeneration
-	6,  // 36: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.LoadUnloadRecord.gnss_place_auth_record:type_name -> wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssPlaceAuthRecord
-	37, // [37:37] is the sub-list for method output_type
-	37, // [37:37] is the sub-list for method input_type
-	37, // [37:37] is the sub-list for extension type_name
-	37, // [37:37] is the sub-list for extension extendee
-	0,  // [0:37] is the sub-list for field type_name
+	3,  // 4: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.gnss_accumulated_driving:type_name -> wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssAccumulatedDrivingRecord
+	9,  // 5: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.specific_conditions:type_name -> wayplatform.connect.tachograph.dd.v1.SpecificConditionRecord
+	4,  // 6: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.border_crossings:type_name -> wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.BorderCrossingRecord
+	6,  // 7: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.load_unload_operations:type_name -> wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.LoadUnloadRecord
+	10, // 8: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.CardIWRecord.card_holder_name:type_name -> wayplatform.connect.tachograph.dd.v1.HolderName
+	11, // 9: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.CardIWRecord.full_card_number_and_generation:type_name -> wayplatform.connect.tachograph.dd.v1.FullCardNumberAndGeneration
+	12, // 10: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.CardIWRecord.card_expiry_date:type_name -> wayplatform.connect.tachograph.dd.v1.Date
+	7,  // 11: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.CardIWRecord.card_insertion_time:type_name -> google.protobuf.Timestamp
+	13, // 12: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.CardIWRecord.card_slot_number:type_name -> wayplatform.connect.tachograph.dd.v1.CardSlotNumber
+	7,  // 13: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.CardIWRecord.card_withdrawal_time:type_name -> google.protobuf.Timestamp
+	14, // 14: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.CardIWRecord.previous_vehicle_info:type_name -> wayplatform.connect.tachograph.dd.v1.PreviousVehicleInfoG2
+	7,  // 15: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.PlaceRecord.entry_time:type_name -> google.protobuf.Timestamp
+	15, // 16: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.PlaceRecord.entry_type:type_name -> wayplatform.connect.tachograph.dd.v1.EntryTypeDailyWorkPeriod
+	16, // 17: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.PlaceRecord.country:type_name -> wayplatform.connect.tachograph.dd.v1.NationNumeric
+	5,  // 18: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.PlaceRecord.gnss_place_record:type_name -> wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssPlaceAuthRecord
+	7,  // 19: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssAccumulatedDrivingRecord.timestamp:type_name -> google.protobuf.Timestamp
+	17, // 20: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssAccumulatedDrivingRecord.geo_coordinates:type_name -> wayplatform.connect.tachograph.dd.v1.GeoCoordinates
+	18, // 21: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssAccumulatedDrivingRecord.authentication_status:type_name -> wayplatform.connect.tachograph.dd.v1.PositionAuthenticationStatus
+	11, // 22: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.BorderCrossingRecord.card_number_driver_slot:type_name -> wayplatform.connect.tachograph.dd.v1.FullCardNumberAndGeneration
+	11, // 23: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.BorderCrossingRecord.card_number_codriver_slot:type_name -> wayplatform.connect.tachograph.dd.v1.FullCardNumberAndGeneration
+	16, // 24: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.BorderCrossingRecord.country_left:type_name -> wayplatform.connect.tachograph.dd.v1.NationNumeric
+	16, // 25: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.BorderCrossingRecord.country_entered:type_name -> wayplatform.connect.tachograph.dd.v1.NationNumeric
+	5,  // 26: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.BorderCrossingRecord.gnss_place_auth_record:type_name -> wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssPlaceAuthRecord
+	7,  // 27: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssPlaceAuthRecord.timestamp:type_name -> google.protobuf.Timestamp
+	17, // 28: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssPlaceAuthRecord.geo_coordinates:type_name -> wayplatform.connect.tachograph.dd.v1.GeoCoordinates
+	18, // 29: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssPlaceAuthRecord.authentication_status:type_name -> wayplatform.connect.tachograph.dd.v1.PositionAuthenticationStatus
+	7,  // 30: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.LoadUnloadRecord.timestamp:type_name -> google.protobuf.Timestamp
+	19, // 31: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.LoadUnloadRecord.operation_type:type_name -> wayplatform.connect.tachograph.dd.v1.OperationType
+	11, // 32: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.LoadUnloadRecord.card_number_driver_slot:type_name -> wayplatform.connect.tachograph.dd.v1.FullCardNumberAndGeneration
+	11, // 33: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.LoadUnloadRecord.card_number_codriver_slot:type_name -> wayplatform.connect.tachograph.dd.v1.FullCardNumberAndGeneration
+	5,  // 34: wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.LoadUnloadRecord.gnss_place_auth_record:type_name -> wayplatform.connect.tachograph.vu.v1.ActivitiesGen2V2.GnssPlaceAuthRecord
+	35, // [35:35] is the sub-list for method output_type
+	35, // [35:35] is the sub-list for method input_type
+	35, // [35:35] is the sub-list for extension type_name
+	35, // [35:35] is the sub-list for extension extendee
+	0,  // [0:35] is the sub-list for field type_name
 }
 
 func init() { file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_init() }
@@ -2131,7 +1985,7 @@ func file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_rawDesc), len(file_wayplatform_connect_tachograph_vu_v1_activities_gen2_v2_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,58 @@
+package report
+
+import (
+	"time"
+
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+)
+
+// chartActivity is the legend entry and bar style of an activity in the 24h
+// activity chart.
+type chartActivity struct {
+	activity ddv1.DriverActivityValue
+	name     string
+	// level is the height of the bar, as a fraction of the chart height.
+	level float64
+	// color is the RGB color of the bar.
+	color [3]uint8
+}
+
+// chartActivities are the chart styles of the activities, in legend order.
+var chartActivities = []chartActivity{
+	{activity: ddv1.DriverActivityValue_DRIVING, name: "Driving", level: 1, color: [3]uint8{0xe5, 0x39, 0x35}},
+	{activity: ddv1.DriverActivityValue_WORK, name: "Work", level: 0.75, color: [3]uint8{0xfb, 0x8c, 0x00}},
+	{activity: ddv1.DriverActivityValue_AVAILABILITY, name: "Availability", level: 0.5, color: [3]uint8{0x1e, 0x88, 0xe5}},
+	{activity: ddv1.DriverActivityValue_BREAK_REST, name: "Break/rest", level: 0.25, color: [3]uint8{0x43, 0xa0, 0x47}},
+}
+
+// chartUnknown is the chart style of periods with an unknown activity.
+var chartUnknown = chartActivity{name: "Unknown", level: 0.1, color: [3]uint8{0xbd, 0xbd, 0xbd}}
+
+// chartBar is a bar of the 24h activity chart of a day.
+type chartBar struct {
+	// start and width are the position and width of the bar, as fractions of
+	// the day.
+	start, width float64
+	style        chartActivity
+}
+
+// chartBars returns the bars of the 24h activity chart of a day.
+func chartBars(d Day) []chartBar {
+	var bars []chartBar
+	for _, p := range d.Periods {
+		style := chartUnknown
+		if !p.Unknown() {
+			for _, a := range chartActivities {
+				if a.activity == p.Activity {
+					style = a
+				}
+			}
+		}
+		bars = append(bars, chartBar{
+			start: float64(p.Start.Sub(d.Date)) / float64(24*time.Hour),
+			width: float64(p.Duration()) / float64(24*time.Hour),
+			style: style,
+		})
+	}
+	return bars
+}
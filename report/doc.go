@@ -0,0 +1,6 @@
+// Package report renders driver cards and vehicle units as printable activity
+// reports, in HTML or PDF.
+//
+// A report holds the identification of the card or vehicle, a 24h activity
+// bar chart per day, and tables of events, faults, vehicles and places.
+package report
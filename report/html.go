@@ -0,0 +1,135 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"time"
+)
+
+// WriteHTML writes the report as a printable, self-contained HTML document.
+//
+// The activity of each day is drawn as an inline SVG bar chart over 24 hours.
+func (r *Report) WriteHTML(w io.Writer) error {
+	type bar struct {
+		X, Y, Width, Height float64
+		Color, Title        string
+	}
+	type day struct {
+		Date                                       string
+		Bars                                       []bar
+		Driving, Work, Availability, Rest, Unknown string
+	}
+	type legend struct {
+		Name, Color string
+	}
+	data := struct {
+		*Report
+		Days   []day
+		Legend []legend
+		Hours  []int
+	}{Report: r}
+	for _, a := range append(chartActivities, chartUnknown) {
+		data.Legend = append(data.Legend, legend{Name: a.name, Color: htmlColor(a.color)})
+	}
+	for h := 0; h <= 24; h += 2 {
+		data.Hours = append(data.Hours, h)
+	}
+	for _, d := range r.Days {
+		v := day{
+			Date:         d.Date.Format("Mon 2006-01-02"),
+			Driving:      formatDuration(d.Total(chartActivities[0].activity)),
+			Work:         formatDuration(d.Total(chartActivities[1].activity)),
+			Availability: formatDuration(d.Total(chartActivities[2].activity)),
+			Rest:         formatDuration(d.Total(chartActivities[3].activity)),
+			Unknown:      formatDuration(d.Unknown()),
+		}
+		for i, b := range chartBars(d) {
+			height := b.style.level * htmlChartHeight
+			v.Bars = append(v.Bars, bar{
+				X:      b.start * htmlChartWidth,
+				Y:      htmlChartHeight - height,
+				Width:  b.width * htmlChartWidth,
+				Height: height,
+				Color:  htmlColor(b.style.color),
+				Title: fmt.Sprintf("%s %s-%s", b.style.name,
+					d.Periods[i].Start.Format("15:04"), formatEnd(d.Periods[i].End)),
+			})
+		}
+		data.Days = append(data.Days, v)
+	}
+	return htmlTemplate.Execute(w, data)
+}
+
+const (
+	htmlChartWidth  = 720
+	htmlChartHeight = 32
+)
+
+// formatEnd formats the end time of a period, as 24:00 at midnight.
+func formatEnd(t time.Time) string {
+	if t.Hour() == 0 && t.Minute() == 0 {
+		return "24:00"
+	}
+	return t.Format("15:04")
+}
+
+func htmlColor(c [3]uint8) string {
+	return fmt.Sprintf("#%02x%02x%02x", c[0], c[1], c[2])
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"hourX": func(h int) float64 { return float64(h) * htmlChartWidth / 24 },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; font-size: 10pt; margin: 2em; color: #212121; }
+h1 { font-size: 16pt; }
+h2 { font-size: 12pt; margin-top: 2em; border-bottom: 1px solid #9e9e9e; }
+table { border-collapse: collapse; }
+th, td { padding: 2px 8px; text-align: left; vertical-align: middle; }
+tr { page-break-inside: avoid; }
+.data th { background: #eeeeee; }
+.data td { border-bottom: 1px solid #e0e0e0; }
+.legend span { display: inline-block; width: 1em; height: 1em; vertical-align: middle; margin: 0 0.3em 0 1em; }
+.totals { font-size: 8pt; white-space: nowrap; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<table>
+{{- range .Identification}}
+<tr><th>{{.Name}}</th><td>{{.Value}}</td></tr>
+{{- end}}
+</table>
+{{- if .Days}}
+<h2>Activities</h2>
+<p class="legend">{{range .Legend}}<span style="background: {{.Color}}"></span>{{.Name}}{{end}}</p>
+<table>
+<tr><th></th><td><svg width="720" height="12" font-size="8">{{range .Hours}}<text x="{{hourX .}}" y="10" text-anchor="middle">{{.}}</text>{{end}}</svg></td><th class="totals">Driving / Work / Availability / Rest / Unknown</th></tr>
+{{- range .Days}}
+<tr>
+<th>{{.Date}}</th>
+<td><svg width="720" height="33"><rect x="0" y="0" width="720" height="32" fill="#fafafa" stroke="#e0e0e0"/>{{range .Bars}}<rect x="{{.X}}" y="{{.Y}}" width="{{.Width}}" height="{{.Height}}" fill="{{.Color}}"><title>{{.Title}}</title></rect>{{end}}</svg></td>
+<td class="totals">{{.Driving}} / {{.Work}} / {{.Availability}} / {{.Rest}} / {{.Unknown}}</td>
+</tr>
+{{- end}}
+</table>
+{{- end}}
+{{- range .Sections}}
+{{- if .Rows}}
+<h2>{{.Title}}</h2>
+<table class="data">
+<tr>{{range .Columns}}<th>{{.}}</th>{{end}}</tr>
+{{- range .Rows}}
+<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{- end}}
+</table>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
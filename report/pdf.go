@@ -0,0 +1,258 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WritePDF writes the report as an A4 PDF document.
+//
+// The document uses the standard Helvetica fonts, so characters outside of
+// Latin-1 are replaced with question marks.
+func (r *Report) WritePDF(w io.Writer) error {
+	d := &pdfDocument{}
+	d.newPage()
+	d.text(pdfMargin, d.y-16, 16, true, r.Title)
+	d.y -= 30
+	for _, f := range r.Identification {
+		d.ensure(12)
+		d.text(pdfMargin, d.y-9, 9, true, f.Name)
+		d.text(pdfMargin+120, d.y-9, 9, false, f.Value)
+		d.y -= 12
+	}
+	if len(r.Days) > 0 {
+		d.activities(r.Days)
+	}
+	for _, s := range r.Sections {
+		if len(s.Rows) > 0 {
+			d.section(s)
+		}
+	}
+	_, err := w.Write(d.bytes())
+	return err
+}
+
+// A4 page layout, in points.
+const (
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+	pdfMargin     = 40
+
+	pdfChartX      = 105
+	pdfChartWidth  = 336
+	pdfChartHeight = 16
+	pdfTotalsWidth = 22
+)
+
+// pdfDocument is a PDF document being laid out from top to bottom.
+type pdfDocument struct {
+	pages []*bytes.Buffer
+	page  *bytes.Buffer
+	// y is the top of the free space of the current page.
+	y float64
+	// header is redrawn at the top of each new page, if set.
+	header func()
+}
+
+func (d *pdfDocument) newPage() {
+	d.page = &bytes.Buffer{}
+	d.pages = append(d.pages, d.page)
+	d.y = pdfPageHeight - pdfMargin
+	if d.header != nil {
+		d.header()
+	}
+}
+
+// ensure starts a new page unless the current page has the given height left.
+func (d *pdfDocument) ensure(height float64) {
+	if d.y-height < pdfMargin {
+		d.newPage()
+	}
+}
+
+func (d *pdfDocument) text(x, y, size float64, bold bool, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(d.page, "BT /%s %s Tf %s %s Td (%s) Tj ET\n", font, pdfNumber(size), pdfNumber(x), pdfNumber(y), pdfString(s))
+}
+
+func (d *pdfDocument) rect(x, y, width, height float64, color [3]uint8) {
+	fmt.Fprintf(d.page, "%s %s %s rg %s %s %s %s re f 0 g\n",
+		pdfNumber(float64(color[0])/255), pdfNumber(float64(color[1])/255), pdfNumber(float64(color[2])/255),
+		pdfNumber(x), pdfNumber(y), pdfNumber(width), pdfNumber(height))
+}
+
+// heading draws a section heading, keeping room for at least one line below.
+func (d *pdfDocument) heading(title string) {
+	d.header = nil
+	d.ensure(40)
+	d.y -= 12
+	d.text(pdfMargin, d.y-12, 12, true, title)
+	d.rect(pdfMargin, d.y-16, pdfPageWidth-2*pdfMargin, 0.5, [3]uint8{0x9e, 0x9e, 0x9e})
+	d.y -= 22
+}
+
+// activities draws the legend and the 24h activity chart of each day.
+func (d *pdfDocument) activities(days []Day) {
+	d.heading("Activities")
+	x := float64(pdfMargin)
+	for _, a := range append(chartActivities, chartUnknown) {
+		d.rect(x, d.y-8, 8, 8, a.color)
+		d.text(x+11, d.y-7.5, 8, false, a.name)
+		x += 11 + pdfTextWidth(a.name, 8) + 14
+	}
+	d.y -= 14
+	d.header = func() {
+		for h := 0; h <= 24; h += 2 {
+			label := strconv.Itoa(h)
+			d.text(pdfChartX+float64(h)*pdfChartWidth/24-pdfTextWidth(label, 6)/2, d.y-6, 6, false, label)
+		}
+		for i, label := range []string{"Drive", "Work", "Avail.", "Rest", "Unkn."} {
+			d.text(pdfChartX+pdfChartWidth+6+float64(i)*pdfTotalsWidth, d.y-6, 6, true, label)
+		}
+		d.y -= 9
+	}
+	d.header()
+	for _, day := range days {
+		d.ensure(pdfChartHeight + 4)
+		bottom := d.y - pdfChartHeight
+		d.text(pdfMargin, bottom+5, 8, false, day.Date.Format("Mon 2006-01-02"))
+		d.rect(pdfChartX, bottom, pdfChartWidth, pdfChartHeight, [3]uint8{0xf5, 0xf5, 0xf5})
+		for _, b := range chartBars(day) {
+			d.rect(pdfChartX+b.start*pdfChartWidth, bottom, b.width*pdfChartWidth, b.style.level*pdfChartHeight, b.style.color)
+		}
+		for i, total := range []string{
+			formatDuration(day.Total(chartActivities[0].activity)),
+			formatDuration(day.Total(chartActivities[1].activity)),
+			formatDuration(day.Total(chartActivities[2].activity)),
+			formatDuration(day.Total(chartActivities[3].activity)),
+			formatDuration(day.Unknown()),
+		} {
+			d.text(pdfChartX+pdfChartWidth+6+float64(i)*pdfTotalsWidth, bottom+5, 6.5, false, total)
+		}
+		d.y -= pdfChartHeight + 4
+	}
+	d.header = nil
+}
+
+// section draws a table, repeating its header row on each page.
+func (d *pdfDocument) section(s Section) {
+	const size, padding, rowHeight = 7, 6, 10
+	d.heading(s.Title)
+	widths := make([]float64, len(s.Columns))
+	var total float64
+	for i, column := range s.Columns {
+		widths[i] = pdfTextWidth(column, size)
+		for _, row := range s.Rows {
+			widths[i] = max(widths[i], pdfTextWidth(row[i], size))
+		}
+		widths[i] += padding
+		total += widths[i]
+	}
+	if available := float64(pdfPageWidth - 2*pdfMargin); total > available {
+		for i := range widths {
+			widths[i] *= available / total
+		}
+	}
+	row := func(values []string, bold bool) {
+		x := float64(pdfMargin)
+		for i, v := range values {
+			d.text(x, d.y-rowHeight+3, size, bold, pdfTruncate(v, widths[i]-padding, size))
+			x += widths[i]
+		}
+		d.y -= rowHeight
+	}
+	d.header = func() {
+		d.rect(pdfMargin, d.y-rowHeight, pdfPageWidth-2*pdfMargin, rowHeight, [3]uint8{0xee, 0xee, 0xee})
+		row(s.Columns, true)
+	}
+	d.header()
+	for _, values := range s.Rows {
+		d.ensure(rowHeight)
+		row(values, false)
+	}
+	d.header = nil
+}
+
+// bytes assembles the document: the catalog, the page tree, the fonts, and a
+// page and content stream per page, followed by the cross-reference table.
+func (d *pdfDocument) bytes() []byte {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"", // The page tree, once the page objects are numbered.
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+	}
+	var kids []string
+	for _, page := range d.pages {
+		pageObject := len(objects) + 1
+		kids = append(kids, fmt.Sprintf("%d 0 R", pageObject))
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+				pdfPageWidth, pdfPageHeight, pageObject+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.Bytes()),
+		)
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids))
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return b.Bytes()
+}
+
+// pdfTextWidth estimates the width of Helvetica text, erring on the wide side.
+func pdfTextWidth(s string, size float64) float64 {
+	return float64(len([]rune(s))) * 0.6 * size
+}
+
+// pdfTruncate truncates text to fit a width.
+func pdfTruncate(s string, width, size float64) string {
+	runes := []rune(s)
+	n := int(width / (0.6 * size))
+	if len(runes) <= n {
+		return s
+	}
+	if n <= 1 {
+		return ""
+	}
+	return string(runes[:n-1]) + "."
+}
+
+// pdfString encodes text as the content of a PDF literal string in
+// WinAnsiEncoding.
+func pdfString(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 0x20 && r < 0x7F:
+			b.WriteRune(r)
+		case r >= 0xA0 && r <= 0xFF:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+func pdfNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,295 @@
+package report
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/way-platform/tachograph-go"
+	"github.com/way-platform/tachograph-go/export"
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Report is a printable activity report of a driver card or vehicle unit.
+type Report struct {
+	// Title is the title of the report.
+	Title string
+	// Identification are the identification fields of the card or vehicle,
+	// in display order.
+	Identification []Field
+	// Days are the days with recorded activity, ordered by date.
+	Days []Day
+	// Sections are the tables of events, faults, vehicles and places.
+	Sections []Section
+}
+
+// Field is a named value of a report.
+type Field struct {
+	Name  string
+	Value string
+}
+
+// Day is a calendar day of activity, in UTC.
+type Day struct {
+	// Date is the start of the day (00:00 UTC).
+	Date time.Time
+	// Periods are the activity periods of the day, clipped to the day. For a
+	// vehicle unit, these are the periods of the driver slot.
+	Periods []tachograph.ActivityPeriod
+}
+
+// Total returns the total time of an activity during the day. Periods with an
+// unknown activity and periods out of scope are not counted.
+func (d Day) Total(activity ddv1.DriverActivityValue) time.Duration {
+	var total time.Duration
+	for _, p := range d.Periods {
+		if p.Activity == activity && !p.Unknown() && !p.OutOfScope {
+			total += p.Duration()
+		}
+	}
+	return total
+}
+
+// Unknown returns the total time of unknown activity during the day.
+func (d Day) Unknown() time.Duration {
+	var total time.Duration
+	for _, p := range d.Periods {
+		if p.Unknown() {
+			total += p.Duration()
+		}
+	}
+	return total
+}
+
+// Section is a table of a report, with formatted values.
+type Section struct {
+	// Title is the title of the section.
+	Title string
+	// Columns are the column headers.
+	Columns []string
+	// Rows are the rows of formatted values, one per column.
+	Rows [][]string
+}
+
+// New builds the report of a driver card or vehicle unit file.
+func New(file *tachographv1.File) (*Report, error) {
+	r := &Report{}
+	var periods []tachograph.ActivityPeriod
+	switch file.GetType() {
+	case tachographv1.File_DRIVER_CARD:
+		card := file.GetDriverCard()
+		r.Title = "Driver card " + tachograph.DriverCardNumber(card)
+		r.Identification = driverCardIdentification(card)
+		periods = tachograph.ActivityPeriods(card)
+	case tachographv1.File_VEHICLE_UNIT:
+		h, err := tachograph.MergeVehicleUnitFiles(file.GetVehicleUnit())
+		if err != nil {
+			return nil, err
+		}
+		r.Title = "Vehicle unit " + h.VIN
+		r.Identification = vehicleUnitIdentification(h)
+		for _, p := range h.ActivityPeriods() {
+			if p.Slot == ddv1.CardSlotNumber_DRIVER_SLOT {
+				periods = append(periods, p)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported file type: %v", file.GetType())
+	}
+	r.Days = days(periods)
+	tables, err := export.Tables(file)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range []struct {
+		title, table string
+		columns      []string
+	}{
+		{title: "Events", table: "events", columns: []string{"type", "begin_time", "end_time", "registration_nation", "registration_number", "driver_card_number", "co_driver_card_number", "max_speed_kmh"}},
+		{title: "Faults", table: "faults", columns: []string{"type", "begin_time", "end_time", "registration_nation", "registration_number", "driver_card_number", "co_driver_card_number"}},
+		{title: "Vehicles", table: "vehicles", columns: []string{"card_number", "registration_nation", "registration_number", "vin", "first_use", "last_use", "odometer_begin_km", "odometer_end_km", "distance_km"}},
+		{title: "Places", table: "places", columns: []string{"time", "entry_type", "country", "odometer_km", "latitude", "longitude"}},
+	} {
+		for _, t := range tables {
+			if t.Name == s.table {
+				r.Sections = append(r.Sections, newSection(s.title, t, s.columns))
+			}
+		}
+	}
+	return r, nil
+}
+
+// driverCardIdentification returns the identification fields of a driver
+// card, preferring the Gen2 application.
+func driverCardIdentification(card *cardv1.DriverCardFile) []Field {
+	identification := card.GetTachograph().GetIdentification()
+	if g2 := card.GetTachographG2().GetIdentification(); g2 != nil {
+		identification = g2
+	}
+	holder := identification.GetDriverCardHolder()
+	birthDate := holder.GetCardHolderBirthDate()
+	c := identification.GetCard()
+	return nonEmptyFields(
+		Field{Name: "Card number", Value: tachograph.DriverCardNumber(card)},
+		Field{Name: "Surname", Value: strings.TrimSpace(holder.GetCardHolderSurname().GetValue())},
+		Field{Name: "First names", Value: strings.TrimSpace(holder.GetCardHolderFirstNames().GetValue())},
+		Field{Name: "Birth date", Value: formatDate(birthDate.GetYear(), birthDate.GetMonth(), birthDate.GetDay())},
+		Field{Name: "Issuing member state", Value: enumName(c.GetCardIssuingMemberState())},
+		Field{Name: "Issuing authority", Value: strings.TrimSpace(c.GetCardIssuingAuthorityName().GetValue())},
+		Field{Name: "Issue date", Value: formatDay(c.GetCardIssueDate().AsTime(), c.GetCardIssueDate() != nil)},
+		Field{Name: "Validity begin", Value: formatDay(c.GetCardValidityBegin().AsTime(), c.GetCardValidityBegin() != nil)},
+		Field{Name: "Expiry date", Value: formatDay(c.GetCardExpiryDate().AsTime(), c.GetCardExpiryDate() != nil)},
+	)
+}
+
+// vehicleUnitIdentification returns the identification fields of a vehicle.
+func vehicleUnitIdentification(h *tachograph.VehicleHistory) []Field {
+	fields := []Field{{Name: "VIN", Value: h.VIN}}
+	for _, registration := range h.Registrations() {
+		value := registration.Number
+		if nation := enumName(registration.Nation); nation != "" {
+			value = nation + " " + value
+		}
+		fields = append(fields, Field{Name: "Registration", Value: value})
+	}
+	if n := len(h.Downloads); n > 0 {
+		d := h.Downloads[n-1]
+		fields = append(fields,
+			Field{Name: "Generation", Value: enumName(d.Generation)},
+			Field{Name: "Downloaded", Value: formatTime(d.DownloadTime)},
+		)
+	}
+	if n := len(h.Coverage); n > 0 {
+		fields = append(fields, Field{Name: "Period", Value: formatTime(h.Coverage[0].Start) + " - " + formatTime(h.Coverage[n-1].End)})
+	}
+	return nonEmptyFields(fields...)
+}
+
+// days groups activity periods by day, clipping them to the day.
+func days(periods []tachograph.ActivityPeriod) []Day {
+	var result []Day
+	for _, p := range periods {
+		for start := p.Start; start.Before(p.End); {
+			date := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+			end := date.AddDate(0, 0, 1)
+			if p.End.Before(end) {
+				end = p.End
+			}
+			clipped := p
+			clipped.Start, clipped.End = start, end
+			if n := len(result); n == 0 || !result[n-1].Date.Equal(date) {
+				result = append(result, Day{Date: date})
+			}
+			result[len(result)-1].Periods = append(result[len(result)-1].Periods, clipped)
+			start = end
+		}
+	}
+	return result
+}
+
+// newSection selects and formats the non-empty columns of a table.
+func newSection(title string, table *export.Table, columns []string) Section {
+	s := Section{Title: title}
+	var indexes []int
+	for _, name := range columns {
+		for i, column := range table.Columns {
+			if column.Name != name {
+				continue
+			}
+			for _, row := range table.Rows {
+				if row[i] != nil {
+					indexes = append(indexes, i)
+					s.Columns = append(s.Columns, columnTitle(name))
+					break
+				}
+			}
+		}
+	}
+	for _, row := range table.Rows {
+		values := make([]string, len(indexes))
+		for j, i := range indexes {
+			values[j] = formatValue(row[i])
+		}
+		s.Rows = append(s.Rows, values)
+	}
+	return s
+}
+
+// columnTitle converts a snake case column name to a title.
+func columnTitle(name string) string {
+	title := strings.ReplaceAll(name, "_", " ")
+	title = strings.Replace(title, " kmh", " (km/h)", 1)
+	title = strings.Replace(title, " km", " (km)", 1)
+	return strings.ToUpper(title[:1]) + title[1:]
+}
+
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return formatTime(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', 5, 64)
+	case bool:
+		if v {
+			return "yes"
+		}
+		return "no"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format("2006-01-02 15:04")
+}
+
+func formatDay(t time.Time, ok bool) string {
+	if !ok {
+		return ""
+	}
+	return t.UTC().Format(time.DateOnly)
+}
+
+func formatDate(year, month, day int32) string {
+	if year == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", year, month, day)
+}
+
+// formatDuration formats a duration as hours and minutes.
+func formatDuration(d time.Duration) string {
+	minutes := int(d / time.Minute)
+	return fmt.Sprintf("%d:%02d", minutes/60, minutes%60)
+}
+
+func nonEmptyFields(fields ...Field) []Field {
+	result := fields[:0]
+	for _, f := range fields {
+		if f.Value != "" {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// enumName returns the name of an enum value, or an empty string if the value
+// is unspecified.
+func enumName(e protoreflect.Enum) string {
+	if e.Number() == 0 {
+		return ""
+	}
+	if value := e.Descriptor().Values().ByNumber(e.Number()); value != nil {
+		return string(value.Name())
+	}
+	return fmt.Sprint(e.Number())
+}
@@ -0,0 +1,197 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var testDay = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// testDriverCardFile builds a driver card of Jörg Müller, with a vehicle used
+// from 06:00 to 16:00, and a day of rest, driving from 06:00 to 10:00, work
+// until 11:00, and rest.
+func testDriverCardFile() *tachographv1.File {
+	stringValue := func(s string) *ddv1.StringValue {
+		v := &ddv1.StringValue{}
+		v.SetValue(s)
+		return v
+	}
+	driverID := &ddv1.DriverIdentification{}
+	number := &ddv1.Ia5StringValue{}
+	number.SetValue("DRIVER00000001")
+	driverID.SetDriverIdentificationNumber(number)
+	identificationCard := &cardv1.Identification_Card{}
+	identificationCard.SetDriverIdentification(driverID)
+	identificationCard.SetCardIssuingMemberState(ddv1.NationNumeric_GERMANY)
+	identificationCard.SetCardExpiryDate(timestamppb.New(testDay.AddDate(5, 0, 0)))
+	holder := &cardv1.Identification_DriverCardHolder{}
+	holder.SetCardHolderSurname(stringValue("Müller"))
+	holder.SetCardHolderFirstNames(stringValue("Jörg"))
+	identification := &cardv1.Identification{}
+	identification.SetCard(identificationCard)
+	identification.SetDriverCardHolder(holder)
+
+	registration := &ddv1.VehicleRegistrationIdentification{}
+	registration.SetNation(ddv1.NationNumeric_GERMANY)
+	registration.SetNumber(stringValue("AB123CD"))
+	vehicle := &ddv1.CardVehicleRecord{}
+	vehicle.SetVehicleRegistration(registration)
+	vehicle.SetVehicleFirstUse(timestamppb.New(testDay.Add(6 * time.Hour)))
+	vehicle.SetVehicleLastUse(timestamppb.New(testDay.Add(16 * time.Hour)))
+	vehicle.SetVehicleOdometerBeginKm(1000)
+	vehicle.SetVehicleOdometerEndKm(1350)
+	vehiclesUsed := &cardv1.VehiclesUsed{}
+	vehiclesUsed.SetRecords([]*ddv1.CardVehicleRecord{vehicle})
+
+	var changes []*ddv1.ActivityChangeInfo
+	for _, c := range []struct {
+		activity ddv1.DriverActivityValue
+		minutes  int32
+	}{
+		{activity: ddv1.DriverActivityValue_BREAK_REST, minutes: 0},
+		{activity: ddv1.DriverActivityValue_DRIVING, minutes: 6 * 60},
+		{activity: ddv1.DriverActivityValue_WORK, minutes: 10 * 60},
+		{activity: ddv1.DriverActivityValue_BREAK_REST, minutes: 11 * 60},
+	} {
+		change := &ddv1.ActivityChangeInfo{}
+		change.SetSlot(ddv1.CardSlotNumber_DRIVER_SLOT)
+		change.SetInserted(true)
+		change.SetActivity(c.activity)
+		change.SetTimeOfChangeMinutes(c.minutes)
+		changes = append(changes, change)
+	}
+	day := &cardv1.DriverActivityData_DailyRecord{}
+	day.SetValid(true)
+	day.SetActivityRecordDate(timestamppb.New(testDay))
+	day.SetActivityDayDistance(350)
+	day.SetActivityChangeInfo(changes)
+	activityData := &cardv1.DriverActivityData{}
+	activityData.SetDailyRecords([]*cardv1.DriverActivityData_DailyRecord{day})
+
+	tachograph := &cardv1.DriverCardFile_Tachograph{}
+	tachograph.SetIdentification(identification)
+	tachograph.SetVehiclesUsed(vehiclesUsed)
+	tachograph.SetDriverActivityData(activityData)
+	card := &cardv1.DriverCardFile{}
+	card.SetTachograph(tachograph)
+	file := &tachographv1.File{}
+	file.SetType(tachographv1.File_DRIVER_CARD)
+	file.SetDriverCard(card)
+	return file
+}
+
+func TestNew(t *testing.T) {
+	r, err := New(testDriverCardFile())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Title != "Driver card DRIVER00000001" {
+		t.Errorf("Title = %q", r.Title)
+	}
+	var fields []string
+	for _, f := range r.Identification {
+		fields = append(fields, f.Name+"="+f.Value)
+	}
+	if got, want := strings.Join(fields, ";"), "Card number=DRIVER00000001;Surname=Müller;First names=Jörg;Issuing member state=GERMANY;Expiry date=2029-01-01"; got != want {
+		t.Errorf("Identification = %s, want %s", got, want)
+	}
+	if len(r.Days) != 1 {
+		t.Fatalf("got %d days, want 1", len(r.Days))
+	}
+	d := r.Days[0]
+	for activity, want := range map[ddv1.DriverActivityValue]time.Duration{
+		ddv1.DriverActivityValue_DRIVING:      4 * time.Hour,
+		ddv1.DriverActivityValue_WORK:         time.Hour,
+		ddv1.DriverActivityValue_AVAILABILITY: 0,
+		ddv1.DriverActivityValue_BREAK_REST:   19 * time.Hour,
+	} {
+		if got := d.Total(activity); got != want {
+			t.Errorf("Total(%v) = %v, want %v", activity, got, want)
+		}
+	}
+	var vehicles *Section
+	for i := range r.Sections {
+		if r.Sections[i].Title == "Vehicles" {
+			vehicles = &r.Sections[i]
+		}
+	}
+	if vehicles == nil || len(vehicles.Rows) != 1 {
+		t.Fatalf("vehicles section = %+v, want a row", vehicles)
+	}
+	if got, want := strings.Join(vehicles.Columns, ","), "Card number,Registration nation,Registration number,First use,Last use,Odometer begin (km),Odometer end (km),Distance (km)"; got != want {
+		t.Errorf("vehicle columns = %s, want %s", got, want)
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	r, err := New(testDriverCardFile())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	if err := r.WriteHTML(&b); err != nil {
+		t.Fatal(err)
+	}
+	html := b.String()
+	for _, want := range []string{
+		"<title>Driver card DRIVER00000001</title>",
+		"<td>Müller</td>",
+		`<title>Driving 06:00-10:00</title>`,
+		`<title>Break/rest 11:00-24:00</title>`,
+		"4:00 / 1:00 / 0:00 / 19:00 / 0:00",
+		"<h2>Vehicles</h2>",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HTML does not contain %q", want)
+		}
+	}
+}
+
+func TestWritePDF(t *testing.T) {
+	r, err := New(testDriverCardFile())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	if err := r.WritePDF(&b); err != nil {
+		t.Fatal(err)
+	}
+	pdf := b.Bytes()
+	if !bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(pdf, []byte("%%EOF\n")) {
+		t.Fatal("missing PDF header or trailer")
+	}
+	// Each cross-reference entry must point to its object.
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+	if m == nil {
+		t.Fatal("missing startxref")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	lines := strings.Split(string(pdf[xref:]), "\n")
+	if lines[0] != "xref" {
+		t.Fatalf("startxref points to %q, want xref", lines[0])
+	}
+	var count int
+	if _, err := fmt.Sscanf(lines[1], "0 %d", &count); err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i < count; i++ {
+		offset, _ := strconv.Atoi(lines[2+i][:10])
+		if want := fmt.Sprintf("%d 0 obj\n", i); !bytes.HasPrefix(pdf[offset:], []byte(want)) {
+			t.Errorf("object %d offset %d does not point to the object", i, offset)
+		}
+	}
+	// Latin-1 characters are encoded as octal escapes.
+	if !bytes.Contains(pdf, []byte(`(M\374ller)`)) {
+		t.Error("PDF does not contain the encoded surname")
+	}
+}
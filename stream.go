@@ -0,0 +1,365 @@
+package tachograph
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/way-platform/tachograph-go/internal/card"
+	"github.com/way-platform/tachograph-go/internal/vu"
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
+)
+
+// maxStreamRecordSize is the maximum size of a record read by a [Decoder].
+const maxStreamRecordSize = 64 << 20
+
+// Record is an elementary file (EF) of a card file, or a transfer (TREP) of a
+// vehicle unit file, read by a [Decoder].
+type Record struct {
+	// Index is the index of the record in the raw card file or raw vehicle
+	// unit file.
+	Index int
+	// Offset is the byte offset of the record in the file.
+	Offset int
+	// EF is the data EF of a card file.
+	EF *cardv1.RawCardFile_Record
+	// Signature is the signature EF following the data EF of a card file, if any.
+	Signature *cardv1.RawCardFile_Record
+	// Transfer is the transfer of a vehicle unit file.
+	Transfer *vuv1.RawVehicleUnitFile_Record
+	// File is the decoded record, as a file holding only this record, and
+	// the structure version as far as it is known from the records so far.
+	// It is nil if the record failed to decode.
+	File *tachographv1.File
+}
+
+// Decoder reads and decodes a tachograph file from an input stream, one
+// elementary file (EF) or transfer (TREP) at a time.
+//
+// Unlike [UnmarshalFile], a decoder does not need the whole file in memory,
+// so records can be processed while the file is still arriving.
+//
+// Like [UnmarshalFile], a decoder unwraps a file in a container, such as a
+// zip archive or an email (see [Unwrap]). A container is read whole before
+// the first record is returned, and record offsets are relative to the
+// unwrapped file.
+type Decoder struct {
+	opts     UnmarshalOptions
+	r        *bufio.Reader
+	sc       *bufio.Scanner
+	fileType tachographv1.File_Type
+	index    int
+	offset   int
+	// pending is a card EF read ahead while looking for a signature EF.
+	pending []byte
+	// driverCard parses the EFs of a driver card file, keeping track of the
+	// card structure version.
+	driverCard *card.DriverCardParser
+	// rawCard is true if the card file is not a driver card file.
+	rawCard bool
+	// version is the version of a Gen2 vehicle unit file.
+	version ddv1.Version
+	// err is the error that stopped the decoder, if any.
+	err error
+}
+
+// NewDecoder returns a decoder that reads from r.
+//
+// See [UnmarshalOptions.NewDecoder] if you need more control over the parsing.
+func NewDecoder(r io.Reader) *Decoder {
+	return UnmarshalOptions{}.NewDecoder(r)
+}
+
+// NewDecoder returns a decoder that reads from r.
+//
+// The decoder reads only as much of r as needed to return the next record.
+func (o UnmarshalOptions) NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{opts: o, r: bufio.NewReader(r), driverCard: o.driverCardOptions().NewDriverCardParser()}
+}
+
+// Type returns the type of the file being decoded, once the first record has
+// been read. A card file other than a driver card file is reported as
+// [tachographv1.File_RAW_CARD] once its application identification EF has
+// been read.
+func (d *Decoder) Type() tachographv1.File_Type {
+	if d.fileType == tachographv1.File_DRIVER_CARD && d.rawCard {
+		return tachographv1.File_RAW_CARD
+	}
+	return d.fileType
+}
+
+// Next reads and decodes the next record of the file. It returns [io.EOF] at
+// the end of the file.
+//
+// EFs of a card file are decoded as EFs of a driver card file, until the
+// application identification EF shows that the card is of another type. The
+// EFs of other card types, and EFs not selected by
+// [UnmarshalOptions.ElementaryFiles], are not decoded, and their file is of
+// type [tachographv1.File_RAW_CARD].
+//
+// Transfers of a Gen2 vehicle unit file are decoded as version 1, until a
+// version 2 transfer has been read. Version 2 files start with a download
+// interface version transfer, so this only matters for malformed files.
+//
+// If the file can't be read up to the next record, the error is a
+// [*ParseError], and the decoder stops. If the next record fails to decode,
+// it is returned together with a [*ParseError]. The decoder then stops, unless
+// lenient parsing is enabled.
+func (d *Decoder) Next() (*Record, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	if d.sc == nil {
+		if err := d.start(); err != nil {
+			d.err = err
+			return nil, err
+		}
+	}
+	var record *Record
+	var err error
+	if d.fileType == tachographv1.File_VEHICLE_UNIT {
+		record, err = d.nextTransfer()
+	} else {
+		record, err = d.nextEF()
+	}
+	if err != nil && (record == nil || !d.opts.Lenient) {
+		d.err = err
+	}
+	if d.opts.DiscardRawData && record != nil && record.File != nil {
+		discardRawData(record.File.ProtoReflect())
+	}
+	return record, err
+}
+
+// start detects the type of the file, unwrapping it from its containers if
+// needed, and prepares the scanner for its records.
+func (d *Decoder) start() error {
+	header, err := d.r.Peek(2)
+	if len(header) < 2 {
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		return &ParseError{Err: fmt.Errorf("insufficient data for tachograph file: %w", ErrTruncated)}
+	}
+	if header[0] != 0x76 && binary.BigEndian.Uint16(header) != 0x0002 {
+		// Tachograph file data in a container, such as a zip archive, can
+		// only be unwrapped as a whole.
+		data, err := readUnwrapped(d.r)
+		if err != nil {
+			return &ParseError{Err: err}
+		}
+		unwrapped, _, err := Unwrap(data)
+		if err != nil {
+			return &ParseError{Err: err}
+		}
+		d.r = bufio.NewReader(bytes.NewReader(unwrapped))
+		return d.start()
+	}
+	d.sc = bufio.NewScanner(d.r)
+	d.sc.Buffer(nil, maxStreamRecordSize)
+	if header[0] == 0x76 {
+		d.fileType = tachographv1.File_VEHICLE_UNIT
+		d.sc.Split(vu.ScanTransfer)
+	} else {
+		d.fileType = tachographv1.File_DRIVER_CARD
+		d.sc.Split(card.ScanRecord)
+	}
+	return nil
+}
+
+// scan reads the data of the next record, or returns nil at the end of the file.
+func (d *Decoder) scan() ([]byte, error) {
+	if d.sc.Scan() {
+		return d.sc.Bytes(), nil
+	}
+	if err := d.sc.Err(); err != nil {
+		fileType := d.fileType
+		if fileType == tachographv1.File_DRIVER_CARD {
+			fileType = tachographv1.File_RAW_CARD
+		}
+		return nil, &ParseError{File: fileType, Record: d.index, Offset: d.offset, Err: err}
+	}
+	return nil, nil
+}
+
+// nextTransfer reads and decodes the next transfer of a vehicle unit file.
+func (d *Decoder) nextTransfer() (*Record, error) {
+	data, err := d.scan()
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, io.EOF
+	}
+	transfer, err := vu.UnmarshalRawTransfer(data)
+	if err != nil {
+		return nil, &ParseError{File: tachographv1.File_VEHICLE_UNIT, Record: d.index, Offset: d.offset, Err: err}
+	}
+	record := &Record{Index: d.index, Offset: d.offset, Transfer: transfer}
+	d.index++
+	d.offset += len(data)
+	vehicleUnitFile, err := d.opts.vehicleUnitOptions().UnmarshalTransfer(transfer, d.version)
+	if err != nil {
+		var transferErr *vu.TransferError
+		if errors.As(err, &transferErr) {
+			transferErr.Index, transferErr.Offset = record.Index, record.Offset
+		}
+		return record, newParseError(tachographv1.File_VEHICLE_UNIT, err)
+	}
+	if vehicleUnitFile.GetVersion() == ddv1.Version_VERSION_2 {
+		d.version = ddv1.Version_VERSION_2
+	}
+	var file tachographv1.File
+	file.SetType(tachographv1.File_VEHICLE_UNIT)
+	file.SetVehicleUnit(vehicleUnitFile)
+	recordStructureVersion(&file)
+	record.File = &file
+	return record, nil
+}
+
+// nextEF reads and decodes the next EF of a card file, together with its
+// signature EF, if any.
+func (d *Decoder) nextEF() (*Record, error) {
+	data := d.pending
+	d.pending = nil
+	if data == nil {
+		var err error
+		if data, err = d.scan(); err != nil {
+			return nil, err
+		}
+		if data == nil {
+			return nil, io.EOF
+		}
+		// The scanner reuses its buffer, so keep a copy of the data.
+		data = append([]byte(nil), data...)
+	}
+	ef, err := card.UnmarshalRawRecord(data)
+	if err != nil {
+		return nil, &ParseError{File: tachographv1.File_RAW_CARD, Record: d.index, Offset: d.offset, Err: err}
+	}
+	record := &Record{Index: d.index, Offset: d.offset, EF: ef}
+	d.index++
+	d.offset += len(data)
+	if ef.GetContentType() == cardv1.ContentType_DATA {
+		next, err := d.scan()
+		if err != nil {
+			// Report the error on the next call, after this record.
+			d.err = err
+		}
+		if next != nil {
+			nextEF, err := card.UnmarshalRawRecord(next)
+			if err == nil && nextEF.GetFile() == ef.GetFile() && nextEF.GetContentType() == cardv1.ContentType_SIGNATURE {
+				record.Signature = nextEF
+				d.index++
+				d.offset += len(next)
+			} else {
+				d.pending = append([]byte(nil), next...)
+			}
+		}
+	}
+	var file tachographv1.File
+	if d.rawCard || !d.opts.driverCardOptions().Parses(ef.GetFile()) {
+		var rawCardFile cardv1.RawCardFile
+		rawCardFile.SetRecords(record.cardRecords())
+		file.SetType(tachographv1.File_RAW_CARD)
+		file.SetRawCard(&rawCardFile)
+		recordStructureVersion(&file)
+		record.File = &file
+		return record, nil
+	}
+	driverCardFile, err := d.driverCard.ParseRecord(ef, record.Signature.GetValue())
+	if err != nil {
+		return record, newParseError(tachographv1.File_DRIVER_CARD, &card.RecordError{
+			Index:  record.Index,
+			Offset: record.Offset,
+			Record: ef,
+			Err:    err,
+		})
+	}
+	if cardType := applicationIdentificationCardType(driverCardFile); cardType != cardv1.CardType_CARD_TYPE_UNSPECIFIED && cardType != cardv1.CardType_DRIVER_CARD {
+		d.rawCard = true
+	}
+	file.SetType(tachographv1.File_DRIVER_CARD)
+	file.SetDriverCard(driverCardFile)
+	recordStructureVersion(&file)
+	record.File = &file
+	return record, nil
+}
+
+// applicationIdentificationCardType returns the card type of the application
+// identification EF of a driver card file, if any.
+func applicationIdentificationCardType(file *cardv1.DriverCardFile) cardv1.CardType {
+	if appID := file.GetTachograph().GetApplicationIdentification(); appID != nil {
+		return appID.GetCardType()
+	}
+	return file.GetTachographG2().GetApplicationIdentification().GetCardType()
+}
+
+// cardRecords returns the data EF of a card record and its signature EF, if any.
+func (r *Record) cardRecords() []*cardv1.RawCardFile_Record {
+	records := []*cardv1.RawCardFile_Record{r.EF}
+	if r.Signature != nil {
+		records = append(records, r.Signature)
+	}
+	return records
+}
+
+// Encoder writes tachograph files and records to an output stream.
+type Encoder struct {
+	opts MarshalOptions
+	w    io.Writer
+}
+
+// NewEncoder returns an encoder that writes to w.
+//
+// See [MarshalOptions.NewEncoder] if you need more control over the
+// serialization.
+func NewEncoder(w io.Writer) *Encoder {
+	return MarshalOptions{}.NewEncoder(w)
+}
+
+// NewEncoder returns an encoder that writes to w.
+func (o MarshalOptions) NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{opts: o, w: w}
+}
+
+// Encode writes a driver card, raw card or vehicle unit file in the binary
+// DDD format. See [MarshalOptions.MarshalFile].
+func (e *Encoder) Encode(file *tachographv1.File) error {
+	data, err := e.opts.MarshalFile(file)
+	if err != nil {
+		return err
+	}
+	_, err = e.w.Write(data)
+	return err
+}
+
+// EncodeRecord writes the raw data of a record read by a [Decoder], followed
+// by its signature EF, if any.
+//
+// Writing all records of a file in order reproduces the file.
+func (e *Encoder) EncodeRecord(record *Record) error {
+	var data []byte
+	switch {
+	case record.EF != nil:
+		var rawCardFile cardv1.RawCardFile
+		rawCardFile.SetRecords(record.cardRecords())
+		var err error
+		if data, err = card.MarshalRawCardFile(&rawCardFile); err != nil {
+			return err
+		}
+	case record.Transfer != nil:
+		data = binary.BigEndian.AppendUint16(data, uint16(record.Transfer.GetTag()))
+		data = append(data, record.Transfer.GetValue()...)
+	default:
+		return fmt.Errorf("empty record")
+	}
+	_, err := e.w.Write(data)
+	return err
+}
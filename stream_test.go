@@ -0,0 +1,194 @@
+package tachograph
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+)
+
+// testVehicleUnitData returns a Gen1 vehicle unit file of detailed speed
+// transfers, each with one speed block per begin date.
+func testVehicleUnitData(t testing.TB, beginDates ...uint32) []byte {
+	t.Helper()
+	var data []byte
+	for _, beginDate := range beginDates {
+		data = append(data, 0x76, 0x04)
+		data = binary.BigEndian.AppendUint16(data, 1)
+		data = binary.BigEndian.AppendUint32(data, beginDate)
+		for i := range 60 {
+			data = append(data, byte(i))
+		}
+		data = append(data, make([]byte, 128)...) // signature
+	}
+	return data
+}
+
+// decodeAll reads all records of data with a decoder, merging their files.
+func decodeAll(t *testing.T, opts UnmarshalOptions, data []byte) ([]*Record, *tachographv1.File) {
+	t.Helper()
+	dec := opts.NewDecoder(iotest.OneByteReader(bytes.NewReader(data)))
+	var records []*Record
+	var file tachographv1.File
+	for {
+		record, err := dec.Next()
+		if errors.Is(err, io.EOF) {
+			return records, &file
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		records = append(records, record)
+		proto.Merge(&file, record.File)
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		data        []byte
+		wantRecords int
+		// unwrapped is the file data in a container, if data is a container.
+		unwrapped []byte
+	}{
+		{name: "driver card", data: testDriverCardData(t), wantRecords: 7},
+		{name: "vehicle unit", data: testVehicleUnitData(t, 1709280000, 1709283600), wantRecords: 2},
+		{
+			name:        "zip archive",
+			data:        testZip(t, map[string][]byte{"driver.ddd": testDriverCardData(t)}),
+			wantRecords: 7,
+			unwrapped:   testDriverCardData(t),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := UnmarshalFile(tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			records, got := decodeAll(t, UnmarshalOptions{}, tt.data)
+			if len(records) != tt.wantRecords {
+				t.Errorf("Next() returned %d records, want %d", len(records), tt.wantRecords)
+			}
+			if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("merged records mismatch (-UnmarshalFile +Decoder):\n%s", diff)
+			}
+			var buf bytes.Buffer
+			enc := NewEncoder(&buf)
+			offset := 0
+			for i, record := range records {
+				if record.Offset != offset {
+					t.Errorf("records[%d].Offset = %d, want %d", i, record.Offset, offset)
+				}
+				if err := enc.EncodeRecord(record); err != nil {
+					t.Fatal(err)
+				}
+				offset = buf.Len()
+			}
+			wantData := tt.data
+			if tt.unwrapped != nil {
+				wantData = tt.unwrapped
+			}
+			if !bytes.Equal(buf.Bytes(), wantData) {
+				t.Error("encoded records differ from the decoded data")
+			}
+		})
+	}
+}
+
+func TestEncoder(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		data []byte
+	}{
+		{name: "driver card", data: testDriverCardData(t)},
+		{name: "vehicle unit gen1", data: testSyntheticVehicleUnitFile(t, "synthetic_gen1")},
+		{name: "vehicle unit gen2 v1", data: testSyntheticVehicleUnitFile(t, "synthetic_gen2_v1")},
+		{name: "vehicle unit gen2 v2", data: testSyntheticVehicleUnitFile(t, "synthetic_gen2_v2")},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := UnmarshalFile(tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var buf bytes.Buffer
+			if err := NewEncoder(&buf).Encode(file); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			encoded, err := UnmarshalFile(buf.Bytes())
+			if err != nil {
+				t.Fatalf("parse encoded file: %v", err)
+			}
+			if diff := cmp.Diff(file, encoded, protocmp.Transform()); diff != "" {
+				t.Errorf("encoded file mismatch (-decoded +encoded):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecoder_errors(t *testing.T) {
+	t.Run("truncated", func(t *testing.T) {
+		data := testVehicleUnitData(t, 1709280000, 1709283600)
+		dec := NewDecoder(bytes.NewReader(data[:len(data)-1]))
+		if _, err := dec.Next(); err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		_, err := dec.Next()
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) || !errors.Is(err, ErrTruncated) {
+			t.Fatalf("Next() error = %v, want truncated *ParseError", err)
+		}
+		if want := len(data) / 2; parseErr.Record != 1 || parseErr.Offset != want {
+			t.Errorf("ParseError = record %d at offset %d, want record 1 at offset %d", parseErr.Record, parseErr.Offset, want)
+		}
+		if _, err2 := dec.Next(); err2 != err {
+			t.Errorf("Next() after error = %v, want %v", err2, err)
+		}
+	})
+
+	t.Run("lenient", func(t *testing.T) {
+		data := testDriverCardData(t)
+		malformedOffset := len(data)
+		data = append(data, 0x05, 0x05, 0x00, 0x00, 0x01, 0xFF) // EF_VEHICLES_USED with a truncated value
+		data = append(data, testDriverCardData(t)[:5+25]...)    // EF_ICC
+
+		dec := NewDecoder(bytes.NewReader(data))
+		for range 7 {
+			if _, err := dec.Next(); err != nil {
+				t.Fatalf("Next() error = %v", err)
+			}
+		}
+		if _, err := dec.Next(); err == nil {
+			t.Fatal("Next() on malformed EF: expected error")
+		}
+		if _, err := dec.Next(); err == nil || errors.Is(err, io.EOF) {
+			t.Fatalf("Next() after error = %v, want the error", err)
+		}
+
+		dec = UnmarshalOptions{Lenient: true}.NewDecoder(bytes.NewReader(data))
+		for range 7 {
+			if _, err := dec.Next(); err != nil {
+				t.Fatalf("Next() error = %v", err)
+			}
+		}
+		record, err := dec.Next()
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) || record == nil || record.File != nil {
+			t.Fatalf("Next() = %v, %v, want record with *ParseError", record, err)
+		}
+		if parseErr.Offset < malformedOffset {
+			t.Errorf("ParseError.Offset = %d, want at least %d", parseErr.Offset, malformedOffset)
+		}
+		record, err = dec.Next()
+		if err != nil || record.File.GetDriverCard().GetIcc() == nil {
+			t.Fatalf("Next() after malformed EF = %v, %v, want EF_ICC", record, err)
+		}
+	})
+}
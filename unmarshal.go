@@ -2,29 +2,118 @@ package tachograph
 
 import (
 	"encoding/binary"
-	"errors"
 	"fmt"
-	"io"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
 
 	"github.com/way-platform/tachograph-go/internal/card"
 	"github.com/way-platform/tachograph-go/internal/vu"
 	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	securityv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/security/v1"
 	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
 )
 
 // UnmarshalFile parses a .DDD file's byte data into a protobuf File message.
+//
+// See [UnmarshalOptions] if you need more control over the parsing.
 func UnmarshalFile(data []byte) (*tachographv1.File, error) {
+	return UnmarshalOptions{}.UnmarshalFile(data)
+}
+
+// UnmarshalOptions configures the parsing of tachograph files.
+type UnmarshalOptions struct {
+	// Lenient continues parsing past elementary files (EFs) and transfers
+	// (TREPs) that fail to parse, instead of failing the whole file.
+	//
+	// The skipped EFs and transfers are reported as [Diagnostics], together
+	// with the partially parsed file. Their data is kept like that of the EFs
+	// and transfers that are not selected for decoding: skipped EFs in the raw
+	// card of the file, and skipped transfers with only their raw data, so
+	// that [MarshalFile] still returns it.
+	Lenient bool
+
+	// ElementaryFiles restricts the decoding of driver card files to these
+	// elementary files (EFs). If empty, all EFs are decoded.
+	//
+	// The EFs that are not decoded are kept, with their signatures, as the raw
+	// card of the file, which remains a [tachographv1.File_DRIVER_CARD] file.
+	// The application identification EFs are always decoded, since they
+	// determine the layout of other EFs.
+	ElementaryFiles []cardv1.ElementaryFileType
+
+	// Transfers restricts the decoding of vehicle unit files to these
+	// transfers (TREPs). If empty, all transfers are decoded.
+	//
+	// The transfers that are not decoded hold only their raw data.
+	Transfers []vuv1.TransferType
+
+	// Version overrides the version of the data structures, which is
+	// otherwise read from the card structure version of driver cards, or
+	// detected from the transfers of Gen2 vehicle units.
+	//
+	// This parses files that declare an unsupported or wrong version. The
+	// generation of each EF and transfer is given by its tag, so it can't be
+	// overridden.
+	Version ddv1.Version
+
+	// DiscardRawData drops the raw data of the parsed messages, which holds a
+	// copy of the file data, to reduce the memory used by parsed files.
+	//
+	// Driver card files without raw data are marshaled from their parsed
+	// values. Vehicle unit transfers and certificates keep their raw data,
+	// since they are marshaled and verified from it, and so do the EFs that
+	// are not decoded. The raw data of the messages within a transfer is
+	// dropped.
+	DiscardRawData bool
+}
+
+// UnmarshalFile parses a .DDD file's byte data into a protobuf File message.
+//
+// If the file fails to parse, the error is a [*ParseError] that locates the
+// failure in the file.
+//
+// Data in a container, such as a zip archive, base64 text or an email, is
+// unwrapped first, see [Unwrap]. Offsets in errors are relative to the
+// unwrapped data.
+//
+// In lenient mode, if any part of the file fails to parse, the partially
+// parsed file is returned together with a [Diagnostics] error describing each
+// skipped part. The file is nil only if no part of the file could be parsed.
+func (o UnmarshalOptions) UnmarshalFile(data []byte) (*tachographv1.File, error) {
+	file, err := o.unmarshalFile(data)
+	if file != nil {
+		recordStructureVersion(file)
+	}
+	if o.DiscardRawData && file != nil {
+		discardRawData(file.ProtoReflect())
+	}
+	return file, err
+}
+
+// unmarshalFile parses a .DDD file's byte data, keeping all raw data.
+func (o UnmarshalOptions) unmarshalFile(data []byte) (*tachographv1.File, error) {
 	if len(data) < 2 {
-		return nil, fmt.Errorf("insufficient data for tachograph file: %w", io.ErrUnexpectedEOF)
+		return nil, &ParseError{Err: fmt.Errorf("insufficient data for tachograph file: %w", ErrTruncated)}
 	}
 	var output tachographv1.File
 	switch {
 
 	// Vehicle unit file (starts with TREP prefix).
 	case data[0] == 0x76:
-		vehicleUnitFile, err := vu.UnmarshalVehicleUnitFile(data)
+		if o.Lenient {
+			vehicleUnitFile, errs, err := o.vehicleUnitOptions().ScanVehicleUnitFile(data)
+			if err != nil {
+				return nil, newParseError(tachographv1.File_VEHICLE_UNIT, err)
+			}
+			output.SetType(tachographv1.File_VEHICLE_UNIT)
+			output.SetVehicleUnit(vehicleUnitFile)
+			return &output, transferDiagnostics(data, errs).err()
+		}
+		vehicleUnitFile, err := o.vehicleUnitOptions().UnmarshalVehicleUnitFile(data)
 		if err != nil {
-			return nil, err
+			return nil, newParseError(tachographv1.File_VEHICLE_UNIT, err)
 		}
 		output.SetType(tachographv1.File_VEHICLE_UNIT)
 		output.SetVehicleUnit(vehicleUnitFile)
@@ -32,9 +121,17 @@ func UnmarshalFile(data []byte) (*tachographv1.File, error) {
 
 	// Card file (starts with EF_ICC prefix).
 	case binary.BigEndian.Uint16(data[0:2]) == 0x0002:
-		rawCardFile, err := card.UnmarshalRawCardFile(data)
+		var diagnostics Diagnostics
+		rawCardFile, err := card.ScanRawCardFile(data)
 		if err != nil {
-			return nil, err
+			offset := 0
+			for _, record := range rawCardFile.GetRecords() {
+				offset += 5 + len(record.GetValue())
+			}
+			if !o.Lenient || len(rawCardFile.GetRecords()) == 0 {
+				return nil, &ParseError{File: tachographv1.File_RAW_CARD, Record: len(rawCardFile.GetRecords()), Offset: offset, Err: err}
+			}
+			diagnostics = append(diagnostics, Diagnostic{Offset: offset, Data: data[offset:], Err: err})
 		}
 
 		// Infer the card type
@@ -43,21 +140,105 @@ func UnmarshalFile(data []byte) (*tachographv1.File, error) {
 		// Parse structured card data based on type
 		switch cardType {
 		case cardv1.CardType_DRIVER_CARD:
-			driverCard, err := card.UnmarshalDriverCardFile(rawCardFile)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse driver card: %w", err)
+			var driverCard *cardv1.DriverCardFile
+			var undecoded *cardv1.RawCardFile
+			if o.Lenient {
+				var errs []*card.RecordError
+				driverCard, undecoded, errs = o.driverCardOptions().ScanDriverCardFile(rawCardFile)
+				diagnostics = append(recordDiagnostics(errs), diagnostics...)
+			} else {
+				driverCard, undecoded, err = o.driverCardOptions().UnmarshalDriverCardFile(rawCardFile)
+				if err != nil {
+					return nil, newParseError(tachographv1.File_DRIVER_CARD, err)
+				}
 			}
 			output.SetType(tachographv1.File_DRIVER_CARD)
 			output.SetDriverCard(driverCard)
-			return &output, nil
+			if len(undecoded.GetRecords()) > 0 {
+				output.SetRawCard(undecoded)
+			}
+			return &output, diagnostics.err()
 		default:
 			// For unsupported card types, return raw card data
 			output.SetType(tachographv1.File_RAW_CARD)
 			output.SetRawCard(rawCardFile)
-			return &output, nil
+			return &output, diagnostics.err()
 		}
 
+	// Tachograph file data in a container, such as a zip archive.
 	default:
-		return nil, errors.New("unknown or unsupported file type")
+		unwrapped, _, err := Unwrap(data)
+		if err != nil {
+			return nil, &ParseError{Err: err}
+		}
+		return o.unmarshalFile(unwrapped)
+	}
+}
+
+// driverCardOptions returns the options for decoding driver card files.
+func (o UnmarshalOptions) driverCardOptions() card.DriverCardOptions {
+	return card.DriverCardOptions{ElementaryFiles: o.ElementaryFiles, Version: o.Version}
+}
+
+// vehicleUnitOptions returns the options for decoding vehicle unit files.
+func (o UnmarshalOptions) vehicleUnitOptions() vu.VehicleUnitOptions {
+	return vu.VehicleUnitOptions{Transfers: o.Transfers, Version: o.Version}
+}
+
+// discardRawData clears the raw data of the decoded messages in m. Messages
+// that hold only raw data, such as the transfers that are not decoded, keep
+// their raw data, and so do the messages that are only marshaled from their
+// raw data: vehicle unit transfers and certificates.
+func discardRawData(m protoreflect.Message) {
+	discardRawDataOf(m, false)
+}
+
+// discardRawDataOf clears the raw data of the decoded messages in m, except
+// the raw data of m itself if keep is set.
+func discardRawDataOf(m protoreflect.Message, keep bool) {
+	rawData := m.Descriptor().Fields().ByName("raw_data")
+	keepsTransfers := keepsRawDataOfFields(m.Descriptor())
+	decoded := false
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd == rawData {
+			return true
+		}
+		decoded = true
+		switch {
+		case fd.Message() == nil:
+		case fd.IsList():
+			for i := 0; i < v.List().Len(); i++ {
+				discardRawDataOf(v.List().Get(i).Message(), keepsTransfers || isCertificate(fd.Message()))
+			}
+		default:
+			discardRawDataOf(v.Message(), keepsTransfers || isCertificate(fd.Message()))
+		}
+		return true
+	})
+	if decoded && !keep && rawData != nil {
+		m.Clear(rawData)
+	}
+}
+
+// keepsRawDataOfFields reports whether the fields of messages of type md are
+// vehicle unit transfers, which are marshaled from their raw data.
+func keepsRawDataOfFields(md protoreflect.MessageDescriptor) bool {
+	switch md.FullName() {
+	case (*vuv1.VehicleUnitFileGen1)(nil).ProtoReflect().Descriptor().FullName(),
+		(*vuv1.VehicleUnitFileGen2V1)(nil).ProtoReflect().Descriptor().FullName(),
+		(*vuv1.VehicleUnitFileGen2V2)(nil).ProtoReflect().Descriptor().FullName():
+		return true
+	}
+	return false
+}
+
+// isCertificate reports whether messages of type md are certificates, which
+// are marshaled and verified from their raw data.
+func isCertificate(md protoreflect.MessageDescriptor) bool {
+	switch md.FullName() {
+	case (*securityv1.RsaCertificate)(nil).ProtoReflect().Descriptor().FullName(),
+		(*securityv1.EccCertificate)(nil).ProtoReflect().Descriptor().FullName():
+		return true
 	}
+	return false
 }
@@ -3,6 +3,8 @@ package tachograph
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -11,6 +13,13 @@ import (
 	"buf.build/go/protovalidate"
 	"github.com/google/go-cmp/cmp"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
 )
 
 func TestUnmarshalFile_golden(t *testing.T) {
@@ -84,3 +93,304 @@ func TestUnmarshalFile_golden(t *testing.T) {
 		t.Fatalf("Failed to walk testdata directory: %v", err)
 	}
 }
+
+func TestUnmarshalOptions_lenient(t *testing.T) {
+	data := testDriverCardData(t)
+	malformedOffset := len(data)
+	data = append(data, 0x05, 0x05, 0x00, 0x00, 0x01, 0xFF) // EF_VEHICLES_USED with a truncated value
+	trailingOffset := len(data)
+	data = append(data, 0x05, 0x06) // truncated TLV header
+
+	if _, err := UnmarshalFile(data); err == nil {
+		t.Fatal("UnmarshalFile() on malformed data: expected error")
+	}
+	file, err := UnmarshalOptions{Lenient: true}.UnmarshalFile(data)
+	var diagnostics Diagnostics
+	if !errors.As(err, &diagnostics) {
+		t.Fatalf("UnmarshalFile() error = %v, want Diagnostics", err)
+	}
+	if got := len(file.GetDriverCard().GetTachograph().GetVehiclesUsed().GetRecords()); got == 0 {
+		t.Error("vehicles used were not parsed")
+	}
+	if len(diagnostics) != 2 {
+		t.Fatalf("diagnostics = %v, want 2", diagnostics)
+	}
+	if d := diagnostics[0]; d.Name != "EF_VEHICLES_USED" || d.Offset != malformedOffset || !bytes.Equal(d.Data, []byte{0xFF}) {
+		t.Errorf("diagnostics[0] = %+v, want EF_VEHICLES_USED at offset %d", d, malformedOffset)
+	}
+	if d := diagnostics[1]; d.Name != "" || d.Offset != trailingOffset || !bytes.Equal(d.Data, []byte{0x05, 0x06}) {
+		t.Errorf("diagnostics[1] = %+v, want trailing data at offset %d", d, trailingOffset)
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("errors.Is(%v, io.ErrUnexpectedEOF) = false", err)
+	}
+
+	// The skipped EF is kept in the raw card, and marshaled again.
+	records := file.GetRawCard().GetRecords()
+	if len(records) != 1 || records[0].GetFile() != cardv1.ElementaryFileType_EF_VEHICLES_USED {
+		t.Fatalf("raw card records = %v, want the skipped EF_VEHICLES_USED", records)
+	}
+	marshaled, err := MarshalFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(marshaled, data[malformedOffset:trailingOffset]) {
+		t.Error("marshaled file does not contain the skipped EF")
+	}
+}
+
+func TestUnmarshalOptions_selective(t *testing.T) {
+	data := testDriverCardData(t)
+	want, err := UnmarshalFile(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want.HasRawCard() {
+		t.Error("fully decoded driver card file has a raw card")
+	}
+	opts := UnmarshalOptions{ElementaryFiles: []cardv1.ElementaryFileType{cardv1.ElementaryFileType_EF_IDENTIFICATION}}
+	file, err := opts.UnmarshalFile(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.GetType() != tachographv1.File_DRIVER_CARD {
+		t.Errorf("type = %v, want %v", file.GetType(), tachographv1.File_DRIVER_CARD)
+	}
+	tachograph := file.GetDriverCard().GetTachograph()
+	if !tachograph.HasIdentification() || !tachograph.HasApplicationIdentification() {
+		t.Error("identification and application identification were not decoded")
+	}
+	if tachograph.HasVehiclesUsed() || tachograph.HasPlaces() {
+		t.Error("unselected EFs were decoded")
+	}
+	var undecoded []cardv1.ElementaryFileType
+	for _, record := range file.GetRawCard().GetRecords() {
+		undecoded = append(undecoded, record.GetFile())
+	}
+	wantUndecoded := []cardv1.ElementaryFileType{
+		cardv1.ElementaryFileType_EF_ICC,
+		cardv1.ElementaryFileType_EF_IC,
+		cardv1.ElementaryFileType_EF_DRIVING_LICENCE_INFO,
+		cardv1.ElementaryFileType_EF_VEHICLES_USED,
+		cardv1.ElementaryFileType_EF_PLACES,
+	}
+	if diff := cmp.Diff(wantUndecoded, undecoded); diff != "" {
+		t.Errorf("undecoded EFs mismatch (-want +got):\n%s", diff)
+	}
+
+	marshaled, err := MarshalFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := UnmarshalFile(marshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("marshaled selective file mismatch (-full +selective):\n%s", diff)
+	}
+}
+
+func BenchmarkUnmarshalFile(b *testing.B) {
+	beginDates := make([]uint32, 1000)
+	for i := range beginDates {
+		beginDates[i] = 1709280000 + uint32(i)*60
+	}
+	for _, bb := range []struct {
+		name string
+		data []byte
+		opts UnmarshalOptions
+	}{
+		{
+			name: "driver card/full",
+			data: testDriverCardData(b),
+		},
+		{
+			name: "driver card/identification",
+			data: testDriverCardData(b),
+			opts: UnmarshalOptions{ElementaryFiles: []cardv1.ElementaryFileType{cardv1.ElementaryFileType_EF_IDENTIFICATION}},
+		},
+		{
+			name: "vehicle unit/full",
+			data: testVehicleUnitData(b, beginDates...),
+		},
+		{
+			name: "vehicle unit/overview",
+			data: testVehicleUnitData(b, beginDates...),
+			opts: UnmarshalOptions{Transfers: []vuv1.TransferType{vuv1.TransferType_OVERVIEW_GEN1}},
+		},
+	} {
+		b.Run(bb.name, func(b *testing.B) {
+			b.SetBytes(int64(len(bb.data)))
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := bb.opts.UnmarshalFile(bb.data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestUnmarshalOptions_version(t *testing.T) {
+	data := testDriverCardDataWithStructureVersion(t, [2]byte{0x02, 0x00})
+	if _, err := UnmarshalFile(data); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Fatalf("UnmarshalFile() error = %v, want %v", err, ErrUnsupportedVersion)
+	}
+	file, err := UnmarshalOptions{Version: ddv1.Version_VERSION_1}.UnmarshalFile(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := UnmarshalFile(testDriverCardData(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want.GetDriverCard().GetTachograph().GetPlaces(), file.GetDriverCard().GetTachograph().GetPlaces(), protocmp.Transform()); diff != "" {
+		t.Errorf("places mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestUnmarshalOptions_discardRawData(t *testing.T) {
+	data := testDriverCardData(t)
+	want, err := UnmarshalFile(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if countRawData(want.ProtoReflect()) == 0 {
+		t.Fatal("parsed file holds no raw data")
+	}
+	file, err := UnmarshalOptions{DiscardRawData: true}.UnmarshalFile(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := countRawData(file.ProtoReflect()); got != 0 {
+		t.Errorf("raw data fields = %d, want 0", got)
+	}
+	marshaled, err := MarshalFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := UnmarshalOptions{DiscardRawData: true}.UnmarshalFile(marshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(file, got, protocmp.Transform()); diff != "" {
+		t.Errorf("marshaled file without raw data mismatch (-want +got):\n%s", diff)
+	}
+
+	// Transfers that are not decoded hold only their raw data, which is kept.
+	vehicleUnit, err := UnmarshalOptions{
+		Transfers:      []vuv1.TransferType{vuv1.TransferType_OVERVIEW_GEN1},
+		DiscardRawData: true,
+	}.UnmarshalFile(testVehicleUnitData(t, 1709280000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detailedSpeed := vehicleUnit.GetVehicleUnit().GetGen1().GetDetailedSpeed(); len(detailedSpeed) != 1 || len(detailedSpeed[0].GetRawData()) == 0 {
+		t.Error("raw data of undecoded transfer was discarded")
+	}
+}
+
+func TestMarshalOptions_ignoreRawData(t *testing.T) {
+	file, err := UnmarshalFile(testDriverCardData(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The raw data of strings takes precedence over their values.
+	approvalNumber := file.GetDriverCard().GetIcc().GetCardApprovalNumber()
+	want := approvalNumber.GetValue()
+	approvalNumber.SetValue("EDITED01")
+	for _, tt := range []struct {
+		opts MarshalOptions
+		want string
+	}{
+		{opts: MarshalOptions{}, want: want},
+		{opts: MarshalOptions{IgnoreRawData: true}, want: "EDITED01"},
+	} {
+		data, err := tt.opts.MarshalFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := UnmarshalFile(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if approvalNumber := got.GetDriverCard().GetIcc().GetCardApprovalNumber().GetValue(); approvalNumber != tt.want {
+			t.Errorf("%+v: card approval number = %q, want %q", tt.opts, approvalNumber, tt.want)
+		}
+	}
+}
+
+func TestMarshalOptions_ignoreRawData_vehicleUnit(t *testing.T) {
+	for _, name := range []string{"synthetic_gen1", "synthetic_gen2_v1", "synthetic_gen2_v2"} {
+		t.Run(name, func(t *testing.T) {
+			data := testSyntheticVehicleUnitFile(t, name)
+			file, err := UnmarshalFile(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			discarded, err := UnmarshalOptions{DiscardRawData: true}.UnmarshalFile(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if countRawData(discarded.ProtoReflect()) >= countRawData(file.ProtoReflect()) {
+				t.Error("raw data within transfers was not discarded")
+			}
+			// Transfers are written from their raw data, which is kept.
+			for _, tt := range []struct {
+				name string
+				opts MarshalOptions
+				file *tachographv1.File
+			}{
+				{name: "IgnoreRawData", opts: MarshalOptions{IgnoreRawData: true}, file: file},
+				{name: "DiscardRawData", file: discarded},
+			} {
+				got, err := tt.opts.MarshalFile(tt.file)
+				if err != nil {
+					t.Fatalf("%s: MarshalFile() error = %v", tt.name, err)
+				}
+				if !bytes.Equal(got, data) {
+					t.Errorf("%s: marshaled file differs from the parsed data", tt.name)
+				}
+			}
+		})
+	}
+}
+
+func TestMarshalOptions_ignoreRawData_certificates(t *testing.T) {
+	certificate := bytes.Repeat([]byte{0xC1}, 194)
+	data := testDriverCardData(t)
+	data = append(data, 0xC1, 0x00, 0x00, 0x00, 194)
+	data = append(data, certificate...)
+	file, err := UnmarshalOptions{DiscardRawData: true}.UnmarshalFile(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	marshaled, err := MarshalOptions{IgnoreRawData: true}.MarshalFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(marshaled, certificate) {
+		t.Error("certificate was not marshaled")
+	}
+}
+
+// countRawData counts the raw data fields set in m.
+func countRawData(m protoreflect.Message) int {
+	var n int
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.Name() == "raw_data":
+			n++
+		case fd.Message() == nil:
+		case fd.IsList():
+			for i := 0; i < v.List().Len(); i++ {
+				n += countRawData(v.List().Get(i).Message())
+			}
+		default:
+			n += countRawData(v.Message())
+		}
+		return true
+	})
+	return n
+}
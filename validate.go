@@ -0,0 +1,424 @@
+package tachograph
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
+)
+
+// FindingType is the type of a [Finding].
+type FindingType int
+
+const (
+	// FindingInvalidNation is a NationNumeric code that is not in the data
+	// dictionary.
+	FindingInvalidNation FindingType = iota + 1
+	// FindingInvalidBCD is a BCD-encoded value with a digit greater than 9.
+	FindingInvalidBCD
+	// FindingInvalidDate is a date that does not exist, or a card validity
+	// period that ends before it begins.
+	FindingInvalidDate
+	// FindingOutsideCardValidity is a record dated outside the validity
+	// period of the card it is stored on.
+	FindingOutsideCardValidity
+	// FindingPresenceCounterGap is a daily activity record whose presence
+	// counter does not follow the counter of the previous record.
+	FindingPresenceCounterGap
+	// FindingDailyRecordOrder is a daily activity record that is not dated
+	// after the previous record.
+	FindingDailyRecordOrder
+	// FindingInvalidPointer is a cyclic buffer pointer that is out of range,
+	// or that does not point to the newest record.
+	FindingInvalidPointer
+	// FindingOdometerDecrease is an odometer value that is lower than an
+	// earlier value recorded for the same vehicle.
+	FindingOdometerDecrease
+	// FindingInvalidEntryType is a place record with an entry type that is not
+	// in the data dictionary, or not defined for its generation.
+	FindingInvalidEntryType
+	// FindingMalformedRecord is a record of a cyclic buffer that could not be
+	// parsed, and was kept as raw data.
+	FindingMalformedRecord
+)
+
+// String returns a human-readable name for the finding type.
+func (t FindingType) String() string {
+	switch t {
+	case FindingInvalidNation:
+		return "invalid nation"
+	case FindingInvalidBCD:
+		return "invalid BCD"
+	case FindingInvalidDate:
+		return "invalid date"
+	case FindingOutsideCardValidity:
+		return "outside card validity"
+	case FindingPresenceCounterGap:
+		return "presence counter gap"
+	case FindingDailyRecordOrder:
+		return "daily record order"
+	case FindingInvalidPointer:
+		return "invalid pointer"
+	case FindingOdometerDecrease:
+		return "odometer decrease"
+	case FindingInvalidEntryType:
+		return "invalid entry type"
+	case FindingMalformedRecord:
+		return "malformed record"
+	default:
+		return "unknown"
+	}
+}
+
+// Finding is a value of a parsed file that breaks a rule of the data
+// dictionary, or an invariant between records, suggesting a suspicious or
+// corrupted download.
+type Finding struct {
+	// Type is the type of the finding.
+	Type FindingType
+	// Path is the path of the value in the file, in protobuf field names
+	// (e.g. "driver_card.tachograph.places.records[3]").
+	Path string
+	// Time is the time of the record, if known.
+	Time time.Time
+	// Message describes the finding.
+	Message string
+}
+
+// String returns the finding as "path: type: message".
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s: %s", f.Path, f.Type, f.Message)
+}
+
+// Validate checks the semantic rules of the data dictionary and the
+// invariants between the records of a parsed file, and returns the findings
+// in the order of the file.
+//
+// The following checks are made:
+//   - NationNumeric codes of card issuers, driving licences, vehicle
+//     registrations and places are in the data dictionary.
+//   - BCD digits of daily activity records that could not be parsed.
+//   - Dates exist, and card records are dated within the card validity.
+//   - Daily activity records are dated in order, and their presence counters
+//     increase by one from record to record.
+//   - Cyclic buffer pointers are in range and point to the newest record.
+//   - Odometer values do not decrease within a vehicle use on a card, or from
+//     day to day on a vehicle unit.
+//   - Place entry types are in the data dictionary and defined for their
+//     generation.
+//
+// Parsing already rejects values that don't fit the byte layout, so
+// validation is meant for files that parsed successfully. Raw card files are
+// not validated.
+func Validate(file *tachographv1.File) []Finding {
+	var v validator
+	switch file.GetType() {
+	case tachographv1.File_DRIVER_CARD:
+		v.validateDriverCard(file.GetDriverCard())
+	case tachographv1.File_VEHICLE_UNIT:
+		v.validateVehicleUnit(file.GetVehicleUnit())
+	}
+	return v.findings
+}
+
+// validator collects the findings of [Validate].
+type validator struct {
+	findings []Finding
+}
+
+func (v *validator) add(t FindingType, path string, at time.Time, format string, args ...any) {
+	v.findings = append(v.findings, Finding{
+		Type:    t,
+		Path:    path,
+		Time:    at,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// checkNation reports a NationNumeric code that is not in the data dictionary.
+func (v *validator) checkNation(path string, at time.Time, nation ddv1.NationNumeric) {
+	if nation == ddv1.NationNumeric_NATION_NUMERIC_UNRECOGNIZED {
+		v.add(FindingInvalidNation, path, at, "nation code is not in the data dictionary")
+	}
+}
+
+// cardValidity is the validity period of a card, by whole days.
+type cardValidity struct {
+	begin, end time.Time
+}
+
+// newCardValidity returns the validity period of a card from its
+// identification, from the start of the validity begin day to the end of the
+// expiry day.
+func newCardValidity(card *cardv1.Identification_Card) cardValidity {
+	var result cardValidity
+	if begin := timeOf(card.GetCardValidityBegin()); !begin.IsZero() {
+		result.begin = begin.Truncate(24 * time.Hour)
+	}
+	if expiry := timeOf(card.GetCardExpiryDate()); !expiry.IsZero() {
+		result.end = expiry.Truncate(24 * time.Hour).Add(24 * time.Hour)
+	}
+	return result
+}
+
+// check reports a card record dated outside the validity period.
+func (c cardValidity) check(v *validator, path string, at time.Time) {
+	if at.IsZero() {
+		return
+	}
+	if (!c.begin.IsZero() && at.Before(c.begin)) || (!c.end.IsZero() && !at.Before(c.end)) {
+		v.add(FindingOutsideCardValidity, path, at,
+			"record dated %s is outside the card validity from %s to %s",
+			at.Format(time.RFC3339), c.begin.Format(time.DateOnly), c.end.Add(-24*time.Hour).Format(time.DateOnly))
+	}
+}
+
+func (v *validator) validateDriverCard(card *cardv1.DriverCardFile) {
+	validity := newCardValidity(card.GetTachographG2().GetIdentification().GetCard())
+	if card.GetTachographG2().GetIdentification() == nil {
+		validity = newCardValidity(card.GetTachograph().GetIdentification().GetCard())
+	}
+	if g1 := card.GetTachograph(); g1 != nil {
+		const path = "driver_card.tachograph"
+		v.validateIdentification(path+".identification", g1.GetIdentification())
+		v.validateDrivingLicence(path+".driving_licence_info", g1.GetDrivingLicenceInfo())
+		v.validateDriverActivity(path+".driver_activity_data", g1.GetDriverActivityData(), validity)
+		validateVehiclesUsed(v, path+".vehicles_used", g1.GetVehiclesUsed().GetNewestRecordIndex(), g1.GetVehiclesUsed().GetRecords(), validity)
+		validatePlaces(v, path+".places", ddv1.Generation_GENERATION_1, g1.GetPlaces().GetNewestRecordIndex(), g1.GetPlaces().GetRecords(), validity)
+	}
+	if g2 := card.GetTachographG2(); g2 != nil {
+		const path = "driver_card.tachograph_g2"
+		v.validateIdentification(path+".identification", g2.GetIdentification())
+		v.validateDrivingLicence(path+".driving_licence_info", g2.GetDrivingLicenceInfo())
+		v.validateDriverActivity(path+".driver_activity_data", g2.GetDriverActivityData(), validity)
+		validateVehiclesUsed(v, path+".vehicles_used", g2.GetVehiclesUsed().GetNewestRecordIndex(), g2.GetVehiclesUsed().GetRecords(), validity)
+		validatePlaces(v, path+".places", ddv1.Generation_GENERATION_2, g2.GetPlaces().GetNewestRecordIndex(), g2.GetPlaces().GetRecords(), validity)
+	}
+}
+
+func (v *validator) validateIdentification(path string, identification *cardv1.Identification) {
+	if identification == nil {
+		return
+	}
+	card := identification.GetCard()
+	v.checkNation(path+".card.card_issuing_member_state", time.Time{}, card.GetCardIssuingMemberState())
+	begin, expiry := timeOf(card.GetCardValidityBegin()), timeOf(card.GetCardExpiryDate())
+	if !begin.IsZero() && !expiry.IsZero() && expiry.Before(begin) {
+		v.add(FindingInvalidDate, path+".card.card_expiry_date", expiry,
+			"card expires on %s, before its validity begins on %s", expiry.Format(time.DateOnly), begin.Format(time.DateOnly))
+	}
+	if birthDate := identification.GetDriverCardHolder().GetCardHolderBirthDate(); birthDate != nil {
+		v.checkDate(path+".driver_card_holder.card_holder_birth_date", birthDate)
+	}
+}
+
+// checkDate reports a date that does not exist. A date of all zeros means
+// that the date is not known.
+func (v *validator) checkDate(path string, date *ddv1.Date) {
+	year, month, day := int(date.GetYear()), int(date.GetMonth()), int(date.GetDay())
+	if year == 0 && month == 0 && day == 0 {
+		return
+	}
+	if month < 1 || month > 12 || day < 1 || day > 31 ||
+		time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC).Day() != day {
+		v.add(FindingInvalidDate, path, time.Time{}, "date %04d-%02d-%02d does not exist", year, month, day)
+	}
+}
+
+func (v *validator) validateDrivingLicence(path string, licence *cardv1.DrivingLicenceInfo) {
+	if licence == nil {
+		return
+	}
+	v.checkNation(path+".driving_licence_issuing_nation", time.Time{}, licence.GetDrivingLicenceIssuingNation())
+}
+
+// maxDailyPresenceCounter is the modulus of the 4-digit BCD daily presence counter.
+const maxDailyPresenceCounter = 10000
+
+func (v *validator) validateDriverActivity(path string, activity *cardv1.DriverActivityData, validity cardValidity) {
+	if activity == nil {
+		return
+	}
+	if size := int32(len(activity.GetRawData())); size > 0 {
+		if oldest := activity.GetOldestDayRecordIndex(); oldest >= size {
+			v.add(FindingInvalidPointer, path+".oldest_day_record_index", time.Time{},
+				"pointer %d is outside the cyclic buffer of %d bytes", oldest, size)
+		}
+		if newest := activity.GetNewestDayRecordIndex(); newest >= size {
+			v.add(FindingInvalidPointer, path+".newest_day_record_index", time.Time{},
+				"pointer %d is outside the cyclic buffer of %d bytes", newest, size)
+		}
+	}
+	var previous *cardv1.DriverActivityData_DailyRecord
+	for i, record := range activity.GetDailyRecords() {
+		recordPath := fmt.Sprintf("%s.daily_records[%d]", path, i)
+		if !record.GetValid() {
+			v.checkMalformedDailyRecord(recordPath, record.GetRawData())
+			continue
+		}
+		date := timeOf(record.GetActivityRecordDate())
+		validity.check(v, recordPath+".activity_record_date", date)
+		if previous != nil {
+			previousDate := timeOf(previous.GetActivityRecordDate())
+			if !date.After(previousDate) {
+				v.add(FindingDailyRecordOrder, recordPath+".activity_record_date", date,
+					"record dated %s follows a record dated %s", date.Format(time.DateOnly), previousDate.Format(time.DateOnly))
+			}
+			counter := record.GetActivityDailyPresenceCounter().GetValue()
+			previousCounter := previous.GetActivityDailyPresenceCounter().GetValue()
+			if want := (previousCounter + 1) % maxDailyPresenceCounter; counter != want {
+				v.add(FindingPresenceCounterGap, recordPath+".activity_daily_presence_counter", date,
+					"presence counter is %d, want %d after the previous record", counter, want)
+			}
+		}
+		previous = record
+	}
+}
+
+// checkMalformedDailyRecord reports a daily activity record that could not be
+// parsed, pointing out an invalid BCD presence counter if that is the cause.
+func (v *validator) checkMalformedDailyRecord(path string, data []byte) {
+	const idxDailyPresenceCounter = 8
+	if len(data) >= idxDailyPresenceCounter+2 {
+		for _, b := range data[idxDailyPresenceCounter : idxDailyPresenceCounter+2] {
+			if b>>4 > 9 || b&0x0F > 9 {
+				v.add(FindingInvalidBCD, path+".activity_daily_presence_counter", time.Time{},
+					"presence counter %X is not a valid BCD value", data[idxDailyPresenceCounter:idxDailyPresenceCounter+2])
+				return
+			}
+		}
+	}
+	v.add(FindingMalformedRecord, path, time.Time{}, "daily record of %d bytes could not be parsed", len(data))
+}
+
+// cardVehicleRecord is implemented by the vehicle records of all card generations.
+type cardVehicleRecord interface {
+	GetVehicleOdometerBeginKm() int32
+	GetVehicleOdometerEndKm() int32
+	GetVehicleFirstUse() *timestamppb.Timestamp
+	GetVehicleLastUse() *timestamppb.Timestamp
+	GetVehicleRegistration() *ddv1.VehicleRegistrationIdentification
+}
+
+func validateVehiclesUsed[R cardVehicleRecord](v *validator, path string, newestIndex int32, records []R, validity cardValidity) {
+	newest := -1
+	for i, record := range records {
+		firstUse := timeOf(record.GetVehicleFirstUse())
+		if firstUse.IsZero() {
+			continue
+		}
+		recordPath := fmt.Sprintf("%s.records[%d]", path, i)
+		v.checkNation(recordPath+".vehicle_registration.nation", firstUse, record.GetVehicleRegistration().GetNation())
+		validity.check(v, recordPath+".vehicle_first_use", firstUse)
+		lastUse := timeOf(record.GetVehicleLastUse())
+		validity.check(v, recordPath+".vehicle_last_use", lastUse)
+		if !lastUse.IsZero() && record.GetVehicleOdometerEndKm() < record.GetVehicleOdometerBeginKm() {
+			v.add(FindingOdometerDecrease, recordPath+".vehicle_odometer_end_km", lastUse,
+				"odometer decreases from %d km to %d km during the vehicle use", record.GetVehicleOdometerBeginKm(), record.GetVehicleOdometerEndKm())
+		}
+		if newest < 0 || firstUse.After(timeOf(records[newest].GetVehicleFirstUse())) {
+			newest = i
+		}
+	}
+	v.checkNewestRecordIndex(path, newestIndex, len(records), newest)
+}
+
+// checkNewestRecordIndex reports a newest record pointer of a cyclic buffer
+// of records that is out of range, or that does not point to the newest
+// record, if known.
+func (v *validator) checkNewestRecordIndex(path string, newestIndex int32, records, newest int) {
+	switch {
+	case records == 0:
+	case newestIndex < 0 || int(newestIndex) >= records:
+		v.add(FindingInvalidPointer, path+".newest_record_index", time.Time{},
+			"pointer %d is outside the cyclic buffer of %d records", newestIndex, records)
+	case newest >= 0 && int(newestIndex) != newest:
+		v.add(FindingInvalidPointer, path+".newest_record_index", time.Time{},
+			"pointer %d does not point to the newest record %d", newestIndex, newest)
+	}
+}
+
+// cardPlaceRecord is implemented by the place records of all card generations.
+type cardPlaceRecord interface {
+	GetRawData() []byte
+	GetEntryTime() *timestamppb.Timestamp
+	GetEntryTypeDailyWorkPeriod() ddv1.EntryTypeDailyWorkPeriod
+	GetUnrecognizedEntryTypeDailyWorkPeriod() int32
+	GetDailyWorkPeriodCountry() ddv1.NationNumeric
+}
+
+func validatePlaces[R cardPlaceRecord](v *validator, path string, generation ddv1.Generation, newestIndex int32, records []R, validity cardValidity) {
+	newest := -1
+	for i, record := range records {
+		recordPath := fmt.Sprintf("%s.records[%d]", path, i)
+		// Records that could not be parsed only hold their raw data.
+		if record.GetEntryTypeDailyWorkPeriod() == ddv1.EntryTypeDailyWorkPeriod_ENTRY_TYPE_DAILY_WORK_PERIOD_UNSPECIFIED {
+			if len(record.GetRawData()) > 0 {
+				v.add(FindingMalformedRecord, recordPath, time.Time{}, "place record could not be parsed")
+			}
+			continue
+		}
+		entryTime := timeOf(record.GetEntryTime())
+		if entryTime.IsZero() {
+			continue
+		}
+		validity.check(v, recordPath+".entry_time", entryTime)
+		v.checkNation(recordPath+".daily_work_period_country", entryTime, record.GetDailyWorkPeriodCountry())
+		if record.GetEntryTypeDailyWorkPeriod() == ddv1.EntryTypeDailyWorkPeriod_ENTRY_TYPE_DAILY_WORK_PERIOD_UNRECOGNIZED {
+			v.add(FindingInvalidEntryType, recordPath+".entry_type_daily_work_period", entryTime,
+				"entry type %d is not in the data dictionary", record.GetUnrecognizedEntryTypeDailyWorkPeriod())
+		} else {
+			v.checkEntryTypeGeneration(recordPath+".entry_type_daily_work_period", entryTime, generation, record.GetEntryTypeDailyWorkPeriod())
+		}
+		if newest < 0 || entryTime.After(timeOf(records[newest].GetEntryTime())) {
+			newest = i
+		}
+	}
+	v.checkNewestRecordIndex(path, newestIndex, len(records), newest)
+}
+
+// checkEntryTypeGeneration reports a Gen2 place entry type in a Gen1 record.
+func (v *validator) checkEntryTypeGeneration(path string, at time.Time, generation ddv1.Generation, entryType ddv1.EntryTypeDailyWorkPeriod) {
+	if generation != ddv1.Generation_GENERATION_1 {
+		return
+	}
+	switch entryType {
+	case ddv1.EntryTypeDailyWorkPeriod_BEGIN, ddv1.EntryTypeDailyWorkPeriod_END:
+	default:
+		v.add(FindingInvalidEntryType, path, at, "entry type %v is not defined for generation 1", entryType)
+	}
+}
+
+func (v *validator) validateVehicleUnit(file *vuv1.VehicleUnitFile) {
+	r := newVehicleUnitRecords(file)
+	v.checkNation("vehicle_unit.overview.vehicle_registration_with_nation.nation", r.download.DownloadTime, r.download.Registration.Nation)
+	days := slices.Clone(r.activityDays)
+	slices.SortStableFunc(days, func(a, b VehicleActivityDay) int {
+		return a.Date.Compare(b.Date)
+	})
+	for i := 1; i < len(days); i++ {
+		if days[i].OdometerMidnightKm < days[i-1].OdometerMidnightKm {
+			v.add(FindingOdometerDecrease, "vehicle_unit.activities.odometer_midnight_km", days[i].Date,
+				"odometer at midnight decreases from %d km on %s to %d km on %s",
+				days[i-1].OdometerMidnightKm, days[i-1].Date.Format(time.DateOnly),
+				days[i].OdometerMidnightKm, days[i].Date.Format(time.DateOnly))
+		}
+	}
+	for _, place := range r.places {
+		if place.Time.IsZero() {
+			continue
+		}
+		v.checkNation("vehicle_unit.activities.places.country", place.Time, place.Country)
+		if place.EntryType == ddv1.EntryTypeDailyWorkPeriod_ENTRY_TYPE_DAILY_WORK_PERIOD_UNRECOGNIZED {
+			v.add(FindingInvalidEntryType, "vehicle_unit.activities.places.entry_type", place.Time, "entry type is not in the data dictionary")
+		} else {
+			v.checkEntryTypeGeneration("vehicle_unit.activities.places.entry_type", place.Time, file.GetGeneration(), place.EntryType)
+		}
+	}
+}
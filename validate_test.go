@@ -0,0 +1,154 @@
+package tachograph
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+)
+
+// testCountedDailyRecord returns a daily activity record with a presence counter.
+func testCountedDailyRecord(day time.Time, presenceCounter int32) *cardv1.DriverActivityData_DailyRecord {
+	counter := &ddv1.BcdString{}
+	counter.SetValue(presenceCounter)
+	counter.SetLength(2)
+	record := testDailyRecord(day, 0)
+	record.SetActivityDailyPresenceCounter(counter)
+	return record
+}
+
+func TestValidate(t *testing.T) {
+	// The test driver card data is synthetic, and breaks some invariants of
+	// real cards.
+	baseline := []Finding{
+		{Type: FindingInvalidPointer, Path: "driver_card.tachograph.vehicles_used.newest_record_index"},
+		{Type: FindingInvalidEntryType, Path: "driver_card.tachograph.places.records[101].entry_type_daily_work_period"},
+		{Type: FindingInvalidPointer, Path: "driver_card.tachograph.places.newest_record_index"},
+	}
+	for _, tt := range []struct {
+		name   string
+		modify func(*cardv1.DriverCardFile_Tachograph)
+		want   []Finding
+	}{
+		{
+			name:   "baseline",
+			modify: func(*cardv1.DriverCardFile_Tachograph) {},
+		},
+		{
+			name: "nation",
+			modify: func(g1 *cardv1.DriverCardFile_Tachograph) {
+				g1.GetIdentification().GetCard().SetCardIssuingMemberState(ddv1.NationNumeric_NATION_NUMERIC_UNRECOGNIZED)
+			},
+			want: []Finding{
+				{Type: FindingInvalidNation, Path: "driver_card.tachograph.identification.card.card_issuing_member_state"},
+			},
+		},
+		{
+			name: "birth date",
+			modify: func(g1 *cardv1.DriverCardFile_Tachograph) {
+				g1.GetIdentification().GetDriverCardHolder().GetCardHolderBirthDate().SetDay(30)
+				g1.GetIdentification().GetDriverCardHolder().GetCardHolderBirthDate().SetMonth(2)
+			},
+			want: []Finding{
+				{Type: FindingInvalidDate, Path: "driver_card.tachograph.identification.driver_card_holder.card_holder_birth_date"},
+			},
+		},
+		{
+			name: "daily records",
+			modify: func(g1 *cardv1.DriverCardFile_Tachograph) {
+				day := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+				malformed := &cardv1.DriverActivityData_DailyRecord{}
+				malformed.SetRawData([]byte{0, 0, 0, 12, 0x5e, 0x5b, 0x3a, 0x80, 0x1A, 0x00, 0, 0})
+				activity := &cardv1.DriverActivityData{}
+				activity.SetRawData(make([]byte, 100))
+				activity.SetNewestDayRecordIndex(100)
+				activity.SetDailyRecords([]*cardv1.DriverActivityData_DailyRecord{
+					testCountedDailyRecord(day, 9998),
+					testCountedDailyRecord(day.AddDate(0, 0, 1), 9999),
+					testCountedDailyRecord(day.AddDate(0, 0, 2), 0),
+					testCountedDailyRecord(day.AddDate(0, 0, 2), 2),
+					malformed,
+					testCountedDailyRecord(day.AddDate(5, 0, 0), 3),
+				})
+				g1.SetDriverActivityData(activity)
+			},
+			want: []Finding{
+				{Type: FindingInvalidPointer, Path: "driver_card.tachograph.driver_activity_data.newest_day_record_index"},
+				{Type: FindingDailyRecordOrder, Path: "driver_card.tachograph.driver_activity_data.daily_records[3].activity_record_date"},
+				{Type: FindingPresenceCounterGap, Path: "driver_card.tachograph.driver_activity_data.daily_records[3].activity_daily_presence_counter"},
+				{Type: FindingInvalidBCD, Path: "driver_card.tachograph.driver_activity_data.daily_records[4].activity_daily_presence_counter"},
+				{Type: FindingOutsideCardValidity, Path: "driver_card.tachograph.driver_activity_data.daily_records[5].activity_record_date"},
+			},
+		},
+		{
+			name: "vehicle odometer",
+			modify: func(g1 *cardv1.DriverCardFile_Tachograph) {
+				record := g1.GetVehiclesUsed().GetRecords()[0]
+				record.SetVehicleOdometerEndKm(record.GetVehicleOdometerBeginKm() - 1)
+			},
+			want: []Finding{
+				{Type: FindingOdometerDecrease, Path: "driver_card.tachograph.vehicles_used.records[0].vehicle_odometer_end_km"},
+			},
+		},
+		{
+			name: "place entry type",
+			modify: func(g1 *cardv1.DriverCardFile_Tachograph) {
+				g1.GetPlaces().GetRecords()[0].SetEntryTypeDailyWorkPeriod(ddv1.EntryTypeDailyWorkPeriod_ENTRY_TYPE_DAILY_WORK_PERIOD_UNRECOGNIZED)
+			},
+			want: []Finding{
+				{Type: FindingInvalidEntryType, Path: "driver_card.tachograph.places.records[0].entry_type_daily_work_period"},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := UnmarshalFile(testDriverCardData(t))
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.modify(file.GetDriverCard().GetTachograph())
+			want := append(append([]Finding(nil), baseline...), tt.want...)
+			got := Validate(file)
+			if diff := cmp.Diff(findingCounts(want), findingCounts(got)); diff != "" {
+				t.Errorf("Validate() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate_vehicleUnit(t *testing.T) {
+	vehicleUnit := testVehicleUnitFileGen1("WDB9634031L123456", Period{Start: testDay(0), End: testDay(3)}, map[time.Time][]*ddv1.ActivityChangeInfo{
+		testDay(0): nil,
+		testDay(1): nil,
+		testDay(2): nil,
+	})
+	for _, activities := range vehicleUnit.GetGen1().GetActivities() {
+		day := activities.GetDateOfDay().AsTime()
+		odometer := map[time.Time]int32{testDay(0): 1000, testDay(1): 1200, testDay(2): 1100}[day]
+		activities.SetOdometerMidnightKm(odometer)
+	}
+	var file tachographv1.File
+	file.SetType(tachographv1.File_VEHICLE_UNIT)
+	file.SetVehicleUnit(vehicleUnit)
+	got := Validate(&file)
+	want := []Finding{
+		{Type: FindingOdometerDecrease, Path: "vehicle_unit.activities.odometer_midnight_km", Time: testDay(2)},
+	}
+	if diff := cmp.Diff(want, got, cmp.FilterPath(func(p cmp.Path) bool {
+		return p.Last().String() == ".Message"
+	}, cmp.Ignore())); diff != "" {
+		t.Errorf("Validate() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// findingCounts counts findings by type and path.
+func findingCounts(findings []Finding) map[string]int {
+	keys := map[string]int{}
+	for _, f := range findings {
+		keys[f.Type.String()+" "+f.Path]++
+	}
+	return keys
+}
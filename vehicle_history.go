@@ -0,0 +1,413 @@
+package tachograph
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
+)
+
+// VehicleHistory is the history of a single vehicle, merged from one or more
+// vehicle unit downloads.
+//
+// Records that occur in several downloads are reported once. When downloads
+// disagree, the record from the most recent download is kept.
+type VehicleHistory struct {
+	// VIN is the vehicle identification number shared by all downloads.
+	VIN string
+	// Downloads describes each merged download, ordered by download time.
+	Downloads []VehicleDownload
+	// CardInsertions are the card insertions and withdrawals, ordered by insertion time.
+	CardInsertions []CardInsertion
+	// ActivityDays are the recorded activity days, ordered by date.
+	ActivityDays []VehicleActivityDay
+	// Events are the recorded events and faults, ordered by begin time.
+	Events []VehicleEvent
+	// Places are the daily work period places, ordered by entry time.
+	Places []Place
+	// BorderCrossings are the recorded border crossings, ordered by time.
+	BorderCrossings []BorderCrossing
+	// LoadUnloadOperations are the recorded load and unload operations, ordered by time.
+	LoadUnloadOperations []LoadUnloadOperation
+	// AccumulatedDrivingPositions are the recorded accumulated driving positions, ordered by time.
+	AccumulatedDrivingPositions []AccumulatedDrivingPosition
+	// SpeedBlocks are the detailed speed blocks, ordered by begin time.
+	SpeedBlocks []SpeedBlock
+	// ControlActivities are the controls performed on the vehicle unit, ordered by time.
+	ControlActivities []ControlActivity
+	// Calibrations are the calibrations of the vehicle unit, ordered by new time value.
+	Calibrations []Calibration
+	// Coverage are the periods covered by the downloadable periods of the downloads.
+	Coverage []Period
+	// Gaps are the periods between the first and the last downloaded data that
+	// are not covered by any download.
+	Gaps []Period
+}
+
+// Period is a time interval from Start (inclusive) to End (exclusive).
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration returns the length of the period.
+func (p Period) Duration() time.Duration {
+	return p.End.Sub(p.Start)
+}
+
+// VehicleRegistration identifies a vehicle by its registration number.
+type VehicleRegistration struct {
+	// Nation is the registering member state, if known.
+	Nation ddv1.NationNumeric
+	// Number is the vehicle registration number.
+	Number string
+}
+
+// String returns the registration number.
+func (r VehicleRegistration) String() string {
+	return r.Number
+}
+
+// VehicleDownload describes a single vehicle unit download.
+type VehicleDownload struct {
+	// Generation is the generation of the vehicle unit data.
+	Generation ddv1.Generation
+	// Version is the version of the vehicle unit data.
+	Version ddv1.Version
+	// DownloadTime is the vehicle unit's current time at download.
+	DownloadTime time.Time
+	// DownloadablePeriod is the period of data held by the vehicle unit at download.
+	DownloadablePeriod Period
+	// Registration is the vehicle registration at download.
+	Registration VehicleRegistration
+}
+
+// CardInsertion is a card insertion and withdrawal cycle in a vehicle unit.
+type CardInsertion struct {
+	// CardNumber is the number of the inserted card.
+	CardNumber string
+	// CardHolderName is the name of the card holder.
+	CardHolderName string
+	// Slot is the slot the card was inserted into.
+	Slot ddv1.CardSlotNumber
+	// InsertionTime is the time of the card insertion.
+	InsertionTime time.Time
+	// WithdrawalTime is the time of the card withdrawal, zero if the card was
+	// still inserted at download.
+	WithdrawalTime time.Time
+	// OdometerAtInsertionKm is the odometer value at card insertion.
+	OdometerAtInsertionKm int32
+	// OdometerAtWithdrawalKm is the odometer value at card withdrawal.
+	OdometerAtWithdrawalKm int32
+	// ManualInputFlag reports whether manual entries were made at insertion.
+	ManualInputFlag bool
+}
+
+// VehicleActivityDay is the activity data recorded by a vehicle unit for a single day.
+type VehicleActivityDay struct {
+	// Date is the start of the day (00:00 UTC).
+	Date time.Time
+	// OdometerMidnightKm is the odometer value at midnight.
+	OdometerMidnightKm int32
+	// ActivityChanges are the activity changes of both slots during the day.
+	ActivityChanges []*ddv1.ActivityChangeInfo
+	// SpecificConditions are the specific conditions entered during the day.
+	SpecificConditions []*ddv1.SpecificConditionRecord
+}
+
+// VehicleEvent is an event or fault recorded by a vehicle unit.
+type VehicleEvent struct {
+	// Type is the type of the event or fault.
+	Type ddv1.EventFaultType
+	// Fault is true for faults and false for events.
+	Fault bool
+	// RecordPurpose is the reason the event or fault was recorded.
+	RecordPurpose ddv1.EventFaultRecordPurpose
+	// BeginTime is the time the event or fault began.
+	BeginTime time.Time
+	// EndTime is the time the event or fault ended.
+	EndTime time.Time
+	// DriverCardNumber is the card in the driver slot when the event began.
+	DriverCardNumber string
+	// CoDriverCardNumber is the card in the co-driver slot when the event began.
+	CoDriverCardNumber string
+	// SimilarEvents is the number of similar events that day, if recorded.
+	SimilarEvents int32
+	// MaxSpeedKmh is the maximum speed during an overspeeding event.
+	MaxSpeedKmh int32
+	// AverageSpeedKmh is the average speed during an overspeeding event.
+	AverageSpeedKmh int32
+}
+
+// Place is a place where a daily work period began or ended.
+type Place struct {
+	// Time is the time of the entry.
+	Time time.Time
+	// EntryType tells if the entry is the beginning or end of a daily work period.
+	EntryType ddv1.EntryTypeDailyWorkPeriod
+	// Country is the country entered.
+	Country ddv1.NationNumeric
+	// OdometerKm is the odometer value at the time of the entry.
+	OdometerKm int32
+	// Position is the GNSS position of the entry, nil for Gen1 data.
+	Position *ddv1.GeoCoordinates
+	// AuthenticationStatus is the authentication status of the position,
+	// unspecified if not recorded.
+	AuthenticationStatus ddv1.PositionAuthenticationStatus
+}
+
+// SpeedBlock is one minute of detailed speed data, sampled every second.
+type SpeedBlock struct {
+	// BeginTime is the time of the first sample.
+	BeginTime time.Time
+	// SpeedsKmh are the 60 speed samples.
+	SpeedsKmh []int32
+}
+
+// ControlActivity is a control performed with a control card.
+type ControlActivity struct {
+	// Time is the time of the control.
+	Time time.Time
+	// Type tells which control activities were performed.
+	Type *ddv1.ControlType
+	// ControlCardNumber is the number of the control card.
+	ControlCardNumber string
+	// Registration is the registration of the controlled vehicle, recorded on
+	// driver cards only.
+	Registration VehicleRegistration
+	// DownloadPeriod is the period of data downloaded during the control, zero
+	// if no data was downloaded.
+	DownloadPeriod Period
+}
+
+// Calibration is a calibration of a vehicle unit by a workshop.
+type Calibration struct {
+	// Purpose is the purpose of the calibration.
+	Purpose ddv1.CalibrationPurpose
+	// WorkshopName is the name of the workshop.
+	WorkshopName string
+	// WorkshopCardNumber is the number of the workshop card used.
+	WorkshopCardNumber string
+	// VIN is the vehicle identification number of the calibrated vehicle.
+	VIN string
+	// Registration is the registration of the calibrated vehicle.
+	Registration VehicleRegistration
+	// WVehicleCharacteristicConstant is the characteristic coefficient of the
+	// vehicle, in impulses per km.
+	WVehicleCharacteristicConstant int32
+	// KConstantOfRecordingEquipment is the constant of the recording
+	// equipment, in impulses per km.
+	KConstantOfRecordingEquipment int32
+	// LTyreCircumferenceEighthsMm is the effective tyre circumference, in
+	// eighths of a millimetre.
+	LTyreCircumferenceEighthsMm int32
+	// TyreSize is the designation of the tyre size.
+	TyreSize string
+	// AuthorisedSpeedKmh is the maximum authorised speed of the vehicle.
+	AuthorisedSpeedKmh int32
+	// OldOdometerKm is the odometer value before the calibration.
+	OldOdometerKm int32
+	// NewOdometerKm is the odometer value after the calibration.
+	NewOdometerKm int32
+	// OldTime is the time before the calibration.
+	OldTime time.Time
+	// NewTime is the time after the calibration.
+	NewTime time.Time
+	// NextCalibrationDate is the date of the next calibration.
+	NextCalibrationDate time.Time
+}
+
+// MergeVehicleUnitFiles merges vehicle unit downloads of the same vehicle into
+// a single [VehicleHistory].
+//
+// Downloads of any generation and version can be merged. It is an error to
+// merge downloads with different vehicle identification numbers.
+//
+// Records found in several downloads are taken from the latest download,
+// except that an event or fault keeps its end time from an earlier download
+// if it has none in a later one.
+func MergeVehicleUnitFiles(files ...*vuv1.VehicleUnitFile) (*VehicleHistory, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no vehicle unit files to merge")
+	}
+	downloads := make([]*vehicleUnitRecords, 0, len(files))
+	for i, file := range files {
+		if file == nil {
+			return nil, fmt.Errorf("vehicle unit file %d is nil", i)
+		}
+		records := newVehicleUnitRecords(file)
+		if len(downloads) > 0 && records.vin != downloads[0].vin {
+			return nil, fmt.Errorf("vehicle unit file %d has VIN %q, want %q", i, records.vin, downloads[0].vin)
+		}
+		downloads = append(downloads, records)
+	}
+	// Merge in download order, so that later downloads replace earlier records.
+	slices.SortStableFunc(downloads, func(a, b *vehicleUnitRecords) int {
+		return a.download.DownloadTime.Compare(b.download.DownloadTime)
+	})
+	h := &VehicleHistory{VIN: downloads[0].vin}
+	cardInsertions := map[string]CardInsertion{}
+	activityDays := map[time.Time]VehicleActivityDay{}
+	events := map[string]VehicleEvent{}
+	places := map[string]Place{}
+	borderCrossings := map[string]BorderCrossing{}
+	loadUnloadOperations := map[string]LoadUnloadOperation{}
+	accumulatedDrivingPositions := map[time.Time]AccumulatedDrivingPosition{}
+	speedBlocks := map[time.Time]SpeedBlock{}
+	controlActivities := map[string]ControlActivity{}
+	calibrations := map[string]Calibration{}
+	for _, d := range downloads {
+		h.Downloads = append(h.Downloads, d.download)
+		for _, c := range d.cardInsertions {
+			cardInsertions[fmt.Sprintf("%s/%d/%d", c.CardNumber, c.Slot, c.InsertionTime.Unix())] = c
+		}
+		for _, day := range d.activityDays {
+			// A day that was still in progress at an earlier download has fewer changes.
+			if prev, ok := activityDays[day.Date]; ok && len(prev.ActivityChanges) > len(day.ActivityChanges) {
+				continue
+			}
+			activityDays[day.Date] = day
+		}
+		for _, e := range d.events {
+			key := fmt.Sprintf("%t/%d/%d", e.Fault, e.Type, e.BeginTime.Unix())
+			// An event that was still in progress at a download has no end time.
+			if prev, ok := events[key]; ok && !prev.EndTime.IsZero() && e.EndTime.IsZero() {
+				continue
+			}
+			events[key] = e
+		}
+		for _, p := range d.places {
+			places[fmt.Sprintf("%d/%d/%d/%d", p.Time.Unix(), p.EntryType, p.Country, p.OdometerKm)] = p
+		}
+		for _, c := range d.borderCrossings {
+			borderCrossings[fmt.Sprintf("%d/%d/%d", c.Time.Unix(), c.CountryLeft, c.CountryEntered)] = c
+		}
+		for _, o := range d.loadUnloadOperations {
+			loadUnloadOperations[fmt.Sprintf("%d/%d", o.Time.Unix(), o.OperationType)] = o
+		}
+		for _, p := range d.accumulatedDrivingPositions {
+			accumulatedDrivingPositions[p.Time] = p
+		}
+		for _, b := range d.speedBlocks {
+			speedBlocks[b.BeginTime] = b
+		}
+		for _, c := range d.controlActivities {
+			controlActivities[fmt.Sprintf("%s/%d", c.ControlCardNumber, c.Time.Unix())] = c
+		}
+		for _, c := range d.calibrations {
+			calibrations[fmt.Sprintf("%d/%s/%d", c.Purpose, c.WorkshopCardNumber, c.NewTime.Unix())] = c
+		}
+	}
+	h.CardInsertions = sortedValues(cardInsertions, func(a, b CardInsertion) int {
+		return cmp.Or(a.InsertionTime.Compare(b.InsertionTime), cmp.Compare(a.Slot, b.Slot))
+	})
+	h.ActivityDays = sortedValues(activityDays, func(a, b VehicleActivityDay) int {
+		return a.Date.Compare(b.Date)
+	})
+	h.Events = sortedValues(events, func(a, b VehicleEvent) int {
+		return cmp.Or(a.BeginTime.Compare(b.BeginTime), cmp.Compare(a.Type, b.Type))
+	})
+	h.Places = sortedValues(places, func(a, b Place) int {
+		return cmp.Or(a.Time.Compare(b.Time), cmp.Compare(a.EntryType, b.EntryType))
+	})
+	h.BorderCrossings = sortedValues(borderCrossings, func(a, b BorderCrossing) int {
+		return a.Time.Compare(b.Time)
+	})
+	h.LoadUnloadOperations = sortedValues(loadUnloadOperations, func(a, b LoadUnloadOperation) int {
+		return cmp.Or(a.Time.Compare(b.Time), cmp.Compare(a.OperationType, b.OperationType))
+	})
+	h.AccumulatedDrivingPositions = sortedValues(accumulatedDrivingPositions, func(a, b AccumulatedDrivingPosition) int {
+		return a.Time.Compare(b.Time)
+	})
+	h.SpeedBlocks = sortedValues(speedBlocks, func(a, b SpeedBlock) int {
+		return a.BeginTime.Compare(b.BeginTime)
+	})
+	h.ControlActivities = sortedValues(controlActivities, func(a, b ControlActivity) int {
+		return a.Time.Compare(b.Time)
+	})
+	h.Calibrations = sortedValues(calibrations, func(a, b Calibration) int {
+		return cmp.Or(a.NewTime.Compare(b.NewTime), cmp.Compare(a.Purpose, b.Purpose))
+	})
+	h.Coverage, h.Gaps = downloadCoverage(h.Downloads)
+	return h, nil
+}
+
+// Registrations returns the distinct vehicle registrations seen across the
+// downloads, in order of first appearance.
+func (h *VehicleHistory) Registrations() []VehicleRegistration {
+	var result []VehicleRegistration
+	for _, d := range h.Downloads {
+		if d.Registration.Number == "" {
+			continue
+		}
+		if !slices.ContainsFunc(result, func(r VehicleRegistration) bool {
+			return strings.EqualFold(r.Number, d.Registration.Number)
+		}) {
+			result = append(result, d.Registration)
+		}
+	}
+	return result
+}
+
+// DownloadIntervalViolations returns the intervals between consecutive
+// downloads that exceed maxInterval.
+//
+// The interval is measured from the end of the downloadable period of one
+// download to the end of the downloadable period of the next, so that a
+// vehicle that is downloaded regularly returns no violations.
+func (h *VehicleHistory) DownloadIntervalViolations(maxInterval time.Duration) []Period {
+	var result []Period
+	for i := 1; i < len(h.Downloads); i++ {
+		interval := Period{
+			Start: h.Downloads[i-1].DownloadablePeriod.End,
+			End:   h.Downloads[i].DownloadablePeriod.End,
+		}
+		if interval.Duration() > maxInterval {
+			result = append(result, interval)
+		}
+	}
+	return result
+}
+
+// downloadCoverage returns the union of the downloadable periods of the
+// downloads, and the gaps in between.
+func downloadCoverage(downloads []VehicleDownload) (coverage, gaps []Period) {
+	periods := make([]Period, 0, len(downloads))
+	for _, d := range downloads {
+		p := d.DownloadablePeriod
+		if p.Start.IsZero() || p.End.IsZero() || p.End.Before(p.Start) {
+			continue
+		}
+		periods = append(periods, p)
+	}
+	slices.SortFunc(periods, func(a, b Period) int {
+		return a.Start.Compare(b.Start)
+	})
+	for _, p := range periods {
+		if n := len(coverage); n > 0 && !p.Start.After(coverage[n-1].End) {
+			if p.End.After(coverage[n-1].End) {
+				coverage[n-1].End = p.End
+			}
+			continue
+		}
+		coverage = append(coverage, p)
+	}
+	for i := 1; i < len(coverage); i++ {
+		gaps = append(gaps, Period{Start: coverage[i-1].End, End: coverage[i].Start})
+	}
+	return coverage, gaps
+}
+
+// sortedValues returns the values of m, sorted by compare.
+func sortedValues[K comparable, V any](m map[K]V, compare func(a, b V) int) []V {
+	result := make([]V, 0, len(m))
+	for _, v := range m {
+		result = append(result, v)
+	}
+	slices.SortFunc(result, compare)
+	return result
+}
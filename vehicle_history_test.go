@@ -0,0 +1,189 @@
+package tachograph
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
+)
+
+var testEpoch = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func testDay(n int) time.Time {
+	return testEpoch.AddDate(0, 0, n)
+}
+
+func testIa5(s string) *ddv1.Ia5StringValue {
+	v := &ddv1.Ia5StringValue{}
+	v.SetValue(s)
+	v.SetLength(int32(len(s)))
+	return v
+}
+
+func testDriverCardNumber(number string) *ddv1.FullCardNumber {
+	driverID := &ddv1.DriverIdentification{}
+	driverID.SetDriverIdentificationNumber(testIa5(number))
+	cardNumber := &ddv1.FullCardNumber{}
+	cardNumber.SetCardType(ddv1.EquipmentType_DRIVER_CARD)
+	cardNumber.SetDriverIdentification(driverID)
+	return cardNumber
+}
+
+func testActivityChange(slot ddv1.CardSlotNumber, activity ddv1.DriverActivityValue, minutes int32) *ddv1.ActivityChangeInfo {
+	change := &ddv1.ActivityChangeInfo{}
+	change.SetSlot(slot)
+	change.SetInserted(true)
+	change.SetActivity(activity)
+	change.SetTimeOfChangeMinutes(minutes)
+	return change
+}
+
+// testVehicleUnitFileGen1 builds a Gen1 download holding the given days.
+func testVehicleUnitFileGen1(vin string, downloadablePeriod Period, days map[time.Time][]*ddv1.ActivityChangeInfo) *vuv1.VehicleUnitFile {
+	period := &ddv1.DownloadablePeriod{}
+	period.SetMinTime(timestamppb.New(downloadablePeriod.Start))
+	period.SetMaxTime(timestamppb.New(downloadablePeriod.End))
+	overview := &vuv1.OverviewGen1{}
+	overview.SetVehicleIdentificationNumber(testIa5(vin))
+	overview.SetCurrentDateTime(timestamppb.New(downloadablePeriod.End))
+	overview.SetDownloadablePeriod(period)
+	gen1 := &vuv1.VehicleUnitFileGen1{}
+	gen1.SetOverview(overview)
+	var activities []*vuv1.ActivitiesGen1
+	for day, changes := range days {
+		iw := &ddv1.VuCardIWRecord{}
+		iw.SetFullCardNumber(testDriverCardNumber("DRIVER00000001"))
+		iw.SetCardSlotNumber(ddv1.CardSlotNumber_DRIVER_SLOT)
+		iw.SetCardInsertionTime(timestamppb.New(day.Add(6 * time.Hour)))
+		iw.SetOdometerAtInsertionKm(1000)
+		a := &vuv1.ActivitiesGen1{}
+		a.SetDateOfDay(timestamppb.New(day))
+		a.SetCardIwData([]*ddv1.VuCardIWRecord{iw})
+		a.SetActivityChanges(changes)
+		activities = append(activities, a)
+	}
+	gen1.SetActivities(activities)
+	file := &vuv1.VehicleUnitFile{}
+	file.SetGeneration(ddv1.Generation_GENERATION_1)
+	file.SetGen1(gen1)
+	return file
+}
+
+func TestMergeVehicleUnitFiles(t *testing.T) {
+	const vin = "WDB9634031L123456"
+	partialDay := []*ddv1.ActivityChangeInfo{
+		testActivityChange(ddv1.CardSlotNumber_DRIVER_SLOT, ddv1.DriverActivityValue_WORK, 360),
+	}
+	fullDay := []*ddv1.ActivityChangeInfo{
+		testActivityChange(ddv1.CardSlotNumber_DRIVER_SLOT, ddv1.DriverActivityValue_WORK, 360),
+		testActivityChange(ddv1.CardSlotNumber_DRIVER_SLOT, ddv1.DriverActivityValue_DRIVING, 420),
+		testActivityChange(ddv1.CardSlotNumber_DRIVER_SLOT, ddv1.DriverActivityValue_BREAK_REST, 960),
+	}
+	first := testVehicleUnitFileGen1(vin, Period{Start: testDay(0), End: testDay(10).Add(12 * time.Hour)}, map[time.Time][]*ddv1.ActivityChangeInfo{
+		testDay(9):  fullDay,
+		testDay(10): partialDay,
+	})
+	second := testVehicleUnitFileGen1(vin, Period{Start: testDay(5), End: testDay(20)}, map[time.Time][]*ddv1.ActivityChangeInfo{
+		testDay(10): fullDay,
+		testDay(11): fullDay,
+	})
+	third := testVehicleUnitFileGen1(vin, Period{Start: testDay(30), End: testDay(40)}, nil)
+
+	// Input order must not matter.
+	history, err := MergeVehicleUnitFiles(third, second, first)
+	if err != nil {
+		t.Fatalf("MergeVehicleUnitFiles() error = %v", err)
+	}
+	if history.VIN != vin {
+		t.Errorf("VIN = %q, want %q", history.VIN, vin)
+	}
+	if got := len(history.Downloads); got != 3 {
+		t.Fatalf("downloads = %d, want 3", got)
+	}
+	if !history.Downloads[0].DownloadTime.Before(history.Downloads[2].DownloadTime) {
+		t.Errorf("downloads are not ordered by download time")
+	}
+	if got := len(history.ActivityDays); got != 3 {
+		t.Fatalf("activity days = %d, want 3", got)
+	}
+	if got := len(history.ActivityDays[1].ActivityChanges); got != len(fullDay) {
+		t.Errorf("day 10 activity changes = %d, want %d (complete day from later download)", got, len(fullDay))
+	}
+	if got := len(history.CardInsertions); got != 3 {
+		t.Errorf("card insertions = %d, want 3", got)
+	}
+	if got := history.CardInsertions[0].CardNumber; got != "DRIVER00000001" {
+		t.Errorf("card number = %q", got)
+	}
+	wantCoverage := []Period{
+		{Start: testDay(0), End: testDay(20)},
+		{Start: testDay(30), End: testDay(40)},
+	}
+	if len(history.Coverage) != len(wantCoverage) {
+		t.Fatalf("coverage = %v, want %v", history.Coverage, wantCoverage)
+	}
+	for i, p := range wantCoverage {
+		if !history.Coverage[i].Start.Equal(p.Start) || !history.Coverage[i].End.Equal(p.End) {
+			t.Errorf("coverage[%d] = %v, want %v", i, history.Coverage[i], p)
+		}
+	}
+	if len(history.Gaps) != 1 || !history.Gaps[0].Start.Equal(testDay(20)) || !history.Gaps[0].End.Equal(testDay(30)) {
+		t.Errorf("gaps = %v, want [%v - %v]", history.Gaps, testDay(20), testDay(30))
+	}
+	if got := history.DownloadIntervalViolations(15 * 24 * time.Hour); len(got) != 1 {
+		t.Errorf("download interval violations = %v, want 1", got)
+	}
+	if got := history.DownloadIntervalViolations(90 * 24 * time.Hour); len(got) != 0 {
+		t.Errorf("download interval violations = %v, want none", got)
+	}
+}
+
+func TestMergeVehicleUnitFiles_differentVIN(t *testing.T) {
+	period := Period{Start: testDay(0), End: testDay(1)}
+	a := testVehicleUnitFileGen1("WDB9634031L123456", period, nil)
+	b := testVehicleUnitFileGen1("WDB9634031L654321", period, nil)
+	if _, err := MergeVehicleUnitFiles(a, b); err == nil {
+		t.Error("MergeVehicleUnitFiles() with different VINs: expected error")
+	}
+	if _, err := MergeVehicleUnitFiles(); err == nil {
+		t.Error("MergeVehicleUnitFiles() without files: expected error")
+	}
+}
+
+func TestMergeVehicleUnitFiles_events(t *testing.T) {
+	const vin = "WDB9634031L123456"
+	begin := testDay(9).Add(12 * time.Hour)
+	end := begin.Add(time.Hour)
+	testFile := func(downloadablePeriod Period, endTime time.Time) *vuv1.VehicleUnitFile {
+		event := &vuv1.EventsAndFaultsGen1_EventRecord{}
+		event.SetEventType(ddv1.EventFaultType_GENERAL_CARD_CONFLICT)
+		event.SetBeginTime(timestamppb.New(begin))
+		if !endTime.IsZero() {
+			event.SetEndTime(timestamppb.New(endTime))
+		}
+		eventsAndFaults := &vuv1.EventsAndFaultsGen1{}
+		eventsAndFaults.SetEvents([]*vuv1.EventsAndFaultsGen1_EventRecord{event})
+		file := testVehicleUnitFileGen1(vin, downloadablePeriod, nil)
+		file.GetGen1().SetEventsAndFaults([]*vuv1.EventsAndFaultsGen1{eventsAndFaults})
+		return file
+	}
+	// The event is still in progress at the first download, and its end time
+	// is no longer recorded at the last.
+	inProgress := testFile(Period{Start: testDay(0), End: begin.Add(time.Minute)}, time.Time{})
+	ended := testFile(Period{Start: testDay(0), End: testDay(20)}, end)
+	last := testFile(Period{Start: testDay(0), End: testDay(30)}, time.Time{})
+
+	history, err := MergeVehicleUnitFiles(last, inProgress, ended)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history.Events) != 1 {
+		t.Fatalf("events = %+v, want 1", history.Events)
+	}
+	if got := history.Events[0]; !got.BeginTime.Equal(begin) || !got.EndTime.Equal(end) {
+		t.Errorf("event = %v - %v, want %v - %v", got.BeginTime, got.EndTime, begin, end)
+	}
+}
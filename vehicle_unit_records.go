@@ -0,0 +1,515 @@
+package tachograph
+
+import (
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	vuv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/vu/v1"
+)
+
+// vehicleUnitRecords is a generation-independent view of the records in a
+// single vehicle unit download.
+type vehicleUnitRecords struct {
+	download                    VehicleDownload
+	vin                         string
+	cardInsertions              []CardInsertion
+	activityDays                []VehicleActivityDay
+	events                      []VehicleEvent
+	places                      []Place
+	borderCrossings             []BorderCrossing
+	loadUnloadOperations        []LoadUnloadOperation
+	accumulatedDrivingPositions []AccumulatedDrivingPosition
+	speedBlocks                 []SpeedBlock
+	controlActivities           []ControlActivity
+	calibrations                []Calibration
+}
+
+// newVehicleUnitRecords collects the records of a vehicle unit file,
+// regardless of its generation and version.
+func newVehicleUnitRecords(file *vuv1.VehicleUnitFile) *vehicleUnitRecords {
+	r := &vehicleUnitRecords{}
+	r.download.Generation = file.GetGeneration()
+	r.download.Version = file.GetVersion()
+	switch file.GetGeneration() {
+	case ddv1.Generation_GENERATION_1:
+		gen1 := file.GetGen1()
+		overview := gen1.GetOverview()
+		r.vin = ia5String(overview.GetVehicleIdentificationNumber())
+		r.download.Registration = newVehicleRegistration(overview.GetVehicleRegistrationWithNation())
+		r.download.DownloadTime = timeOf(overview.GetCurrentDateTime())
+		r.download.DownloadablePeriod = periodOf(overview.GetDownloadablePeriod())
+		for _, activities := range gen1.GetActivities() {
+			for _, iw := range activities.GetCardIwData() {
+				r.addCardInsertion(iw, iw.GetFullCardNumber())
+			}
+			r.addActivityDay(activities.GetDateOfDay(), activities.GetOdometerMidnightKm(), activities.GetActivityChanges(), activities.GetSpecificConditions())
+			for _, place := range activities.GetPlaces() {
+				r.addPlace(place, nil, ddv1.PositionAuthenticationStatus_POSITION_AUTHENTICATION_STATUS_UNSPECIFIED)
+			}
+		}
+		for _, eventsAndFaults := range gen1.GetEventsAndFaults() {
+			for _, fault := range eventsAndFaults.GetFaults() {
+				r.addEvent(fault, true, fault.GetFaultType(), fault.GetCardNumberDriverSlotBegin(), fault.GetCardNumberCodriverSlotBegin())
+			}
+			for _, event := range eventsAndFaults.GetEvents() {
+				r.addEvent(event, false, event.GetEventType(), event.GetCardNumberDriverSlotBegin(), event.GetCardNumberCodriverSlotBegin())
+			}
+			for _, event := range eventsAndFaults.GetOverspeedingEvents() {
+				r.addEvent(event, false, event.GetEventType(), event.GetCardNumberDriverSlotBegin(), nil)
+			}
+		}
+		for _, detailedSpeed := range gen1.GetDetailedSpeed() {
+			for _, block := range detailedSpeed.GetSpeedBlocks() {
+				r.addSpeedBlock(block.GetBeginDate(), block.GetSpeedsKmh())
+			}
+		}
+		for _, control := range overview.GetControlActivities() {
+			r.addControlActivity(control, control.GetControlCardNumber())
+		}
+		for _, technicalData := range gen1.GetTechnicalData() {
+			for _, calibration := range technicalData.GetCalibrationRecords() {
+				r.addCalibration(calibration, calibration.GetWorkshopCardNumber())
+			}
+		}
+
+	case ddv1.Generation_GENERATION_2:
+		if file.GetVersion() == ddv1.Version_VERSION_2 {
+			gen2 := file.GetGen2V2()
+			addGen2Records(r, gen2)
+			r.download.Registration = VehicleRegistration{Number: ia5String(gen2.GetOverview().GetVehicleRegistrationNumber())}
+			for _, activities := range gen2.GetActivities() {
+				for _, crossing := range activities.GetBorderCrossings() {
+					gnssPlace := crossing.GetGnssPlaceAuthRecord()
+					r.borderCrossings = append(r.borderCrossings, BorderCrossing{
+						Time:                 timeOf(gnssPlace.GetTimestamp()),
+						CountryLeft:          crossing.GetCountryLeft(),
+						CountryEntered:       crossing.GetCountryEntered(),
+						OdometerKm:           crossing.GetOdometerKm(),
+						Position:             gnssPlace.GetGeoCoordinates(),
+						AuthenticationStatus: gnssPlace.GetAuthenticationStatus(),
+					})
+				}
+				for _, operation := range activities.GetLoadUnloadOperations() {
+					gnssPlace := operation.GetGnssPlaceAuthRecord()
+					r.loadUnloadOperations = append(r.loadUnloadOperations, LoadUnloadOperation{
+						Time:                 timeOf(operation.GetTimestamp()),
+						OperationType:        operation.GetOperationType(),
+						OdometerKm:           operation.GetOdometerKm(),
+						Position:             gnssPlace.GetGeoCoordinates(),
+						AuthenticationStatus: gnssPlace.GetAuthenticationStatus(),
+					})
+				}
+			}
+		} else {
+			gen2 := file.GetGen2V1()
+			addGen2Records(r, gen2)
+			r.download.Registration = newVehicleRegistration(gen2.GetOverview().GetVehicleRegistrationWithNation())
+		}
+	}
+	return r
+}
+
+// gen2File is implemented by the Gen2 vehicle unit files of all versions,
+// whose transfers differ only in the records that are specific to a version.
+type gen2File[O, A, E, T any] interface {
+	GetOverview() O
+	GetActivities() []A
+	GetEventsAndFaults() []E
+	GetDetailedSpeed() []*vuv1.DetailedSpeedGen2
+	GetTechnicalData() []T
+}
+
+// gen2Overview is implemented by the overview transfers of all Gen2 versions.
+type gen2Overview[C any] interface {
+	GetVehicleIdentificationNumber() *ddv1.Ia5StringValue
+	GetCurrentDateTime() *timestamppb.Timestamp
+	GetDownloadablePeriod() *ddv1.DownloadablePeriod
+	GetControlActivities() []C
+}
+
+// gen2Activities is implemented by the activities transfers of all Gen2 versions.
+type gen2Activities[IW, P, AD any] interface {
+	GetDateOfDay() *timestamppb.Timestamp
+	GetOdometerMidnightKm() int32
+	GetActivityChanges() []*ddv1.ActivityChangeInfo
+	GetSpecificConditions() []*ddv1.SpecificConditionRecord
+	GetCardIwData() []IW
+	GetPlaces() []P
+	GetGnssAccumulatedDriving() []AD
+}
+
+// gen2EventsAndFaults is implemented by the events and faults transfers of
+// all Gen2 versions.
+type gen2EventsAndFaults[F, EV, OS any] interface {
+	GetFaults() []F
+	GetEvents() []EV
+	GetOverspeedingEvents() []OS
+}
+
+// gen2TechnicalData is implemented by the technical data transfers of all
+// Gen2 versions.
+type gen2TechnicalData[CR any] interface {
+	GetCalibrationRecords() []CR
+}
+
+// addGen2Records collects the records that are common to all Gen2 versions.
+// The type parameters are inferred from the transfers of the file.
+func addGen2Records[
+	O gen2Overview[C],
+	A gen2Activities[IW, P, AD],
+	E gen2EventsAndFaults[F, EV, OS],
+	T gen2TechnicalData[CR],
+	C interface {
+		controlActivityRecord
+		GetControlCardNumberAndGeneration() *ddv1.FullCardNumberAndGeneration
+	},
+	IW interface {
+		cardIWRecord
+		GetFullCardNumberAndGeneration() *ddv1.FullCardNumberAndGeneration
+	},
+	P interface {
+		placeRecord
+		GetGnssPlaceRecord() G
+	},
+	G interface {
+		GetGeoCoordinates() *ddv1.GeoCoordinates
+	},
+	AD accumulatedDrivingRecord,
+	F interface {
+		eventRecordGen2
+		GetFaultType() ddv1.EventFaultType
+		GetCardNumberAndGenCodriverSlotBegin() *ddv1.FullCardNumberAndGeneration
+	},
+	EV interface {
+		eventRecordGen2
+		GetEventType() ddv1.EventFaultType
+		GetCardNumberAndGenCodriverSlotBegin() *ddv1.FullCardNumberAndGeneration
+	},
+	OS interface {
+		eventRecordGen2
+		GetEventType() ddv1.EventFaultType
+	},
+	CR interface {
+		calibrationRecord
+		GetWorkshopCardNumberAndGeneration() *ddv1.FullCardNumberAndGeneration
+	},
+](r *vehicleUnitRecords, file gen2File[O, A, E, T]) {
+	overview := file.GetOverview()
+	r.vin = ia5String(overview.GetVehicleIdentificationNumber())
+	r.download.DownloadTime = timeOf(overview.GetCurrentDateTime())
+	r.download.DownloadablePeriod = periodOf(overview.GetDownloadablePeriod())
+	for _, activities := range file.GetActivities() {
+		for _, iw := range activities.GetCardIwData() {
+			r.addCardInsertion(iw, iw.GetFullCardNumberAndGeneration().GetFullCardNumber())
+		}
+		r.addActivityDay(activities.GetDateOfDay(), activities.GetOdometerMidnightKm(), activities.GetActivityChanges(), activities.GetSpecificConditions())
+		for _, place := range activities.GetPlaces() {
+			gnssPlace := place.GetGnssPlaceRecord()
+			r.addPlace(place, gnssPlace.GetGeoCoordinates(), authenticationStatusOf(gnssPlace))
+		}
+		for _, position := range activities.GetGnssAccumulatedDriving() {
+			r.addAccumulatedDrivingPosition(position)
+		}
+	}
+	for _, eventsAndFaults := range file.GetEventsAndFaults() {
+		for _, fault := range eventsAndFaults.GetFaults() {
+			r.addEvent(fault, true, fault.GetFaultType(),
+				fault.GetCardNumberAndGenDriverSlotBegin().GetFullCardNumber(),
+				fault.GetCardNumberAndGenCodriverSlotBegin().GetFullCardNumber())
+		}
+		for _, event := range eventsAndFaults.GetEvents() {
+			r.addEvent(event, false, event.GetEventType(),
+				event.GetCardNumberAndGenDriverSlotBegin().GetFullCardNumber(),
+				event.GetCardNumberAndGenCodriverSlotBegin().GetFullCardNumber())
+		}
+		for _, event := range eventsAndFaults.GetOverspeedingEvents() {
+			r.addEvent(event, false, event.GetEventType(), event.GetCardNumberAndGenDriverSlotBegin().GetFullCardNumber(), nil)
+		}
+	}
+	for _, detailedSpeed := range file.GetDetailedSpeed() {
+		for _, block := range detailedSpeed.GetSpeedBlocks() {
+			r.addSpeedBlock(block.GetBeginDate(), block.GetSpeedsKmh())
+		}
+	}
+	for _, control := range overview.GetControlActivities() {
+		r.addControlActivity(control, control.GetControlCardNumberAndGeneration().GetFullCardNumber())
+	}
+	for _, technicalData := range file.GetTechnicalData() {
+		for _, calibration := range technicalData.GetCalibrationRecords() {
+			r.addCalibration(calibration, calibration.GetWorkshopCardNumberAndGeneration().GetFullCardNumber())
+		}
+	}
+}
+
+// cardIWRecord is implemented by the card insertion/withdrawal records of all generations.
+type cardIWRecord interface {
+	GetCardHolderName() *ddv1.HolderName
+	GetCardInsertionTime() *timestamppb.Timestamp
+	GetOdometerAtInsertionKm() int32
+	GetCardSlotNumber() ddv1.CardSlotNumber
+	GetCardWithdrawalTime() *timestamppb.Timestamp
+	GetOdometerAtWithdrawalKm() int32
+	GetManualInputFlag() bool
+}
+
+func (r *vehicleUnitRecords) addCardInsertion(iw cardIWRecord, cardNumber *ddv1.FullCardNumber) {
+	r.cardInsertions = append(r.cardInsertions, CardInsertion{
+		CardNumber:             cardNumberString(cardNumber),
+		CardHolderName:         holderNameString(iw.GetCardHolderName()),
+		Slot:                   iw.GetCardSlotNumber(),
+		InsertionTime:          timeOf(iw.GetCardInsertionTime()),
+		WithdrawalTime:         timeOf(iw.GetCardWithdrawalTime()),
+		OdometerAtInsertionKm:  iw.GetOdometerAtInsertionKm(),
+		OdometerAtWithdrawalKm: iw.GetOdometerAtWithdrawalKm(),
+		ManualInputFlag:        iw.GetManualInputFlag(),
+	})
+}
+
+func (r *vehicleUnitRecords) addActivityDay(
+	date *timestamppb.Timestamp,
+	odometerMidnightKm int32,
+	changes []*ddv1.ActivityChangeInfo,
+	specificConditions []*ddv1.SpecificConditionRecord,
+) {
+	if date == nil {
+		return
+	}
+	r.activityDays = append(r.activityDays, VehicleActivityDay{
+		Date:               date.AsTime(),
+		OdometerMidnightKm: odometerMidnightKm,
+		ActivityChanges:    changes,
+		SpecificConditions: specificConditions,
+	})
+}
+
+// placeRecord is implemented by the daily work period place records of all generations.
+type placeRecord interface {
+	GetEntryTime() *timestamppb.Timestamp
+	GetEntryType() ddv1.EntryTypeDailyWorkPeriod
+	GetCountry() ddv1.NationNumeric
+	GetOdometerKm() int32
+}
+
+func (r *vehicleUnitRecords) addPlace(place placeRecord, position *ddv1.GeoCoordinates, authenticationStatus ddv1.PositionAuthenticationStatus) {
+	r.places = append(r.places, Place{
+		Time:                 timeOf(place.GetEntryTime()),
+		EntryType:            place.GetEntryType(),
+		Country:              place.GetCountry(),
+		OdometerKm:           place.GetOdometerKm(),
+		Position:             position,
+		AuthenticationStatus: authenticationStatus,
+	})
+}
+
+// authenticationStatusOf returns the authentication status of a GNSS place
+// record, or unspecified if the record has none, as in Gen2 V1 places.
+func authenticationStatusOf(gnssPlace any) ddv1.PositionAuthenticationStatus {
+	if record, ok := gnssPlace.(interface {
+		GetAuthenticationStatus() ddv1.PositionAuthenticationStatus
+	}); ok {
+		return record.GetAuthenticationStatus()
+	}
+	return ddv1.PositionAuthenticationStatus_POSITION_AUTHENTICATION_STATUS_UNSPECIFIED
+}
+
+// accumulatedDrivingRecord is implemented by the GNSS accumulated driving
+// records of all Gen2 versions.
+type accumulatedDrivingRecord interface {
+	GetTimestamp() *timestamppb.Timestamp
+	GetGeoCoordinates() *ddv1.GeoCoordinates
+	GetAuthenticationStatus() ddv1.PositionAuthenticationStatus
+}
+
+func (r *vehicleUnitRecords) addAccumulatedDrivingPosition(record accumulatedDrivingRecord) {
+	if record.GetTimestamp() == nil {
+		return
+	}
+	r.accumulatedDrivingPositions = append(r.accumulatedDrivingPositions, AccumulatedDrivingPosition{
+		Time:                 timeOf(record.GetTimestamp()),
+		Position:             record.GetGeoCoordinates(),
+		AuthenticationStatus: record.GetAuthenticationStatus(),
+	})
+}
+
+// eventRecord is implemented by the event, fault and overspeeding records of all generations.
+type eventRecord interface {
+	GetRecordPurpose() ddv1.EventFaultRecordPurpose
+	GetBeginTime() *timestamppb.Timestamp
+	GetEndTime() *timestamppb.Timestamp
+}
+
+// eventRecordGen2 is implemented by the event, fault and overspeeding
+// records of all Gen2 versions.
+type eventRecordGen2 interface {
+	eventRecord
+	GetCardNumberAndGenDriverSlotBegin() *ddv1.FullCardNumberAndGeneration
+}
+
+func (r *vehicleUnitRecords) addEvent(
+	record eventRecord,
+	fault bool,
+	eventType ddv1.EventFaultType,
+	driverCard, coDriverCard *ddv1.FullCardNumber,
+) {
+	event := VehicleEvent{
+		Type:               eventType,
+		Fault:              fault,
+		RecordPurpose:      record.GetRecordPurpose(),
+		BeginTime:          timeOf(record.GetBeginTime()),
+		EndTime:            timeOf(record.GetEndTime()),
+		DriverCardNumber:   cardNumberString(driverCard),
+		CoDriverCardNumber: cardNumberString(coDriverCard),
+	}
+	if similar, ok := record.(interface{ GetSimilarEventsNumber() int32 }); ok {
+		event.SimilarEvents = similar.GetSimilarEventsNumber()
+	}
+	if overspeeding, ok := record.(interface {
+		GetMaxSpeedKmh() int32
+		GetAverageSpeedKmh() int32
+	}); ok {
+		event.MaxSpeedKmh = overspeeding.GetMaxSpeedKmh()
+		event.AverageSpeedKmh = overspeeding.GetAverageSpeedKmh()
+	}
+	r.events = append(r.events, event)
+}
+
+func (r *vehicleUnitRecords) addSpeedBlock(begin *timestamppb.Timestamp, speeds []int32) {
+	if begin == nil {
+		return
+	}
+	r.speedBlocks = append(r.speedBlocks, SpeedBlock{
+		BeginTime: begin.AsTime(),
+		SpeedsKmh: speeds,
+	})
+}
+
+// controlActivityRecord is implemented by the control activity records of all generations.
+type controlActivityRecord interface {
+	GetControlType() *ddv1.ControlType
+	GetControlTime() *timestamppb.Timestamp
+	GetDownloadPeriodBeginTime() *timestamppb.Timestamp
+	GetDownloadPeriodEndTime() *timestamppb.Timestamp
+}
+
+func (r *vehicleUnitRecords) addControlActivity(control controlActivityRecord, cardNumber *ddv1.FullCardNumber) {
+	if control.GetControlTime() == nil {
+		return
+	}
+	r.controlActivities = append(r.controlActivities, ControlActivity{
+		Time:              timeOf(control.GetControlTime()),
+		Type:              control.GetControlType(),
+		ControlCardNumber: cardNumberString(cardNumber),
+		DownloadPeriod: Period{
+			Start: timeOf(control.GetDownloadPeriodBeginTime()),
+			End:   timeOf(control.GetDownloadPeriodEndTime()),
+		},
+	})
+}
+
+// calibrationRecord is implemented by the calibration records of all generations.
+type calibrationRecord interface {
+	GetPurpose() ddv1.CalibrationPurpose
+	GetWorkshopName() *ddv1.StringValue
+	GetVin() *ddv1.StringValue
+	GetVehicleRegistration() *ddv1.VehicleRegistrationIdentification
+	GetWVehicleCharacteristicConstant() int32
+	GetKConstantOfRecordingEquipment() int32
+	GetLTyreCircumferenceEighthsMm() int32
+	GetTyreSize() *ddv1.StringValue
+	GetAuthorisedSpeedKmh() int32
+	GetOldOdometerValueKm() int32
+	GetNewOdometerValueKm() int32
+	GetOldTimeValue() *timestamppb.Timestamp
+	GetNewTimeValue() *timestamppb.Timestamp
+	GetNextCalibrationDate() *timestamppb.Timestamp
+}
+
+func (r *vehicleUnitRecords) addCalibration(calibration calibrationRecord, workshopCardNumber *ddv1.FullCardNumber) {
+	r.calibrations = append(r.calibrations, Calibration{
+		Purpose:                        calibration.GetPurpose(),
+		WorkshopName:                   strings.TrimSpace(calibration.GetWorkshopName().GetValue()),
+		WorkshopCardNumber:             cardNumberString(workshopCardNumber),
+		VIN:                            strings.TrimSpace(calibration.GetVin().GetValue()),
+		Registration:                   newVehicleRegistration(calibration.GetVehicleRegistration()),
+		WVehicleCharacteristicConstant: calibration.GetWVehicleCharacteristicConstant(),
+		KConstantOfRecordingEquipment:  calibration.GetKConstantOfRecordingEquipment(),
+		LTyreCircumferenceEighthsMm:    calibration.GetLTyreCircumferenceEighthsMm(),
+		TyreSize:                       strings.TrimSpace(calibration.GetTyreSize().GetValue()),
+		AuthorisedSpeedKmh:             calibration.GetAuthorisedSpeedKmh(),
+		OldOdometerKm:                  calibration.GetOldOdometerValueKm(),
+		NewOdometerKm:                  calibration.GetNewOdometerValueKm(),
+		OldTime:                        timeOf(calibration.GetOldTimeValue()),
+		NewTime:                        timeOf(calibration.GetNewTimeValue()),
+		NextCalibrationDate:            timeOf(calibration.GetNextCalibrationDate()),
+	})
+}
+
+// timeOf converts a protobuf timestamp to a UTC time, mapping nil to the zero time.
+func timeOf(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+// periodOf converts a downloadable period to a [Period].
+func periodOf(p *ddv1.DownloadablePeriod) Period {
+	return Period{Start: timeOf(p.GetMinTime()), End: timeOf(p.GetMaxTime())}
+}
+
+// ia5String returns the trimmed value of an IA5 string.
+func ia5String(s *ddv1.Ia5StringValue) string {
+	return strings.TrimSpace(s.GetValue())
+}
+
+// holderNameString formats a holder name as "Surname, First names".
+func holderNameString(name *ddv1.HolderName) string {
+	surname := strings.TrimSpace(name.GetHolderSurname().GetValue())
+	firstNames := strings.TrimSpace(name.GetHolderFirstNames().GetValue())
+	switch {
+	case surname == "":
+		return firstNames
+	case firstNames == "":
+		return surname
+	default:
+		return surname + ", " + firstNames
+	}
+}
+
+// cardNumberString formats a full card number as its card number string,
+// including the replacement and renewal indexes when present.
+func cardNumberString(cardNumber *ddv1.FullCardNumber) string {
+	if cardNumber == nil {
+		return ""
+	}
+	if driver := cardNumber.GetDriverIdentification(); driver != nil {
+		return driverIdentificationString(driver)
+	}
+	var sb strings.Builder
+	if owner := cardNumber.GetOwnerIdentification(); owner != nil {
+		sb.WriteString(ia5String(owner.GetOwnerIdentification()))
+		sb.WriteString(ia5String(owner.GetConsecutiveIndex()))
+		sb.WriteString(ia5String(owner.GetReplacementIndex()))
+		sb.WriteString(ia5String(owner.GetRenewalIndex()))
+	}
+	return sb.String()
+}
+
+// driverIdentificationString formats a driver identification as its card
+// number string.
+func driverIdentificationString(driver *ddv1.DriverIdentification) string {
+	return ia5String(driver.GetDriverIdentificationNumber()) +
+		ia5String(driver.GetCardReplacementIndex()) +
+		ia5String(driver.GetCardRenewalIndex())
+}
+
+// newVehicleRegistration converts a vehicle registration identification to a [VehicleRegistration].
+func newVehicleRegistration(vri *ddv1.VehicleRegistrationIdentification) VehicleRegistration {
+	return VehicleRegistration{
+		Nation: vri.GetNation(),
+		Number: strings.TrimSpace(vri.GetNumber().GetValue()),
+	}
+}
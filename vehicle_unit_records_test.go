@@ -0,0 +1,82 @@
+package tachograph
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+)
+
+// testSyntheticVehicleUnitFile reads a synthetic VU download from testdata/vu.
+func testSyntheticVehicleUnitFile(t testing.TB, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "vu", name+".DDD"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestNewVehicleUnitRecords_gen2(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		name                string
+		version             ddv1.Version
+		wantNation          ddv1.NationNumeric
+		wantBorderCrossings int
+		wantPlaceAuth       ddv1.PositionAuthenticationStatus
+	}{
+		{name: "synthetic_gen2_v1", version: ddv1.Version_VERSION_1, wantNation: ddv1.NationNumeric_GERMANY},
+		{name: "synthetic_gen2_v2", version: ddv1.Version_VERSION_2, wantBorderCrossings: 1, wantPlaceAuth: ddv1.PositionAuthenticationStatus_AUTHENTICATED},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := UnmarshalFile(testSyntheticVehicleUnitFile(t, tt.name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := newVehicleUnitRecords(file.GetVehicleUnit())
+			if r.download.Version != tt.version {
+				t.Errorf("version = %v, want %v", r.download.Version, tt.version)
+			}
+			if r.vin != "WDB9634031L123456" {
+				t.Errorf("VIN = %q", r.vin)
+			}
+			wantRegistration := VehicleRegistration{Nation: tt.wantNation, Number: "M-AB 1234"}
+			if r.download.Registration != wantRegistration {
+				t.Errorf("registration = %+v, want %+v", r.download.Registration, wantRegistration)
+			}
+			if got, want := r.download.DownloadTime, time.Date(2024, 3, 2, 9, 30, 0, 0, time.UTC); !got.Equal(want) {
+				t.Errorf("download time = %v, want %v", got, want)
+			}
+			if len(r.cardInsertions) != 1 {
+				t.Fatalf("card insertions = %d, want 1", len(r.cardInsertions))
+			}
+			insertion := r.cardInsertions[0]
+			if insertion.Slot != ddv1.CardSlotNumber_DRIVER_SLOT || !insertion.WithdrawalTime.Equal(day.Add(17*time.Hour)) {
+				t.Errorf("card insertion = %+v", insertion)
+			}
+			if len(r.activityDays) != 1 || len(r.places) != 1 || len(r.accumulatedDrivingPositions) != 1 {
+				t.Errorf("activity days, places, positions = %d, %d, %d, want 1 each",
+					len(r.activityDays), len(r.places), len(r.accumulatedDrivingPositions))
+			}
+			if r.places[0].Position.GetLatitude() != 48082 {
+				t.Errorf("place position = %v", r.places[0].Position)
+			}
+			if r.places[0].AuthenticationStatus != tt.wantPlaceAuth {
+				t.Errorf("place authentication status = %v, want %v", r.places[0].AuthenticationStatus, tt.wantPlaceAuth)
+			}
+			// A fault, an event and an overspeeding event.
+			if len(r.events) != 3 {
+				t.Errorf("events = %d, want 3", len(r.events))
+			}
+			if len(r.speedBlocks) != 2 {
+				t.Errorf("speed blocks = %d, want 2", len(r.speedBlocks))
+			}
+			if len(r.borderCrossings) != tt.wantBorderCrossings {
+				t.Errorf("border crossings = %d, want %d", len(r.borderCrossings), tt.wantBorderCrossings)
+			}
+		})
+	}
+}
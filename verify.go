@@ -15,18 +15,21 @@ type VerifyOptions struct {
 	CertificateResolver CertificateResolver
 }
 
-// VerifyFile verifies the certificates in a tachograph file.
+// VerifyFile verifies the certificates and signatures in a tachograph file.
 //
 // See [VerifyOptions] if you need more control over the verification process.
 func VerifyFile(ctx context.Context, file *tachographv1.File) error {
 	return VerifyOptions{}.VerifyFile(ctx, file)
 }
 
-// VerifyFile verifies the certificates in a tachograph file.
+// VerifyFile verifies the certificates and signatures in a tachograph file.
 //
 // For driver card files, this function verifies:
-//   - Generation 1: Card certificate using the CA certificate
-//   - Generation 2: Card sign certificate using the CA certificate
+//   - Generation 1: CA certificate using the root certificate, card certificate
+//     using the CA certificate, and the EF signatures using the card certificate
+//   - Generation 2: CA certificate using the root certificate (if the resolver
+//     provides it), card MA and card sign certificates using the CA certificate,
+//     and the EF signatures using the card sign certificate
 //
 // The verification process uses a certificate resolver to fetch CA certificates
 // by their Certificate Authority Reference (CAR). If no resolver is configured,
@@ -36,10 +39,13 @@ func VerifyFile(ctx context.Context, file *tachographv1.File) error {
 // For vehicle unit files, certificate verification is not currently implemented
 // as VU certificates are stored as raw bytes and require additional parsing.
 //
+// Raw card files are not verified either.
+//
 // This function mutates the certificate structures by setting their signature_valid
-// fields to true or false based on the verification result.
+// fields, and the signed EFs by setting their signature_verified fields, to true or
+// false based on the verification result. Fields that remain unset were not verified.
 //
-// Returns an error if verification fails for any certificate.
+// Returns an error if verification fails for any certificate or signature.
 func (o VerifyOptions) VerifyFile(ctx context.Context, file *tachographv1.File) error {
 	if file == nil {
 		return fmt.Errorf("file cannot be nil")
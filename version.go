@@ -0,0 +1,130 @@
+package tachograph
+
+import (
+	"fmt"
+
+	"github.com/way-platform/tachograph-go/internal/dd"
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+)
+
+// StructureVersion returns the generation and version of the data structures
+// of a parsed file.
+//
+// For card files, this is the card structure version of the application
+// identification EF of the newest generation on the card, which is also
+// recorded on the driver card file. For vehicle unit files, this is the
+// generation and version detected from the transfers of the file, which is
+// also recorded on the vehicle unit file. [UnmarshalFile] records the
+// structure version on the file, together with the major and minor version
+// coded in the file, if any.
+//
+// If the file holds no version information, the generation is
+// [ddv1.Generation_GENERATION_UNSPECIFIED]. A version that is newer than the
+// versions known to this package returns an error wrapping
+// [ErrUnsupportedVersion]. Parsing fails on such versions, but they are
+// reported for raw card files, which are not parsed.
+func StructureVersion(file *tachographv1.File) (ddv1.Generation, ddv1.Version, error) {
+	switch file.GetType() {
+	case tachographv1.File_DRIVER_CARD:
+		card := file.GetDriverCard()
+		if csv := card.GetTachographG2().GetApplicationIdentification().GetCardStructureVersion(); csv != nil {
+			return dd.StructureVersion(csv.GetMajor(), csv.GetMinor())
+		}
+		if csv := card.GetTachograph().GetApplicationIdentification().GetCardStructureVersion(); csv != nil {
+			return dd.StructureVersion(csv.GetMajor(), csv.GetMinor())
+		}
+	case tachographv1.File_RAW_CARD:
+		return rawCardStructureVersion(file.GetRawCard())
+	case tachographv1.File_VEHICLE_UNIT:
+		vehicleUnit := file.GetVehicleUnit()
+		switch vehicleUnit.GetGeneration() {
+		case ddv1.Generation_GENERATION_1:
+			return ddv1.Generation_GENERATION_1, ddv1.Version_VERSION_1, nil
+		case ddv1.Generation_GENERATION_2:
+			return ddv1.Generation_GENERATION_2, vehicleUnit.GetVersion(), nil
+		}
+	}
+	return ddv1.Generation_GENERATION_UNSPECIFIED, ddv1.Version_VERSION_UNSPECIFIED, nil
+}
+
+// recordStructureVersion records the structure version of a parsed file on
+// the file, if it holds any version information.
+//
+// A version that is newer than the versions known to this package is recorded
+// with its major and minor version only, and an unknown generation or version.
+func recordStructureVersion(file *tachographv1.File) {
+	generation, version, _ := StructureVersion(file)
+	var major, minor []byte
+	switch file.GetType() {
+	case tachographv1.File_DRIVER_CARD:
+		card := file.GetDriverCard()
+		// The generation and version recorded on the driver card file take a
+		// version override into account.
+		generation, version = card.GetGeneration(), card.GetVersion()
+		csv := card.GetTachographG2().GetApplicationIdentification().GetCardStructureVersion()
+		if csv == nil {
+			csv = card.GetTachograph().GetApplicationIdentification().GetCardStructureVersion()
+		}
+		if csv != nil {
+			major, minor = []byte{byte(csv.GetMajor())}, []byte{byte(csv.GetMinor())}
+		}
+	case tachographv1.File_RAW_CARD:
+		if csv := rawCardStructureVersionData(file.GetRawCard()); csv != nil {
+			if structureVersion, err := (dd.UnmarshalOptions{}).UnmarshalCardStructureVersion(csv); err == nil {
+				major, minor = []byte{byte(structureVersion.GetMajor())}, []byte{byte(structureVersion.GetMinor())}
+			}
+		}
+	case tachographv1.File_VEHICLE_UNIT:
+		if div := file.GetVehicleUnit().GetGen2V2().GetDownloadInterfaceVersion().GetRawData(); len(div) == 2 {
+			major, minor = div[:1], div[1:]
+		}
+	}
+	if generation == ddv1.Generation_GENERATION_UNSPECIFIED && major == nil {
+		return
+	}
+	var structureVersion tachographv1.File_StructureVersion
+	structureVersion.SetGeneration(generation)
+	structureVersion.SetVersion(version)
+	if major != nil {
+		structureVersion.SetMajor(int32(major[0]))
+		structureVersion.SetMinor(int32(minor[0]))
+	}
+	file.SetStructureVersion(&structureVersion)
+}
+
+// rawCardStructureVersion returns the card structure version of the
+// application identification EF of the newest generation in a raw card file.
+func rawCardStructureVersion(rawCard *cardv1.RawCardFile) (ddv1.Generation, ddv1.Version, error) {
+	csv := rawCardStructureVersionData(rawCard)
+	if csv == nil {
+		return ddv1.Generation_GENERATION_UNSPECIFIED, ddv1.Version_VERSION_UNSPECIFIED, nil
+	}
+	structureVersion, err := dd.UnmarshalOptions{}.UnmarshalCardStructureVersion(csv)
+	if err != nil {
+		return ddv1.Generation_GENERATION_UNSPECIFIED, ddv1.Version_VERSION_UNSPECIFIED, fmt.Errorf("card structure version: %w", err)
+	}
+	return dd.StructureVersion(structureVersion.GetMajor(), structureVersion.GetMinor())
+}
+
+// rawCardStructureVersionData returns the card structure version data of the
+// application identification EF of the newest generation in a raw card file,
+// or nil if there is none.
+func rawCardStructureVersionData(rawCard *cardv1.RawCardFile) []byte {
+	// The card structure version follows the 1-byte type of tachograph card ID.
+	const idxCardStructureVersion = 1
+	var csv []byte
+	var csvGeneration ddv1.Generation
+	for _, record := range rawCard.GetRecords() {
+		if record.GetFile() != cardv1.ElementaryFileType_EF_APPLICATION_IDENTIFICATION ||
+			record.GetContentType() != cardv1.ContentType_DATA ||
+			len(record.GetValue()) < idxCardStructureVersion+2 ||
+			record.GetGeneration() < csvGeneration {
+			continue
+		}
+		csv = record.GetValue()[idxCardStructureVersion : idxCardStructureVersion+2]
+		csvGeneration = record.GetGeneration()
+	}
+	return csv
+}
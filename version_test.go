@@ -0,0 +1,121 @@
+package tachograph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/way-platform/tachograph-go/internal/card"
+	cardv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/card/v1"
+	ddv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/dd/v1"
+	tachographv1 "github.com/way-platform/tachograph-go/proto/gen/go/wayplatform/connect/tachograph/v1"
+)
+
+// testDriverCardDataWithStructureVersion returns the test driver card data
+// with a card structure version in the application identification EF.
+func testDriverCardDataWithStructureVersion(t testing.TB, csv [2]byte) []byte {
+	t.Helper()
+	rawCard, err := card.UnmarshalRawCardFile(testDriverCardData(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, record := range rawCard.GetRecords() {
+		if record.GetFile() == cardv1.ElementaryFileType_EF_APPLICATION_IDENTIFICATION &&
+			record.GetContentType() == cardv1.ContentType_DATA {
+			copy(record.GetValue()[1:3], csv[:])
+		}
+	}
+	data, err := card.MarshalRawCardFile(rawCard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestStructureVersion(t *testing.T) {
+	t.Run("driver card", func(t *testing.T) {
+		file, err := UnmarshalFile(testDriverCardData(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		generation, version, err := StructureVersion(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if generation != ddv1.Generation_GENERATION_1 || version != ddv1.Version_VERSION_1 {
+			t.Errorf("StructureVersion() = %v, %v, want GENERATION_1, VERSION_1", generation, version)
+		}
+		if card := file.GetDriverCard(); card.GetGeneration() != generation || card.GetVersion() != version {
+			t.Errorf("driver card file = %v, %v, want %v, %v", card.GetGeneration(), card.GetVersion(), generation, version)
+		}
+		if sv := file.GetStructureVersion(); sv.GetGeneration() != generation || sv.GetVersion() != version || !sv.HasMajor() {
+			t.Errorf("file structure version = %v, want %v, %v", sv, generation, version)
+		}
+	})
+
+	t.Run("gen1 driver card with a minor version", func(t *testing.T) {
+		// The minor version of Gen1 cards doesn't change the data structures.
+		for _, csv := range [][2]byte{{0x00, 0x01}, {0x00, 0x02}} {
+			file, err := UnmarshalFile(testDriverCardDataWithStructureVersion(t, csv))
+			if err != nil {
+				t.Fatalf("%X: %v", csv, err)
+			}
+			generation, version, err := StructureVersion(file)
+			if err != nil {
+				t.Fatalf("%X: %v", csv, err)
+			}
+			if generation != ddv1.Generation_GENERATION_1 || version != ddv1.Version_VERSION_1 {
+				t.Errorf("%X: StructureVersion() = %v, %v, want GENERATION_1, VERSION_1", csv, generation, version)
+			}
+			if card := file.GetDriverCard(); card.GetVersion() != ddv1.Version_VERSION_1 {
+				t.Errorf("%X: driver card version = %v, want VERSION_1", csv, card.GetVersion())
+			}
+			if sv := file.GetStructureVersion(); sv.GetGeneration() != generation || sv.GetVersion() != version ||
+				sv.GetMajor() != int32(csv[0]) || sv.GetMinor() != int32(csv[1]) {
+				t.Errorf("%X: file structure version = %v", csv, sv)
+			}
+		}
+	})
+
+	t.Run("vehicle unit", func(t *testing.T) {
+		file, err := UnmarshalFile(testVehicleUnitData(t, 1709280000))
+		if err != nil {
+			t.Fatal(err)
+		}
+		generation, version, err := StructureVersion(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if generation != ddv1.Generation_GENERATION_1 || version != ddv1.Version_VERSION_1 {
+			t.Errorf("StructureVersion() = %v, %v, want GENERATION_1, VERSION_1", generation, version)
+		}
+		// Gen1 vehicle units don't record a download interface version.
+		if sv := file.GetStructureVersion(); sv.GetGeneration() != generation || sv.GetVersion() != version || sv.HasMajor() {
+			t.Errorf("file structure version = %v, want %v, %v", sv, generation, version)
+		}
+	})
+
+	t.Run("unsupported driver card", func(t *testing.T) {
+		_, err := UnmarshalFile(testDriverCardDataWithStructureVersion(t, [2]byte{0x02, 0x00}))
+		if !errors.Is(err, ErrUnsupportedVersion) {
+			t.Errorf("UnmarshalFile() error = %v, want %v", err, ErrUnsupportedVersion)
+		}
+	})
+
+	t.Run("unsupported raw card", func(t *testing.T) {
+		rawCard, err := card.UnmarshalRawCardFile(testDriverCardDataWithStructureVersion(t, [2]byte{0x01, 0x05}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var file tachographv1.File
+		file.SetType(tachographv1.File_RAW_CARD)
+		file.SetRawCard(rawCard)
+		if _, _, err := StructureVersion(&file); !errors.Is(err, ErrUnsupportedVersion) {
+			t.Errorf("StructureVersion() error = %v, want %v", err, ErrUnsupportedVersion)
+		}
+		recordStructureVersion(&file)
+		if sv := file.GetStructureVersion(); sv.GetGeneration() != ddv1.Generation_GENERATION_2 ||
+			sv.GetVersion() != ddv1.Version_VERSION_UNSPECIFIED || sv.GetMajor() != 1 || sv.GetMinor() != 5 {
+			t.Errorf("file structure version = %v, want GENERATION_2 01.05", sv)
+		}
+	})
+}